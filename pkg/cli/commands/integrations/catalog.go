@@ -0,0 +1,152 @@
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/cli/core"
+)
+
+type catalogField struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+type catalogItem struct {
+	Name          string         `json:"name"`
+	Label         string         `json:"label"`
+	Description   string         `json:"description"`
+	Configuration []catalogField `json:"configuration"`
+}
+
+type catalogEntry struct {
+	Name          string         `json:"name"`
+	Label         string         `json:"label"`
+	Icon          string         `json:"icon"`
+	Description   string         `json:"description"`
+	Configuration []catalogField `json:"configuration"`
+	Components    []catalogItem  `json:"components"`
+	Triggers      []catalogItem  `json:"triggers"`
+}
+
+// catalogCommand lists the integrations the server provides,
+// with their triggers, components and configuration schemas.
+type catalogCommand struct{}
+
+func (c *catalogCommand) Execute(ctx core.CommandContext) error {
+	catalog, err := listIntegrationsCatalogRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(catalog)
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		writer := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "INTEGRATION\tTYPE\tNAME\tLABEL\tCONFIGURATION\tDESCRIPTION")
+		for _, entry := range catalog {
+			_, _ = fmt.Fprintf(writer, "%s\tintegration\t%s\t%s\t%s\t%s\n", entry.Name, entry.Name, entry.Label, catalogFieldNames(entry.Configuration), entry.Description)
+
+			for _, trigger := range entry.Triggers {
+				_, _ = fmt.Fprintf(writer, "%s\ttrigger\t%s\t%s\t%s\t%s\n", entry.Name, trigger.Name, trigger.Label, catalogFieldNames(trigger.Configuration), trigger.Description)
+			}
+
+			for _, component := range entry.Components {
+				_, _ = fmt.Fprintf(writer, "%s\tcomponent\t%s\t%s\t%s\t%s\n", entry.Name, component.Name, component.Label, catalogFieldNames(component.Configuration), component.Description)
+			}
+		}
+
+		return writer.Flush()
+	})
+}
+
+// catalogFieldNames lists the configuration fields of a catalog item,
+// marking the required ones with an asterisk.
+func catalogFieldNames(fields []catalogField) string {
+	if len(fields) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Required {
+			names = append(names, field.Name+"*")
+			continue
+		}
+
+		names = append(names, field.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func listIntegrationsCatalogRequest(ctx core.CommandContext) ([]catalogEntry, error) {
+	config := ctx.API.GetConfig()
+	if config == nil {
+		return nil, fmt.Errorf("api client config is required")
+	}
+
+	baseURL, err := config.ServerURLWithContext(ctx.Context, "IntegrationAPIService.IntegrationsListIntegrations")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(baseURL) == "" {
+		return nil, fmt.Errorf("api_url is required")
+	}
+
+	endpoint := strings.TrimRight(baseURL, "/") + "/api/v1/integrations/catalog"
+	request, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	if authorization := strings.TrimSpace(config.DefaultHeader["Authorization"]); authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		errorPayload := struct {
+			Message string `json:"message"`
+		}{}
+		_ = json.Unmarshal(body, &errorPayload)
+		if errorPayload.Message != "" {
+			return nil, errors.New(errorPayload.Message)
+		}
+		return nil, fmt.Errorf("failed to list integrations catalog: %s", response.Status)
+	}
+
+	catalog := []catalogEntry{}
+	if err := json.Unmarshal(body, &catalog); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
@@ -25,6 +25,13 @@ func NewCommand(options core.BindOptions) *cobra.Command {
 	}
 	core.Bind(getCmd, &getCommand{}, options)
 
+	catalogCmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "List available integrations with their triggers, components and configuration",
+		Args:  cobra.NoArgs,
+	}
+	core.Bind(catalogCmd, &catalogCommand{}, options)
+
 	var integrationID string
 	var resourceType string
 	var parameters string
@@ -43,6 +50,7 @@ func NewCommand(options core.BindOptions) *cobra.Command {
 	root.AddCommand(listCmd)
 	root.AddCommand(getCmd)
 	root.AddCommand(listResourcesCmd)
+	root.AddCommand(catalogCmd)
 
 	return root
 }
@@ -19,7 +19,6 @@ func ValidateConfiguration(fields []Field, config map[string]any) error {
 	for _, field := range fields {
 		value, exists := config[field.Name]
 
-		// Check if field is required (either always or conditionally)
 		isRequired := field.Required
 		if !isRequired && len(field.RequiredConditions) > 0 {
 			isRequired = isRequiredByCondition(field, config)
@@ -48,6 +47,82 @@ func ValidateConfiguration(fields []Field, config map[string]any) error {
 	return nil
 }
 
+// ValidateFields validates an integration configuration against its field definitions,
+// and reports all failing fields instead of stopping at the first one.
+// Unlike ValidateConfiguration, blank strings do not satisfy required fields,
+// which is what integrations expect for values like API keys and slugs.
+func ValidateFields(fields []Field, config map[string]any) error {
+	var errs ValidationErrors
+	for _, field := range fields {
+		value, exists := config[field.Name]
+
+		isRequired := field.Required
+		if !isRequired && len(field.RequiredConditions) > 0 {
+			isRequired = isRequiredByCondition(field, config)
+		}
+
+		if isBlank(value, exists) {
+			if isRequired {
+				errs = append(errs, FieldError{Field: field.Name, Message: fieldRequiredMessage})
+			}
+
+			continue
+		}
+
+		if err := validateFieldValue(field, value); err != nil {
+			errs = append(errs, FieldError{Field: field.Name, Message: err.Error()})
+			continue
+		}
+
+		if err := validateFieldRules(field, value, config); err != nil {
+			errs = append(errs, FieldError{Field: field.Name, Message: err.Error()})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+const fieldRequiredMessage = "is required"
+
+// FieldError describes why a single configuration field is invalid.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Message == fieldRequiredMessage {
+		return fmt.Sprintf("field '%s' %s", e.Field, e.Message)
+	}
+
+	return fmt.Sprintf("field '%s': %s", e.Field, e.Message)
+}
+
+// ValidationErrors collects every field-level error found in a configuration.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func isBlank(value any, exists bool) bool {
+	if !exists || value == nil {
+		return true
+	}
+
+	text, ok := value.(string)
+	return ok && strings.TrimSpace(text) == ""
+}
+
 func validateNumber(field Field, value any) error {
 	var num float64
 	switch v := value.(type) {
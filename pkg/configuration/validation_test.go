@@ -547,6 +547,102 @@ func TestValidateList_MaxItems(t *testing.T) {
 	}
 }
 
+func TestValidateFields(t *testing.T) {
+	fields := []Field{
+		{
+			Name:     "site",
+			Type:     FieldTypeSelect,
+			Required: true,
+			TypeOptions: &TypeOptions{
+				Select: &SelectTypeOptions{
+					Options: []FieldOption{
+						{Label: "US", Value: "us"},
+						{Label: "EU", Value: "eu"},
+					},
+				},
+			},
+		},
+		{
+			Name:     "apiKey",
+			Type:     FieldTypeString,
+			Required: true,
+		},
+		{
+			Name: "verbose",
+			Type: FieldTypeBool,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]any
+		expected ValidationErrors
+	}{
+		{
+			name:   "valid configuration",
+			config: map[string]any{"site": "us", "apiKey": "secret", "verbose": true},
+		},
+		{
+			name:     "missing required field",
+			config:   map[string]any{"site": "us"},
+			expected: ValidationErrors{{Field: "apiKey", Message: "is required"}},
+		},
+		{
+			name:     "blank required field",
+			config:   map[string]any{"site": "us", "apiKey": "  "},
+			expected: ValidationErrors{{Field: "apiKey", Message: "is required"}},
+		},
+		{
+			name:     "invalid select value",
+			config:   map[string]any{"site": "ap", "apiKey": "secret"},
+			expected: ValidationErrors{{Field: "site", Message: "must be one of: us, eu"}},
+		},
+		{
+			name:     "wrong type",
+			config:   map[string]any{"site": "us", "apiKey": "secret", "verbose": "yes"},
+			expected: ValidationErrors{{Field: "verbose", Message: "must be a boolean"}},
+		},
+		{
+			name:   "multiple errors",
+			config: map[string]any{"site": 1},
+			expected: ValidationErrors{
+				{Field: "site", Message: "must be a string"},
+				{Field: "apiKey", Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFields(fields, tt.config)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var errs ValidationErrors
+			assert.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
+
+func TestValidateConfiguration_BlankRequiredString(t *testing.T) {
+	fields := []Field{
+		{Name: "name", Type: FieldTypeString, Required: true},
+		{Name: "count", Type: FieldTypeNumber, Required: true},
+	}
+
+	t.Run("blank required string -> valid", func(t *testing.T) {
+		assert.NoError(t, ValidateConfiguration(fields, map[string]any{"name": "  ", "count": 1}))
+	})
+
+	t.Run("invalid fields -> only the first error", func(t *testing.T) {
+		err := ValidateConfiguration(fields, map[string]any{"count": "one"})
+		assert.EqualError(t, err, "field 'name' is required")
+	})
+}
+
 func ptrInt(v int) *int {
 	return &v
 }
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +16,20 @@ var DefaultOutputChannel = OutputChannel{Name: "default", Label: "Default"}
 
 var ErrSecretKeyNotFound = errors.New("secret or key not found")
 
+/*
+ * ResultStatusField is the payload field holding the machine-readable
+ * outcome of a component execution. Components set it to one of the
+ * ResultStatus* values, so conditions on it work the same for every provider.
+ */
+const ResultStatusField = "status"
+
+const (
+	ResultStatusSuccess = "success"
+	ResultStatusSkipped = "skipped"
+	ResultStatusFailed  = "failed"
+	ResultStatusNoop    = "noop"
+)
+
 type Component interface {
 
 	/*
@@ -123,6 +139,136 @@ type Component interface {
 	Cleanup(ctx SetupContext) error
 }
 
+/*
+ * RetryPolicyProvider is implemented by components that want the HTTP requests
+ * made during Execute() to be retried, instead of implementing retries themselves.
+ *
+ * The engine wraps the HTTP context given to Execute() with the policy,
+ * so only requests that are safe to send again should be made by such components.
+ */
+type RetryPolicyProvider interface {
+	RetryPolicy() RetryPolicy
+}
+
+/*
+ * RetryPolicy describes how requests are retried.
+ * A request is sent up to MaxAttempts times, as long as the response
+ * has one of the RetryableStatuses. The wait between attempts starts at
+ * InitialBackoff and doubles on every attempt, up to MaxBackoff.
+ * A Retry-After header in seconds is honored, up to MaxBackoff.
+ */
+type RetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	RetryableStatuses []int
+}
+
+func (p RetryPolicy) IsRetryableStatus(status int) bool {
+	return slices.Contains(p.RetryableStatuses, status)
+}
+
+/*
+ * Backoff returns how long to wait after the given failed attempt, starting at 1.
+ */
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return backoff
+}
+
+/*
+ * ErrorOutputChannel receives the failures of components whose node
+ * has ContinueOnErrorField set, instead of the node failing. See ErrorReporter.
+ */
+var ErrorOutputChannel = OutputChannel{
+	Name:        "error",
+	Label:       "Error",
+	Description: "Receives the error when the component fails and Continue On Error is set",
+}
+
+const (
+	ContinueOnErrorField = "continueOnError"
+	ErrorPayloadType     = "error"
+)
+
+/*
+ * ErrorReporter is implemented by components, or by integrations for all their components,
+ * that can continue on error. Those get a ContinueOnErrorField configuration field.
+ *
+ * When it is set and Execute() returns an error, the engine emits the payload
+ * DescribeError returns for it on the ErrorOutputChannel, instead of failing the node.
+ */
+type ErrorReporter interface {
+	DescribeError(err error) ErrorPayload
+}
+
+/*
+ * ErrorPayload is the standardized payload emitted on the ErrorOutputChannel.
+ * Code is a short machine-readable identifier of the failure, such as "rate_limited",
+ * and Retryable tells whether running the component again can succeed.
+ */
+type ErrorPayload struct {
+	Code      string `json:"code" mapstructure:"code"`
+	Message   string `json:"message" mapstructure:"message"`
+	Retryable bool   `json:"retryable" mapstructure:"retryable"`
+}
+
+const (
+	ErrorCodeFailed         = "execution_failed"
+	ErrorCodeBadRequest     = "bad_request"
+	ErrorCodeUnauthorized   = "unauthorized"
+	ErrorCodeForbidden      = "forbidden"
+	ErrorCodeNotFound       = "not_found"
+	ErrorCodeConflict       = "conflict"
+	ErrorCodeRateLimited    = "rate_limited"
+	ErrorCodeServerError    = "server_error"
+	ErrorCodeNetworkError   = "network_error"
+	ErrorCodeRequestTimeout = "timeout"
+)
+
+/*
+ * HTTPErrorPayload describes a failure caused by an API responding with the given status.
+ * Rate limiting, timeouts and server errors are retryable.
+ */
+func HTTPErrorPayload(status int, message string) ErrorPayload {
+	payload := ErrorPayload{Code: ErrorCodeFailed, Message: message}
+
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		payload.Code = ErrorCodeBadRequest
+	case status == http.StatusUnauthorized:
+		payload.Code = ErrorCodeUnauthorized
+	case status == http.StatusForbidden:
+		payload.Code = ErrorCodeForbidden
+	case status == http.StatusNotFound:
+		payload.Code = ErrorCodeNotFound
+	case status == http.StatusConflict:
+		payload.Code = ErrorCodeConflict
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		payload.Code = ErrorCodeRequestTimeout
+		payload.Retryable = true
+	case status == http.StatusTooManyRequests:
+		payload.Code = ErrorCodeRateLimited
+		payload.Retryable = true
+	case status >= 500:
+		payload.Code = ErrorCodeServerError
+		payload.Retryable = true
+	}
+
+	return payload
+}
+
 type OutputChannel struct {
 	Name        string
 	Label       string
@@ -155,6 +301,13 @@ type ExecutionContext struct {
 	Secrets        SecretsContext
 	CanvasMemory   CanvasMemoryContext
 	Webhook        NodeWebhookContext
+
+	/*
+	 * Context is done when the worker running the execution stops,
+	 * so components can stop waiting on slow or rate limited APIs.
+	 * It is nil outside of the node executor.
+	 */
+	Context context.Context
 }
 
 /*
@@ -168,6 +321,30 @@ type HTTPContext interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
+/*
+ * HTTPTracer is implemented by HTTP contexts that record the requests
+ * integration clients make, when tracing of integration requests is enabled.
+ *
+ * Clients report each request once they have read the response,
+ * with secrets already removed, see registry.TraceHTTP.
+ */
+type HTTPTracer interface {
+	TraceHTTP(trace HTTPTrace)
+}
+
+/*
+ * HTTPTrace is a sanitized request made by an integration client and its response.
+ * Bodies are truncated, and Status is 0 when no response was received.
+ */
+type HTTPTrace struct {
+	Method       string
+	URL          string
+	Status       int
+	RequestBody  string
+	ResponseBody string
+	Error        string
+}
+
 /*
  * ExecutionContext allows the component
  * to control the state and metadata of each execution of it.
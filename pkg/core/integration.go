@@ -1,7 +1,11 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -109,6 +113,19 @@ type WebhookHandler interface {
 	Merge(current, requested any) (merged any, changed bool, err error)
 }
 
+/*
+ * WebhookReferenceReleaser is implemented by webhook handlers that keep track
+ * of what each node merged into a shared webhook configuration.
+ *
+ * When a node stops using a webhook that other nodes still use, Release is called
+ * with the current configuration and the metadata of the node, and returns the
+ * configuration without the node's references. Return changed=false when the
+ * node had no references, and the webhook is left as it is.
+ */
+type WebhookReferenceReleaser interface {
+	Release(current, nodeMetadata any) (released any, changed bool, err error)
+}
+
 type WebhookHandlerContext struct {
 	Logger      *logrus.Entry
 	HTTP        HTTPContext
@@ -162,6 +179,34 @@ type ListResourcesContext struct {
 	Parameters  map[string]string
 }
 
+/*
+ * IntegrationResourceWithChildren is a resource listed together
+ * with the resources that depend on it, e.g. a project and its environments.
+ */
+type IntegrationResourceWithChildren struct {
+	IntegrationResource
+	Children []IntegrationResource
+}
+
+/*
+ * BatchResourceLister can be implemented by integrations
+ * that can list a resource type together with its dependent resources
+ * in a single call, so resource pickers for dependent fields
+ * do not need one request per parent.
+ */
+type BatchResourceLister interface {
+	ListResourcesWithChildren(resourceType, childType string, ctx ListResourcesContext) ([]IntegrationResourceWithChildren, error)
+}
+
+/*
+ * WebhookRegistry can be implemented by integration contexts
+ * to report whether SuperPlane still has a webhook, so components
+ * can find the webhooks left behind in external services.
+ */
+type WebhookRegistry interface {
+	WebhookExists(id uuid.UUID) (bool, error)
+}
+
 type WebhookOptions struct {
 	ID            string
 	URL           string
@@ -181,6 +226,56 @@ type SyncContext struct {
 	OIDC            oidc.Provider
 }
 
+/*
+ * SyncProgress records the steps of a multi-step Sync that completed,
+ * so a Sync retried after a failure can skip them instead of running
+ * everything again and creating duplicate provider resources.
+ *
+ * Integrations keep it in their metadata while a Sync is failing,
+ * and drop it once a Sync succeeds. Progress recorded for another
+ * configuration is discarded, since the completed steps may not apply to it.
+ */
+type SyncProgress struct {
+	Fingerprint    string   `json:"fingerprint" mapstructure:"fingerprint"`
+	CompletedSteps []string `json:"completedSteps" mapstructure:"completedSteps"`
+}
+
+/*
+ * ResumeSyncProgress returns the progress of a previous, failed Sync
+ * if it was recorded for the same configuration, or an empty one.
+ */
+func ResumeSyncProgress(previous *SyncProgress, configuration any) *SyncProgress {
+	fingerprint := syncFingerprint(configuration)
+	if previous != nil && previous.Fingerprint == fingerprint {
+		return &SyncProgress{
+			Fingerprint:    fingerprint,
+			CompletedSteps: slices.Clone(previous.CompletedSteps),
+		}
+	}
+
+	return &SyncProgress{Fingerprint: fingerprint, CompletedSteps: []string{}}
+}
+
+func syncFingerprint(configuration any) string {
+	data, err := json.Marshal(configuration)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *SyncProgress) IsCompleted(step string) bool {
+	return slices.Contains(p.CompletedSteps, step)
+}
+
+func (p *SyncProgress) Complete(step string) {
+	if !p.IsCompleted(step) {
+		p.CompletedSteps = append(p.CompletedSteps, step)
+	}
+}
+
 type IntegrationCleanupContext struct {
 	Configuration  any
 	BaseURL        string
@@ -220,6 +315,12 @@ type IntegrationContext interface {
 	Ready()
 	Error(message string)
 
+	//
+	// Mark the integration as partially working.
+	// Used when Sync succeeds for some capabilities but not others.
+	//
+	Degraded(message string)
+
 	//
 	// Control the browser action of the integration
 	//
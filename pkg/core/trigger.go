@@ -1,8 +1,13 @@
 package core
 
 import (
+	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/expr-lang/expr"
 	log "github.com/sirupsen/logrus"
 	"github.com/superplanehq/superplane/pkg/configuration"
 )
@@ -81,6 +86,41 @@ type Trigger interface {
 	Cleanup(ctx TriggerContext) error
 }
 
+/*
+ * SetupRetryIntervalProvider is implemented by triggers whose webhooks are provisioned
+ * through providers with strict rate limits. When provisioning fails, the engine waits
+ * at least the interval before the next attempt, doubling it on every failed attempt.
+ */
+type SetupRetryIntervalProvider interface {
+	SetupRetryInterval() time.Duration
+}
+
+/*
+ * DryRunSetupProvider is implemented by triggers whose Setup honors DryRun,
+ * so what they would provision can be previewed before a canvas change is saved.
+ */
+type DryRunSetupProvider interface {
+	SupportsDryRun() bool
+}
+
+/*
+ * MaxSetupRetryBackoff caps the wait between provisioning attempts.
+ */
+const MaxSetupRetryBackoff = 15 * time.Minute
+
+/*
+ * SetupRetryBackoff returns how long to wait after the given number of failed
+ * provisioning attempts, for a trigger declaring the given minimum interval.
+ */
+func SetupRetryBackoff(interval time.Duration, failedAttempts int) time.Duration {
+	if interval <= 0 || failedAttempts < 1 {
+		return 0
+	}
+
+	policy := RetryPolicy{InitialBackoff: interval, MaxBackoff: MaxSetupRetryBackoff}
+	return policy.Backoff(failedAttempts)
+}
+
 type TriggerContext struct {
 	Logger        *log.Entry
 	Configuration any
@@ -90,12 +130,212 @@ type TriggerContext struct {
 	Events        EventContext
 	Webhook       NodeWebhookContext
 	Integration   IntegrationContext
+
+	/*
+	 * DryRun is set when a canvas change is previewed. Setup must not
+	 * call the provider or request webhooks, and records what it would
+	 * provision in Plan instead.
+	 */
+	DryRun bool
+	Plan   *SetupPlan
+}
+
+/*
+ * SetupPlan is what a trigger Setup would provision, recorded in dry-run mode.
+ */
+type SetupPlan struct {
+	Steps []SetupPlanStep `json:"steps"`
+}
+
+/*
+ * SetupPlanStep is a single resource a trigger Setup would provision,
+ * e.g. a webhook, a webhook recipient or an API key.
+ */
+type SetupPlanStep struct {
+	Action      string         `json:"action"`
+	Resource    string         `json:"resource"`
+	Description string         `json:"description"`
+	Details     map[string]any `json:"details,omitempty"`
+}
+
+const (
+	SetupPlanActionCreate = "create"
+	SetupPlanActionUpdate = "update"
+	SetupPlanActionEnsure = "ensure"
+)
+
+func (p *SetupPlan) Add(step SetupPlanStep) {
+	if p == nil {
+		return
+	}
+
+	p.Steps = append(p.Steps, step)
 }
 
 type EventContext interface {
 	Emit(payloadType string, payload any) error
 }
 
+/*
+ * EventTypePrefixField is the trigger configuration field that replaces
+ * the provider prefix of the emitted event types, for example to namespace
+ * events by team. When a custom prefix is used, the original type is kept
+ * in the payload, under CanonicalTypeField.
+ */
+const (
+	EventTypePrefixField = "eventTypePrefix"
+	CanonicalTypeField   = "canonicalType"
+)
+
+var eventTypePrefixRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+/*
+ * EventTypePrefixConfiguration returns the optional configuration
+ * field for triggers that allow the prefix of their event types,
+ * defaultPrefix, to be customized.
+ */
+func EventTypePrefixConfiguration(defaultPrefix string) configuration.Field {
+	return configuration.Field{
+		Name:        EventTypePrefixField,
+		Label:       "Event Type Prefix",
+		Type:        configuration.FieldTypeString,
+		Required:    false,
+		Togglable:   true,
+		Placeholder: defaultPrefix,
+		Description: fmt.Sprintf("Replaces %s in the type of emitted events", defaultPrefix),
+	}
+}
+
+/*
+ * ValidateEventTypePrefix checks that a custom prefix is made of
+ * dot-separated segments of letters, digits, dashes and underscores.
+ * An empty prefix is valid, and keeps the default one.
+ */
+func ValidateEventTypePrefix(prefix string) error {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || eventTypePrefixRegex.MatchString(prefix) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid event type prefix %q: use dot-separated letters, digits, dashes and underscores", prefix)
+}
+
+/*
+ * ApplyEventTypePrefix replaces defaultPrefix in eventType with customPrefix.
+ * The event type is returned as is if no custom prefix is set,
+ * or if it does not start with defaultPrefix.
+ */
+func ApplyEventTypePrefix(eventType, defaultPrefix, customPrefix string) string {
+	customPrefix = strings.TrimSpace(customPrefix)
+	if customPrefix == "" || !strings.HasPrefix(eventType, defaultPrefix) {
+		return eventType
+	}
+
+	return customPrefix + strings.TrimPrefix(eventType, defaultPrefix)
+}
+
+/*
+ * OutputMappingField is the trigger configuration field that reshapes
+ * the payload of the emitted events, so downstream nodes get exactly
+ * the fields they need. Each entry sets a field of the emitted payload
+ * to an expression evaluated against the provider payload, available as $.
+ * Fields of the provider payload without an entry are dropped.
+ */
+const OutputMappingField = "outputMapping"
+
+type OutputMappingEntry struct {
+	Field      string `json:"field" mapstructure:"field"`
+	Expression string `json:"expression" mapstructure:"expression"`
+}
+
+/*
+ * OutputMappingConfiguration returns the optional configuration
+ * field for triggers that allow their payload to be reshaped.
+ */
+func OutputMappingConfiguration() configuration.Field {
+	return configuration.Field{
+		Name:        OutputMappingField,
+		Label:       "Output Mapping",
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Togglable:   true,
+		Description: "Emit only these fields, each set to an expression on the event payload, e.g. $.pipeline.result",
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Field",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeObject,
+					Schema: []configuration.Field{
+						{Name: "field", Label: "Field", Type: configuration.FieldTypeString, Required: true, DisallowExpression: true, Placeholder: "result"},
+						{Name: "expression", Label: "Expression", Type: configuration.FieldTypeExpression, Required: true, Placeholder: "$.pipeline.result"},
+					},
+				},
+			},
+		},
+	}
+}
+
+/*
+ * ValidateOutputMapping checks that every entry of the mapping
+ * has a unique field name and an expression that compiles.
+ * An empty mapping is valid, and keeps the provider payload.
+ */
+func ValidateOutputMapping(mapping []OutputMappingEntry) error {
+	fields := map[string]bool{}
+	for i, entry := range mapping {
+		field := strings.TrimSpace(entry.Field)
+		if field == "" {
+			return fmt.Errorf("output mapping entry %d: field is required", i+1)
+		}
+
+		if fields[field] {
+			return fmt.Errorf("output mapping field %s is set more than once", field)
+		}
+
+		fields[field] = true
+
+		if strings.TrimSpace(entry.Expression) == "" {
+			return fmt.Errorf("output mapping field %s: expression is required", field)
+		}
+
+		env := outputMappingEnv(map[string]any{})
+		if _, err := expr.Compile(entry.Expression, expr.Env(env), expr.AsAny()); err != nil {
+			return fmt.Errorf("output mapping field %s: invalid expression: %w", field, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+ * ApplyOutputMapping evaluates the mapping against the provider payload,
+ * returning the payload to emit. The payload is returned as is
+ * if no mapping is set.
+ */
+func ApplyOutputMapping(payload map[string]any, mapping []OutputMappingEntry) (map[string]any, error) {
+	if len(mapping) == 0 {
+		return payload, nil
+	}
+
+	env := outputMappingEnv(payload)
+	mapped := make(map[string]any, len(mapping))
+	for _, entry := range mapping {
+		field := strings.TrimSpace(entry.Field)
+		value, err := expr.Eval(entry.Expression, env)
+		if err != nil {
+			return nil, fmt.Errorf("output mapping field %s: %w", field, err)
+		}
+
+		mapped[field] = value
+	}
+
+	return mapped, nil
+}
+
+func outputMappingEnv(payload map[string]any) map[string]any {
+	return map[string]any{"$": payload}
+}
+
 type TriggerActionContext struct {
 	Name          string
 	Parameters    map[string]any
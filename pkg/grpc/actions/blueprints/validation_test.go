@@ -0,0 +1,36 @@
+package blueprints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "github.com/superplanehq/superplane/pkg/components/http"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+func TestValidateNodeConfiguration_ComponentConfiguration(t *testing.T) {
+	reg, err := registry.NewRegistry(crypto.NewNoOpEncryptor(), registry.HTTPOptions{})
+	require.NoError(t, err)
+
+	node := func(config map[string]any) models.Node {
+		return models.Node{
+			ID:            "http-node",
+			Type:          models.NodeTypeComponent,
+			Ref:           models.NodeRef{Component: &models.ComponentRef{Name: "http"}},
+			Configuration: config,
+		}
+	}
+
+	t.Run("blank required string -> valid", func(t *testing.T) {
+		err := ValidateNodeConfiguration(node(map[string]any{"method": "GET", "url": " "}), reg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing required fields -> first missing field", func(t *testing.T) {
+		err := ValidateNodeConfiguration(node(map[string]any{}), reg)
+		assert.EqualError(t, err, "node http-node: field 'method' is required")
+	})
+}
@@ -106,6 +106,7 @@ func cancelExecutionInTransaction(tx *gorm.DB, authService authorization.Authori
 
 				logger = logging.WithIntegration(logger, *integration)
 				ctx.Integration = contexts.NewIntegrationContext(tx, node, integration, encryptor, registry)
+				ctx.HTTP = registry.HTTPContextForIntegration(integration.Configuration.Data())
 			}
 
 			ctx.Logger = logger
@@ -102,6 +102,7 @@ func InvokeNodeExecutionAction(
 
 		logger = logging.WithIntegration(logger, *integration)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, integration, encryptor, registry)
+		actionCtx.HTTP = registry.HTTPContextForIntegration(integration.Configuration.Data())
 	}
 
 	actionCtx.Logger = logger
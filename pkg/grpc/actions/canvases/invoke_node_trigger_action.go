@@ -98,6 +98,7 @@ func InvokeNodeTriggerAction(
 
 		logger = logging.WithIntegration(logger, *integration)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, integration, encryptor, registry)
+		actionCtx.HTTP = registry.HTTPContextForIntegration(integration.Configuration.Data())
 	}
 
 	actionCtx.Logger = logger
@@ -0,0 +1,67 @@
+package canvases
+
+import (
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/workers/contexts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+ * PreviewTriggerSetup runs the Setup of a trigger in dry-run mode,
+ * and returns what it would provision for the given configuration.
+ * Nothing is saved, and the provider is not called.
+ */
+func PreviewTriggerSetup(registry *registry.Registry, organizationID, triggerName, integrationID string, config map[string]any) (*core.SetupPlan, error) {
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+
+	trigger, err := registry.GetTrigger(triggerName)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "trigger %s not found", triggerName)
+	}
+
+	provider, ok := trigger.(core.DryRunSetupProvider)
+	if !ok || !provider.SupportsDryRun() {
+		return nil, status.Errorf(codes.InvalidArgument, "trigger %s does not support previewing its setup", triggerName)
+	}
+
+	plan := &core.SetupPlan{Steps: []core.SetupPlanStep{}}
+	logger := log.WithFields(log.Fields{"trigger": triggerName})
+	triggerCtx := core.TriggerContext{
+		Logger:        logger,
+		Configuration: config,
+		HTTP:          registry.HTTPContext(),
+		DryRun:        true,
+		Plan:          plan,
+	}
+
+	if integrationID != "" {
+		ID, err := uuid.Parse(integrationID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid integration ID")
+		}
+
+		instance, err := models.FindIntegration(orgID, ID)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "integration not found")
+		}
+
+		triggerCtx.Logger = logger.WithField("integration_id", instance.ID.String())
+		triggerCtx.Integration = contexts.NewIntegrationContext(database.Conn(), nil, instance, registry.Encryptor, registry)
+		triggerCtx.HTTP = registry.HTTPContextForIntegration(instance.Configuration.Data())
+	}
+
+	if err := trigger.Setup(triggerCtx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return plan, nil
+}
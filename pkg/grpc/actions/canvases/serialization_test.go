@@ -0,0 +1,41 @@
+package canvases
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "github.com/superplanehq/superplane/pkg/components/http"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	componentpb "github.com/superplanehq/superplane/pkg/protos/components"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValidateNodeRef_ComponentConfiguration(t *testing.T) {
+	reg, err := registry.NewRegistry(crypto.NewNoOpEncryptor(), registry.HTTPOptions{})
+	require.NoError(t, err)
+
+	node := func(config map[string]any) *componentpb.Node {
+		configuration, err := structpb.NewStruct(config)
+		require.NoError(t, err)
+
+		return &componentpb.Node{
+			Id:            "http-node",
+			Type:          componentpb.Node_TYPE_COMPONENT,
+			Component:     &componentpb.Node_ComponentRef{Name: "http"},
+			Configuration: configuration,
+		}
+	}
+
+	t.Run("blank required string -> valid", func(t *testing.T) {
+		err := validateNodeRef(reg, uuid.NewString(), node(map[string]any{"method": "GET", "url": " "}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing required fields -> first missing field", func(t *testing.T) {
+		err := validateNodeRef(reg, uuid.NewString(), node(map[string]any{}))
+		assert.EqualError(t, err, "field 'method' is required")
+	})
+}
@@ -159,7 +159,7 @@ func UpdateCanvasWithAutoLayout(
 			return err
 		}
 
-		return deleteNodes(tx, existingNodes, expandedNodes)
+		return deleteNodes(tx, encryptor, registry, existingNodes, expandedNodes)
 	})
 
 	if err != nil {
@@ -375,6 +375,7 @@ func setupTrigger(ctx context.Context, tx *gorm.DB, encryptor crypto.Encryptor,
 			encryptor,
 			registry,
 		)
+		triggerCtx.HTTP = registry.HTTPContextForIntegration(integration.Configuration.Data())
 	}
 
 	triggerCtx.Logger = logger
@@ -416,6 +417,7 @@ func setupComponent(ctx context.Context, tx *gorm.DB, encryptor crypto.Encryptor
 			encryptor,
 			registry,
 		)
+		setupCtx.HTTP = registry.HTTPContextForIntegration(integration.Configuration.Data())
 	}
 
 	setupCtx.Logger = logger
@@ -427,9 +429,13 @@ func setupComponent(ctx context.Context, tx *gorm.DB, encryptor crypto.Encryptor
 	return tx.Save(node).Error
 }
 
-func deleteNodes(tx *gorm.DB, existingNodes []models.CanvasNode, newNodes []models.Node) error {
+func deleteNodes(tx *gorm.DB, encryptor crypto.Encryptor, registry *registry.Registry, existingNodes []models.CanvasNode, newNodes []models.Node) error {
 	for _, existingNode := range existingNodes {
 		if !slices.ContainsFunc(newNodes, func(n models.Node) bool { return n.ID == existingNode.NodeID }) {
+			if err := releaseNodeWebhook(tx, encryptor, registry, existingNode); err != nil {
+				return err
+			}
+
 			err := models.DeleteCanvasNode(tx, existingNode)
 			if err != nil {
 				return err
@@ -439,3 +445,22 @@ func deleteNodes(tx *gorm.DB, existingNodes []models.CanvasNode, newNodes []mode
 
 	return nil
 }
+
+// releaseNodeWebhook lets the webhook handler drop the references of a deleted node
+// from a webhook that other nodes still use.
+func releaseNodeWebhook(tx *gorm.DB, encryptor crypto.Encryptor, registry *registry.Registry, node models.CanvasNode) error {
+	if node.WebhookID == nil || node.AppInstallationID == nil {
+		return nil
+	}
+
+	integration, err := models.FindUnscopedIntegrationInTransaction(tx, *node.AppInstallationID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to find app installation: %v", err)
+	}
+
+	return contexts.NewIntegrationContext(tx, &node, integration, encryptor, registry).ReleaseWebhook()
+}
@@ -39,6 +39,10 @@ func CreateIntegration(ctx context.Context, registry *registry.Registry, oidcPro
 		return nil, status.Errorf(codes.AlreadyExists, "an integration with the name %s already exists in this organization", name)
 	}
 
+	if err := registry.ValidateHTTPConfiguration(appConfig.AsMap()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	//
 	// We must encrypt the sensitive configuration fields before storing
 	//
@@ -69,7 +73,7 @@ func CreateIntegration(ctx context.Context, registry *registry.Registry, oidcPro
 
 	syncErr := integration.Sync(core.SyncContext{
 		Logger:          logging.ForIntegration(*newIntegration),
-		HTTP:            registry.HTTPContext(),
+		HTTP:            registry.HTTPContextForIntegration(newIntegration.Configuration.Data()),
 		Integration:     integrationCtx,
 		Configuration:   newIntegration.Configuration.Data(),
 		BaseURL:         baseURL,
@@ -55,11 +55,19 @@ func ListIntegrationResources(ctx context.Context, registry *registry.Registry,
 			"integration_name": instance.AppName,
 			"resource_type":    resourceType,
 		}),
-		HTTP:        registry.HTTPContext(),
+		HTTP:        registry.HTTPContextForIntegration(instance.Configuration.Data()),
 		Integration: integrationCtx,
 		Parameters:  parameters,
 	}
 
+	//
+	// Resource pickers for dependent fields can ask for the children of each resource,
+	// so they get e.g. the projects and their environments in a single request.
+	//
+	if childType := parameters["childType"]; childType != "" {
+		return listIntegrationResourcesWithChildren(integration, instance, resourceType, childType, listCtx)
+	}
+
 	resources, err := integration.ListResources(resourceType, listCtx)
 	if err != nil {
 		log.WithError(err).Errorf("failed to list resources for integration %s", instance.ID)
@@ -71,6 +79,37 @@ func ListIntegrationResources(ctx context.Context, registry *registry.Registry,
 	}, nil
 }
 
+func listIntegrationResourcesWithChildren(
+	integration core.Integration,
+	instance *models.Integration,
+	resourceType string,
+	childType string,
+	listCtx core.ListResourcesContext,
+) (*pb.ListIntegrationResourcesResponse, error) {
+	lister, ok := integration.(core.BatchResourceLister)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "integration %s does not list resources with children", instance.AppName)
+	}
+
+	resources, err := lister.ListResourcesWithChildren(resourceType, childType, listCtx)
+	if err != nil {
+		log.WithError(err).Errorf("failed to list resources with children for integration %s", instance.ID)
+		return nil, status.Error(codes.Internal, "failed to list integration resources")
+	}
+
+	out := make([]*pb.IntegrationResourceRef, 0, len(resources))
+	for _, resource := range resources {
+		out = append(out, &pb.IntegrationResourceRef{
+			Type:     resource.Type,
+			Name:     resource.Name,
+			Id:       resource.ID,
+			Children: serializeIntegrationResources(resource.Children),
+		})
+	}
+
+	return &pb.ListIntegrationResourcesResponse{Resources: out}, nil
+}
+
 func serializeIntegrationResources(resources []core.IntegrationResource) []*pb.IntegrationResourceRef {
 	out := make([]*pb.IntegrationResourceRef, 0, len(resources))
 	for _, resource := range resources {
@@ -0,0 +1,95 @@
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// batchDummyIntegration lists projects together with their environments.
+type batchDummyIntegration struct {
+	*support.DummyIntegration
+	calls int
+}
+
+func (i *batchDummyIntegration) ListResourcesWithChildren(resourceType, childType string, ctx core.ListResourcesContext) ([]core.IntegrationResourceWithChildren, error) {
+	i.calls++
+	return []core.IntegrationResourceWithChildren{
+		{
+			IntegrationResource: core.IntegrationResource{Type: resourceType, Name: "Default", ID: "default"},
+			Children: []core.IntegrationResource{
+				{Type: childType, Name: "Production", ID: "production"},
+				{Type: childType, Name: "Test", ID: "test"},
+			},
+		},
+	}, nil
+}
+
+func Test__ListIntegrationResources(t *testing.T) {
+	r := support.Setup(t)
+	ctx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+	baseURL := "http://localhost"
+
+	createIntegration := func(t *testing.T) string {
+		appConfig, err := structpb.NewStruct(map[string]any{})
+		require.NoError(t, err)
+
+		response, err := CreateIntegration(ctx, r.Registry, nil, baseURL, baseURL, r.Organization.ID.String(), "dummy", support.RandomName("integration"), appConfig)
+		require.NoError(t, err)
+		return response.Integration.Metadata.Id
+	}
+
+	t.Run("child type -> resources and their children in one call", func(t *testing.T) {
+		integration := &batchDummyIntegration{DummyIntegration: support.NewDummyIntegration(support.DummyIntegrationOptions{})}
+		r.Registry.Integrations["dummy"] = integration
+		integrationID := createIntegration(t)
+
+		response, err := ListIntegrationResources(ctx, r.Registry, r.Organization.ID.String(), integrationID, map[string]string{
+			"type":      "project",
+			"childType": "environment",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, integration.calls)
+
+		require.Len(t, response.Resources, 1)
+		assert.Equal(t, "project", response.Resources[0].Type)
+		assert.Equal(t, "default", response.Resources[0].Id)
+
+		children := response.Resources[0].Children
+		require.Len(t, children, 2)
+		assert.Equal(t, "environment", children[0].Type)
+		assert.Equal(t, "production", children[0].Id)
+		assert.Equal(t, "test", children[1].Id)
+	})
+
+	t.Run("child type for integration without batch listing -> error", func(t *testing.T) {
+		r.Registry.Integrations["dummy"] = support.NewDummyIntegration(support.DummyIntegrationOptions{})
+		integrationID := createIntegration(t)
+
+		_, err := ListIntegrationResources(ctx, r.Registry, r.Organization.ID.String(), integrationID, map[string]string{
+			"type":      "project",
+			"childType": "environment",
+		})
+		require.Error(t, err)
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+
+	t.Run("no child type -> resources without children", func(t *testing.T) {
+		r.Registry.Integrations["dummy"] = support.NewDummyIntegration(support.DummyIntegrationOptions{})
+		integrationID := createIntegration(t)
+
+		response, err := ListIntegrationResources(ctx, r.Registry, r.Organization.ID.String(), integrationID, map[string]string{"type": "project"})
+		require.NoError(t, err)
+		assert.Empty(t, response.Resources)
+	})
+}
@@ -70,6 +70,10 @@ func UpdateIntegration(
 		configuration = map[string]any{}
 	}
 
+	if err := registry.ValidateHTTPConfiguration(configuration); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	existingConfig := instance.Configuration.Data()
 	configuration, err = encryptConfigurationIfNeeded(ctx, registry, integration, configuration, instance.ID, existingConfig)
 	if err != nil {
@@ -90,7 +94,7 @@ func UpdateIntegration(
 
 	syncErr := integration.Sync(core.SyncContext{
 		Logger:          logging.ForIntegration(*instance),
-		HTTP:            registry.HTTPContext(),
+		HTTP:            registry.HTTPContextForIntegration(instance.Configuration.Data()),
 		Configuration:   instance.Configuration.Data(),
 		BaseURL:         baseURL,
 		WebhooksBaseURL: webhooksBaseURL,
@@ -102,7 +106,7 @@ func UpdateIntegration(
 	if syncErr != nil {
 		instance.State = "error"
 		instance.StateDescription = fmt.Sprintf("Sync failed: %v", syncErr)
-	} else {
+	} else if instance.State != models.IntegrationStateDegraded {
 		instance.StateDescription = ""
 	}
 
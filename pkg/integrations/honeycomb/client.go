@@ -2,17 +2,25 @@ package honeycomb
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 )
 
 const (
@@ -25,9 +33,89 @@ type Client struct {
 	ManagementKey  string
 	http           core.HTTPContext
 	integrationCtx core.IntegrationContext
+
+	//
+	// The environment the configuration and ingest keys belong to,
+	// and whether it is the default one of the integration.
+	//
+	environment        string
+	defaultEnvironment bool
+
+	//
+	// Trigger lists are only served from the cache when it is enabled,
+	// so callers that act on triggers always see the current ones.
+	//
+	triggersCache *triggersCache
+
+	//
+	// Events are sent with this key instead of the ingest key
+	// of the environment when it is set, see UseIngestKey.
+	//
+	ingestKeyOverride string
+
+	// ctx stops requests and the waits between retried attempts when it is done. See WithContext.
+	ctx context.Context
+}
+
+// WithContext returns a copy of the client whose requests, and the waits
+// between retried attempts, stop as soon as ctx is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// environmentSlugs returns the environments configured in the integration.
+// The first one is the default environment.
+func environmentSlugs(ctx core.IntegrationContext) []string {
+	value, err := ctx.GetConfig("environmentSlug")
+	if err != nil {
+		return []string{}
+	}
+
+	slugs := []string{}
+	for _, slug := range strings.Split(string(value), ",") {
+		slug = strings.TrimSpace(slug)
+		if slug != "" && !slices.Contains(slugs, slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+
+	return slugs
 }
 
+// NewClient returns a client for the default environment of the integration.
 func NewClient(httpCtx core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	return NewClientForEnvironment(httpCtx, ctx, "")
+}
+
+// NewClientForEnvironment returns a client for one of the environments of the integration.
+// An empty environment selects the default one.
+func NewClientForEnvironment(httpCtx core.HTTPContext, ctx core.IntegrationContext, environment string) (*Client, error) {
+	slugs := environmentSlugs(ctx)
+	environment = strings.TrimSpace(environment)
+
+	defaultEnvironment := true
+	if environment != "" && len(slugs) > 0 && environment != slugs[0] {
+		if !slices.Contains(slugs, environment) {
+			return nil, fmt.Errorf("environment %q is not configured in the Honeycomb integration", environment)
+		}
+
+		defaultEnvironment = false
+	}
+
+	if environment == "" && len(slugs) > 0 {
+		environment = slugs[0]
+	}
+
 	siteAny, err := ctx.GetConfig("site")
 	if err != nil {
 		siteAny = []byte("api.honeycomb.io")
@@ -52,13 +140,48 @@ func NewClient(httpCtx core.HTTPContext, ctx core.IntegrationContext) (*Client,
 	}
 
 	return &Client{
-		BaseURL:        baseURL,
-		ManagementKey:  mk,
-		http:           httpCtx,
-		integrationCtx: ctx,
+		BaseURL:            baseURL,
+		ManagementKey:      mk,
+		http:               httpCtx,
+		integrationCtx:     ctx,
+		environment:        environment,
+		defaultEnvironment: defaultEnvironment,
 	}, nil
 }
 
+// Integration fields with the environment the keys of the default environment are created in,
+// for teams whose configuration and ingest keys live in different environments.
+const (
+	configEnvironmentSlugField = "configEnvironmentSlug"
+	ingestEnvironmentSlugField = "ingestEnvironmentSlug"
+)
+
+// keyEnvironment returns the slug of the environment a key is created in.
+// The keys of the default environment are created in the environment of the field when it is set.
+func (c *Client) keyEnvironment(field string) string {
+	if !c.defaultEnvironment {
+		return c.environment
+	}
+
+	value, err := c.integrationCtx.GetConfig(field)
+	if err != nil || strings.TrimSpace(string(value)) == "" {
+		return c.environment
+	}
+
+	return strings.TrimSpace(string(value))
+}
+
+// webhookEnvironment is the environment recorded in webhook configurations,
+// which is empty for the default environment so webhooks created before
+// environments could be selected keep being shared.
+func (c *Client) webhookEnvironment() string {
+	if c.defaultEnvironment {
+		return ""
+	}
+
+	return c.environment
+}
+
 // bearerFromManagementKey normalizes the management key into "keyID:secret" format
 // required by the Honeycomb v2 API Authorization header.
 func (c *Client) bearerFromManagementKey() (string, error) {
@@ -91,18 +214,18 @@ func (c *Client) newReqV1(method, path string, body io.Reader) (*http.Request, e
 	u, _ := url.Parse(c.BaseURL)
 	u.Path = path
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(c.context(), method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
 	cfgKey, err := c.getSecretValue(secretNameConfigurationKey)
 	if err != nil {
-		return nil, fmt.Errorf("missing configuration key secret %q: %w", secretNameConfigurationKey, err)
+		return nil, fmt.Errorf("missing configuration key secret %q: %w", c.secretName(secretNameConfigurationKey), err)
 	}
 	cfgKey = strings.TrimSpace(cfgKey)
 	if cfgKey == "" {
-		return nil, fmt.Errorf("configuration key secret %q is empty", secretNameConfigurationKey)
+		return nil, fmt.Errorf("configuration key secret %q is empty", c.secretName(secretNameConfigurationKey))
 	}
 
 	req.Header.Set("X-Honeycomb-Team", cfgKey)
@@ -116,7 +239,7 @@ func (c *Client) newReqV2(method, path string, body io.Reader) (*http.Request, e
 	u, _ := url.Parse(c.BaseURL)
 	u.Path = path
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(c.context(), method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -132,15 +255,124 @@ func (c *Client) newReqV2(method, path string, body io.Reader) (*http.Request, e
 	return req, nil
 }
 
+const (
+	maxRateLimitAttempts = 3
+	rateLimitBaseBackoff = time.Second
+	rateLimitDeadline    = 30 * time.Second
+)
+
+// rateLimitSleep is replaced in tests, so retries do not wait.
+var rateLimitSleep = sleepContext
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// do sends the request and returns the response body and status code.
+// Requests are retried when Honeycomb is rate limiting or unavailable, see isRetryable,
+// honoring the Retry-After header, for up to maxRateLimitAttempts attempts
+// and as long as the request completes within rateLimitDeadline.
+// Waiting stops as soon as the context of the request is done.
 func (c *Client) do(req *http.Request) ([]byte, int, error) {
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return nil, 0, err
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.http.Do(req)
+		if err != nil {
+			registry.TraceHTTP(c.http, req, 0, nil, err, c.traceSecrets(req)...)
+			return nil, 0, err
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		registry.TraceHTTP(c.http, req, resp.StatusCode, b, nil, c.traceSecrets(req)...)
+
+		if attempt >= maxRateLimitAttempts || !isRetryable(req, resp.StatusCode) {
+			return b, resp.StatusCode, nil
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"), attempt)
+		if time.Since(start)+wait > rateLimitDeadline {
+			return b, resp.StatusCode, nil
+		}
+
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return b, resp.StatusCode, nil
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, 0, err
+			}
+			req.Body = body
+		}
+
+		if err := rateLimitSleep(req.Context(), wait); err != nil {
+			return nil, 0, fmt.Errorf("stopped waiting to retry: %w", err)
+		}
+	}
+}
+
+// traceSecrets returns the keys a request is sent with, so they are redacted from its trace.
+func (c *Client) traceSecrets(req *http.Request) []string {
+	secrets := []string{c.ManagementKey, req.Header.Get("X-Honeycomb-Team")}
+	if _, secret, ok := strings.Cut(c.ManagementKey, ":"); ok {
+		secrets = append(secrets, secret)
 	}
-	defer resp.Body.Close()
 
-	b, _ := io.ReadAll(resp.Body)
-	return b, resp.StatusCode, nil
+	return secrets
+}
+
+// isRetryable reports whether a request can be sent again after a response with the status.
+// GETs have no side effects, so they are retried when Honeycomb is rate limiting.
+// Event ingestion is append-only, so resending events is safe,
+// and it is also retried when Honeycomb is unavailable.
+func isRetryable(req *http.Request, status int) bool {
+	switch {
+	case req.Method == http.MethodGet:
+		return status == http.StatusTooManyRequests
+	case req.Method == http.MethodPost && isIngestPath(req.URL.Path):
+		return status == http.StatusTooManyRequests || slices.Contains(ingestRetryableStatuses, status)
+	default:
+		return false
+	}
+}
+
+// ingestRetryableStatuses are the statuses Honeycomb responds with when it is unavailable.
+var ingestRetryableStatuses = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// isIngestPath reports whether the path is one of the Honeycomb event ingestion APIs.
+func isIngestPath(path string) bool {
+	return strings.HasPrefix(path, "/1/events/") || strings.HasPrefix(path, "/1/batch/")
+}
+
+// retryAfter returns how long to wait before the next attempt.
+// Retry-After can hold seconds or an HTTP date; without it, the wait doubles on every attempt.
+func retryAfter(header string, attempt int) time.Duration {
+	header = strings.TrimSpace(header)
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return rateLimitBaseBackoff << (attempt - 1)
 }
 
 func (c *Client) ValidateManagementKey(teamSlug string) error {
@@ -163,16 +395,122 @@ func (c *Client) ValidateManagementKey(teamSlug string) error {
 		return fmt.Errorf("failed to validate management key: %w", err)
 	}
 
-	switch code {
-	case http.StatusOK:
+	if code == http.StatusOK {
 		return nil
-	case http.StatusUnauthorized:
-		return fmt.Errorf("invalid management key (401): check keyID:secret and that you're using the correct site (US vs EU). body=%s", string(body))
-	case http.StatusForbidden:
-		return fmt.Errorf("management key forbidden (403): missing permissions/scopes. body=%s", string(body))
+	}
+
+	return c.newAPIError(code, body)
+}
+
+// Honeycomb sites, selected with the site setting of the integration.
+const (
+	siteUS = "api.honeycomb.io"
+	siteEU = "api.eu1.honeycomb.io"
+)
+
+// HoneycombAPIError is returned when Honeycomb answers a request with an error status,
+// with the message parsed from the /1 or /2 error formats.
+type HoneycombAPIError struct {
+	StatusCode int
+	Message    string
+	Body       string
+	Site       string
+
+	//
+	// What failed, like "list triggers failed".
+	// Errors without an operation are management key validation errors.
+	//
+	Operation string
+}
+
+func (c *Client) newAPIError(code int, body []byte) *HoneycombAPIError {
+	return &HoneycombAPIError{
+		StatusCode: code,
+		Message:    apiErrorMessage(body),
+		Body:       string(body),
+		Site:       strings.TrimPrefix(strings.TrimPrefix(c.BaseURL, "https://"), "http://"),
+	}
+}
+
+// newRequestError returns the error of a request Honeycomb answered with an error status.
+// The body is included in the error message, unless it is nil.
+func (c *Client) newRequestError(operation string, code int, body []byte) *HoneycombAPIError {
+	err := c.newAPIError(code, body)
+	err.Operation = operation
+	return err
+}
+
+// apiErrorMessage reads the message of a /1 ({"error": "..."})
+// or a /2 ({"errors": [{"title": "...", "detail": "..."}]}) error response.
+func apiErrorMessage(body []byte) string {
+	var response struct {
+		Error  string `json:"error"`
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+
+	if response.Error != "" {
+		return response.Error
+	}
+
+	for _, e := range response.Errors {
+		if e.Detail != "" {
+			return e.Detail
+		}
+		if e.Title != "" {
+			return e.Title
+		}
+	}
+
+	return strings.TrimSpace(string(body))
+}
+
+func (e *HoneycombAPIError) Error() string {
+	if e.Operation != "" {
+		if e.Body == "" {
+			return fmt.Sprintf("%s (http %d)", e.Operation, e.StatusCode)
+		}
+
+		return fmt.Sprintf("%s (http %d): %s", e.Operation, e.StatusCode, e.Body)
+	}
+
+	switch {
+	case e.IsRegionMismatch():
+		return fmt.Sprintf("invalid management key (401): %s", e.RegionGuidance())
+	case e.StatusCode == http.StatusUnauthorized:
+		return fmt.Sprintf("invalid management key (401): check keyID:secret and that you're using the correct site (US vs EU): %s", e.Message)
+	case e.StatusCode == http.StatusForbidden:
+		return fmt.Sprintf("management key forbidden (403): missing permissions/scopes: %s", e.Message)
 	default:
-		return fmt.Errorf("management key validation failed (http %d): %s", code, string(body))
+		return fmt.Sprintf("management key validation failed (http %d): %s", e.StatusCode, e.Message)
+	}
+}
+
+// IsRegionMismatch reports whether the key was rejected because it belongs to
+// the other region. Honeycomb answers with an unknown key in that case,
+// since keys of one region do not exist in the other.
+func (e *HoneycombAPIError) IsRegionMismatch() bool {
+	if e.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	message := strings.ToLower(e.Message)
+	return strings.Contains(message, "unknown api key") || strings.Contains(message, "region")
+}
+
+// RegionGuidance tells which site the key appears to be for.
+func (e *HoneycombAPIError) RegionGuidance() string {
+	if e.Site == siteEU {
+		return fmt.Sprintf("Your key appears to be for the US region; switch the Site setting to US (%s).", siteUS)
 	}
+
+	return fmt.Sprintf("Your key appears to be for the EU region; switch the Site setting to EU (%s).", siteEU)
 }
 
 func (c *Client) pingV1WithConfigKey() (int, []byte, error) {
@@ -194,7 +532,7 @@ func (c *Client) pingV1WithKey(key string) (int, []byte, error) {
 	}
 	u, _ := url.Parse(c.BaseURL)
 	u.Path = "/1/auth"
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(c.context(), http.MethodGet, u.String(), nil)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -213,6 +551,90 @@ func (c *Client) pingV1WithIngestKey() (int, []byte, error) {
 	return c.pingV1WithKey(ingestKey)
 }
 
+// UseIngestKey makes the client send events with the given key,
+// which can belong to another Honeycomb account than the integration.
+func (c *Client) UseIngestKey(key string) {
+	c.ingestKeyOverride = strings.TrimSpace(key)
+}
+
+// ingestKey returns the key events are sent with.
+func (c *Client) ingestKey() (string, error) {
+	if c.ingestKeyOverride != "" {
+		return c.ingestKeyOverride, nil
+	}
+
+	ingestKey, err := c.getSecretValue(secretNameIngestKey)
+	if err != nil || strings.TrimSpace(ingestKey) == "" {
+		return "", fmt.Errorf("ingest key not found (expected secret %q)", c.secretName(secretNameIngestKey))
+	}
+
+	return ingestKey, nil
+}
+
+// ValidateIngestKey checks that Honeycomb accepts the key events are sent with,
+// and that it is allowed to send events.
+func (c *Client) ValidateIngestKey() error {
+	ingestKey, err := c.ingestKey()
+	if err != nil {
+		return err
+	}
+
+	code, body, err := c.pingV1WithKey(ingestKey)
+	if err != nil {
+		return err
+	}
+
+	if code == http.StatusUnauthorized || code == http.StatusForbidden {
+		return c.newRequestError("ingest key was rejected by Honeycomb", code, nil)
+	}
+
+	if code < 200 || code >= 300 {
+		return c.newRequestError("ingest key auth check failed", code, body)
+	}
+
+	var auth authResponse
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return fmt.Errorf("failed to parse auth response: %w", err)
+	}
+
+	if auth.APIKeyAccess.Events != nil && !*auth.APIKeyAccess.Events {
+		return errors.New("ingest key is not allowed to send events")
+	}
+
+	return nil
+}
+
+type authResponse struct {
+	APIKeyAccess struct {
+		CreateDatasets *bool `json:"createDatasets"`
+		Events         *bool `json:"events"`
+	} `json:"api_key_access"`
+}
+
+// IngestKeyCanCreateDatasets reports whether the stored ingest key is allowed to create datasets,
+// which Honeycomb does automatically when an event is sent to a dataset that does not exist yet.
+// If the permission is not reported, the key is assumed to have it.
+func (c *Client) IngestKeyCanCreateDatasets() (bool, error) {
+	code, body, err := c.pingV1WithIngestKey()
+	if err != nil {
+		return false, err
+	}
+	if code < 200 || code >= 300 {
+		return false, c.newRequestError("ingest key auth check failed", code, body)
+	}
+
+	var auth authResponse
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return false, fmt.Errorf("failed to parse auth response: %w", err)
+	}
+
+	if auth.APIKeyAccess.CreateDatasets == nil {
+		return true, nil
+	}
+
+	return *auth.APIKeyAccess.CreateDatasets, nil
+}
+
 type listEnvironmentsResponse struct {
 	Data []struct {
 		ID         string `json:"id"`
@@ -243,7 +665,7 @@ func (c *Client) getEnvironmentID(teamSlug, envSlug string) (string, error) {
 		return "", err
 	}
 	if code < 200 || code >= 300 {
-		return "", fmt.Errorf("list environments failed (http %d): %s", code, string(body))
+		return "", c.newRequestError("list environments failed", code, body)
 	}
 
 	var parsed listEnvironmentsResponse
@@ -263,9 +685,97 @@ func (c *Client) getEnvironmentID(teamSlug, envSlug string) (string, error) {
 	return "", fmt.Errorf("environmentSlug %q not found in team %q", envSlug, teamSlug)
 }
 
+// Permissions that can be granted to the configuration key.
+const (
+	PermissionManageTriggers   = "manage_triggers"
+	PermissionManageRecipients = "manage_recipients"
+	PermissionManageMarkers    = "manage_markers"
+	PermissionRunQueries       = "run_queries"
+	PermissionCreateDatasets   = "create_datasets"
+	PermissionManageSLOs       = "manage_slos"
+)
+
+// ConfigurationKeyPermissions are the scopes requested for the configuration key.
+// Events are always sent with the ingest key, so send_events is never granted.
+type ConfigurationKeyPermissions struct {
+	ManageTriggers   bool
+	ManageRecipients bool
+	ManageMarkers    bool
+	RunQueries       bool
+	CreateDatasets   bool
+	ManageSLOs       bool
+}
+
+// DefaultConfigurationKeyPermissions grants everything SuperPlane uses,
+// except create_datasets, which also allows datasets to be deleted,
+// and manage_slos, which was added after keys were provisioned with the defaults.
+func DefaultConfigurationKeyPermissions() ConfigurationKeyPermissions {
+	return ConfigurationKeyPermissions{
+		ManageTriggers:   true,
+		ManageRecipients: true,
+		ManageMarkers:    true,
+		RunQueries:       true,
+	}
+}
+
+// ParseConfigurationKeyPermissions builds the permissions from their names.
+// A nil list means the permissions were never chosen, so the defaults are used.
+func ParseConfigurationKeyPermissions(names []string) (ConfigurationKeyPermissions, error) {
+	if names == nil {
+		return DefaultConfigurationKeyPermissions(), nil
+	}
+
+	permissions := ConfigurationKeyPermissions{}
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case PermissionManageTriggers:
+			permissions.ManageTriggers = true
+		case PermissionManageRecipients:
+			permissions.ManageRecipients = true
+		case PermissionManageMarkers:
+			permissions.ManageMarkers = true
+		case PermissionRunQueries:
+			permissions.RunQueries = true
+		case PermissionCreateDatasets:
+			permissions.CreateDatasets = true
+		case PermissionManageSLOs:
+			permissions.ManageSLOs = true
+		default:
+			return ConfigurationKeyPermissions{}, fmt.Errorf("unknown configuration key permission %q", name)
+		}
+	}
+
+	return permissions, nil
+}
+
+// Names returns the names of the granted permissions.
+func (p ConfigurationKeyPermissions) Names() []string {
+	names := []string{}
+	for name, granted := range p.attributes() {
+		if granted {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+func (p ConfigurationKeyPermissions) attributes() map[string]bool {
+	return map[string]bool{
+		PermissionManageTriggers:   p.ManageTriggers,
+		PermissionManageRecipients: p.ManageRecipients,
+		PermissionManageMarkers:    p.ManageMarkers,
+		PermissionRunQueries:       p.RunQueries,
+		PermissionCreateDatasets:   p.CreateDatasets,
+		PermissionManageSLOs:       p.ManageSLOs,
+		"send_events":              false,
+	}
+}
+
 // EnsureConfigurationKey creates a configuration API key via the /2 API and stores
 // its secret for use in /1 API requests. If a valid key already exists, it is reused.
-func (c *Client) EnsureConfigurationKey(teamSlug string) error {
+func (c *Client) EnsureConfigurationKey(teamSlug string, permissions ConfigurationKeyPermissions) error {
 	teamSlug = strings.TrimSpace(teamSlug)
 	if teamSlug == "" {
 		return fmt.Errorf("teamSlug is required")
@@ -274,24 +784,23 @@ func (c *Client) EnsureConfigurationKey(teamSlug string) error {
 	if c.hasSecret(secretNameConfigurationKey) {
 		code, body, err := c.pingV1WithConfigKey()
 		if err == nil && code >= 200 && code < 300 {
-			return nil
+			return c.migrateLegacySecret(secretNameConfigurationKey)
 		}
 
 		if err != nil {
 			return fmt.Errorf("configuration key v1 ping failed: %w", err)
 		}
 		if code == http.StatusUnauthorized || code == http.StatusForbidden {
-			_ = c.integrationCtx.SetSecret(secretNameConfigurationKey, []byte{})
+			c.resetSecret(secretNameConfigurationKey)
 		} else {
-			return fmt.Errorf("existing configuration key failed v1 ping (http %d): %s", code, string(body))
+			return c.newRequestError("existing configuration key failed v1 ping", code, body)
 		}
 	}
 
-	envSlugAny, err := c.integrationCtx.GetConfig("environmentSlug")
-	if err != nil || strings.TrimSpace(string(envSlugAny)) == "" {
+	envSlug := c.keyEnvironment(configEnvironmentSlugField)
+	if envSlug == "" {
 		return fmt.Errorf("environmentSlug is required")
 	}
-	envSlug := strings.TrimSpace(string(envSlugAny))
 
 	envID, err := c.getEnvironmentID(teamSlug, envSlug)
 	if err != nil {
@@ -302,14 +811,10 @@ func (c *Client) EnsureConfigurationKey(teamSlug string) error {
 		"data": map[string]any{
 			"type": "api-keys",
 			"attributes": map[string]any{
-				"key_type": "configuration",
-				"name":     "SuperPlane Configuration Key",
-				"disabled": false,
-				"permissions": map[string]any{
-					"manage_triggers":   true,
-					"manage_recipients": true,
-					"send_events":       false,
-				},
+				"key_type":    "configuration",
+				"name":        "SuperPlane Configuration Key",
+				"disabled":    false,
+				"permissions": permissions.attributes(),
 			},
 			"relationships": map[string]any{
 				"environment": map[string]any{
@@ -338,7 +843,7 @@ func (c *Client) EnsureConfigurationKey(teamSlug string) error {
 		return err
 	}
 	if code < 200 || code >= 300 {
-		return fmt.Errorf("create configuration key failed (http %d): %s", code, string(respBody))
+		return c.newRequestError("create configuration key failed", code, respBody)
 	}
 
 	keySecret, err := parseCreatedEnvKeyValue(respBody)
@@ -346,7 +851,7 @@ func (c *Client) EnsureConfigurationKey(teamSlug string) error {
 		return err
 	}
 
-	if err := c.integrationCtx.SetSecret(secretNameConfigurationKey, []byte(keySecret)); err != nil {
+	if err := c.setSecret(secretNameConfigurationKey, keySecret); err != nil {
 		return fmt.Errorf("failed to store configuration key: %w", err)
 	}
 
@@ -355,7 +860,7 @@ func (c *Client) EnsureConfigurationKey(teamSlug string) error {
 		return fmt.Errorf("v1 ping failed after creating config key: %w", err2)
 	}
 	if code2 < 200 || code2 >= 300 {
-		return fmt.Errorf("created configuration key but v1 ping failed (http %d): %s", code2, string(body2))
+		return c.newRequestError("created configuration key but v1 ping failed", code2, body2)
 	}
 
 	return nil
@@ -367,16 +872,16 @@ func (c *Client) EnsureIngestKey(teamSlug string) error {
 	if c.hasSecret(secretNameIngestKey) {
 		code, body, err := c.pingV1WithIngestKey()
 		if err == nil && code >= 200 && code < 300 {
-			return nil
+			return c.migrateLegacySecret(secretNameIngestKey)
 		}
 
 		if err != nil {
 			return fmt.Errorf("ingest key v1 ping failed: %w", err)
 		}
 		if code == http.StatusUnauthorized || code == http.StatusForbidden {
-			_ = c.integrationCtx.SetSecret(secretNameIngestKey, []byte{})
+			c.resetSecret(secretNameIngestKey)
 		} else {
-			return fmt.Errorf("existing ingest key failed v1 ping (http %d): %s", code, string(body))
+			return c.newRequestError("existing ingest key failed v1 ping", code, body)
 		}
 	}
 
@@ -385,11 +890,10 @@ func (c *Client) EnsureIngestKey(teamSlug string) error {
 		return fmt.Errorf("teamSlug is required")
 	}
 
-	envSlugAny, err := c.integrationCtx.GetConfig("environmentSlug")
-	if err != nil || strings.TrimSpace(string(envSlugAny)) == "" {
+	envSlug := c.keyEnvironment(ingestEnvironmentSlugField)
+	if envSlug == "" {
 		return fmt.Errorf("environmentSlug is required")
 	}
-	envSlug := strings.TrimSpace(string(envSlugAny))
 
 	envID, err := c.getEnvironmentID(teamSlug, envSlug)
 	if err != nil {
@@ -434,7 +938,7 @@ func (c *Client) EnsureIngestKey(teamSlug string) error {
 		return err
 	}
 	if code < 200 || code >= 300 {
-		return fmt.Errorf("create ingest key failed (http %d): %s", code, string(respBody))
+		return c.newRequestError("create ingest key failed", code, respBody)
 	}
 
 	keyValue, err := parseCreatedIngestKeyValue(respBody)
@@ -442,7 +946,7 @@ func (c *Client) EnsureIngestKey(teamSlug string) error {
 		return err
 	}
 
-	if err := c.integrationCtx.SetSecret(secretNameIngestKey, []byte(keyValue)); err != nil {
+	if err := c.setSecret(secretNameIngestKey, keyValue); err != nil {
 		return fmt.Errorf("failed to store ingest key secret: %w", err)
 	}
 
@@ -451,7 +955,7 @@ func (c *Client) EnsureIngestKey(teamSlug string) error {
 		return fmt.Errorf("v1 ping failed after creating ingest key: %w", err2)
 	}
 	if code2 < 200 || code2 >= 300 {
-		return fmt.Errorf("created ingest key but v1 ping failed (http %d): %s", code2, string(body2))
+		return c.newRequestError("created ingest key but v1 ping failed", code2, body2)
 	}
 
 	return nil
@@ -463,17 +967,81 @@ type HoneycombTrigger struct {
 	Raw  map[string]any `json:"-"`
 }
 
+// triggersPageSize is the number of triggers requested per page.
+const triggersPageSize = 100
+
+// WithTriggersCache makes the client serve trigger lists from the shared cache.
+func (c *Client) WithTriggersCache() *Client {
+	c.triggersCache = sharedTriggersCache
+	return c
+}
+
+func (c *Client) triggersCacheKey(datasetSlug string) string {
+	return fmt.Sprintf("%s|%s|%s", c.integrationCtx.ID(), c.environment, datasetSlug)
+}
+
+// ListTriggers returns all the triggers of a dataset,
+// requesting pages until a partial or an already seen page is returned.
 func (c *Client) ListTriggers(datasetSlug string) ([]HoneycombTrigger, error) {
+	if c.triggersCache != nil {
+		if triggers, ok := c.triggersCache.Get(c.triggersCacheKey(datasetSlug)); ok {
+			return triggers, nil
+		}
+	}
+
+	triggers := []HoneycombTrigger{}
+	seen := map[string]struct{}{}
+
+	for page := 1; ; page++ {
+		items, err := c.listTriggersPage(datasetSlug, page)
+		if err != nil {
+			return nil, err
+		}
+
+		//
+		// A page with no new triggers means the endpoint is
+		// not paginating, so everything was already returned.
+		//
+		added := 0
+		for _, trigger := range items {
+			if _, ok := seen[trigger.ID]; ok {
+				continue
+			}
+
+			seen[trigger.ID] = struct{}{}
+			triggers = append(triggers, trigger)
+			added++
+		}
+
+		if added == 0 || len(items) < triggersPageSize {
+			break
+		}
+	}
+
+	if c.triggersCache != nil {
+		c.triggersCache.Set(c.triggersCacheKey(datasetSlug), triggers)
+	}
+
+	return triggers, nil
+}
+
+func (c *Client) listTriggersPage(datasetSlug string, page int) ([]HoneycombTrigger, error) {
 	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/triggers/%s", url.PathEscape(datasetSlug)), nil)
 	if err != nil {
 		return nil, err
 	}
+
+	req.URL.RawQuery = url.Values{
+		"limit": {strconv.Itoa(triggersPageSize)},
+		"page":  {strconv.Itoa(page)},
+	}.Encode()
+
 	respBody, code, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	if code < 200 || code >= 300 {
-		return nil, fmt.Errorf("list triggers failed (http %d): %s", code, string(respBody))
+		return nil, c.newRequestError("list triggers failed", code, respBody)
 	}
 
 	var arr []map[string]any
@@ -490,7 +1058,75 @@ func (c *Client) ListTriggers(datasetSlug string) ([]HoneycombTrigger, error) {
 	return out, nil
 }
 
-func (c *Client) GetTrigger(datasetSlug, triggerID string) (map[string]any, error) {
+// Trigger is a Honeycomb trigger.
+// Raw keeps the full trigger as returned by the API, so it can be sent back
+// in updates without dropping fields that are not modeled here.
+type Trigger struct {
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Disabled   bool               `json:"disabled"`
+	Triggered  bool               `json:"triggered"`
+	Threshold  *TriggerThreshold  `json:"threshold,omitempty"`
+	Frequency  int                `json:"frequency"`
+	Recipients []TriggerRecipient `json:"recipients"`
+	Raw        map[string]any     `json:"-"`
+}
+
+// TriggerThreshold is the condition that fires a Honeycomb trigger.
+type TriggerThreshold struct {
+	Op            string  `json:"op"`
+	Value         float64 `json:"value"`
+	ExceededLimit int     `json:"exceeded_limit,omitempty"`
+}
+
+// TriggerRecipient is a recipient notified by a Honeycomb trigger.
+type TriggerRecipient struct {
+	ID      string         `json:"id"`
+	Type    string         `json:"type,omitempty"`
+	Target  string         `json:"target,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// HasRecipient reports whether the recipient is attached to the trigger.
+func (t *Trigger) HasRecipient(recipientID string) bool {
+	for _, r := range t.Recipients {
+		if strings.TrimSpace(r.ID) == recipientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updatePayload returns the raw trigger with the typed recipients applied,
+// ready to be sent to the Honeycomb update API.
+func (t *Trigger) updatePayload() map[string]any {
+	payload := maps.Clone(t.Raw)
+	if payload == nil {
+		payload = map[string]any{}
+	}
+
+	recipients := make([]any, 0, len(t.Recipients))
+	for _, r := range t.Recipients {
+		recipient := map[string]any{"id": r.ID}
+		if r.Type != "" {
+			recipient["type"] = r.Type
+		}
+		if r.Target != "" {
+			recipient["target"] = r.Target
+		}
+		if len(r.Details) > 0 {
+			recipient["details"] = r.Details
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	payload["recipients"] = recipients
+	stripTriggerForUpdate(payload)
+	return payload
+}
+
+func (c *Client) getTriggerBody(datasetSlug, triggerID string) ([]byte, error) {
 	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/triggers/%s/%s", url.PathEscape(datasetSlug), url.PathEscape(triggerID)), nil)
 	if err != nil {
 		return nil, err
@@ -500,7 +1136,16 @@ func (c *Client) GetTrigger(datasetSlug, triggerID string) (map[string]any, erro
 		return nil, err
 	}
 	if code < 200 || code >= 300 {
-		return nil, fmt.Errorf("get trigger failed (http %d): %s", code, string(respBody))
+		return nil, c.newRequestError("get trigger failed", code, respBody)
+	}
+
+	return respBody, nil
+}
+
+func (c *Client) GetTrigger(datasetSlug, triggerID string) (map[string]any, error) {
+	respBody, err := c.getTriggerBody(datasetSlug, triggerID)
+	if err != nil {
+		return nil, err
 	}
 
 	var obj map[string]any
@@ -510,6 +1155,24 @@ func (c *Client) GetTrigger(datasetSlug, triggerID string) (map[string]any, erro
 	return obj, nil
 }
 
+// GetTriggerTyped returns a trigger with its commonly used fields parsed.
+func (c *Client) GetTriggerTyped(datasetSlug, triggerID string) (*Trigger, error) {
+	respBody, err := c.getTriggerBody(datasetSlug, triggerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var trigger Trigger
+	if err := json.Unmarshal(respBody, &trigger); err != nil {
+		return nil, fmt.Errorf("failed to parse trigger: %w", err)
+	}
+	if err := json.Unmarshal(respBody, &trigger.Raw); err != nil {
+		return nil, fmt.Errorf("failed to parse trigger: %w", err)
+	}
+
+	return &trigger, nil
+}
+
 // stripTriggerForUpdate removes read-only and conflicting fields from a trigger
 // payload so it can be sent to the Honeycomb update API.
 func stripTriggerForUpdate(trigger map[string]any) {
@@ -534,40 +1197,35 @@ func (c *Client) UpdateTrigger(datasetSlug, triggerID string, trigger map[string
 		return err
 	}
 	if code < 200 || code >= 300 {
-		return fmt.Errorf("update trigger failed (http %d): %s", code, string(respBody))
+		return c.newRequestError("update trigger failed", code, respBody)
 	}
+
+	sharedTriggersCache.Invalidate(c.triggersCacheKey(datasetSlug))
 	return nil
 }
 
 // EnsureRecipientOnTrigger attaches a webhook recipient to a Honeycomb trigger if not already attached.
-func (c *Client) EnsureRecipientOnTrigger(datasetSlug, triggerID, recipientID string) error {
-	trigger, err := c.GetTrigger(datasetSlug, triggerID)
-	if err != nil {
-		return err
+// The target is the label shown for the recipient on the trigger, and defaults to "SuperPlane".
+func (c *Client) EnsureRecipientOnTrigger(datasetSlug, triggerID, recipientID, target string) error {
+	if target == "" {
+		target = "SuperPlane"
 	}
 
-	recipientsAny, ok := trigger["recipients"]
-	if !ok || recipientsAny == nil {
-		recipientsAny = []any{}
+	trigger, err := c.GetTriggerTyped(datasetSlug, triggerID)
+	if err != nil {
+		return err
 	}
-	recipientsSlice, _ := recipientsAny.([]any)
 
-	for _, r := range recipientsSlice {
-		if rm, ok := r.(map[string]any); ok {
-			if id, _ := rm["id"].(string); strings.TrimSpace(id) == recipientID {
-				return nil // already attached
-			}
-		}
+	if trigger.HasRecipient(recipientID) {
+		return nil
 	}
 
-	recipientsSlice = append(recipientsSlice, map[string]any{
-		"id":     recipientID,
-		"type":   "webhook",
-		"target": "SuperPlane",
+	trigger.Recipients = append(trigger.Recipients, TriggerRecipient{
+		ID:     recipientID,
+		Type:   "webhook",
+		Target: target,
 	})
-	trigger["recipients"] = recipientsSlice
-	stripTriggerForUpdate(trigger)
-	return c.UpdateTrigger(datasetSlug, triggerID, trigger)
+	return c.UpdateTrigger(datasetSlug, triggerID, trigger.updatePayload())
 }
 
 type Recipient struct {
@@ -577,13 +1235,15 @@ type Recipient struct {
 	Details map[string]any `json:"details,omitempty"`
 }
 
-func (c *Client) CreateWebhookRecipient(webhookURL, secret string) (Recipient, error) {
+// CreateWebhookRecipient creates a webhook recipient. Honeycomb signs the notifications
+// sent to it with signingSecret, so the secret is kept with the webhook, not in the recipient metadata.
+func (c *Client) CreateWebhookRecipient(name, webhookURL, signingSecret string) (Recipient, error) {
 	payload := map[string]any{
 		"type": "webhook",
 		"details": map[string]any{
-			"webhook_name":   "SuperPlane",
+			"webhook_name":   name,
 			"webhook_url":    webhookURL,
-			"webhook_secret": secret,
+			"webhook_secret": signingSecret,
 		},
 	}
 
@@ -598,11 +1258,15 @@ func (c *Client) CreateWebhookRecipient(webhookURL, secret string) (Recipient, e
 		return Recipient{}, err
 	}
 
+	//
+	// A recipient for the same URL exists when a previous setup created it
+	// but did not record it, so it is reused instead of failing the setup.
+	//
 	if code == http.StatusConflict {
-		return Recipient{}, fmt.Errorf("recipient already exists in Honeycomb but cannot be retrieved. Delete old SuperPlane recipients in Honeycomb UI under Team Settings > Recipients, then retry")
+		return c.findWebhookRecipientByURL(name, webhookURL)
 	}
 	if code < 200 || code >= 300 {
-		return Recipient{}, fmt.Errorf("create recipient failed (http %d): %s", code, string(respBody))
+		return Recipient{}, c.newRequestError("create recipient failed", code, respBody)
 	}
 
 	var obj map[string]any
@@ -617,6 +1281,123 @@ func (c *Client) CreateWebhookRecipient(webhookURL, secret string) (Recipient, e
 	return Recipient{ID: id, Type: typ, Target: webhookURL, Details: details}, nil
 }
 
+func (c *Client) findWebhookRecipientByURL(name, webhookURL string) (Recipient, error) {
+	recipients, err := c.ListRecipients()
+	if err != nil {
+		return Recipient{}, fmt.Errorf("recipient %q already exists in Honeycomb but cannot be retrieved: %w", name, err)
+	}
+
+	for _, recipient := range recipients {
+		if recipient.Type == "webhook" && recipient.Target == webhookURL {
+			return recipient, nil
+		}
+	}
+
+	return Recipient{}, fmt.Errorf("recipient %q already exists in Honeycomb with another URL. Delete the %q recipient in Honeycomb UI under Team Settings > Recipients, then retry", name, name)
+}
+
+// ListRecipients returns the recipients of the environment the configuration key belongs to.
+func (c *Client) ListRecipients() ([]Recipient, error) {
+	req, err := c.newReqV1(http.MethodGet, "/1/recipients", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, code, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError("list recipients failed", code, respBody)
+	}
+
+	var arr []map[string]any
+	if err := json.Unmarshal(respBody, &arr); err != nil {
+		return nil, fmt.Errorf("failed to parse recipients list: %w", err)
+	}
+
+	out := make([]Recipient, 0, len(arr))
+	for _, m := range arr {
+		id, _ := m["id"].(string)
+		typ, _ := m["type"].(string)
+		details, _ := m["details"].(map[string]any)
+		target, _ := details["webhook_url"].(string)
+		out = append(out, Recipient{ID: id, Type: typ, Target: target, Details: details})
+	}
+	return out, nil
+}
+
+// SendTestNotification posts a test notification to a webhook recipient,
+// signed with the recipient's secret the same way Honeycomb signs trigger notifications.
+// Honeycomb has no API to send a recipient test, so the notification is built here.
+// It returns the HTTP status code returned by the webhook.
+func (c *Client) SendTestNotification(recipient Recipient, payload map[string]any) (int, error) {
+	if recipient.Type != "webhook" {
+		return 0, fmt.Errorf("recipient %s is not a webhook recipient", recipient.ID)
+	}
+
+	target := strings.TrimSpace(recipient.Target)
+	if target == "" {
+		return 0, fmt.Errorf("recipient %s has no webhook URL", recipient.ID)
+	}
+
+	secret, _ := recipient.Details["webhook_secret"].(string)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal test notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(c.context(), http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		h := hmac.New(sha256.New, []byte(secret))
+		h.Write(body)
+		req.Header.Set("X-Honeycomb-Webhook-Signature", hex.EncodeToString(h.Sum(nil)))
+		req.Header.Set("X-Honeycomb-Webhook-Token", secret)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send test notification: %w", err)
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode, nil
+}
+
+// DeleteDataset deletes a dataset and all of its data.
+// A dataset that does not exist is treated as already deleted.
+func (c *Client) DeleteDataset(slug string) error {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return fmt.Errorf("dataset is required")
+	}
+
+	req, err := c.newReqV1(http.MethodDelete, fmt.Sprintf("/1/datasets/%s", url.PathEscape(slug)), nil)
+	if err != nil {
+		return err
+	}
+	body, code, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if code == http.StatusNotFound {
+		return nil
+	}
+	if code == http.StatusConflict {
+		return fmt.Errorf("dataset %s has deletion protection enabled, disable it in the dataset settings first", slug)
+	}
+	if code < 200 || code >= 300 {
+		return c.newRequestError("delete dataset failed", code, body)
+	}
+	return nil
+}
+
 func (c *Client) DeleteRecipient(recipientID string, datasetSlug string) error {
 	// First, remove the recipient from all associated triggers
 	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/recipients/%s/triggers", url.PathEscape(recipientID)), nil)
@@ -653,51 +1434,298 @@ func (c *Client) DeleteRecipient(recipientID string, datasetSlug string) error {
 		return nil
 	}
 	if code < 200 || code >= 300 {
-		return fmt.Errorf("delete recipient failed (http %d)", code)
+		return c.newRequestError("delete recipient failed", code, nil)
 	}
 	return nil
 }
 
 func (c *Client) RemoveRecipientFromTrigger(datasetSlug, triggerID, recipientID string) error {
-	trigger, err := c.GetTrigger(datasetSlug, triggerID)
+	trigger, err := c.GetTriggerTyped(datasetSlug, triggerID)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]TriggerRecipient, 0, len(trigger.Recipients))
+	for _, r := range trigger.Recipients {
+		if r.ID != recipientID {
+			filtered = append(filtered, r)
+		}
+	}
+	trigger.Recipients = filtered
+	return c.UpdateTrigger(datasetSlug, triggerID, trigger.updatePayload())
+}
+
+// SLO is a Honeycomb service level objective.
+type SLO struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	TargetPerMillion int    `json:"target_per_million"`
+}
+
+func (c *Client) ListSLOs(datasetSlug string) ([]SLO, error) {
+	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/slos/%s", url.PathEscape(datasetSlug)), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, code, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError("list SLOs failed", code, body)
+	}
+
+	slos := []SLO{}
+	if err := json.Unmarshal(body, &slos); err != nil {
+		return nil, fmt.Errorf("failed to parse SLOs: %w", err)
+	}
+	return slos, nil
+}
+
+var errBurnAlertNotFound = errors.New("burn alert not found")
+
+// BurnAlert is a Honeycomb SLO burn alert.
+// Raw keeps the full burn alert as returned by the API, so it can be sent back
+// in updates without dropping fields that are not modeled here.
+type BurnAlert struct {
+	ID         string             `json:"id"`
+	AlertType  string             `json:"alert_type"`
+	Recipients []TriggerRecipient `json:"recipients"`
+	Raw        map[string]any     `json:"-"`
+}
+
+// HasRecipient reports whether the recipient is attached to the burn alert.
+func (a *BurnAlert) HasRecipient(recipientID string) bool {
+	for _, r := range a.Recipients {
+		if strings.TrimSpace(r.ID) == recipientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updatePayload returns the raw burn alert with the recipients applied,
+// ready to be sent to the Honeycomb update API. Recipients are referenced by ID.
+func (a *BurnAlert) updatePayload() map[string]any {
+	payload := maps.Clone(a.Raw)
+	if payload == nil {
+		payload = map[string]any{}
+	}
+
+	recipients := make([]any, 0, len(a.Recipients))
+	for _, r := range a.Recipients {
+		recipients = append(recipients, map[string]any{"id": r.ID})
+	}
+
+	payload["recipients"] = recipients
+	delete(payload, "id")
+	delete(payload, "created_at")
+	delete(payload, "updated_at")
+	return payload
+}
+
+// ListBurnAlerts returns the burn alerts of an SLO.
+func (c *Client) ListBurnAlerts(datasetSlug, sloID string) ([]BurnAlert, error) {
+	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/burn_alerts/%s", url.PathEscape(datasetSlug)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL.RawQuery = url.Values{"slo_id": {sloID}}.Encode()
+	body, code, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError("list burn alerts failed", code, body)
+	}
+
+	alerts := []BurnAlert{}
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to parse burn alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+func (c *Client) getBurnAlert(datasetSlug, burnAlertID string) (*BurnAlert, error) {
+	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/burn_alerts/%s/%s", url.PathEscape(datasetSlug), url.PathEscape(burnAlertID)), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, code, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if code == http.StatusNotFound {
+		return nil, errBurnAlertNotFound
+	}
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError("get burn alert failed", code, body)
+	}
+
+	var alert BurnAlert
+	if err := json.Unmarshal(body, &alert); err != nil {
+		return nil, fmt.Errorf("failed to parse burn alert: %w", err)
+	}
+	if err := json.Unmarshal(body, &alert.Raw); err != nil {
+		return nil, fmt.Errorf("failed to parse burn alert: %w", err)
+	}
+
+	return &alert, nil
+}
+
+func (c *Client) updateBurnAlert(datasetSlug, burnAlertID string, alert *BurnAlert) error {
+	body, _ := json.Marshal(alert.updatePayload())
+	req, err := c.newReqV1(http.MethodPut, fmt.Sprintf("/1/burn_alerts/%s/%s", url.PathEscape(datasetSlug), url.PathEscape(burnAlertID)), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	respBody, code, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if code < 200 || code >= 300 {
+		return c.newRequestError("update burn alert failed", code, respBody)
+	}
+	return nil
+}
+
+// EnsureRecipientOnBurnAlert attaches a recipient to a burn alert if not already attached.
+func (c *Client) EnsureRecipientOnBurnAlert(datasetSlug, burnAlertID, recipientID string) error {
+	alert, err := c.getBurnAlert(datasetSlug, burnAlertID)
+	if err != nil {
+		return err
+	}
+
+	if alert.HasRecipient(recipientID) {
+		return nil
+	}
+
+	alert.Recipients = append(alert.Recipients, TriggerRecipient{ID: recipientID})
+	return c.updateBurnAlert(datasetSlug, burnAlertID, alert)
+}
+
+// RemoveRecipientFromBurnAlert detaches a recipient from a burn alert.
+// Burn alerts that no longer exist have nothing to detach.
+func (c *Client) RemoveRecipientFromBurnAlert(datasetSlug, burnAlertID, recipientID string) error {
+	alert, err := c.getBurnAlert(datasetSlug, burnAlertID)
+	if errors.Is(err, errBurnAlertNotFound) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	recipientsAny, _ := trigger["recipients"].([]any)
-	filtered := make([]any, 0)
-	for _, r := range recipientsAny {
-		if rm, ok := r.(map[string]any); ok {
-			if id, _ := rm["id"].(string); id != recipientID {
-				filtered = append(filtered, rm)
+	if !alert.HasRecipient(recipientID) {
+		return nil
+	}
+
+	filtered := make([]TriggerRecipient, 0, len(alert.Recipients))
+	for _, r := range alert.Recipients {
+		if r.ID != recipientID {
+			filtered = append(filtered, r)
+		}
+	}
+	alert.Recipients = filtered
+	return c.updateBurnAlert(datasetSlug, burnAlertID, alert)
+}
+
+// EventEnvelope is how events are wrapped in the body of an ingest request.
+// The events API takes the fields of one event as the body, and
+// the batch API takes an array of {"time": ..., "data": {...}} entries.
+type EventEnvelope string
+
+const (
+	EventEnvelopeFlat EventEnvelope = "flat"
+	EventEnvelopeData EventEnvelope = "data"
+)
+
+// validateEventEnvelope checks that decoded fields have the shape of the envelope:
+// a single object for the flat envelope, and an array for the data envelope.
+func validateEventEnvelope(envelope EventEnvelope, fields any) error {
+	switch envelope {
+	case EventEnvelopeFlat:
+		if _, ok := fields.([]any); ok {
+			return errors.New("fields json must be a single object, not an array; use Create Events to send several events")
+		}
+
+		if _, ok := fields.(map[string]any); !ok {
+			return errors.New("fields json must be an object")
+		}
+
+		return nil
+	case EventEnvelopeData:
+		if _, ok := fields.([]any); !ok {
+			return errors.New("fields json must be an array of objects")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown event envelope %q", envelope)
+	}
+}
+
+// eventsBody returns the request body for the events in the envelope.
+// The flat envelope holds exactly one event. In the data envelope, the "time" key
+// of an event becomes the time of its entry, and events without one get the current time.
+func eventsBody(envelope EventEnvelope, events []map[string]any) ([]byte, error) {
+	switch envelope {
+	case EventEnvelopeFlat:
+		if len(events) != 1 {
+			return nil, fmt.Errorf("the flat envelope holds a single event, got %d", len(events))
+		}
+
+		return json.Marshal(events[0])
+	case EventEnvelopeData:
+		now := time.Now().UTC().Format(time.RFC3339Nano)
+		batch := make([]batchEvent, 0, len(events))
+		for _, event := range events {
+			entry := batchEvent{Time: now, Data: event}
+			if t, ok := event["time"]; ok {
+				entry.Time = fmt.Sprint(t)
+				entry.Data = maps.Clone(event)
+				delete(entry.Data, "time")
 			}
+
+			batch = append(batch, entry)
 		}
+
+		return json.Marshal(batch)
+	default:
+		return nil, fmt.Errorf("unknown event envelope %q", envelope)
 	}
-	trigger["recipients"] = filtered
-	stripTriggerForUpdate(trigger)
-	return c.UpdateTrigger(datasetSlug, triggerID, trigger)
 }
 
-func (c *Client) CreateEvent(datasetSlug string, fields map[string]any) error {
+// CreateEvent sends a single event to a dataset.
+// When coerceTypes is set, string values that look like numbers or booleans
+// are sent as numbers and booleans. A sampleRate above 1 is sent in the
+// X-Honeycomb-Samplerate header, so Honeycomb weights the event in queries.
+func (c *Client) CreateEvent(datasetSlug string, fields map[string]any, coerceTypes bool, sampleRate int) error {
 	datasetSlug = strings.TrimSpace(datasetSlug)
 	if datasetSlug == "" {
 		return fmt.Errorf("dataset is required")
 	}
 
-	ingestHeader, err := c.getSecretValue(secretNameIngestKey)
-	if err != nil || strings.TrimSpace(ingestHeader) == "" {
-		return fmt.Errorf("ingest key not found (expected secret %q)", secretNameIngestKey)
+	ingestHeader, err := c.ingestKey()
+	if err != nil {
+		return err
 	}
 
 	u, _ := url.Parse(c.BaseURL)
 	u.Path = fmt.Sprintf("/1/events/%s", url.PathEscape(datasetSlug))
 
-	body, err := json.Marshal(fields)
+	if coerceTypes {
+		fields = coerceFieldTypes(fields)
+	}
+
+	body, err := eventsBody(EventEnvelopeFlat, []map[string]any{fields})
 	if err != nil {
 		return fmt.Errorf("failed to marshal fields: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(c.context(), http.MethodPost, u.String(), bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -706,52 +1734,226 @@ func (c *Client) CreateEvent(datasetSlug string, fields map[string]any) error {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	if sampleRate > 1 {
+		req.Header.Set("X-Honeycomb-Samplerate", strconv.Itoa(sampleRate))
+	}
+
 	// If the event does not include a time field, set it automatically
 	if _, hasTimeField := fields["time"]; !hasTimeField {
 		req.Header.Set("X-Honeycomb-Event-Time", time.Now().UTC().Format(time.RFC3339Nano))
 	}
 
-	resp, err := c.http.Do(req)
+	b, code, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+	if code >= 200 && code < 300 {
 		return nil
 	}
 
-	b, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("honeycomb create event failed (status %d): %s", resp.StatusCode, string(b))
+	return c.newRequestError("honeycomb create event failed", code, b)
 }
 
-func (c *Client) getSecretValue(name string) (string, error) {
-	secrets, err := c.integrationCtx.GetSecrets()
+// BatchResult is the outcome of a single event sent through the batch API.
+type BatchResult struct {
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (r BatchResult) Succeeded() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+type batchEvent struct {
+	Time string         `json:"time"`
+	Data map[string]any `json:"data"`
+}
+
+// CreateEvents sends events to a dataset in a single request.
+// Honeycomb accepts or rejects each event separately,
+// so the returned results hold one status per event, in the same order.
+func (c *Client) CreateEvents(datasetSlug string, events []map[string]any) ([]BatchResult, error) {
+	datasetSlug = strings.TrimSpace(datasetSlug)
+	if datasetSlug == "" {
+		return nil, fmt.Errorf("dataset is required")
+	}
+
+	ingestHeader, err := c.ingestKey()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	for _, s := range secrets {
-		if s.Name == name {
-			v := strings.TrimSpace(string(s.Value))
-			if v != "" {
-				return v, nil
-			}
-		}
+
+	//
+	// The batch API has no per-event header, so the event time
+	// is taken from the "time" key of each event, if present.
+	//
+	body, err := eventsBody(EventEnvelopeData, events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal events: %w", err)
+	}
+
+	u, _ := url.Parse(c.BaseURL)
+	u.Path = fmt.Sprintf("/1/batch/%s", url.PathEscape(datasetSlug))
+
+	req, err := http.NewRequestWithContext(c.context(), http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-Honeycomb-Team", ingestHeader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	respBody, code, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError("honeycomb create events failed", code, respBody)
+	}
+
+	var results []BatchResult
+	if err := json.Unmarshal(respBody, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse batch response: %w", err)
+	}
+
+	if len(results) != len(events) {
+		return nil, fmt.Errorf("honeycomb returned %d results for %d events", len(results), len(events))
 	}
-	return "", fmt.Errorf("secret %q not found", name)
+
+	return results, nil
 }
 
-func (c *Client) hasSecret(name string) bool {
+// MarkerRequest is the body sent to the Honeycomb markers API.
+// StartTime and EndTime are Unix timestamps in seconds.
+type MarkerRequest struct {
+	Message   string `json:"message,omitempty"`
+	Type      string `json:"type,omitempty"`
+	URL       string `json:"url,omitempty"`
+	StartTime *int64 `json:"start_time,omitempty"`
+	EndTime   *int64 `json:"end_time,omitempty"`
+}
+
+type Marker struct {
+	ID        string `json:"id"`
+	Message   string `json:"message"`
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CreateMarker creates a marker in a dataset.
+// Use the __all__ dataset to create an environment-wide marker.
+func (c *Client) CreateMarker(datasetSlug string, marker MarkerRequest) (*Marker, error) {
+	datasetSlug = strings.TrimSpace(datasetSlug)
+	if datasetSlug == "" {
+		return nil, fmt.Errorf("dataset is required")
+	}
+
+	body, err := json.Marshal(marker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal marker: %w", err)
+	}
+
+	req, err := c.newReqV1(http.MethodPost, fmt.Sprintf("/1/markers/%s", url.PathEscape(datasetSlug)), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, code, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError("create marker failed", code, respBody)
+	}
+
+	var created Marker
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return nil, fmt.Errorf("failed to parse marker: %w", err)
+	}
+
+	return &created, nil
+}
+
+// secretName namespaces a secret by the environment of the client,
+// so every environment has its own configuration and ingest keys.
+func (c *Client) secretName(name string) string {
+	if c.environment == "" {
+		return name
+	}
+
+	return name + "_" + c.environment
+}
+
+// lookupSecret returns the value of the secret for the environment of the client.
+// Integrations created before secrets were namespaced only have the secret under its
+// plain name, which is used for the default environment until it is migrated.
+func (c *Client) lookupSecret(name string) (value string, legacy bool, err error) {
 	secrets, err := c.integrationCtx.GetSecrets()
 	if err != nil {
-		return false
+		return "", false, err
 	}
+
+	values := map[string]string{}
 	for _, s := range secrets {
-		if s.Name == name && strings.TrimSpace(string(s.Value)) != "" {
-			return true
+		if v := strings.TrimSpace(string(s.Value)); v != "" {
+			values[s.Name] = v
 		}
 	}
-	return false
+
+	if v, ok := values[c.secretName(name)]; ok {
+		return v, false, nil
+	}
+
+	if v, ok := values[name]; ok && c.defaultEnvironment {
+		return v, true, nil
+	}
+
+	return "", false, fmt.Errorf("secret %q not found", c.secretName(name))
+}
+
+func (c *Client) getSecretValue(name string) (string, error) {
+	value, _, err := c.lookupSecret(name)
+	return value, err
+}
+
+func (c *Client) hasSecret(name string) bool {
+	_, _, err := c.lookupSecret(name)
+	return err == nil
+}
+
+func (c *Client) setSecret(name, value string) error {
+	return c.integrationCtx.SetSecret(c.secretName(name), []byte(value))
+}
+
+// resetSecret clears the secret, and its legacy copy for the default environment,
+// so a new key is created.
+func (c *Client) resetSecret(name string) {
+	_ = c.integrationCtx.SetSecret(c.secretName(name), []byte{})
+	if c.defaultEnvironment && c.secretName(name) != name {
+		_ = c.integrationCtx.SetSecret(name, []byte{})
+	}
+}
+
+// migrateLegacySecret moves a working secret stored under its plain name
+// to the name namespaced by the environment.
+func (c *Client) migrateLegacySecret(name string) error {
+	value, legacy, err := c.lookupSecret(name)
+	if err != nil || !legacy || c.secretName(name) == name {
+		return nil
+	}
+
+	if err := c.setSecret(name, value); err != nil {
+		return fmt.Errorf("failed to migrate secret %q: %w", name, err)
+	}
+
+	_ = c.integrationCtx.SetSecret(name, []byte{})
+	return nil
 }
 
 func generateTokenHex(nBytes int) (string, error) {
@@ -819,18 +2021,59 @@ type Dataset struct {
 	Slug string `json:"slug"`
 }
 
+// datasetsPageSize is the number of datasets requested per page.
+const datasetsPageSize = 100
+
+// ListDatasets returns all the datasets in the environment,
+// requesting pages until an empty page is returned.
 func (c *Client) ListDatasets() ([]Dataset, error) {
+	datasets := []Dataset{}
+	seen := map[string]struct{}{}
+
+	for page := 1; ; page++ {
+		items, err := c.listDatasetsPage(page)
+		if err != nil {
+			return nil, err
+		}
+
+		//
+		// A page with no new datasets means the endpoint is
+		// not paginating, so everything was already returned.
+		//
+		added := 0
+		for _, dataset := range items {
+			if _, ok := seen[dataset.Slug]; ok {
+				continue
+			}
+
+			seen[dataset.Slug] = struct{}{}
+			datasets = append(datasets, dataset)
+			added++
+		}
+
+		if added == 0 {
+			return datasets, nil
+		}
+	}
+}
+
+func (c *Client) listDatasetsPage(page int) ([]Dataset, error) {
 	req, err := c.newReqV1(http.MethodGet, "/1/datasets", nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req.URL.RawQuery = url.Values{
+		"limit": {strconv.Itoa(datasetsPageSize)},
+		"page":  {strconv.Itoa(page)},
+	}.Encode()
+
 	body, code, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	if code < 200 || code >= 300 {
-		return nil, fmt.Errorf("list datasets failed (http %d): %s", code, string(body))
+		return nil, c.newRequestError("list datasets failed", code, body)
 	}
 
 	var datasets []Dataset
@@ -0,0 +1,529 @@
+package honeycomb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Client__RateLimitRetries(t *testing.T) {
+	var waits []time.Duration
+	recordWait := func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	rateLimitSleep = recordWait
+	t.Cleanup(func() { rateLimitSleep = sleepContext })
+
+	t.Run("GET 429 then 200 -> retried after Retry-After", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimitedResponse("2"),
+				jsonResponse(`{"id":"t1","name":"High Error Rate"}`),
+			},
+		}
+
+		trigger, err := newTestClient(t, httpCtx).GetTrigger("production", "t1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "High Error Rate", trigger["name"])
+		assert.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, []time.Duration{2 * time.Second}, waits)
+	})
+
+	t.Run("ingest POST 429 then 200 -> retried with the same body", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimitedResponse(""),
+				jsonResponse(`{}`),
+			},
+		}
+
+		err := newTestClient(t, httpCtx).CreateEvent("production", map[string]any{"message": "deploy"}, false, 1)
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		body, err := io.ReadAll(httpCtx.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"message":"deploy"}`, string(body))
+		assert.Equal(t, []time.Duration{time.Second}, waits)
+	})
+
+	t.Run("ingest POST 5xx -> retried", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusServiceUnavailable, ``),
+				jsonResponse(`[{"status":202}]`),
+			},
+		}
+
+		results, err := newTestClient(t, httpCtx).CreateEvents("production", []map[string]any{{"message": "deploy"}})
+
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("GET 5xx -> not retried", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusServiceUnavailable, ``),
+				jsonResponse(`[]`),
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).ListDatasets()
+
+		require.ErrorContains(t, err, "http 503")
+		assert.Len(t, httpCtx.Requests, 1)
+		assert.Empty(t, waits)
+	})
+
+	t.Run("always 429 -> gives up after max attempts with exponential backoff", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimitedResponse(""),
+				rateLimitedResponse(""),
+				rateLimitedResponse(""),
+				jsonResponse(`[]`),
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).ListDatasets()
+
+		require.ErrorContains(t, err, "list datasets failed (http 429)")
+		assert.Len(t, httpCtx.Requests, maxRateLimitAttempts)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+	})
+
+	t.Run("Retry-After beyond deadline -> no retry", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimitedResponse("120"),
+				jsonResponse(`[]`),
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).ListDatasets()
+
+		require.ErrorContains(t, err, "http 429")
+		assert.Len(t, httpCtx.Requests, 1)
+		assert.Empty(t, waits)
+	})
+
+	t.Run("non-ingest POST 429 -> not retried", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimitedResponse("1"),
+				jsonResponse(`{"id":"marker-1"}`),
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).CreateMarker("production", MarkerRequest{Message: "deploy"})
+
+		require.ErrorContains(t, err, "create marker failed (http 429)")
+		assert.Len(t, httpCtx.Requests, 1)
+		assert.Empty(t, waits)
+	})
+
+	t.Run("context cancelled while waiting -> stops without retrying", func(t *testing.T) {
+		rateLimitSleep = sleepContext
+		t.Cleanup(func() { rateLimitSleep = recordWait })
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimitedResponse("10"),
+				jsonResponse(`[]`),
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := newTestClient(t, httpCtx).WithContext(ctx).ListDatasets()
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+}
+
+func Test__Client__ListDatasets(t *testing.T) {
+	t.Run("multiple pages -> requests pages until an empty one and merges them", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"name":"Production","slug":"production"},{"name":"Staging","slug":"staging"}]`),
+				jsonResponse(`[{"name":"Development","slug":"development"}]`),
+				jsonResponse(`[]`),
+			},
+		}
+
+		datasets, err := newTestClient(t, httpCtx).ListDatasets()
+
+		require.NoError(t, err)
+		assert.Equal(t, []Dataset{
+			{Name: "Production", Slug: "production"},
+			{Name: "Staging", Slug: "staging"},
+			{Name: "Development", Slug: "development"},
+		}, datasets)
+
+		require.Len(t, httpCtx.Requests, 3)
+		for i, req := range httpCtx.Requests {
+			assert.Equal(t, "/1/datasets", req.URL.Path)
+			assert.Equal(t, "100", req.URL.Query().Get("limit"))
+			assert.Equal(t, strconv.Itoa(i+1), req.URL.Query().Get("page"))
+		}
+	})
+
+	t.Run("endpoint ignores pagination -> stops when a page has no new datasets", func(t *testing.T) {
+		page := `[{"name":"Production","slug":"production"}]`
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(page), jsonResponse(page)},
+		}
+
+		datasets, err := newTestClient(t, httpCtx).ListDatasets()
+
+		require.NoError(t, err)
+		assert.Equal(t, []Dataset{{Name: "Production", Slug: "production"}}, datasets)
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("page request fails -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"name":"Production","slug":"production"}]`),
+				{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(`oops`))},
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).ListDatasets()
+		require.ErrorContains(t, err, "list datasets failed (http 500)")
+	})
+}
+
+func triggersPage(from, count int) string {
+	items := make([]map[string]any, 0, count)
+	for i := from; i < from+count; i++ {
+		items = append(items, map[string]any{"id": "t" + strconv.Itoa(i), "name": "Trigger " + strconv.Itoa(i)})
+	}
+
+	body, _ := json.Marshal(items)
+	return string(body)
+}
+
+func Test__Client__ListTriggers(t *testing.T) {
+	t.Run("full page -> requests the next page and merges them", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(triggersPage(0, triggersPageSize)),
+				jsonResponse(triggersPage(triggersPageSize, 2)),
+			},
+		}
+
+		triggers, err := newTestClient(t, httpCtx).ListTriggers("production")
+
+		require.NoError(t, err)
+		assert.Len(t, triggers, triggersPageSize+2)
+		assert.Equal(t, "t101", triggers[triggersPageSize+1].ID)
+
+		require.Len(t, httpCtx.Requests, 2)
+		for i, req := range httpCtx.Requests {
+			assert.Equal(t, "/1/triggers/production", req.URL.Path)
+			assert.Equal(t, "100", req.URL.Query().Get("limit"))
+			assert.Equal(t, strconv.Itoa(i+1), req.URL.Query().Get("page"))
+		}
+	})
+
+	t.Run("endpoint ignores pagination -> stops when a page has no new triggers", func(t *testing.T) {
+		page := triggersPage(0, triggersPageSize)
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(page), jsonResponse(page)},
+		}
+
+		triggers, err := newTestClient(t, httpCtx).ListTriggers("production")
+
+		require.NoError(t, err)
+		assert.Len(t, triggers, triggersPageSize)
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("cache enabled -> second list is served from the cache", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(triggersPage(0, 1))},
+		}
+
+		client := newTestClient(t, httpCtx).WithTriggersCache()
+
+		first, err := client.ListTriggers("production")
+		require.NoError(t, err)
+		second, err := client.ListTriggers("production")
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("cache disabled -> every list requests Honeycomb", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(triggersPage(0, 1)), jsonResponse(triggersPage(0, 1))},
+		}
+
+		client := newTestClient(t, httpCtx)
+
+		_, err := client.ListTriggers("production")
+		require.NoError(t, err)
+		_, err = client.ListTriggers("production")
+		require.NoError(t, err)
+
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("recipient attached to a trigger -> cached list is invalidated", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"t1","name":"High Error Rate","recipients":[]}]`),
+				jsonResponse(`{"id":"t1","name":"High Error Rate","recipients":[]}`),
+				jsonResponse(`{}`),
+				jsonResponse(`[{"id":"t1","name":"High Error Rate","recipients":[{"id":"rcp-1"}]}]`),
+			},
+		}
+
+		client := newTestClient(t, httpCtx).WithTriggersCache()
+
+		_, err := client.ListTriggers("production")
+		require.NoError(t, err)
+		require.NoError(t, client.EnsureRecipientOnTrigger("production", "t1", "rcp-1", ""))
+
+		triggers, err := client.ListTriggers("production")
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 4)
+		assert.Equal(t, []any{map[string]any{"id": "rcp-1"}}, triggers[0].Raw["recipients"])
+	})
+}
+
+func Test__Client__Environments(t *testing.T) {
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"managementKey":   "keyid:secret",
+			"site":            "api.honeycomb.io",
+			"environmentSlug": "production, staging",
+		},
+		Secrets: map[string]core.IntegrationSecret{
+			secretNameConfigurationKey:              {Name: secretNameConfigurationKey, Value: []byte("legacy-config-key")},
+			secretNameConfigurationKey + "_staging": {Name: secretNameConfigurationKey + "_staging", Value: []byte("staging-config-key")},
+		},
+	}
+
+	t.Run("no environment -> default environment", func(t *testing.T) {
+		client, err := NewClientForEnvironment(&contexts.HTTPContext{}, integrationCtx, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "production", client.environment)
+		assert.Equal(t, "", client.webhookEnvironment())
+	})
+
+	t.Run("default environment -> legacy secret is used", func(t *testing.T) {
+		client, err := NewClientForEnvironment(&contexts.HTTPContext{}, integrationCtx, "production")
+		require.NoError(t, err)
+
+		req, err := client.newReqV1(http.MethodGet, "/1/auth", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-config-key", req.Header.Get("X-Honeycomb-Team"))
+		assert.Equal(t, "", client.webhookEnvironment())
+	})
+
+	t.Run("other environment -> environment secret is used", func(t *testing.T) {
+		client, err := NewClientForEnvironment(&contexts.HTTPContext{}, integrationCtx, "staging")
+		require.NoError(t, err)
+
+		req, err := client.newReqV1(http.MethodGet, "/1/auth", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "staging-config-key", req.Header.Get("X-Honeycomb-Team"))
+		assert.Equal(t, "staging", client.webhookEnvironment())
+	})
+
+	t.Run("unknown environment -> error", func(t *testing.T) {
+		_, err := NewClientForEnvironment(&contexts.HTTPContext{}, integrationCtx, "dev")
+
+		require.ErrorContains(t, err, `environment "dev" is not configured`)
+	})
+}
+
+func Test__Client__GetTriggerTyped(t *testing.T) {
+	triggerBody := `{
+		"id": "t1",
+		"name": "High Error Rate",
+		"disabled": true,
+		"triggered": false,
+		"frequency": 900,
+		"query_id": "q1",
+		"query": {"calculations": [{"op": "COUNT"}]},
+		"threshold": {"op": ">", "value": 5, "exceeded_limit": 2},
+		"recipients": [
+			{"id": "rcp-1", "type": "webhook", "target": "SuperPlane"},
+			{"id": "rcp-2", "type": "pagerduty", "details": {"pagerduty_severity": "critical"}}
+		]
+	}`
+
+	t.Run("trigger -> typed fields and raw trigger", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(triggerBody)}}
+
+		trigger, err := newTestClient(t, httpCtx).GetTriggerTyped("production", "t1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "t1", trigger.ID)
+		assert.Equal(t, "High Error Rate", trigger.Name)
+		assert.True(t, trigger.Disabled)
+		assert.Equal(t, 900, trigger.Frequency)
+		assert.Equal(t, &TriggerThreshold{Op: ">", Value: 5, ExceededLimit: 2}, trigger.Threshold)
+		assert.Equal(t, []TriggerRecipient{
+			{ID: "rcp-1", Type: "webhook", Target: "SuperPlane"},
+			{ID: "rcp-2", Type: "pagerduty", Details: map[string]any{"pagerduty_severity": "critical"}},
+		}, trigger.Recipients)
+		assert.True(t, trigger.HasRecipient("rcp-2"))
+		assert.False(t, trigger.HasRecipient("rcp-3"))
+		assert.Equal(t, "q1", trigger.Raw["query_id"])
+	})
+
+	t.Run("remove recipient -> other fields are sent back unchanged", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(triggerBody),
+				jsonResponse(`{}`),
+			},
+		}
+
+		err := newTestClient(t, httpCtx).RemoveRecipientFromTrigger("production", "t1", "rcp-1")
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, http.MethodPut, httpCtx.Requests[1].Method)
+
+		update := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[1].Body).Decode(&update))
+		assert.Equal(t, map[string]any{
+			"name":      "High Error Rate",
+			"disabled":  true,
+			"frequency": float64(900),
+			"query_id":  "q1",
+			"threshold": map[string]any{"op": ">", "value": float64(5), "exceeded_limit": float64(2)},
+			"recipients": []any{
+				map[string]any{"id": "rcp-2", "type": "pagerduty", "details": map[string]any{"pagerduty_severity": "critical"}},
+			},
+		}, update)
+	})
+
+	t.Run("recipient already attached -> trigger is not updated", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(triggerBody)}}
+
+		err := newTestClient(t, httpCtx).EnsureRecipientOnTrigger("production", "t1", "rcp-1", "")
+
+		require.NoError(t, err)
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+}
+
+func Test__Client__Trace(t *testing.T) {
+	t.Run("tracing HTTP context -> records the request without the keys", func(t *testing.T) {
+		httpCtx := &contexts.TracingHTTPContext{
+			HTTPContext: contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(`{"id":"m1","message":"deploy","echo":"test-config-key"}`),
+				},
+			},
+		}
+
+		client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("test-config-key")},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.CreateMarker("production", MarkerRequest{Message: "deploy"})
+		require.NoError(t, err)
+
+		require.Len(t, httpCtx.Traces, 1)
+		assert.Equal(t, core.HTTPTrace{
+			Method:       http.MethodPost,
+			URL:          "https://api.honeycomb.io/1/markers/production",
+			Status:       http.StatusOK,
+			RequestBody:  `{"message":"deploy"}`,
+			ResponseBody: `{"id":"m1","message":"deploy","echo":"[REDACTED]"}`,
+		}, httpCtx.Traces[0])
+	})
+}
+
+func Test__Client__EventEnvelope(t *testing.T) {
+	t.Run("flat envelope -> fields are the body", func(t *testing.T) {
+		body, err := eventsBody(EventEnvelopeFlat, []map[string]any{{"message": "deploy", "time": "2026-01-19T12:00:00Z"}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"message":"deploy","time":"2026-01-19T12:00:00Z"}`, string(body))
+	})
+
+	t.Run("flat envelope with several events -> error", func(t *testing.T) {
+		_, err := eventsBody(EventEnvelopeFlat, []map[string]any{{"message": "a"}, {"message": "b"}})
+		require.ErrorContains(t, err, "the flat envelope holds a single event, got 2")
+	})
+
+	t.Run("data envelope -> events are wrapped with their time", func(t *testing.T) {
+		body, err := eventsBody(EventEnvelopeData, []map[string]any{
+			{"message": "a", "time": "2026-01-19T12:00:00Z"},
+			{"message": "b"},
+		})
+		require.NoError(t, err)
+
+		batch := []map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &batch))
+		require.Len(t, batch, 2)
+		assert.Equal(t, map[string]any{"time": "2026-01-19T12:00:00Z", "data": map[string]any{"message": "a"}}, batch[0])
+		assert.Equal(t, map[string]any{"message": "b"}, batch[1]["data"])
+		_, err = time.Parse(time.RFC3339Nano, batch[1]["time"].(string))
+		assert.NoError(t, err)
+	})
+
+	t.Run("flat envelope and array -> error", func(t *testing.T) {
+		err := validateEventEnvelope(EventEnvelopeFlat, []any{map[string]any{"message": "a"}})
+		require.ErrorContains(t, err, "fields json must be a single object, not an array")
+	})
+
+	t.Run("flat envelope and object -> valid", func(t *testing.T) {
+		require.NoError(t, validateEventEnvelope(EventEnvelopeFlat, map[string]any{"message": "a"}))
+	})
+
+	t.Run("data envelope and object -> error", func(t *testing.T) {
+		err := validateEventEnvelope(EventEnvelopeData, map[string]any{"message": "a"})
+		require.ErrorContains(t, err, "fields json must be an array of objects")
+	})
+
+	t.Run("data envelope and array -> valid", func(t *testing.T) {
+		require.NoError(t, validateEventEnvelope(EventEnvelopeData, []any{map[string]any{"message": "a"}}))
+	})
+
+	t.Run("unknown envelope -> error", func(t *testing.T) {
+		_, err := eventsBody(EventEnvelope("nested"), []map[string]any{{"message": "a"}})
+		require.ErrorContains(t, err, `unknown event envelope "nested"`)
+	})
+}
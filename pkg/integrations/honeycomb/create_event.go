@@ -1,10 +1,13 @@
 package honeycomb
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
@@ -12,11 +15,39 @@ import (
 	"github.com/superplanehq/superplane/pkg/core"
 )
 
+// MaxEventSizeBytes is the maximum size of a single event accepted by the Honeycomb events API.
+const MaxEventSizeBytes = 1_000_000
+
+// defaultSampleRate is the sample rate of events that are not sampled.
+const defaultSampleRate = 1
+
 type CreateEvent struct{}
 
 type CreateEventConfiguration struct {
-	Dataset string         `json:"dataset" mapstructure:"dataset"`
-	Fields  map[string]any `json:"fields" mapstructure:"fields"`
+	Environment string         `json:"environment" mapstructure:"environment"`
+	Dataset     string         `json:"dataset" mapstructure:"dataset"`
+	Fields      map[string]any `json:"fields" mapstructure:"fields"`
+	CoerceTypes bool           `json:"coerceTypes" mapstructure:"coerceTypes"`
+
+	//
+	// SampleRate tells Honeycomb that the event stands for this many events,
+	// so queries weight it. The component does not drop events itself.
+	//
+	SampleRate int `json:"sampleRate" mapstructure:"sampleRate"`
+
+	//
+	// In buffered mode, the event is sent in a batch with the other events
+	// created for the same dataset within the window, or as soon as the batch is full.
+	//
+	Buffered     bool `json:"buffered" mapstructure:"buffered"`
+	BufferWindow int  `json:"bufferWindow" mapstructure:"bufferWindow"`
+	BufferSize   int  `json:"bufferSize" mapstructure:"bufferSize"`
+
+	//
+	// IngestKey overrides the ingest key of the integration,
+	// to send the event to another Honeycomb account.
+	//
+	IngestKey configuration.SecretKeyRef `json:"ingestKey" mapstructure:"ingestKey"`
 }
 
 func (c *CreateEvent) Name() string {
@@ -47,8 +78,18 @@ Each key in the JSON object becomes a Honeycomb field.
 
 Notes:
 • Dataset must exist
-• Fields must be valid JSON object
+• Dataset can be an expression, such as {{ $["Build"].data.dataset }}, resolved from the execution input when the event is sent
+• Fields must be valid JSON object; arrays are rejected, use Create Events to send several events
 • Timestamp is auto-added if missing
+• Fields must not exceed 1 MB once encoded as JSON
+• Enable "Coerce Types" to send string values that look like numbers or booleans (e.g. "1520", "true") as real numbers and booleans, so they can be aggregated
+• Coercion keeps strings with leading zeros (e.g. the zip code "02134") and anything that is not a plain JSON number or boolean as strings
+• Set "Sample Rate" to N when the workflow sends one event out of every N, so Honeycomb weights the event as N events in queries. The default of 1 means no sampling
+• Sample rates other than 1 are not supported in buffered mode
+• Requests rate limited by Honeycomb (429) or failing with a 5xx are sent again with exponential backoff, for up to 3 attempts
+• Enable "Buffered" for high-frequency workflows: events created for the same dataset are sent together in one batch request, once the buffer window elapses or the batch reaches the buffer size
+• In buffered mode, the execution waits until its batch is sent, so it takes up to the buffer window to complete
+• Set "Ingest Key" to send the event with an ingest key stored in a secret instead of the one of the integration, e.g. to send it to another Honeycomb account on the same site. The key is checked with Honeycomb before the event is sent, and events are only batched with events sent with the same key
 `
 }
 
@@ -58,15 +99,18 @@ func (c *CreateEvent) OutputChannels(configuration any) []core.OutputChannel {
 
 func (c *CreateEvent) Configuration() []configuration.Field {
 	return []configuration.Field{
+		environmentField(),
 		{
-			Name:     "dataset",
-			Label:    "Dataset",
-			Type:     configuration.FieldTypeIntegrationResource,
-			Required: true,
+			Name:        "dataset",
+			Label:       "Dataset",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The dataset to send the event to (supports expressions)",
 			TypeOptions: &configuration.TypeOptions{
 				Resource: &configuration.ResourceTypeOptions{
 					Type:           "dataset",
 					UseNameAsValue: false,
+					Parameters:     []configuration.ParameterRef{environmentParameter()},
 				},
 			},
 		},
@@ -80,10 +124,74 @@ func (c *CreateEvent) Configuration() []configuration.Field {
 							Example:
 							{"message":"deploy","status":"ok"}`,
 		},
+		{
+			Name:        "coerceTypes",
+			Label:       "Coerce Types",
+			Type:        configuration.FieldTypeBool,
+			Default:     false,
+			Description: "Send string values that look like numbers or booleans as numbers and booleans",
+		},
+		{
+			Name:        "sampleRate",
+			Label:       "Sample Rate",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     fmt.Sprintf("%d", defaultSampleRate),
+			Description: "Number of events this event stands for, when sending one event out of every N",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+				},
+			},
+		},
+		{
+			Name:        "buffered",
+			Label:       "Buffered",
+			Type:        configuration.FieldTypeBool,
+			Default:     false,
+			Description: "Send the event in a batch with the other events created for the dataset, to reduce the number of requests",
+		},
+		{
+			Name:        "bufferWindow",
+			Label:       "Buffer Window (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Default:     fmt.Sprintf("%d", defaultBufferWindowSeconds),
+			Description: "How long to collect events before sending the batch",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxBufferWindowSeconds; return &max }(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "buffered", Values: []string{"true"}},
+			},
+		},
+		{
+			Name:        "bufferSize",
+			Label:       "Buffer Size",
+			Type:        configuration.FieldTypeNumber,
+			Default:     fmt.Sprintf("%d", defaultBufferSize),
+			Description: "Number of events that sends the batch before the window elapses",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxBufferSize; return &max }(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "buffered", Values: []string{"true"}},
+			},
+		},
+		ingestKeyField(),
 	}
 }
 
 func (c *CreateEvent) Setup(ctx core.SetupContext) error {
+	if err := validateEventFields(ctx.Configuration); err != nil {
+		return err
+	}
+
 	var cfg CreateEventConfiguration
 	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
@@ -98,32 +206,211 @@ func (c *CreateEvent) Setup(ctx core.SetupContext) error {
 		return errors.New("fields json is required")
 	}
 
+	if err := validateIngestKeyRef(cfg.IngestKey); err != nil {
+		return err
+	}
+
+	sampleRate, err := effectiveSampleRate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Buffered {
+		if _, _, err := bufferOptions(cfg); err != nil {
+			return err
+		}
+
+		if sampleRate != defaultSampleRate {
+			return errors.New("sample rate is not supported in buffered mode")
+		}
+	}
+
+	return validateEventSize(cfg.Fields)
+}
+
+// validateEventFields checks the fields before the configuration is decoded,
+// so an array of events fails with a clear error instead of a decoding one.
+func validateEventFields(config any) error {
+	values, _ := config.(map[string]any)
+	fields, ok := values["fields"]
+	if !ok || fields == nil {
+		return errors.New("fields json is required")
+	}
+
+	return validateEventEnvelope(EventEnvelopeFlat, fields)
+}
+
+// resolvedDataset returns the dataset of an execution. Expressions in the dataset
+// are resolved from the execution input before Execute, so they can resolve to an empty value.
+func resolvedDataset(dataset string) (string, error) {
+	dataset = strings.TrimSpace(dataset)
+	if dataset == "" {
+		return "", errors.New("dataset resolved to an empty value; check the dataset expression and the execution input")
+	}
+
+	if strings.Contains(dataset, "{{") {
+		return "", fmt.Errorf("dataset expression %q was not resolved", dataset)
+	}
+
+	return dataset, nil
+}
+
+func effectiveSampleRate(cfg CreateEventConfiguration) (int, error) {
+	if cfg.SampleRate == 0 {
+		return defaultSampleRate, nil
+	}
+
+	if cfg.SampleRate < 1 {
+		return 0, errors.New("sample rate must be a positive integer")
+	}
+
+	return cfg.SampleRate, nil
+}
+
+func bufferOptions(cfg CreateEventConfiguration) (time.Duration, int, error) {
+	window := cfg.BufferWindow
+	if window == 0 {
+		window = defaultBufferWindowSeconds
+	}
+
+	if window < 1 || window > maxBufferWindowSeconds {
+		return 0, 0, fmt.Errorf("buffer window must be between 1 and %d seconds", maxBufferWindowSeconds)
+	}
+
+	size := cfg.BufferSize
+	if size == 0 {
+		size = defaultBufferSize
+	}
+
+	if size < 1 || size > maxBufferSize {
+		return 0, 0, fmt.Errorf("buffer size must be between 1 and %d events", maxBufferSize)
+	}
+
+	return time.Duration(window) * time.Second, size, nil
+}
+
+func validateEventSize(fields map[string]any) error {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fields: %w", err)
+	}
+
+	if len(body) > MaxEventSizeBytes {
+		return fmt.Errorf("fields json is %d bytes, which exceeds the Honeycomb limit of %d bytes per event", len(body), MaxEventSizeBytes)
+	}
+
 	return nil
 }
 
+// jsonNumberRegex matches the JSON number syntax,
+// which does not allow leading zeros such as in "02134".
+var jsonNumberRegex = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// coerceFieldTypes returns a copy of the fields where string values that are
+// JSON numbers or booleans are replaced by numbers and booleans.
+// Nested objects and arrays are coerced too. Other values are left untouched.
+func coerceFieldTypes(fields map[string]any) map[string]any {
+	coerced := make(map[string]any, len(fields))
+	for key, value := range fields {
+		coerced[key] = coerceFieldValue(value)
+	}
+
+	return coerced
+}
+
+func coerceFieldValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		switch v {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+
+		if jsonNumberRegex.MatchString(v) {
+			return json.Number(v)
+		}
+
+		return v
+	case map[string]any:
+		return coerceFieldTypes(v)
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, coerceFieldValue(item))
+		}
+
+		return items
+	default:
+		return value
+	}
+}
+
 func (c *CreateEvent) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
 	return ctx.DefaultProcessing()
 }
 
 func (c *CreateEvent) Execute(ctx core.ExecutionContext) error {
+	if err := validateEventFields(ctx.Configuration); err != nil {
+		return err
+	}
+
 	var cfg CreateEventConfiguration
 	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
 		return err
 	}
 
-	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	dataset, err := resolvedDataset(cfg.Dataset)
 	if err != nil {
 		return err
 	}
+	cfg.Dataset = dataset
 
-	if err := client.CreateEvent(cfg.Dataset, cfg.Fields); err != nil {
+	if err := validateEventSize(cfg.Fields); err != nil {
 		return err
 	}
 
+	sampleRate, err := effectiveSampleRate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Buffered && sampleRate != defaultSampleRate {
+		return errors.New("sample rate is not supported in buffered mode")
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+	client = client.WithContext(ctx.Context)
+
+	if err := useIngestKeyOverride(client, ctx.Secrets, cfg.IngestKey); err != nil {
+		return err
+	}
+
+	fields := cfg.Fields
+	if cfg.CoerceTypes {
+		fields = coerceFieldTypes(fields)
+	}
+
 	output := map[string]any{
-		"status":  "sent",
-		"dataset": cfg.Dataset,
-		"fields":  cfg.Fields,
+		"status":     core.ResultStatusSuccess,
+		"dataset":    cfg.Dataset,
+		"fields":     fields,
+		"sampleRate": sampleRate,
+	}
+
+	if cfg.Buffered {
+		batchSize, err := sendBufferedEvent(client, cfg, fields)
+		if err != nil {
+			return err
+		}
+
+		output["batchSize"] = batchSize
+	} else if err := client.CreateEvent(cfg.Dataset, cfg.Fields, cfg.CoerceTypes, sampleRate); err != nil {
+		return err
 	}
 
 	return ctx.ExecutionState.Emit(
@@ -133,6 +420,36 @@ func (c *CreateEvent) Execute(ctx core.ExecutionContext) error {
 	)
 }
 
+// sendBufferedEvent waits until the batch of the event is sent,
+// and returns the number of events the batch had.
+func sendBufferedEvent(client *Client, cfg CreateEventConfiguration, fields map[string]any) (int, error) {
+	window, size, err := bufferOptions(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	pending, err := createEventBuffer.Enqueue(client, strings.TrimSpace(cfg.Dataset), fields, window, size)
+	if err != nil {
+		return 0, err
+	}
+
+	result := <-pending
+	if result.Err != nil {
+		return 0, result.Err
+	}
+
+	if !result.Result.Succeeded() {
+		return 0, &HoneycombAPIError{
+			StatusCode: result.Result.Status,
+			Message:    result.Result.Error,
+			Body:       result.Result.Error,
+			Operation:  "honeycomb rejected the event",
+		}
+	}
+
+	return result.BatchSize, nil
+}
+
 func (c *CreateEvent) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
 	return http.StatusOK, nil
 }
@@ -1,10 +1,13 @@
 package honeycomb
 
 import (
+	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,6 +38,46 @@ func Test__CreateEvent__Setup(t *testing.T) {
 		require.ErrorContains(t, err, "fields json is required")
 	})
 
+	t.Run("array of events -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  []any{map[string]any{"key": "value"}},
+			},
+		})
+		require.ErrorContains(t, err, "fields json must be a single object, not an array")
+	})
+
+	t.Run("fields over size limit -> error with measured size", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  map[string]any{"message": strings.Repeat("a", MaxEventSizeBytes)},
+			},
+		})
+		require.ErrorContains(t, err, "fields json is 1000014 bytes, which exceeds the Honeycomb limit of 1000000 bytes per event")
+	})
+
+	t.Run("dataset expression -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": `{{ $["Build"].data.dataset }}`,
+				"fields":  map[string]any{"key": "value"},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("fields just under size limit -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  map[string]any{"message": strings.Repeat("a", MaxEventSizeBytes-14)},
+			},
+		})
+		require.NoError(t, err)
+	})
+
 	t.Run("valid configuration -> success", func(t *testing.T) {
 		err := component.Setup(core.SetupContext{
 			Configuration: map[string]any{
@@ -44,6 +87,64 @@ func Test__CreateEvent__Setup(t *testing.T) {
 		})
 		require.NoError(t, err)
 	})
+
+	t.Run("buffer window out of range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset":      "test-dataset",
+				"fields":       map[string]any{"message": "hello"},
+				"buffered":     true,
+				"bufferWindow": 60,
+			},
+		})
+		require.ErrorContains(t, err, "buffer window must be between 1 and 10 seconds")
+	})
+
+	t.Run("buffer size out of range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset":    "test-dataset",
+				"fields":     map[string]any{"message": "hello"},
+				"buffered":   true,
+				"bufferSize": 5000,
+			},
+		})
+		require.ErrorContains(t, err, "buffer size must be between 1 and 1000 events")
+	})
+
+	t.Run("negative sample rate -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset":    "test-dataset",
+				"fields":     map[string]any{"message": "hello"},
+				"sampleRate": -5,
+			},
+		})
+		require.ErrorContains(t, err, "sample rate must be a positive integer")
+	})
+
+	t.Run("sample rate in buffered mode -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset":    "test-dataset",
+				"fields":     map[string]any{"message": "hello"},
+				"buffered":   true,
+				"sampleRate": 10,
+			},
+		})
+		require.ErrorContains(t, err, "sample rate is not supported in buffered mode")
+	})
+
+	t.Run("ingest key without a key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset":   "test-dataset",
+				"fields":    map[string]any{"message": "hello"},
+				"ingestKey": map[string]any{"secret": "other-account"},
+			},
+		})
+		require.ErrorContains(t, err, "ingest key requires both a secret and a key")
+	})
 }
 
 func Test__CreateEvent__Execute(t *testing.T) {
@@ -121,6 +222,102 @@ func Test__CreateEvent__Execute(t *testing.T) {
 		require.ErrorContains(t, err, "401")
 	})
 
+	t.Run("dataset resolved to empty -> Execute fails before sending", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration: integrationCtx,
+			HTTP:        httpCtx,
+			Configuration: map[string]any{
+				"dataset": " ",
+				"fields":  map[string]any{"key": "value"},
+			},
+		})
+
+		require.ErrorContains(t, err, "dataset resolved to an empty value")
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("unresolved dataset expression -> Execute fails before sending", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		err := component.Execute(core.ExecutionContext{
+			Integration: &contexts.IntegrationContext{},
+			HTTP:        httpCtx,
+			Configuration: map[string]any{
+				"dataset": `{{ $["Build"].data.dataset }}`,
+				"fields":  map[string]any{"key": "value"},
+			},
+		})
+
+		require.ErrorContains(t, err, "was not resolved")
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("resolved dataset with whitespace -> event sent to trimmed dataset", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(`{}`)},
+		}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+		execState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			HTTP:           httpCtx,
+			ExecutionState: execState,
+			Configuration: map[string]any{
+				"dataset": " deploys-production\n",
+				"fields":  map[string]any{"key": "value"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.honeycomb.io/1/events/deploys-production", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "deploys-production", execState.Payloads[0].(map[string]any)["data"].(map[string]any)["dataset"])
+	})
+
+	t.Run("fields over size limit -> Execute fails before sending", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration: integrationCtx,
+			HTTP:        httpCtx,
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  map[string]any{"message": strings.Repeat("a", MaxEventSizeBytes)},
+			},
+		})
+
+		require.ErrorContains(t, err, "exceeds the Honeycomb limit")
+		assert.Empty(t, httpCtx.Requests)
+	})
+
 	t.Run("successful event creation without time field -> emits payload and sets header", func(t *testing.T) {
 		httpCtx := &contexts.HTTPContext{
 			Responses: []*http.Response{
@@ -156,6 +353,9 @@ func Test__CreateEvent__Execute(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
 		assert.Equal(t, "honeycomb.event.created", execState.Type)
+		require.Len(t, execState.Payloads, 1)
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, core.ResultStatusSuccess, output["status"])
 
 		require.Len(t, httpCtx.Requests, 1)
 		req := httpCtx.Requests[0]
@@ -173,6 +373,69 @@ func Test__CreateEvent__Execute(t *testing.T) {
 		assert.NotEmpty(t, req.Header.Get("X-Honeycomb-Event-Time"), "event time header should be set when time field is not provided")
 	})
 
+	t.Run("sample rate -> header is set and the rate is emitted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"managementKey": "keyid:secret", "site": "api.honeycomb.io"},
+				Secrets: map[string]core.IntegrationSecret{
+					secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+				},
+			},
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset":    "test-dataset",
+				"fields":     map[string]any{"message": "request"},
+				"sampleRate": 20,
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "20", httpCtx.Requests[0].Header.Get("X-Honeycomb-Samplerate"))
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 20, output["sampleRate"])
+	})
+
+	t.Run("no sample rate -> no header and a rate of 1 is emitted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"managementKey": "keyid:secret", "site": "api.honeycomb.io"},
+				Secrets: map[string]core.IntegrationSecret{
+					secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+				},
+			},
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  map[string]any{"message": "request"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Empty(t, httpCtx.Requests[0].Header.Get("X-Honeycomb-Samplerate"))
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1, output["sampleRate"])
+	})
+
 	t.Run("successful event creation with time field -> emits payload without header", func(t *testing.T) {
 		httpCtx := &contexts.HTTPContext{
 			Responses: []*http.Response{
@@ -208,6 +471,9 @@ func Test__CreateEvent__Execute(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
 		assert.Equal(t, "honeycomb.event.created", execState.Type)
+		require.Len(t, execState.Payloads, 1)
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, core.ResultStatusSuccess, output["status"])
 
 		require.Len(t, httpCtx.Requests, 1)
 		req := httpCtx.Requests[0]
@@ -225,4 +491,376 @@ func Test__CreateEvent__Execute(t *testing.T) {
 
 		assert.Empty(t, req.Header.Get("X-Honeycomb-Event-Time"), "event time header should not be set when time field is provided")
 	})
+
+	t.Run("coerce types -> numeric and boolean strings are sent as JSON types", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset":     "test-dataset",
+				"coerceTypes": true,
+				"fields": map[string]any{
+					"duration_ms": "1520",
+					"ratio":       "-0.25",
+					"success":     "true",
+					"rollback":    "false",
+					"zip":         "02134",
+					"version":     "1.2.3",
+					"padded":      " 42",
+					"yes":         "TRUE",
+					"count":       7,
+					"build":       map[string]any{"number": "981", "tags": []any{"1e3", "main"}},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[0].Body).Decode(&body))
+		assert.Equal(t, map[string]any{
+			"duration_ms": float64(1520),
+			"ratio":       -0.25,
+			"success":     true,
+			"rollback":    false,
+			"zip":         "02134",
+			"version":     "1.2.3",
+			"padded":      " 42",
+			"yes":         "TRUE",
+			"count":       float64(7),
+			"build":       map[string]any{"number": float64(981), "tags": []any{float64(1000), "main"}},
+		}, body)
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		fields := output["fields"].(map[string]any)
+		assert.Equal(t, json.Number("1520"), fields["duration_ms"])
+		assert.Equal(t, "02134", fields["zip"])
+	})
+
+	t.Run("coerce types off -> string values are sent untouched", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  map[string]any{"duration_ms": "1520", "success": "true"},
+			},
+		})
+
+		require.NoError(t, err)
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[0].Body).Decode(&body))
+		assert.Equal(t, map[string]any{"duration_ms": "1520", "success": "true"}, body)
+	})
+	t.Run("buffered -> event is sent through the batch API", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"status":202}]`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset":     "test-dataset",
+				"fields":      map[string]any{"duration_ms": "1520"},
+				"coerceTypes": true,
+				"buffered":    true,
+				"bufferSize":  1,
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.honeycomb.io/1/batch/test-dataset", httpCtx.Requests[0].URL.String())
+
+		events := decodeBatchRequest(t, httpCtx.Requests[0])
+		require.Len(t, events, 1)
+		assert.Equal(t, map[string]any{"duration_ms": float64(1520)}, events[0].Data)
+
+		output := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1, output["batchSize"])
+	})
+
+	t.Run("buffered and event rejected -> Execute fails", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"status":400,"error":"invalid event"}]`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset":    "test-dataset",
+				"fields":     map[string]any{"message": "deploy"},
+				"buffered":   true,
+				"bufferSize": 1,
+			},
+		})
+
+		require.ErrorContains(t, err, "honeycomb rejected the event (http 400): invalid event")
+	})
+
+	t.Run("ingest key override -> event is sent with the key of the other account", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"api_key_access":{"events":true}}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Secrets: &contexts.SecretsContext{
+				Values: map[string][]byte{"other-account/ingest-key": []byte("other-ingest-key")},
+			},
+			Configuration: map[string]any{
+				"dataset":   "test-dataset",
+				"fields":    map[string]any{"message": "deploy"},
+				"ingestKey": map[string]any{"secret": "other-account", "key": "ingest-key"},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "honeycomb.event.created", execState.Type)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://api.honeycomb.io/1/auth", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "other-ingest-key", httpCtx.Requests[0].Header.Get("X-Honeycomb-Team"))
+		assert.Equal(t, "https://api.honeycomb.io/1/events/test-dataset", httpCtx.Requests[1].URL.String())
+		assert.Equal(t, "other-ingest-key", httpCtx.Requests[1].Header.Get("X-Honeycomb-Team"))
+	})
+
+	t.Run("ingest key override rejected -> Execute fails before sending", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader(`{"error":"unknown API key"}`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Secrets: &contexts.SecretsContext{
+				Values: map[string][]byte{"other-account/ingest-key": []byte("wrong-key")},
+			},
+			Configuration: map[string]any{
+				"dataset":   "test-dataset",
+				"fields":    map[string]any{"message": "deploy"},
+				"ingestKey": map[string]any{"secret": "other-account", "key": "ingest-key"},
+			},
+		})
+
+		require.ErrorContains(t, err, "invalid ingest key in secret other-account/ingest-key: ingest key was rejected by Honeycomb (http 401)")
+		require.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("ingest key override not allowed to send events -> Execute fails", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"api_key_access":{"events":false,"markers":true}}`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Secrets: &contexts.SecretsContext{
+				Values: map[string][]byte{"other-account/ingest-key": []byte("markers-key")},
+			},
+			Configuration: map[string]any{
+				"dataset":   "test-dataset",
+				"fields":    map[string]any{"message": "deploy"},
+				"ingestKey": map[string]any{"secret": "other-account", "key": "ingest-key"},
+			},
+		})
+
+		require.ErrorContains(t, err, "ingest key is not allowed to send events")
+		require.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("ingest key secret not found -> Execute fails", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Secrets:        &contexts.SecretsContext{Values: map[string][]byte{}},
+			Configuration: map[string]any{
+				"dataset":   "test-dataset",
+				"fields":    map[string]any{"message": "deploy"},
+				"ingestKey": map[string]any{"secret": "other-account", "key": "ingest-key"},
+			},
+		})
+
+		require.ErrorContains(t, err, "failed to resolve secret other-account/ingest-key")
+		assert.Empty(t, httpCtx.Requests)
+	})
+}
+
+func Test__CreateEvent__Retries(t *testing.T) {
+	rateLimitSleep = func(ctx context.Context, d time.Duration) error { return nil }
+	t.Cleanup(func() { rateLimitSleep = sleepContext })
+
+	execute := func(t *testing.T, runCtx context.Context, responses ...*http.Response) (*contexts.HTTPContext, error) {
+		httpCtx := &contexts.HTTPContext{Responses: responses}
+		err := (&CreateEvent{}).Execute(core.ExecutionContext{
+			Context: runCtx,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"managementKey": "keyid:secret", "site": "api.honeycomb.io"},
+				Secrets: map[string]core.IntegrationSecret{
+					secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+				},
+			},
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  map[string]any{"message": "deploy"},
+			},
+		})
+
+		return httpCtx, err
+	}
+
+	t.Run("429 then 200 -> retried and succeeds", func(t *testing.T) {
+		httpCtx, err := execute(t, context.Background(), rateLimitedResponse(""), jsonResponse(`{}`))
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		body, err := io.ReadAll(httpCtx.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"message":"deploy"}`, string(body))
+	})
+
+	t.Run("5xx on every attempt -> fails after max attempts", func(t *testing.T) {
+		httpCtx, err := execute(t, context.Background(),
+			statusResponse(http.StatusServiceUnavailable, ``),
+			statusResponse(http.StatusBadGateway, ``),
+			statusResponse(http.StatusInternalServerError, `{"error":"internal"}`),
+			jsonResponse(`{}`),
+		)
+
+		require.ErrorContains(t, err, "http 500")
+		assert.Len(t, httpCtx.Requests, maxRateLimitAttempts)
+	})
+
+	t.Run("4xx -> not retried", func(t *testing.T) {
+		httpCtx, err := execute(t, context.Background(), statusResponse(http.StatusBadRequest, `{"error":"unknown dataset"}`), jsonResponse(`{}`))
+
+		require.ErrorContains(t, err, "http 400")
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("execution context cancelled -> stops waiting to retry", func(t *testing.T) {
+		rateLimitSleep = sleepContext
+		runCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		httpCtx, err := execute(t, runCtx, rateLimitedResponse("10"), jsonResponse(`{}`))
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, httpCtx.Requests, 1)
+	})
 }
@@ -0,0 +1,243 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateEvents struct{}
+
+type CreateEventsConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	Dataset     string `json:"dataset" mapstructure:"dataset"`
+	Fields      any    `json:"fields" mapstructure:"fields"`
+
+	//
+	// IngestKey overrides the ingest key of the integration,
+	// to send the events to another Honeycomb account.
+	//
+	IngestKey configuration.SecretKeyRef `json:"ingestKey" mapstructure:"ingestKey"`
+}
+
+func (c *CreateEvents) Name() string {
+	return "honeycomb.createEvents"
+}
+
+func (c *CreateEvents) Label() string {
+	return "Create Events"
+}
+
+func (c *CreateEvents) Description() string {
+	return "Send a batch of events to Honeycomb dataset"
+}
+
+func (c *CreateEvents) Icon() string {
+	return "honeycomb"
+}
+
+func (c *CreateEvents) Color() string {
+	return "gray"
+}
+
+func (c *CreateEvents) Documentation() string {
+	return `
+Sends a batch of JSON events to a Honeycomb dataset in a single request.
+
+Each object in the JSON array becomes one Honeycomb event.
+
+Notes:
+• Dataset must exist
+• Fields must be a JSON array of objects
+• The "time" key of each object is used as the event time; the current time is used if missing
+• Each event must not exceed 1 MB once encoded as JSON
+• Honeycomb accepts or rejects each event separately; the output reports how many succeeded and failed
+• Requests rate limited by Honeycomb (429) or failing with a 5xx are sent again with exponential backoff, for up to 3 attempts
+• Set "Ingest Key" to send the events with an ingest key stored in a secret instead of the one of the integration, e.g. to send them to another Honeycomb account on the same site. The key is checked with Honeycomb before the events are sent
+`
+}
+
+func (c *CreateEvents) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateEvents) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:     "dataset",
+			Label:    "Dataset",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "dataset",
+					UseNameAsValue: false,
+					Parameters:     []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+		{
+			Name:     "fields",
+			Label:    "Events JSON",
+			Type:     configuration.FieldTypeObject,
+			Required: true,
+			Default:  "[{\"message\":\"deploy\",\"status\":\"ok\"}]",
+			Description: `JSON array of events to send.
+							Example:
+							[{"message":"deploy","status":"ok"},{"message":"rollback","status":"ok"}]`,
+		},
+		ingestKeyField(),
+	}
+}
+
+func (c *CreateEvents) Setup(ctx core.SetupContext) error {
+	var cfg CreateEventsConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if strings.TrimSpace(cfg.Dataset) == "" {
+		return errors.New("dataset is required")
+	}
+
+	if err := validateIngestKeyRef(cfg.IngestKey); err != nil {
+		return err
+	}
+
+	//
+	// Events built from expressions are only known at execution time.
+	//
+	if text, ok := cfg.Fields.(string); ok && strings.Contains(text, "{{") {
+		return nil
+	}
+
+	_, err := parseBatchEvents(cfg.Fields)
+	return err
+}
+
+// parseBatchEvents accepts the fields configuration as a decoded array or a JSON string.
+func parseBatchEvents(fields any) ([]map[string]any, error) {
+	if text, ok := fields.(string); ok {
+		if err := json.Unmarshal([]byte(text), &fields); err != nil {
+			return nil, errors.New("fields json must be a valid JSON array")
+		}
+	}
+
+	if err := validateEventEnvelope(EventEnvelopeData, fields); err != nil {
+		return nil, err
+	}
+
+	items := fields.([]any)
+
+	if len(items) == 0 {
+		return nil, errors.New("fields json is required")
+	}
+
+	events := make([]map[string]any, 0, len(items))
+	for i, item := range items {
+		event, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("event at index %d must be an object", i)
+		}
+
+		if err := validateEventSize(event); err != nil {
+			return nil, fmt.Errorf("event at index %d: %w", i, err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (c *CreateEvents) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateEvents) Execute(ctx core.ExecutionContext) error {
+	var cfg CreateEventsConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return err
+	}
+
+	events, err := parseBatchEvents(cfg.Fields)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+	client = client.WithContext(ctx.Context)
+
+	if err := useIngestKeyOverride(client, ctx.Secrets, cfg.IngestKey); err != nil {
+		return err
+	}
+
+	results, err := client.CreateEvents(cfg.Dataset, events)
+	if err != nil {
+		return err
+	}
+
+	succeeded := 0
+	failures := []map[string]any{}
+	for i, result := range results {
+		if result.Succeeded() {
+			succeeded++
+			continue
+		}
+
+		failures = append(failures, map[string]any{
+			"index":  i,
+			"status": result.Status,
+			"error":  result.Error,
+		})
+	}
+
+	if len(failures) > 0 {
+		ctx.Logger.Warnf("honeycomb rejected %d of %d events sent to %s", len(failures), len(events), cfg.Dataset)
+	}
+
+	output := map[string]any{
+		"dataset":   cfg.Dataset,
+		"total":     len(events),
+		"succeeded": succeeded,
+		"failed":    len(failures),
+		"failures":  failures,
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"honeycomb.events.created",
+		[]any{output},
+	)
+}
+
+func (c *CreateEvents) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *CreateEvents) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *CreateEvents) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateEvents) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateEvents) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
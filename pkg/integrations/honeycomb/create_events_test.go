@@ -0,0 +1,210 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateEvents__Setup(t *testing.T) {
+	component := &CreateEvents{}
+
+	t.Run("missing dataset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"fields": []any{map[string]any{"key": "value"}},
+			},
+		})
+		require.ErrorContains(t, err, "dataset is required")
+	})
+
+	t.Run("empty array -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "test-dataset", "fields": []any{}},
+		})
+		require.ErrorContains(t, err, "fields json is required")
+	})
+
+	t.Run("object instead of array -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "test-dataset", "fields": map[string]any{"key": "value"}},
+		})
+		require.ErrorContains(t, err, "fields json must be an array of objects")
+	})
+
+	t.Run("array item is not an object -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "test-dataset", "fields": []any{map[string]any{"key": "value"}, "oops"}},
+		})
+		require.ErrorContains(t, err, "event at index 1 must be an object")
+	})
+
+	t.Run("event over size limit -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  []any{map[string]any{"message": strings.Repeat("a", MaxEventSizeBytes)}},
+			},
+		})
+		require.ErrorContains(t, err, "event at index 0: fields json is 1000014 bytes")
+	})
+
+	t.Run("JSON string array -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "test-dataset", "fields": `[{"message":"a"},{"message":"b"}]`},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test__CreateEvents__Execute(t *testing.T) {
+	component := &CreateEvents{}
+
+	integrationCtx := func() *contexts.IntegrationContext {
+		return &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		}
+	}
+
+	t.Run("API returns error -> Execute fails", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"unauthorized"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Logger:         logrus.NewEntry(logrus.New()),
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  []any{map[string]any{"key": "value"}},
+			},
+		})
+
+		require.ErrorContains(t, err, "http 401")
+	})
+
+	t.Run("partial failure -> reports succeeded and failed counts", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`[{"status":202},{"status":400,"error":"event too large"},{"status":202}]`)),
+				},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Logger:         logrus.NewEntry(logrus.New()),
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields": []any{
+					map[string]any{"message": "a", "time": "2026-02-27T11:00:00Z"},
+					map[string]any{"message": "b"},
+					map[string]any{"message": "c"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "honeycomb.events.created", execState.Type)
+
+		require.Len(t, execState.Payloads, 1)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 3, payload["total"])
+		assert.Equal(t, 2, payload["succeeded"])
+		assert.Equal(t, 1, payload["failed"])
+		assert.Equal(t, []map[string]any{{"index": 1, "status": 400, "error": "event too large"}}, payload["failures"])
+
+		require.Len(t, httpCtx.Requests, 1)
+		req := httpCtx.Requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/batch/test-dataset", req.URL.String())
+		assert.Equal(t, "test-ingest-key", req.Header.Get("X-Honeycomb-Team"))
+
+		var batch []map[string]any
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&batch))
+		require.Len(t, batch, 3)
+		assert.Equal(t, "2026-02-27T11:00:00Z", batch[0]["time"])
+		assert.Equal(t, map[string]any{"message": "a"}, batch[0]["data"])
+		assert.NotEmpty(t, batch[1]["time"])
+		assert.Equal(t, map[string]any{"message": "b"}, batch[1]["data"])
+	})
+
+	t.Run("result count mismatch -> Execute fails", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`[{"status":202}]`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Logger:         logrus.NewEntry(logrus.New()),
+			Configuration: map[string]any{
+				"dataset": "test-dataset",
+				"fields":  []any{map[string]any{"message": "a"}, map[string]any{"message": "b"}},
+			},
+		})
+
+		require.ErrorContains(t, err, "honeycomb returned 1 results for 2 events")
+	})
+
+	t.Run("ingest key override -> batch is sent with the key of the other account", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"api_key_access":{"events":true}}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"status":202}]`))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Logger:         logrus.NewEntry(logrus.New()),
+			Secrets: &contexts.SecretsContext{
+				Values: map[string][]byte{"other-account/ingest-key": []byte("other-ingest-key")},
+			},
+			Configuration: map[string]any{
+				"dataset":   "test-dataset",
+				"fields":    []any{map[string]any{"message": "a"}},
+				"ingestKey": map[string]any{"secret": "other-account", "key": "ingest-key"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://api.honeycomb.io/1/batch/test-dataset", httpCtx.Requests[1].URL.String())
+		assert.Equal(t, "other-ingest-key", httpCtx.Requests[1].Header.Get("X-Honeycomb-Team"))
+	})
+}
@@ -0,0 +1,269 @@
+package honeycomb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateMarker struct{}
+
+type CreateMarkerConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	Dataset     string `json:"dataset" mapstructure:"dataset"`
+	Message     string `json:"message" mapstructure:"message"`
+	Type        string `json:"type" mapstructure:"type"`
+	URL         string `json:"url" mapstructure:"url"`
+	StartTime   string `json:"startTime" mapstructure:"startTime"`
+	EndTime     string `json:"endTime" mapstructure:"endTime"`
+}
+
+func (c *CreateMarker) Name() string {
+	return "honeycomb.createMarker"
+}
+
+func (c *CreateMarker) Label() string {
+	return "Create Marker"
+}
+
+func (c *CreateMarker) Description() string {
+	return "Annotate Honeycomb graphs with a marker"
+}
+
+func (c *CreateMarker) Icon() string {
+	return "honeycomb"
+}
+
+func (c *CreateMarker) Color() string {
+	return "gray"
+}
+
+func (c *CreateMarker) Documentation() string {
+	return `
+Creates a marker in a Honeycomb dataset, for example to annotate a deploy on your graphs.
+
+Notes:
+• Select "All datasets (environment)" to create an environment-wide marker
+• Type groups markers, for example "deploy"
+• Start time defaults to the current time
+• Set an end time to show the marker as a time range
+• Times are in UTC, and can also be Unix timestamps in seconds
+• Integrations created before this component was added need their configuration key to be recreated with the "Manage Markers" permission
+`
+}
+
+func (c *CreateMarker) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateMarker) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:     "dataset",
+			Label:    "Dataset",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "dataset",
+					Parameters: []configuration.ParameterRef{
+						environmentParameter(),
+						{
+							Name:  "includeEnvironment",
+							Value: func() *string { v := "true"; return &v }(),
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Text shown on the marker, for example the version being deployed",
+		},
+		{
+			Name:        "type",
+			Label:       "Type",
+			Type:        configuration.FieldTypeString,
+			Default:     "deploy",
+			Description: "Groups markers of the same kind",
+		},
+		{
+			Name:        "url",
+			Label:       "URL",
+			Type:        configuration.FieldTypeString,
+			Description: "Link opened from the marker, for example the build or release page",
+		},
+		{
+			Name:        "startTime",
+			Label:       "Start Time",
+			Type:        configuration.FieldTypeDateTime,
+			Togglable:   true,
+			Description: "When the marker starts (UTC). Defaults to now",
+			TypeOptions: &configuration.TypeOptions{
+				DateTime: &configuration.DateTimeTypeOptions{
+					Format: "2006-01-02T15:04",
+				},
+			},
+		},
+		{
+			Name:        "endTime",
+			Label:       "End Time",
+			Type:        configuration.FieldTypeDateTime,
+			Togglable:   true,
+			Description: "When the marker ends (UTC)",
+			TypeOptions: &configuration.TypeOptions{
+				DateTime: &configuration.DateTimeTypeOptions{
+					Format: "2006-01-02T15:04",
+				},
+			},
+		},
+	}
+}
+
+func (c *CreateMarker) Setup(ctx core.SetupContext) error {
+	var cfg CreateMarkerConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	_, err := buildMarkerRequest(cfg)
+	return err
+}
+
+func buildMarkerRequest(cfg CreateMarkerConfiguration) (*MarkerRequest, error) {
+	if strings.TrimSpace(cfg.Dataset) == "" {
+		return nil, errors.New("dataset is required")
+	}
+
+	message := strings.TrimSpace(cfg.Message)
+	if message == "" {
+		return nil, errors.New("message is required")
+	}
+
+	startTime, err := parseMarkerTime(cfg.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	endTime, err := parseMarkerTime(cfg.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time: %w", err)
+	}
+
+	if startTime != nil && endTime != nil && *endTime < *startTime {
+		return nil, errors.New("end time must not be before start time")
+	}
+
+	return &MarkerRequest{
+		Message:   message,
+		Type:      strings.TrimSpace(cfg.Type),
+		URL:       strings.TrimSpace(cfg.URL),
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, nil
+}
+
+// parseMarkerTime accepts the datetime field format, RFC 3339,
+// or a Unix timestamp in seconds, and returns Unix seconds.
+func parseMarkerTime(value string) (*int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return &seconds, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			seconds := t.Unix()
+			return &seconds, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%q is not a UTC datetime (2006-01-02T15:04), RFC 3339 timestamp, or Unix timestamp", value)
+}
+
+func (c *CreateMarker) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateMarker) Execute(ctx core.ExecutionContext) error {
+	var cfg CreateMarkerConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return err
+	}
+
+	marker, err := buildMarkerRequest(cfg)
+	if err != nil {
+		return err
+	}
+
+	if marker.StartTime == nil {
+		now := time.Now().Unix()
+		marker.StartTime = &now
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+
+	dataset := strings.TrimSpace(cfg.Dataset)
+	created, err := client.CreateMarker(dataset, *marker)
+	if err != nil {
+		return err
+	}
+
+	output := map[string]any{
+		"id":        created.ID,
+		"dataset":   dataset,
+		"message":   created.Message,
+		"type":      created.Type,
+		"url":       created.URL,
+		"startTime": created.StartTime,
+	}
+
+	if created.EndTime != 0 {
+		output["endTime"] = created.EndTime
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"honeycomb.marker.created",
+		[]any{output},
+	)
+}
+
+func (c *CreateMarker) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *CreateMarker) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *CreateMarker) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateMarker) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateMarker) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,173 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateMarker__Setup(t *testing.T) {
+	component := &CreateMarker{}
+
+	t.Run("missing dataset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"message": "deploy"},
+		})
+		require.ErrorContains(t, err, "dataset is required")
+	})
+
+	t.Run("missing message -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "production", "message": "  "},
+		})
+		require.ErrorContains(t, err, "message is required")
+	})
+
+	t.Run("invalid start time -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "production", "message": "deploy", "startTime": "yesterday"},
+		})
+		require.ErrorContains(t, err, "invalid start time")
+	})
+
+	t.Run("end time before start time -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset":   "production",
+				"message":   "deploy",
+				"startTime": "2026-02-27T12:00",
+				"endTime":   "2026-02-27T11:00",
+			},
+		})
+		require.ErrorContains(t, err, "end time must not be before start time")
+	})
+
+	t.Run("environment-wide dataset -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": allDatasetsInEnvironmentScopeSlug, "message": "deploy"},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test__CreateMarker__Execute(t *testing.T) {
+	component := &CreateMarker{}
+
+	integrationCtx := func() *contexts.IntegrationContext {
+		return &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("test-config-key")},
+			},
+		}
+	}
+
+	t.Run("API returns error -> Execute fails", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"forbidden"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": "production", "message": "deploy"},
+		})
+
+		require.ErrorContains(t, err, "create marker failed (http 403)")
+	})
+
+	t.Run("marker created -> sends fields and emits marker ID", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusCreated,
+					Body: io.NopCloser(strings.NewReader(
+						`{"id":"marker-123","message":"v1.2.3","type":"deploy","url":"https://example.com/v1.2.3","start_time":1772190000,"end_time":1772193600}`,
+					)),
+				},
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset":   "production",
+				"message":   "v1.2.3",
+				"type":      "deploy",
+				"url":       "https://example.com/v1.2.3",
+				"startTime": "2026-02-27T11:00",
+				"endTime":   "1772193600",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
+		assert.Equal(t, "honeycomb.marker.created", execState.Type)
+
+		require.Len(t, execState.Payloads, 1)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "marker-123", payload["id"])
+		assert.Equal(t, "production", payload["dataset"])
+		assert.Equal(t, int64(1772193600), payload["endTime"])
+
+		require.Len(t, httpCtx.Requests, 1)
+		req := httpCtx.Requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/markers/production", req.URL.String())
+		assert.Equal(t, "test-config-key", req.Header.Get("X-Honeycomb-Team"))
+
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "v1.2.3", body["message"])
+		assert.Equal(t, "deploy", body["type"])
+		assert.Equal(t, "https://example.com/v1.2.3", body["url"])
+		assert.Equal(t, float64(1772190000), body["start_time"])
+		assert.Equal(t, float64(1772193600), body["end_time"])
+	})
+
+	t.Run("environment-wide dataset and no start time -> defaults start time to now", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"id":"marker-456","message":"deploy"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": allDatasetsInEnvironmentScopeSlug, "message": "deploy"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.honeycomb.io/1/markers/__all__", httpCtx.Requests[0].URL.String())
+
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[0].Body).Decode(&body))
+		assert.NotZero(t, body["start_time"])
+		assert.NotContains(t, body, "end_time")
+	})
+}
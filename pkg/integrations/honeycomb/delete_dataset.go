@@ -0,0 +1,196 @@
+package honeycomb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// datasetSlugRegex matches the slugs Honeycomb generates from dataset names.
+var datasetSlugRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var errDeletionNotConfirmed = errors.New("dataset deletion must be confirmed by setting confirm to true")
+
+type DeleteDataset struct{}
+
+type DeleteDatasetConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	Dataset     string `json:"dataset" mapstructure:"dataset"`
+
+	//
+	// Confirm must be set explicitly, so a dataset is not
+	// deleted by a component added to a canvas by mistake.
+	//
+	Confirm bool `json:"confirm" mapstructure:"confirm"`
+}
+
+func (c *DeleteDataset) Name() string {
+	return "honeycomb.deleteDataset"
+}
+
+func (c *DeleteDataset) Label() string {
+	return "Delete Dataset"
+}
+
+func (c *DeleteDataset) Description() string {
+	return "Delete a Honeycomb dataset"
+}
+
+func (c *DeleteDataset) Icon() string {
+	return "honeycomb"
+}
+
+func (c *DeleteDataset) Color() string {
+	return "gray"
+}
+
+func (c *DeleteDataset) Documentation() string {
+	return `
+Deletes a Honeycomb dataset and all of its data, for example to tear down the dataset of a pull request environment.
+
+Notes:
+• "Confirm Deletion" must be enabled, otherwise the component refuses to run
+• Deleting a dataset that does not exist succeeds, so the component can be retried safely
+• The environment-wide "__all__" dataset cannot be deleted
+• Datasets with deletion protection enabled are not deleted; disable it in the dataset settings first
+• The configuration key needs the "Create and Delete Datasets" permission
+`
+}
+
+func (c *DeleteDataset) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *DeleteDataset) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:     "dataset",
+			Label:    "Dataset",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       "dataset",
+					Parameters: []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+		{
+			Name:        "confirm",
+			Label:       "Confirm Deletion",
+			Type:        configuration.FieldTypeBool,
+			Required:    true,
+			Default:     false,
+			Description: "Confirm that the dataset and all of its data are deleted",
+		},
+	}
+}
+
+func (c *DeleteDataset) Setup(ctx core.SetupContext) error {
+	var cfg DeleteDatasetConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if ctx.Integration != nil && !configurationKeyPermissions(ctx.Integration).CreateDatasets {
+		return fmt.Errorf("the Honeycomb configuration key is missing the %s permission required by the Delete Dataset component; add it to the integration's configuration key permissions", PermissionCreateDatasets)
+	}
+
+	if !cfg.Confirm {
+		return errDeletionNotConfirmed
+	}
+
+	//
+	// Datasets built from expressions are only known at execution time.
+	//
+	if strings.Contains(cfg.Dataset, "{{") {
+		return nil
+	}
+
+	return validateDatasetToDelete(cfg.Dataset)
+}
+
+func validateDatasetToDelete(dataset string) error {
+	dataset = strings.TrimSpace(dataset)
+	if dataset == "" {
+		return errors.New("dataset is required")
+	}
+
+	if dataset == allDatasetsInEnvironmentScopeSlug {
+		return errors.New("the environment-wide dataset cannot be deleted")
+	}
+
+	if !datasetSlugRegex.MatchString(dataset) {
+		return fmt.Errorf("invalid dataset slug %q", dataset)
+	}
+
+	return nil
+}
+
+func (c *DeleteDataset) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *DeleteDataset) Execute(ctx core.ExecutionContext) error {
+	var cfg DeleteDatasetConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return err
+	}
+
+	if !cfg.Confirm {
+		return errDeletionNotConfirmed
+	}
+
+	if err := validateDatasetToDelete(cfg.Dataset); err != nil {
+		return err
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+
+	dataset := strings.TrimSpace(cfg.Dataset)
+	if err := client.DeleteDataset(dataset); err != nil {
+		return err
+	}
+
+	output := map[string]any{
+		"status":  core.ResultStatusSuccess,
+		"dataset": dataset,
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"honeycomb.dataset.deleted",
+		[]any{output},
+	)
+}
+
+func (c *DeleteDataset) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *DeleteDataset) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *DeleteDataset) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *DeleteDataset) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *DeleteDataset) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
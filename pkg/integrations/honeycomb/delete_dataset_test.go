@@ -0,0 +1,159 @@
+package honeycomb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DeleteDataset__Setup(t *testing.T) {
+	component := &DeleteDataset{}
+
+	canDeleteDatasets := &contexts.IntegrationContext{
+		Metadata: Metadata{ConfigurationKeyPermissions: []string{PermissionCreateDatasets}},
+	}
+
+	t.Run("missing dataset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   canDeleteDatasets,
+			Configuration: map[string]any{"confirm": true},
+		})
+		require.ErrorContains(t, err, "dataset is required")
+	})
+
+	t.Run("environment-wide dataset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   canDeleteDatasets,
+			Configuration: map[string]any{"dataset": allDatasetsInEnvironmentScopeSlug, "confirm": true},
+		})
+		require.ErrorContains(t, err, "the environment-wide dataset cannot be deleted")
+	})
+
+	t.Run("invalid slug -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   canDeleteDatasets,
+			Configuration: map[string]any{"dataset": "pr 1234/logs", "confirm": true},
+		})
+		require.ErrorContains(t, err, `invalid dataset slug "pr 1234/logs"`)
+	})
+
+	t.Run("default permissions -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"dataset": "pr-1234", "confirm": true},
+		})
+		require.ErrorContains(t, err, "missing the create_datasets permission")
+	})
+
+	t.Run("dataset from expression -> validated at execution time", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   canDeleteDatasets,
+			Configuration: map[string]any{"dataset": "pr-{{ $['PR'].number }}", "confirm": true},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("missing confirmation -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   canDeleteDatasets,
+			Configuration: map[string]any{"dataset": "pr-1234"},
+		})
+		require.ErrorIs(t, err, errDeletionNotConfirmed)
+	})
+
+	t.Run("valid slug -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   canDeleteDatasets,
+			Configuration: map[string]any{"dataset": "pr-1234", "confirm": true},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test__DeleteDataset__Execute(t *testing.T) {
+	component := &DeleteDataset{}
+
+	integrationCtx := func() *contexts.IntegrationContext {
+		return &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("test-config-key")},
+			},
+		}
+	}
+
+	execute := func(t *testing.T, httpCtx *contexts.HTTPContext, dataset string) (*contexts.ExecutionStateContext, error) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": dataset, "confirm": true},
+		})
+
+		return execState, err
+	}
+
+	t.Run("dataset deleted -> emits honeycomb.dataset.deleted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{statusResponse(http.StatusAccepted, ``)}}
+
+		execState, err := execute(t, httpCtx, "pr-1234")
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpCtx.Requests[0].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/datasets/pr-1234", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "test-config-key", httpCtx.Requests[0].Header.Get("X-Honeycomb-Team"))
+
+		assert.Equal(t, "honeycomb.dataset.deleted", execState.Type)
+		require.Len(t, execState.Payloads, 1)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, map[string]any{"status": core.ResultStatusSuccess, "dataset": "pr-1234"}, payload)
+	})
+
+	t.Run("dataset not found -> treated as deleted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{statusResponse(http.StatusNotFound, `{"error":"dataset not found"}`)}}
+
+		execState, err := execute(t, httpCtx, "pr-1234")
+
+		require.NoError(t, err)
+		assert.Equal(t, "honeycomb.dataset.deleted", execState.Type)
+	})
+
+	t.Run("deletion protection enabled -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{statusResponse(http.StatusConflict, `{"error":"delete protected"}`)}}
+
+		_, err := execute(t, httpCtx, "pr-1234")
+
+		require.ErrorContains(t, err, "dataset pr-1234 has deletion protection enabled")
+	})
+
+	t.Run("environment-wide dataset from expression -> refused before any request", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+
+		_, err := execute(t, httpCtx, allDatasetsInEnvironmentScopeSlug)
+
+		require.ErrorContains(t, err, "the environment-wide dataset cannot be deleted")
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("confirm disabled -> refused before any request", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": "pr-1234", "confirm": false},
+		})
+
+		require.ErrorIs(t, err, errDeletionNotConfirmed)
+		assert.Empty(t, httpCtx.Requests)
+	})
+}
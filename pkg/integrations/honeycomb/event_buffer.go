@@ -0,0 +1,191 @@
+package honeycomb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	defaultBufferWindowSeconds = 1
+	maxBufferWindowSeconds     = 10
+	defaultBufferSize          = 100
+	maxBufferSize              = 1000
+)
+
+// createEventBuffer batches the events of Create Event components running in buffered mode.
+// It is shared by all executions of the process, so that concurrent executions
+// sending to the same dataset share a single batch request.
+var createEventBuffer = newEventBuffer()
+
+// bufferedEventResult is the outcome of an event, once the batch it belongs to was sent.
+type bufferedEventResult struct {
+	Result    BatchResult
+	BatchSize int
+	Err       error
+}
+
+// eventBuffer groups events by HTTP context, integration and dataset and sends each group
+// through the batch API when its window elapses or it reaches its size, whichever happens first.
+//
+// Callers wait for the result of their event, so an event is never reported as sent
+// while it is still only in memory. Close sends the pending batches right away,
+// and events enqueued after Close are sent on their own.
+type eventBuffer struct {
+	mu      sync.Mutex
+	closed  bool
+	batches map[eventBufferKey]*pendingBatch
+}
+
+type pendingBatch struct {
+	//
+	// The batch outlives the execution that started it,
+	// so it is sent with a client of the integration, see batchClient.
+	// All of its events were enqueued with the HTTP context of that client.
+	//
+	client  *Client
+	dataset string
+	events  []map[string]any
+	results []chan bufferedEventResult
+	timer   *time.Timer
+}
+
+func newEventBuffer() *eventBuffer {
+	return &eventBuffer{batches: map[eventBufferKey]*pendingBatch{}}
+}
+
+// Enqueue adds an event to the batch of its destination and returns
+// the channel that receives the result once the batch is sent.
+func (b *eventBuffer) Enqueue(client *Client, dataset string, fields map[string]any, window time.Duration, size int) (<-chan bufferedEventResult, error) {
+	ingestKey, err := client.ingestKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := newEventBufferKey(client, ingestKey, dataset, window, size)
+
+	//
+	// The batch is sent after this event was created,
+	// so the event time is recorded when it is enqueued.
+	//
+	event := maps.Clone(fields)
+	if _, hasTimeField := event["time"]; !hasTimeField {
+		event["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	result := make(chan bufferedEventResult, 1)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		go sendBatch(&pendingBatch{client: batchClient(client, ingestKey), dataset: dataset, events: []map[string]any{event}, results: []chan bufferedEventResult{result}})
+		return result, nil
+	}
+
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &pendingBatch{client: batchClient(client, ingestKey), dataset: dataset}
+		batch.timer = time.AfterFunc(window, func() { b.flush(key, batch) })
+		b.batches[key] = batch
+	}
+
+	batch.events = append(batch.events, event)
+	batch.results = append(batch.results, result)
+
+	if len(batch.events) < size {
+		b.mu.Unlock()
+		return result, nil
+	}
+
+	batch.timer.Stop()
+	delete(b.batches, key)
+	b.mu.Unlock()
+
+	go sendBatch(batch)
+	return result, nil
+}
+
+// Close sends all pending batches and waits for them to complete.
+func (b *eventBuffer) Close() {
+	b.mu.Lock()
+	b.closed = true
+	batches := b.batches
+	b.batches = map[eventBufferKey]*pendingBatch{}
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		batch.timer.Stop()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sendBatch(batch)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// flush sends the batch when its window elapses,
+// unless it was already sent because it reached its size.
+func (b *eventBuffer) flush(key eventBufferKey, batch *pendingBatch) {
+	b.mu.Lock()
+	if b.batches[key] != batch {
+		b.mu.Unlock()
+		return
+	}
+
+	delete(b.batches, key)
+	b.mu.Unlock()
+
+	sendBatch(batch)
+}
+
+func sendBatch(batch *pendingBatch) {
+	results, err := batch.client.CreateEvents(batch.dataset, batch.events)
+	for i, result := range batch.results {
+		if err != nil {
+			result <- bufferedEventResult{BatchSize: len(batch.events), Err: err}
+			continue
+		}
+
+		result <- bufferedEventResult{Result: results[i], BatchSize: len(batch.events)}
+	}
+}
+
+// eventBufferKey identifies the destination of an event. Events are only batched together
+// if they are sent with the same HTTP context, to the same dataset of the same integration environment,
+// with the same ingest key and buffering options.
+//
+// HTTP contexts are shared by the executions of integrations with the same proxy and connection settings,
+// so the batch is never sent through the proxy, or traced in the execution, of another caller.
+type eventBufferKey struct {
+	http        core.HTTPContext
+	destination string
+}
+
+func newEventBufferKey(client *Client, ingestKey, dataset string, window time.Duration, size int) eventBufferKey {
+	hash := sha256.Sum256([]byte(ingestKey))
+	return eventBufferKey{
+		http:        client.http,
+		destination: fmt.Sprintf("%s|%s|%s|%s|%s|%d", client.integrationCtx.ID(), client.environment, hex.EncodeToString(hash[:]), dataset, window, size),
+	}
+}
+
+// batchClient returns a client for the integration of the given client that only sends events.
+// It does not keep the integration context of the execution that created it,
+// which is only valid while that execution runs.
+func batchClient(client *Client, ingestKey string) *Client {
+	return &Client{
+		BaseURL:            client.BaseURL,
+		http:               client.http,
+		environment:        client.environment,
+		defaultEnvironment: client.defaultEnvironment,
+		ingestKeyOverride:  ingestKey,
+	}
+}
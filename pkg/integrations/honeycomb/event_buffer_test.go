@@ -0,0 +1,239 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func decodeBatchRequest(t *testing.T, request *http.Request) []batchEvent {
+	t.Helper()
+
+	events := []batchEvent{}
+	require.NoError(t, json.NewDecoder(request.Body).Decode(&events))
+	return events
+}
+
+func batchEventData(events []batchEvent) []map[string]any {
+	data := make([]map[string]any, 0, len(events))
+	for _, event := range events {
+		data = append(data, event.Data)
+	}
+
+	return data
+}
+
+func Test__EventBuffer(t *testing.T) {
+	t.Run("window elapses -> pending events are sent in one batch", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"status":202},{"status":202}]`),
+			},
+		}
+
+		client := newTestClient(t, httpCtx)
+		buffer := newEventBuffer()
+
+		first, err := buffer.Enqueue(client, "deploys", map[string]any{"service": "api"}, 200*time.Millisecond, 10)
+		require.NoError(t, err)
+		second, err := buffer.Enqueue(client, "deploys", map[string]any{"service": "web", "time": "2026-01-19T12:00:00Z"}, 200*time.Millisecond, 10)
+		require.NoError(t, err)
+
+		firstResult := <-first
+		secondResult := <-second
+
+		require.NoError(t, firstResult.Err)
+		require.NoError(t, secondResult.Err)
+		assert.Equal(t, 2, firstResult.BatchSize)
+		assert.True(t, secondResult.Result.Succeeded())
+
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.honeycomb.io/1/batch/deploys", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "test-ingest-key", httpCtx.Requests[0].Header.Get("X-Honeycomb-Team"))
+
+		events := decodeBatchRequest(t, httpCtx.Requests[0])
+		require.Len(t, events, 2)
+		assert.Equal(t, map[string]any{"service": "api"}, events[0].Data)
+		assert.NotEmpty(t, events[0].Time)
+		assert.Equal(t, map[string]any{"service": "web"}, events[1].Data)
+		assert.Equal(t, "2026-01-19T12:00:00Z", events[1].Time)
+	})
+
+	t.Run("batch reaches size -> sent before the window elapses", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"status":202},{"status":400,"error":"event too large"}]`),
+			},
+		}
+
+		client := newTestClient(t, httpCtx)
+		buffer := newEventBuffer()
+
+		first, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 1}, time.Hour, 2)
+		require.NoError(t, err)
+		second, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 2}, time.Hour, 2)
+		require.NoError(t, err)
+
+		select {
+		case result := <-first:
+			require.NoError(t, result.Err)
+			assert.True(t, result.Result.Succeeded())
+			assert.Equal(t, 2, result.BatchSize)
+		case <-time.After(5 * time.Second):
+			t.Fatal("batch was not sent when it reached its size")
+		}
+
+		result := <-second
+		require.NoError(t, result.Err)
+		assert.False(t, result.Result.Succeeded())
+		assert.Equal(t, "event too large", result.Result.Error)
+
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Len(t, decodeBatchRequest(t, httpCtx.Requests[0]), 2)
+	})
+
+	t.Run("different datasets -> separate batches", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"status":202}]`),
+				jsonResponse(`[{"status":202}]`),
+			},
+		}
+
+		client := newTestClient(t, httpCtx)
+		buffer := newEventBuffer()
+
+		first, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 1}, time.Hour, 1)
+		require.NoError(t, err)
+		require.NoError(t, (<-first).Err)
+
+		second, err := buffer.Enqueue(client, "errors", map[string]any{"n": 2}, time.Hour, 1)
+		require.NoError(t, err)
+		require.NoError(t, (<-second).Err)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://api.honeycomb.io/1/batch/deploys", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "https://api.honeycomb.io/1/batch/errors", httpCtx.Requests[1].URL.String())
+	})
+
+	t.Run("different integrations with the same ingest key -> separate batches", func(t *testing.T) {
+		firstHTTP := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(`[{"status":202}]`)}}
+		secondHTTP := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(`[{"status":202}]`)}}
+		buffer := newEventBuffer()
+
+		first, err := buffer.Enqueue(newTestClient(t, firstHTTP), "deploys", map[string]any{"n": 1}, 200*time.Millisecond, 2)
+		require.NoError(t, err)
+		second, err := buffer.Enqueue(newTestClient(t, secondHTTP), "deploys", map[string]any{"n": 2}, 200*time.Millisecond, 2)
+		require.NoError(t, err)
+
+		firstResult := <-first
+		secondResult := <-second
+		require.NoError(t, firstResult.Err)
+		require.NoError(t, secondResult.Err)
+		assert.Equal(t, 1, firstResult.BatchSize)
+		assert.Equal(t, 1, secondResult.BatchSize)
+		assert.Len(t, firstHTTP.Requests, 1)
+		assert.Len(t, secondHTTP.Requests, 1)
+	})
+
+	t.Run("same integration with different HTTP contexts -> separate batches sent with their own context", func(t *testing.T) {
+		firstHTTP := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(`[{"status":202}]`)}}
+		secondHTTP := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(`[{"status":202}]`)}}
+		buffer := newEventBuffer()
+
+		client := newTestClient(t, firstHTTP)
+		other := *client
+		other.http = secondHTTP
+
+		first, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 1}, 200*time.Millisecond, 2)
+		require.NoError(t, err)
+		second, err := buffer.Enqueue(&other, "deploys", map[string]any{"n": 2}, 200*time.Millisecond, 2)
+		require.NoError(t, err)
+
+		firstResult := <-first
+		secondResult := <-second
+		require.NoError(t, firstResult.Err)
+		require.NoError(t, secondResult.Err)
+		assert.Equal(t, 1, firstResult.BatchSize)
+		assert.Equal(t, 1, secondResult.BatchSize)
+
+		require.Len(t, firstHTTP.Requests, 1)
+		assert.Equal(t, []map[string]any{{"n": float64(1)}}, batchEventData(decodeBatchRequest(t, firstHTTP.Requests[0])))
+		require.Len(t, secondHTTP.Requests, 1)
+		assert.Equal(t, []map[string]any{{"n": float64(2)}}, batchEventData(decodeBatchRequest(t, secondHTTP.Requests[0])))
+	})
+
+	t.Run("batch -> sent without the integration context of the execution that started it", func(t *testing.T) {
+		client := newTestClient(t, &contexts.HTTPContext{})
+		batch := batchClient(client, "test-ingest-key")
+
+		assert.Nil(t, batch.integrationCtx)
+		assert.Equal(t, client.BaseURL, batch.BaseURL)
+
+		ingestKey, err := batch.ingestKey()
+		require.NoError(t, err)
+		assert.Equal(t, "test-ingest-key", ingestKey)
+	})
+
+	t.Run("close -> pending events are sent and later events are not buffered", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"status":202}]`),
+				jsonResponse(`[{"status":202}]`),
+			},
+		}
+
+		client := newTestClient(t, httpCtx)
+		buffer := newEventBuffer()
+
+		pending, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 1}, time.Hour, 10)
+		require.NoError(t, err)
+
+		buffer.Close()
+		require.Len(t, httpCtx.Requests, 1)
+		result := <-pending
+		require.NoError(t, result.Err)
+		assert.Equal(t, 1, result.BatchSize)
+
+		later, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 2}, time.Hour, 10)
+		require.NoError(t, err)
+		require.NoError(t, (<-later).Err)
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("batch request fails -> every event gets the error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusBadRequest, `{"error":"unknown dataset"}`),
+			},
+		}
+
+		client := newTestClient(t, httpCtx)
+		buffer := newEventBuffer()
+
+		first, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 1}, time.Hour, 2)
+		require.NoError(t, err)
+		second, err := buffer.Enqueue(client, "deploys", map[string]any{"n": 2}, time.Hour, 2)
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, (<-first).Err, "unknown dataset")
+		assert.ErrorContains(t, (<-second).Err, "unknown dataset")
+	})
+
+	t.Run("missing ingest key -> error", func(t *testing.T) {
+		client, err := NewClient(&contexts.HTTPContext{}, &contexts.IntegrationContext{
+			Configuration: map[string]any{"managementKey": "keyid:secret", "site": "api.honeycomb.io"},
+			Secrets:       map[string]core.IntegrationSecret{},
+		})
+		require.NoError(t, err)
+
+		_, err = newEventBuffer().Enqueue(client, "deploys", map[string]any{"n": 1}, time.Hour, 2)
+		require.ErrorContains(t, err, "ingest key not found")
+	})
+}
@@ -10,15 +10,57 @@ import (
 //go:embed example_data_on_alert_fired.json
 var exampleDataOnAlertFiredBytes []byte
 
+//go:embed example_data_on_slo_burn_alert.json
+var exampleDataOnSLOBurnAlertBytes []byte
+
 //go:embed example_output_create_event.json
 var exampleOutputCreateEventBytes []byte
 
+//go:embed example_output_create_events.json
+var exampleOutputCreateEventsBytes []byte
+
+//go:embed example_output_create_marker.json
+var exampleOutputCreateMarkerBytes []byte
+
+//go:embed example_output_test_recipient.json
+var exampleOutputTestRecipientBytes []byte
+
+//go:embed example_output_query_dataset.json
+var exampleOutputQueryDatasetBytes []byte
+
+//go:embed example_output_delete_dataset.json
+var exampleOutputDeleteDatasetBytes []byte
+
+//go:embed example_output_update_trigger_threshold.json
+var exampleOutputUpdateTriggerThresholdBytes []byte
+
 var (
 	exampleDataOnAlertFiredOnce sync.Once
 	exampleDataOnAlertFired     map[string]any
 
+	exampleDataOnSLOBurnAlertOnce sync.Once
+	exampleDataOnSLOBurnAlert     map[string]any
+
 	exampleOutputCreateEventOnce sync.Once
 	exampleOutputCreateEvent     map[string]any
+
+	exampleOutputCreateEventsOnce sync.Once
+	exampleOutputCreateEvents     map[string]any
+
+	exampleOutputCreateMarkerOnce sync.Once
+	exampleOutputCreateMarker     map[string]any
+
+	exampleOutputTestRecipientOnce sync.Once
+	exampleOutputTestRecipient     map[string]any
+
+	exampleOutputQueryDatasetOnce sync.Once
+	exampleOutputQueryDataset     map[string]any
+
+	exampleOutputDeleteDatasetOnce sync.Once
+	exampleOutputDeleteDataset     map[string]any
+
+	exampleOutputUpdateTriggerThresholdOnce sync.Once
+	exampleOutputUpdateTriggerThreshold     map[string]any
 )
 
 func embeddedExampleDataOnAlertFired() map[string]any {
@@ -29,6 +71,14 @@ func embeddedExampleDataOnAlertFired() map[string]any {
 	)
 }
 
+func embeddedExampleDataOnSLOBurnAlert() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnSLOBurnAlertOnce,
+		exampleDataOnSLOBurnAlertBytes,
+		&exampleDataOnSLOBurnAlert,
+	)
+}
+
 func embeddedExampleOutputCreateEvent() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(
 		&exampleOutputCreateEventOnce,
@@ -37,10 +87,86 @@ func embeddedExampleOutputCreateEvent() map[string]any {
 	)
 }
 
+func embeddedExampleOutputCreateEvents() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCreateEventsOnce,
+		exampleOutputCreateEventsBytes,
+		&exampleOutputCreateEvents,
+	)
+}
+
+func embeddedExampleOutputCreateMarker() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCreateMarkerOnce,
+		exampleOutputCreateMarkerBytes,
+		&exampleOutputCreateMarker,
+	)
+}
+
+func embeddedExampleOutputTestRecipient() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputTestRecipientOnce,
+		exampleOutputTestRecipientBytes,
+		&exampleOutputTestRecipient,
+	)
+}
+
+func embeddedExampleOutputQueryDataset() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputQueryDatasetOnce,
+		exampleOutputQueryDatasetBytes,
+		&exampleOutputQueryDataset,
+	)
+}
+
+func embeddedExampleOutputDeleteDataset() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputDeleteDatasetOnce,
+		exampleOutputDeleteDatasetBytes,
+		&exampleOutputDeleteDataset,
+	)
+}
+
+func embeddedExampleOutputUpdateTriggerThreshold() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputUpdateTriggerThresholdOnce,
+		exampleOutputUpdateTriggerThresholdBytes,
+		&exampleOutputUpdateTriggerThreshold,
+	)
+}
+
 func (t *OnAlertFired) ExampleData() map[string]any {
 	return embeddedExampleDataOnAlertFired()
 }
 
+func (t *OnSLOBurnAlert) ExampleData() map[string]any {
+	return embeddedExampleDataOnSLOBurnAlert()
+}
+
 func (c *CreateEvent) ExampleOutput() map[string]any {
 	return embeddedExampleOutputCreateEvent()
 }
+
+func (c *CreateEvents) ExampleOutput() map[string]any {
+	return embeddedExampleOutputCreateEvents()
+}
+
+func (c *CreateMarker) ExampleOutput() map[string]any {
+	return embeddedExampleOutputCreateMarker()
+}
+
+func (c *TestRecipient) ExampleOutput() map[string]any {
+	return embeddedExampleOutputTestRecipient()
+}
+
+func (c *QueryDataset) ExampleOutput() map[string]any {
+	return embeddedExampleOutputQueryDataset()
+}
+
+func (c *DeleteDataset) ExampleOutput() map[string]any {
+	return embeddedExampleOutputDeleteDataset()
+}
+
+func (c *UpdateTriggerThreshold) ExampleOutput() map[string]any {
+	return embeddedExampleOutputUpdateTriggerThreshold()
+}
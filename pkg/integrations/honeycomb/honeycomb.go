@@ -1,7 +1,10 @@
 package honeycomb
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -12,6 +15,7 @@ import (
 
 func init() {
 	registry.RegisterIntegrationWithWebhookHandler("honeycomb", &Honeycomb{}, &HoneycombWebhookHandler{})
+	registry.RegisterShutdownHook("honeycomb.createEventBuffer", createEventBuffer.Close)
 }
 
 type Honeycomb struct{}
@@ -21,6 +25,53 @@ type Configuration struct {
 	ManagementKey   string `json:"managementKey" mapstructure:"managementKey"`
 	TeamSlug        string `json:"teamSlug" mapstructure:"teamSlug"`
 	EnvironmentSlug string `json:"environmentSlug" mapstructure:"environmentSlug"`
+
+	//
+	// The environments the configuration and ingest keys of the default environment
+	// are created in, when they are not the default environment itself.
+	//
+	ConfigEnvironmentSlug string `json:"configEnvironmentSlug" mapstructure:"configEnvironmentSlug"`
+	IngestEnvironmentSlug string `json:"ingestEnvironmentSlug" mapstructure:"ingestEnvironmentSlug"`
+
+	ConfigurationKeyPermissions []string `json:"configurationKeyPermissions" mapstructure:"configurationKeyPermissions"`
+}
+
+// Metadata records the permissions the configuration key was provisioned with,
+// so they can be checked by triggers and components without reading the configuration.
+type Metadata struct {
+	ConfigurationKeyPermissions []string `json:"configurationKeyPermissions" mapstructure:"configurationKeyPermissions"`
+
+	//
+	// The steps completed by a failed Sync, skipped when it is retried.
+	//
+	SyncProgress *core.SyncProgress `json:"syncProgress,omitempty" mapstructure:"syncProgress,omitempty"`
+
+	//
+	// The Recipient Setup Concurrency of the configuration,
+	// recorded for the webhook handler, which only has access to string configuration.
+	//
+	RecipientSetupConcurrency int `json:"recipientSetupConcurrency,omitempty" mapstructure:"recipientSetupConcurrency,omitempty"`
+}
+
+// Sync steps recorded in the sync progress.
+// Environment steps are recorded per environment, as "<step>:<environment>".
+const (
+	syncStepManagementKey    = "managementKey"
+	syncStepConfigurationKey = "configurationKey"
+	syncStepIngestKey        = "ingestKey"
+)
+
+func environmentSyncStep(step, environment string) string {
+	return step + ":" + environment
+}
+
+var AllConfigurationKeyPermissions = []configuration.FieldOption{
+	{Label: "Manage Triggers", Value: PermissionManageTriggers},
+	{Label: "Manage Recipients", Value: PermissionManageRecipients},
+	{Label: "Manage Markers", Value: PermissionManageMarkers},
+	{Label: "Run Queries", Value: PermissionRunQueries},
+	{Label: "Create and Delete Datasets", Value: PermissionCreateDatasets},
+	{Label: "Manage SLOs", Value: PermissionManageSLOs},
 }
 
 func (h *Honeycomb) Name() string {
@@ -47,9 +98,14 @@ Connect Honeycomb to SuperPlane using a Management Key.
 - **Site**: US (api.honeycomb.io) or EU (api.eu1.honeycomb.io) based on your account region.
 - **Management Key**: Found in Honeycomb under Team Settings > API Keys. Must be in format <keyID>:<secret>.
 - **Team Slug**: Your team identifier, visible in the Honeycomb URL: honeycomb.io/<team-slug>.
-- **Environment Slug**: The environment containing your datasets (e.g. "production"). Found under Team Settings > Environments.
+- **Environment Slug**: The environment containing your datasets (e.g. "production"). Found under Team Settings > Environments. Separate several environments with commas (e.g. "production, staging") to use them all from one integration; the first one is the default, and triggers and actions can select another one.
+
+**Optional configuration:**
+- **Configuration Key Permissions**: The permissions of the configuration key SuperPlane creates. All of them except Create and Delete Datasets and Manage SLOs are granted by default. The On Alert Fired trigger needs Manage Triggers and Manage Recipients, On SLO Burn Alert needs Manage SLOs and Manage Recipients, Create Marker needs Manage Markers, Test Recipient needs Manage Recipients, Query Dataset needs Run Queries, and Delete Dataset needs Create and Delete Datasets. Changing the permissions creates a new configuration key on the next save; the previous key can be deleted in Honeycomb.
+- **Configuration Key Environment** and **Ingest Key Environment**: The environments the configuration and ingest keys of the default environment are created in, for teams whose triggers and events live in different environments. Both default to the first Environment Slug. Other environments always get keys of their own.
+- **Recipient Setup Concurrency**: How many triggers and burn alerts the webhook recipient of a dataset is attached to at once, 4 by default. Lower it if Honeycomb rate limits the setup of datasets with many triggers.
 
-SuperPlane will automatically validate your credentials and manage all necessary Honeycomb resources — webhook recipients for triggers and ingest keys for actions — so no manual setup is required.
+SuperPlane will automatically validate your credentials and manage all necessary Honeycomb resources — webhook recipients for triggers and ingest keys for actions, in every environment — so no manual setup is required.
 `
 }
 
@@ -90,21 +146,95 @@ func (h *Honeycomb) Configuration() []configuration.Field {
 			Name:        "environmentSlug",
 			Label:       "Environment Slug",
 			Type:        configuration.FieldTypeString,
-			Description: "The environment containing your datasets (e.g. \"production\"). Found under Team Settings > Environments.",
+			Description: "The environments containing your datasets, separated by commas (e.g. \"production, staging\"). The first one is the default. Found under Team Settings > Environments.",
 			Required:    true,
 		},
+		{
+			Name:        configEnvironmentSlugField,
+			Label:       "Configuration Key Environment",
+			Type:        configuration.FieldTypeString,
+			Description: "The environment the configuration key of the default environment is created in, if it is not the default environment.",
+			Required:    false,
+			Togglable:   true,
+		},
+		{
+			Name:        ingestEnvironmentSlugField,
+			Label:       "Ingest Key Environment",
+			Type:        configuration.FieldTypeString,
+			Description: "The environment the ingest key of the default environment is created in, if it is not the default environment.",
+			Required:    false,
+			Togglable:   true,
+		},
+		{
+			Name:        "configurationKeyPermissions",
+			Label:       "Configuration Key Permissions",
+			Type:        configuration.FieldTypeMultiSelect,
+			Description: "Permissions granted to the configuration key SuperPlane creates.",
+			Required:    false,
+			Default: []string{
+				PermissionManageTriggers,
+				PermissionManageRecipients,
+				PermissionManageMarkers,
+				PermissionRunQueries,
+			},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: AllConfigurationKeyPermissions,
+				},
+			},
+		},
+		{
+			Name:        "recipientSetupConcurrency",
+			Label:       "Recipient Setup Concurrency",
+			Type:        configuration.FieldTypeNumber,
+			Description: "How many triggers and burn alerts a webhook recipient is attached to at once.",
+			Required:    false,
+			Default:     fmt.Sprintf("%d", defaultRecipientSetupConcurrency),
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxRecipientSetupConcurrency; return &max }(),
+				},
+			},
+		},
 	}
 }
 
 func (h *Honeycomb) Components() []core.Component {
 	return []core.Component{
 		&CreateEvent{},
+		&CreateEvents{},
+		&CreateMarker{},
+		&TestRecipient{},
+		&QueryDataset{},
+		&DeleteDataset{},
+		&UpdateTriggerThreshold{},
 	}
 }
 
+// DescribeError describes the failures of the components that continue on error.
+func (h *Honeycomb) DescribeError(err error) core.ErrorPayload {
+	var apiErr *HoneycombAPIError
+	if errors.As(err, &apiErr) {
+		return core.HTTPErrorPayload(apiErr.StatusCode, err.Error())
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return core.ErrorPayload{Code: core.ErrorCodeRequestTimeout, Message: err.Error(), Retryable: true}
+		}
+
+		return core.ErrorPayload{Code: core.ErrorCodeNetworkError, Message: err.Error(), Retryable: true}
+	}
+
+	return core.ErrorPayload{Code: core.ErrorCodeFailed, Message: err.Error()}
+}
+
 func (h *Honeycomb) Triggers() []core.Trigger {
 	return []core.Trigger{
 		&OnAlertFired{},
+		&OnSLOBurnAlert{},
 	}
 }
 
@@ -121,46 +251,161 @@ func (h *Honeycomb) Cleanup(ctx core.IntegrationCleanupContext) error {
 }
 
 func (h *Honeycomb) Sync(ctx core.SyncContext) error {
+	config, _ := ctx.Configuration.(map[string]any)
+
 	cfg := Configuration{}
 	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
-	if strings.TrimSpace(cfg.Site) == "" {
-		return fmt.Errorf("site is required")
+	permissions, err := ParseConfigurationKeyPermissions(cfg.ConfigurationKeyPermissions)
+	if err != nil {
+		return err
 	}
 
-	if strings.TrimSpace(cfg.ManagementKey) == "" {
-		return fmt.Errorf("managementKey is required")
+	metadata := Metadata{}
+	_ = mapstructure.Decode(ctx.Integration.GetMetadata(), &metadata)
+	metadata.RecipientSetupConcurrency = recipientSetupConcurrency(config)
+
+	//
+	// Steps completed by a previous Sync that failed are not run again,
+	// so a retry only picks up where that Sync stopped.
+	//
+	progress := core.ResumeSyncProgress(metadata.SyncProgress, ctx.Configuration)
+	fail := func(err error) error {
+		metadata.SyncProgress = progress
+		ctx.Integration.SetMetadata(metadata)
+		return err
 	}
 
-	if strings.TrimSpace(cfg.TeamSlug) == "" {
-		return fmt.Errorf("teamSlug is required")
+	if !progress.IsCompleted(syncStepManagementKey) {
+		client, err := NewClient(ctx.HTTP, ctx.Integration)
+		if err != nil {
+			return err
+		}
+
+		if err := client.ValidateManagementKey(cfg.TeamSlug); err != nil {
+			return fail(err)
+		}
+
+		progress.Complete(syncStepManagementKey)
 	}
 
-	if strings.TrimSpace(cfg.EnvironmentSlug) == "" {
-		return fmt.Errorf("environmentSlug is required")
+	//
+	// A key only has the permissions it was created with,
+	// so keys created with other permissions are replaced.
+	//
+	resetConfigurationKeys := metadata.ConfigurationKeyPermissions != nil && !slices.Equal(metadata.ConfigurationKeyPermissions, permissions.Names())
+
+	environments := environmentSlugs(ctx.Integration)
+	warnings := []string{}
+	configKeysReady := true
+
+	for _, environment := range environments {
+		environmentWarnings, configKeyReady, err := syncEnvironment(ctx, progress, cfg.TeamSlug, environment, permissions, resetConfigurationKeys)
+		if err != nil {
+			if len(environments) > 1 {
+				return fail(fmt.Errorf("environment %s: %w", environment, err))
+			}
+
+			return fail(err)
+		}
+
+		configKeysReady = configKeysReady && configKeyReady
+
+		for _, warning := range environmentWarnings {
+			if len(environments) > 1 {
+				warning = fmt.Sprintf("[%s] %s", environment, warning)
+			}
+
+			warnings = append(warnings, warning)
+		}
 	}
 
-	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	metadata.SyncProgress = nil
+	if configKeysReady {
+		metadata.ConfigurationKeyPermissions = permissions.Names()
+	}
+
+	ctx.Integration.SetMetadata(metadata)
+
+	if len(warnings) > 0 {
+		ctx.Integration.Degraded(strings.Join(warnings, " "))
+		return nil
+	}
+
+	ctx.Integration.Ready()
+	return nil
+}
+
+// syncEnvironment provisions the configuration and ingest keys of one environment,
+// skipping the keys already provisioned by a previous, failed Sync.
+// It returns the warnings to report and whether the configuration key is ready.
+// Only a failure to provision the ingest key is returned as an error.
+func syncEnvironment(ctx core.SyncContext, progress *core.SyncProgress, teamSlug, environment string, permissions ConfigurationKeyPermissions, resetConfigurationKey bool) ([]string, bool, error) {
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, environment)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
 
-	if err := client.ValidateManagementKey(cfg.TeamSlug); err != nil {
-		return err
+	//
+	// The configuration key is only needed for triggers,
+	// so a failure here still allows events to be sent with the ingest key.
+	//
+	var configKeyErr error
+	configKeyStep := environmentSyncStep(syncStepConfigurationKey, environment)
+	if !progress.IsCompleted(configKeyStep) {
+		if resetConfigurationKey {
+			client.resetSecret(secretNameConfigurationKey)
+		}
+
+		configKeyErr = client.EnsureConfigurationKey(teamSlug, permissions)
+		if configKeyErr == nil {
+			progress.Complete(configKeyStep)
+		}
 	}
 
-	if err := client.EnsureConfigurationKey(cfg.TeamSlug); err != nil {
-		return err
+	ingestKeyStep := environmentSyncStep(syncStepIngestKey, environment)
+	if !progress.IsCompleted(ingestKeyStep) {
+		if err := client.EnsureIngestKey(teamSlug); err != nil {
+			return nil, false, err
+		}
+
+		progress.Complete(ingestKeyStep)
 	}
 
-	if err := client.EnsureIngestKey(cfg.TeamSlug); err != nil {
-		return err
+	warnings := []string{}
+	if configKeyErr != nil {
+		warnings = append(warnings, fmt.Sprintf("Events can be sent, but trigger provisioning failed: %v", configKeyErr))
 	}
 
-	ctx.Integration.Ready()
-	return nil
+	//
+	// Without the create_datasets permission, events sent to a dataset
+	// that does not exist yet are rejected, so users are told upfront.
+	// A failed check is not fatal, since the ingest key was already verified.
+	//
+	canCreateDatasets, err := client.IngestKeyCanCreateDatasets()
+	if err == nil && !canCreateDatasets {
+		warnings = append(warnings, "The ingest key cannot create datasets, so events can only be sent to existing datasets. Create the datasets in Honeycomb first, or allow the management key to create ingest keys with the \"Create Datasets\" permission and re-save the integration.")
+	}
+
+	return warnings, configKeyErr == nil, nil
+}
+
+// configurationKeyPermissions returns the permissions the configuration key was provisioned with.
+// Integrations synced before the permissions were recorded have the default ones.
+func configurationKeyPermissions(integration core.IntegrationContext) ConfigurationKeyPermissions {
+	metadata := Metadata{}
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return DefaultConfigurationKeyPermissions()
+	}
+
+	permissions, err := ParseConfigurationKeyPermissions(metadata.ConfigurationKeyPermissions)
+	if err != nil {
+		return DefaultConfigurationKeyPermissions()
+	}
+
+	return permissions
 }
 
 func (h *Honeycomb) HandleRequest(ctx core.HTTPRequestContext) {
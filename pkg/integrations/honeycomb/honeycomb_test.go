@@ -1,19 +1,24 @@
 package honeycomb
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 	contexts "github.com/superplanehq/superplane/test/support/contexts"
 )
 
 func Test__Honeycomb__Sync(t *testing.T) {
-	h := &Honeycomb{}
+	h := registry.NewPanicableIntegration(&Honeycomb{})
 
 	t.Run("missing site -> error", func(t *testing.T) {
 		integrationCtx := &contexts.IntegrationContext{
@@ -30,7 +35,28 @@ func Test__Honeycomb__Sync(t *testing.T) {
 			HTTP:          &contexts.HTTPContext{},
 		})
 
-		require.ErrorContains(t, err, "site is required")
+		require.ErrorContains(t, err, "field 'site' is required")
+	})
+
+	t.Run("unsupported site -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.example.com",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{}
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		require.ErrorContains(t, err, "field 'site': must be one of: api.honeycomb.io, api.eu1.honeycomb.io")
+		assert.Empty(t, httpCtx.Requests)
 	})
 
 	t.Run("missing managementKey -> error", func(t *testing.T) {
@@ -48,7 +74,7 @@ func Test__Honeycomb__Sync(t *testing.T) {
 			HTTP:          &contexts.HTTPContext{},
 		})
 
-		require.ErrorContains(t, err, "managementKey is required")
+		require.ErrorContains(t, err, "field 'managementKey' is required")
 	})
 
 	t.Run("missing teamSlug -> error", func(t *testing.T) {
@@ -66,7 +92,7 @@ func Test__Honeycomb__Sync(t *testing.T) {
 			HTTP:          &contexts.HTTPContext{},
 		})
 
-		require.ErrorContains(t, err, "teamSlug is required")
+		require.ErrorContains(t, err, "field 'teamSlug' is required")
 	})
 
 	t.Run("missing environmentSlug -> error", func(t *testing.T) {
@@ -84,7 +110,7 @@ func Test__Honeycomb__Sync(t *testing.T) {
 			HTTP:          &contexts.HTTPContext{},
 		})
 
-		require.ErrorContains(t, err, "environmentSlug is required")
+		require.ErrorContains(t, err, "field 'environmentSlug' is required")
 	})
 
 	t.Run("invalid managementKey format -> error", func(t *testing.T) {
@@ -135,7 +161,7 @@ func Test__Honeycomb__Sync(t *testing.T) {
 		assert.NotEqual(t, "ready", integrationCtx.State)
 	})
 
-	t.Run("successful sync -> integration is ready, secrets are stored", func(t *testing.T) {
+	t.Run("US site with an EU key -> region mismatch error", func(t *testing.T) {
 		integrationCtx := &contexts.IntegrationContext{
 			Configuration: map[string]any{
 				"site":            "api.honeycomb.io",
@@ -143,6 +169,99 @@ func Test__Honeycomb__Sync(t *testing.T) {
 				"teamSlug":        "myteam",
 				"environmentSlug": "production",
 			},
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"401","title":"Unauthorized","detail":"unknown API key - check your credentials, region, and API URL"}]}`)),
+				},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		var apiErr *HoneycombAPIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.Equal(t, "unknown API key - check your credentials, region, and API URL", apiErr.Message)
+		assert.True(t, apiErr.IsRegionMismatch())
+		assert.ErrorContains(t, err, "Your key appears to be for the EU region; switch the Site setting")
+	})
+
+	t.Run("EU site with a US key -> suggests the US site", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.eu1.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"unknown API key - check your credentials, region, and API URL"}`)),
+				},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		assert.ErrorContains(t, err, "Your key appears to be for the US region; switch the Site setting to US (api.honeycomb.io)")
+	})
+
+	t.Run("API returns 403 -> typed error without region guidance", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"missing scope environments:read"}`)),
+				},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		var apiErr *HoneycombAPIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.False(t, apiErr.IsRegionMismatch())
+		assert.EqualError(t, err, "management key forbidden (403): missing permissions/scopes: missing scope environments:read")
+	})
+
+	t.Run("successful sync -> integration is ready, secrets are stored", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                      "api.honeycomb.io",
+				"managementKey":             "keyid:secret",
+				"teamSlug":                  "myteam",
+				"environmentSlug":           "production",
+				"recipientSetupConcurrency": float64(6),
+			},
 			Secrets: map[string]core.IntegrationSecret{},
 		}
 
@@ -202,6 +321,10 @@ func Test__Honeycomb__Sync(t *testing.T) {
 					StatusCode: http.StatusOK,
 					Body:       io.NopCloser(strings.NewReader(`{}`)), // <-- NEW ping response
 				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"type":"ingest","api_key_access":{"events":true,"createDatasets":true}}`)),
+				},
 			},
 		}
 
@@ -213,15 +336,701 @@ func Test__Honeycomb__Sync(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Empty(t, integrationCtx.StateDescription)
 
-		assert.Len(t, httpCtx.Requests, 7)
+		assert.Len(t, httpCtx.Requests, 8)
 
-		cfgSecret, ok := integrationCtx.Secrets[secretNameConfigurationKey]
+		cfgSecret, ok := integrationCtx.Secrets[secretNameConfigurationKey+"_production"]
 		require.True(t, ok)
 		assert.Equal(t, []byte("cfg-secret-value"), cfgSecret.Value)
 
-		ingestSecret, ok := integrationCtx.Secrets[secretNameIngestKey]
+		ingestSecret, ok := integrationCtx.Secrets[secretNameIngestKey+"_production"]
+		require.True(t, ok)
+		assert.Equal(t, []byte("ingestkey-idingest-secret-value"), ingestSecret.Value)
+		assert.Equal(t, 6, integrationCtx.Metadata.(Metadata).RecipientSetupConcurrency)
+	})
+
+	t.Run("configuration key provisioning fails -> integration is degraded", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		environmentsBody := `{
+			"data": [
+				{
+					"id": "env-123",
+					"type": "environments",
+					"attributes": {"name": "Production", "slug": "production"}
+				}
+			]
+		}`
+
+		ingestKeyBody := `{
+			"data": {
+				"id": "ingestkey-id",
+				"type": "api-keys",
+				"attributes": {"secret": "ingest-secret-value"}
+			}
+		}`
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(environmentsBody)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(environmentsBody)),
+				},
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"forbidden"}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(environmentsBody)),
+				},
+				{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(ingestKeyBody)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "degraded", integrationCtx.State)
+		assert.Contains(t, integrationCtx.StateDescription, "trigger provisioning failed")
+		assert.Contains(t, integrationCtx.StateDescription, "403")
+
+		_, ok := integrationCtx.Secrets[secretNameConfigurationKey+"_production"]
+		assert.False(t, ok)
+
+		ingestSecret, ok := integrationCtx.Secrets[secretNameIngestKey+"_production"]
 		require.True(t, ok)
 		assert.Equal(t, []byte("ingestkey-idingest-secret-value"), ingestSecret.Value)
 	})
+
+	t.Run("ingest key cannot create datasets -> integration is degraded with a warning", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("cfg-secret-value")},
+				secretNameIngestKey:        {Name: secretNameIngestKey, Value: []byte("ingest-secret-value")},
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"data":[]}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"type":"ingest","api_key_access":{"events":true,"createDatasets":false}}`)),
+				},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "degraded", integrationCtx.State)
+		assert.Contains(t, integrationCtx.StateDescription, "cannot create datasets")
+		assert.Contains(t, integrationCtx.StateDescription, "Create the datasets in Honeycomb first")
+	})
+
+	t.Run("ingest key provisioning fails -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		environmentsBody := `{"data": [{"id": "env-123", "type": "environments", "attributes": {"slug": "production"}}]}`
+		configKeyBody := `{"data": {"id": "cfgkey-123", "type": "api-keys", "attributes": {"secret": "cfg-secret-value"}}}`
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+				{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(configKeyBody))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"error":"forbidden"}`))},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			Integration:   integrationCtx,
+			HTTP:          httpCtx,
+		})
+
+		require.ErrorContains(t, err, "create ingest key failed")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+		assert.NotEqual(t, "degraded", integrationCtx.State)
+	})
+}
+
+func Test__Honeycomb__Sync__ConfigurationKeyPermissions(t *testing.T) {
+	h := &Honeycomb{}
+
+	environmentsBody := `{"data": [{"id": "env-123", "type": "environments", "attributes": {"slug": "production"}}]}`
+	configKeyBody := `{"data": {"id": "cfgkey-123", "type": "api-keys", "attributes": {"secret": "new-cfg-secret"}}}`
+	ingestKeyBody := `{"data": {"id": "ingestkey-id", "type": "api-keys", "attributes": {"secret": "ingest-secret-value"}}}`
+
+	syncResponses := func() []*http.Response {
+		return []*http.Response{
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+			{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(configKeyBody))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+			{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(ingestKeyBody))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"type":"ingest","api_key_access":{"events":true,"createDatasets":true}}`))},
+		}
+	}
+
+	configKeyPermissions := func(t *testing.T, req *http.Request) map[string]any {
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		attributes := body["data"].(map[string]any)["attributes"].(map[string]any)
+		return attributes["permissions"].(map[string]any)
+	}
+
+	t.Run("permissions not configured -> key gets the default permissions", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		httpCtx := &contexts.HTTPContext{Responses: syncResponses()}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"manage_triggers":   true,
+			"manage_recipients": true,
+			"manage_markers":    true,
+			"run_queries":       true,
+			"create_datasets":   false,
+			"manage_slos":       false,
+			"send_events":       false,
+		}, configKeyPermissions(t, httpCtx.Requests[2]))
+		assert.Equal(t, Metadata{
+			ConfigurationKeyPermissions: []string{
+				PermissionManageMarkers,
+				PermissionManageRecipients,
+				PermissionManageTriggers,
+				PermissionRunQueries,
+			},
+			RecipientSetupConcurrency: defaultRecipientSetupConcurrency,
+		}, integrationCtx.Metadata)
+	})
+
+	t.Run("read-only permissions -> key only gets the selected permissions", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                        "api.honeycomb.io",
+				"managementKey":               "keyid:secret",
+				"teamSlug":                    "myteam",
+				"environmentSlug":             "production",
+				"configurationKeyPermissions": []any{PermissionRunQueries},
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		httpCtx := &contexts.HTTPContext{Responses: syncResponses()}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"manage_triggers":   false,
+			"manage_recipients": false,
+			"manage_markers":    false,
+			"run_queries":       true,
+			"create_datasets":   false,
+			"manage_slos":       false,
+			"send_events":       false,
+		}, configKeyPermissions(t, httpCtx.Requests[2]))
+		assert.Equal(t, Metadata{
+			ConfigurationKeyPermissions: []string{PermissionRunQueries},
+			RecipientSetupConcurrency:   defaultRecipientSetupConcurrency,
+		}, integrationCtx.Metadata)
+	})
+
+	t.Run("permissions changed -> existing key is replaced", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                        "api.honeycomb.io",
+				"managementKey":               "keyid:secret",
+				"teamSlug":                    "myteam",
+				"environmentSlug":             "production",
+				"configurationKeyPermissions": []any{PermissionManageTriggers, PermissionManageRecipients},
+			},
+			Metadata: Metadata{ConfigurationKeyPermissions: []string{PermissionRunQueries}},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("old-cfg-secret")},
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{Responses: syncResponses()}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, httpCtx.Requests[2].Method)
+		assert.Equal(t, []byte("new-cfg-secret"), integrationCtx.Secrets[secretNameConfigurationKey+"_production"].Value)
+		assert.Equal(t, Metadata{
+			ConfigurationKeyPermissions: []string{
+				PermissionManageRecipients,
+				PermissionManageTriggers,
+			},
+			RecipientSetupConcurrency: defaultRecipientSetupConcurrency,
+		}, integrationCtx.Metadata)
+	})
+
+	t.Run("unknown permission -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                        "api.honeycomb.io",
+				"managementKey":               "keyid:secret",
+				"teamSlug":                    "myteam",
+				"environmentSlug":             "production",
+				"configurationKeyPermissions": []any{"send_events"},
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		httpCtx := &contexts.HTTPContext{Responses: syncResponses()}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.Error(t, err)
+	})
+}
+
+func Test__Honeycomb__Sync__Environments(t *testing.T) {
+	h := &Honeycomb{}
+
+	environmentsBody := `{"data": [` +
+		`{"id": "env-prod", "type": "environments", "attributes": {"slug": "production"}},` +
+		`{"id": "env-staging", "type": "environments", "attributes": {"slug": "staging"}}]}`
+
+	environmentResponses := func(configKeySecret, ingestKeySecret string) []*http.Response {
+		return []*http.Response{
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+			{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(`{"data": {"id": "cfgkey", "type": "api-keys", "attributes": {"secret": "` + configKeySecret + `"}}}`))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+			{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(`{"data": {"id": "ingestkey", "type": "api-keys", "attributes": {"secret": "` + ingestKeySecret + `"}}}`))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"type":"ingest","api_key_access":{"events":true,"createDatasets":true}}`))},
+		}
+	}
+
+	t.Run("several environments -> keys are provisioned for each environment", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production, staging",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		responses := []*http.Response{
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+		}
+		responses = append(responses, environmentResponses("prod-cfg", "prod-ingest")...)
+		responses = append(responses, environmentResponses("staging-cfg", "staging-ingest")...)
+
+		httpCtx := &contexts.HTTPContext{Responses: responses}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpCtx.Requests, 15)
+
+		assert.Equal(t, []byte("prod-cfg"), integrationCtx.Secrets[secretNameConfigurationKey+"_production"].Value)
+		assert.Equal(t, []byte("ingestkeyprod-ingest"), integrationCtx.Secrets[secretNameIngestKey+"_production"].Value)
+		assert.Equal(t, []byte("staging-cfg"), integrationCtx.Secrets[secretNameConfigurationKey+"_staging"].Value)
+		assert.Equal(t, []byte("ingestkeystaging-ingest"), integrationCtx.Secrets[secretNameIngestKey+"_staging"].Value)
+
+		stagingKeyBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[9].Body).Decode(&stagingKeyBody))
+		relationships := stagingKeyBody["data"].(map[string]any)["relationships"].(map[string]any)
+		assert.Equal(t, "env-staging", relationships["environment"].(map[string]any)["data"].(map[string]any)["id"])
+	})
+
+	keyEnvironmentID := func(t *testing.T, request *http.Request) string {
+		keyBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&keyBody))
+		relationships := keyBody["data"].(map[string]any)["relationships"].(map[string]any)
+		return relationships["environment"].(map[string]any)["data"].(map[string]any)["id"].(string)
+	}
+
+	t.Run("key environment overrides -> each key is created in its environment", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                  "api.honeycomb.io",
+				"managementKey":         "keyid:secret",
+				"teamSlug":              "myteam",
+				"environmentSlug":       "production",
+				"configEnvironmentSlug": "staging",
+				"ingestEnvironmentSlug": "production",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		responses := []*http.Response{
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+		}
+		responses = append(responses, environmentResponses("cfg", "ingest")...)
+
+		httpCtx := &contexts.HTTPContext{Responses: responses}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpCtx.Requests, 8)
+		assert.Equal(t, "env-staging", keyEnvironmentID(t, httpCtx.Requests[2]))
+		assert.Equal(t, "env-prod", keyEnvironmentID(t, httpCtx.Requests[5]))
+		assert.Equal(t, []byte("cfg"), integrationCtx.Secrets[secretNameConfigurationKey+"_production"].Value)
+		assert.Equal(t, []byte("ingestkeyingest"), integrationCtx.Secrets[secretNameIngestKey+"_production"].Value)
+	})
+
+	t.Run("ingest key override -> only applies to the default environment", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                  "api.honeycomb.io",
+				"managementKey":         "keyid:secret",
+				"teamSlug":              "myteam",
+				"environmentSlug":       "production, staging",
+				"ingestEnvironmentSlug": "staging",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+
+		responses := []*http.Response{
+			{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+		}
+		responses = append(responses, environmentResponses("prod-cfg", "prod-ingest")...)
+		responses = append(responses, environmentResponses("staging-cfg", "staging-ingest")...)
+
+		httpCtx := &contexts.HTTPContext{Responses: responses}
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 15)
+		assert.Equal(t, "env-prod", keyEnvironmentID(t, httpCtx.Requests[2]))
+		assert.Equal(t, "env-staging", keyEnvironmentID(t, httpCtx.Requests[5]))
+		assert.Equal(t, "env-staging", keyEnvironmentID(t, httpCtx.Requests[9]))
+		assert.Equal(t, "env-staging", keyEnvironmentID(t, httpCtx.Requests[12]))
+	})
+
+	t.Run("unknown key environment -> ingest key provisioning error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":                  "api.honeycomb.io",
+				"managementKey":         "keyid:secret",
+				"teamSlug":              "myteam",
+				"environmentSlug":       "production",
+				"ingestEnvironmentSlug": "qa",
+			},
+			Metadata: Metadata{ConfigurationKeyPermissions: DefaultConfigurationKeyPermissions().Names()},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey + "_production": {Name: secretNameConfigurationKey + "_production", Value: []byte("cfg")},
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+		require.ErrorContains(t, err, `environmentSlug "qa" not found in team "myteam"`)
+	})
+
+	t.Run("legacy secrets -> migrated to the default environment", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production",
+			},
+			Metadata: Metadata{ConfigurationKeyPermissions: DefaultConfigurationKeyPermissions().Names()},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("legacy-cfg")},
+				secretNameIngestKey:        {Name: secretNameIngestKey, Value: []byte("legacy-ingest")},
+			},
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(environmentsBody))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"type":"ingest","api_key_access":{"events":true,"createDatasets":true}}`))},
+			},
+		}
+
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpCtx.Requests, 4)
+		assert.Equal(t, []byte("legacy-cfg"), integrationCtx.Secrets[secretNameConfigurationKey+"_production"].Value)
+		assert.Equal(t, []byte("legacy-ingest"), integrationCtx.Secrets[secretNameIngestKey+"_production"].Value)
+	})
+}
+
+func Test__Honeycomb__Sync__Retry(t *testing.T) {
+	h := &Honeycomb{}
+
+	environmentsBody := `{"data": [` +
+		`{"id": "env-prod", "type": "environments", "attributes": {"slug": "production"}},` +
+		`{"id": "env-staging", "type": "environments", "attributes": {"slug": "staging"}}]}`
+	configKeyBody := `{"data": {"id": "cfgkey", "type": "api-keys", "attributes": {"secret": "cfg-secret"}}}`
+	ingestKeyBody := `{"data": {"id": "ingestkey", "type": "api-keys", "attributes": {"secret": "ingest-secret"}}}`
+	ingestAuthBody := `{"type":"ingest","api_key_access":{"events":true,"createDatasets":true}}`
+
+	response := func(code int, body string) *http.Response {
+		return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	newIntegration := func() *contexts.IntegrationContext {
+		return &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"site":            "api.honeycomb.io",
+				"managementKey":   "keyid:secret",
+				"teamSlug":        "myteam",
+				"environmentSlug": "production, staging",
+			},
+			Secrets: map[string]core.IntegrationSecret{},
+		}
+	}
+
+	//
+	// The management key is validated and both keys of production are provisioned,
+	// but the ingest key of staging cannot be created.
+	//
+	failedSync := func(t *testing.T, integrationCtx *contexts.IntegrationContext) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				response(http.StatusOK, environmentsBody),
+				response(http.StatusOK, environmentsBody),
+				response(http.StatusCreated, configKeyBody),
+				response(http.StatusOK, `{}`),
+				response(http.StatusOK, environmentsBody),
+				response(http.StatusCreated, ingestKeyBody),
+				response(http.StatusOK, `{}`),
+				response(http.StatusOK, ingestAuthBody),
+				response(http.StatusOK, environmentsBody),
+				response(http.StatusCreated, configKeyBody),
+				response(http.StatusOK, `{}`),
+				response(http.StatusOK, environmentsBody),
+				response(http.StatusInternalServerError, `{"error":"unavailable"}`),
+			},
+		}
+
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+		require.ErrorContains(t, err, "environment staging: create ingest key failed")
+	}
+
+	t.Run("failed sync -> completed steps are recorded", func(t *testing.T) {
+		integrationCtx := newIntegration()
+		failedSync(t, integrationCtx)
+
+		metadata := integrationCtx.Metadata.(Metadata)
+		require.NotNil(t, metadata.SyncProgress)
+		assert.Equal(t, []string{
+			syncStepManagementKey,
+			"configurationKey:production",
+			"ingestKey:production",
+			"configurationKey:staging",
+		}, metadata.SyncProgress.CompletedSteps)
+	})
+
+	t.Run("retry after failure -> completed steps are skipped", func(t *testing.T) {
+		integrationCtx := newIntegration()
+		failedSync(t, integrationCtx)
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				response(http.StatusOK, ingestAuthBody),
+				response(http.StatusOK, environmentsBody),
+				response(http.StatusCreated, ingestKeyBody),
+				response(http.StatusOK, `{}`),
+				response(http.StatusOK, ingestAuthBody),
+			},
+		}
+
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpCtx.Requests, 5)
+		assert.Equal(t, "https://api.honeycomb.io/1/auth", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, http.MethodPost, httpCtx.Requests[2].Method)
+		assert.Equal(t, "https://api.honeycomb.io/2/teams/myteam/api-keys", httpCtx.Requests[2].URL.String())
+		assert.Equal(t, []byte("ingestkeyingest-secret"), integrationCtx.Secrets[secretNameIngestKey+"_staging"].Value)
+
+		metadata := integrationCtx.Metadata.(Metadata)
+		assert.Nil(t, metadata.SyncProgress)
+		assert.Equal(t, DefaultConfigurationKeyPermissions().Names(), metadata.ConfigurationKeyPermissions)
+	})
+
+	t.Run("configuration changed after failure -> all steps run again", func(t *testing.T) {
+		integrationCtx := newIntegration()
+		failedSync(t, integrationCtx)
+		integrationCtx.Configuration["teamSlug"] = "otherteam"
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				response(http.StatusUnauthorized, `{"error":"unauthorized"}`),
+			},
+		}
+
+		err := h.Sync(core.SyncContext{Configuration: integrationCtx.Configuration, Integration: integrationCtx, HTTP: httpCtx})
+
+		require.Error(t, err)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://api.honeycomb.io/2/teams/otherteam/environments", httpCtx.Requests[0].URL.String())
+		assert.Empty(t, integrationCtx.Metadata.(Metadata).SyncProgress.CompletedSteps)
+	})
+}
+
+func Test__Honeycomb__DescribeError(t *testing.T) {
+	h := &Honeycomb{}
+
+	t.Run("API error -> code and retryable from the status", func(t *testing.T) {
+		err := fmt.Errorf("environment production: %w", &HoneycombAPIError{
+			StatusCode: http.StatusTooManyRequests,
+			Body:       `{"error":"rate limited"}`,
+			Operation:  "honeycomb create event failed",
+		})
+		assert.Equal(t, core.ErrorPayload{
+			Code:      core.ErrorCodeRateLimited,
+			Message:   `environment production: honeycomb create event failed (http 429): {"error":"rate limited"}`,
+			Retryable: true,
+		}, h.DescribeError(err))
+
+		err = &HoneycombAPIError{StatusCode: http.StatusNotFound, Body: "not found", Operation: "list triggers failed"}
+		assert.Equal(t, core.ErrorPayload{Code: core.ErrorCodeNotFound, Message: err.Error()}, h.DescribeError(err))
+	})
+
+	t.Run("status only in the message -> not retryable", func(t *testing.T) {
+		err := errors.New("list triggers failed (http 503): unavailable")
+		assert.Equal(t, core.ErrorPayload{Code: core.ErrorCodeFailed, Message: err.Error()}, h.DescribeError(err))
+	})
+
+	t.Run("network error -> retryable", func(t *testing.T) {
+		err := fmt.Errorf("request failed: %w", &url.Error{Op: "Post", URL: "https://api.honeycomb.io", Err: errors.New("connection reset")})
+		payload := h.DescribeError(err)
+		assert.Equal(t, core.ErrorCodeNetworkError, payload.Code)
+		assert.True(t, payload.Retryable)
+	})
+
+	t.Run("other error -> not retryable", func(t *testing.T) {
+		assert.Equal(t,
+			core.ErrorPayload{Code: core.ErrorCodeFailed, Message: "dataset is required"},
+			h.DescribeError(errors.New("dataset is required")),
+		)
+	})
+}
+
+func Test__Honeycomb__ContinueOnError(t *testing.T) {
+	var component core.Component
+	for _, c := range registry.NewPanicableIntegration(&Honeycomb{}).Components() {
+		if c.Name() == "honeycomb.createEvent" {
+			component = c
+		}
+	}
+	require.NotNil(t, component)
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader(`{"error":"unknown API key"}`))},
+		},
+	}
+
+	execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	err := component.Execute(core.ExecutionContext{
+		Integration: &contexts.IntegrationContext{
+			Configuration: map[string]any{"managementKey": "keyid:secret", "site": "api.honeycomb.io"},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameIngestKey: {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+			},
+		},
+		ExecutionState: execState,
+		HTTP:           httpCtx,
+		Configuration: map[string]any{
+			"dataset":                 "test-dataset",
+			"fields":                  map[string]any{"message": "deploy"},
+			core.ContinueOnErrorField: true,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, core.ErrorOutputChannel.Name, execState.Channel)
+	assert.Equal(t, core.ErrorPayloadType, execState.Type)
+	require.Len(t, execState.Payloads, 1)
+	assert.Equal(t, map[string]any{
+		"code":      core.ErrorCodeUnauthorized,
+		"message":   `honeycomb create event failed (http 401): {"error":"unknown API key"}`,
+		"retryable": false,
+	}, execState.Payloads[0].(map[string]any)["data"])
+
+	channels := component.OutputChannels(map[string]any{core.ContinueOnErrorField: true})
+	assert.Equal(t, []core.OutputChannel{core.DefaultOutputChannel, core.ErrorOutputChannel}, channels)
 }
@@ -0,0 +1,63 @@
+package honeycomb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// ingestKeyField is the optional field overriding the ingest key events are sent with,
+// so one integration can send events to several Honeycomb accounts.
+func ingestKeyField() configuration.Field {
+	return configuration.Field{
+		Name:        "ingestKey",
+		Label:       "Ingest Key",
+		Type:        configuration.FieldTypeSecretKey,
+		Required:    false,
+		Togglable:   true,
+		Description: "Send the events with this ingest key instead of the one of the integration, e.g. to send them to another Honeycomb account",
+	}
+}
+
+func validateIngestKeyRef(ref configuration.SecretKeyRef) error {
+	if ref == (configuration.SecretKeyRef{}) || ref.IsSet() {
+		return nil
+	}
+
+	return errors.New("ingest key requires both a secret and a key")
+}
+
+// useIngestKeyOverride makes the client send events with the ingest key of the reference, if one is set.
+// The key is checked with Honeycomb first, so a wrong key fails the execution before any event is sent.
+func useIngestKeyOverride(client *Client, secrets core.SecretsContext, ref configuration.SecretKeyRef) error {
+	if err := validateIngestKeyRef(ref); err != nil {
+		return err
+	}
+
+	if !ref.IsSet() {
+		return nil
+	}
+
+	if secrets == nil {
+		return errors.New("secrets are not available to resolve the ingest key")
+	}
+
+	value, err := secrets.GetKey(ref.Secret, ref.Key)
+	if err != nil {
+		return fmt.Errorf("failed to resolve secret %s/%s: %w", ref.Secret, ref.Key, err)
+	}
+
+	if strings.TrimSpace(string(value)) == "" {
+		return fmt.Errorf("ingest key in secret %s/%s is empty", ref.Secret, ref.Key)
+	}
+
+	client.UseIngestKey(string(value))
+	if err := client.ValidateIngestKey(); err != nil {
+		return fmt.Errorf("invalid ingest key in secret %s/%s: %w", ref.Secret, ref.Key, err)
+	}
+
+	return nil
+}
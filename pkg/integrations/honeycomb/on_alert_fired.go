@@ -1,26 +1,113 @@
 package honeycomb
 
 import (
+	"context"
 	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/ratelimit"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 )
 
 type OnAlertFired struct{}
 
+// Alert statuses that can be used to filter which alerts emit.
+const (
+	AlertStatusTriggered = "triggered"
+	AlertStatusResolved  = "resolved"
+)
+
+var AllAlertStatuses = []configuration.FieldOption{
+	{Label: "Triggered", Value: AlertStatusTriggered},
+	{Label: "Resolved", Value: AlertStatusResolved},
+}
+
+// How the trigger of the node is selected.
+const (
+	TriggerSelectionName = "name"
+	TriggerSelectionID   = "id"
+)
+
+var AllTriggerSelections = []configuration.FieldOption{
+	{Label: "By name", Value: TriggerSelectionName},
+	{Label: "By ID", Value: TriggerSelectionID},
+}
+
 type OnAlertFiredConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
 	DatasetSlug string `json:"datasetSlug" mapstructure:"datasetSlug"`
-	Trigger     string `json:"trigger" mapstructure:"trigger"`
+
+	// SelectBy is "id" to select the trigger by TriggerID, which keeps working when the
+	// trigger is renamed. Nodes set up before it was added select the trigger by name.
+	SelectBy      string   `json:"selectBy" mapstructure:"selectBy"`
+	Trigger       string   `json:"trigger" mapstructure:"trigger"`
+	TriggerID     string   `json:"triggerId" mapstructure:"triggerId"`
+	IncludeFields []string `json:"includeFields" mapstructure:"includeFields"`
+	ExcludeFields []string `json:"excludeFields" mapstructure:"excludeFields"`
+	Statuses      []string `json:"statuses" mapstructure:"statuses"`
+
+	// FilterByDataset drops alerts of environment-wide triggers
+	// that were fired for another dataset than the configured one.
+	FilterByDataset bool `json:"filterByDataset" mapstructure:"filterByDataset"`
+
+	// MaxEmitsPerMinute caps how many alerts start workflow runs per minute.
+	MaxEmitsPerMinute int `json:"maxEmitsPerMinute" mapstructure:"maxEmitsPerMinute"`
 }
 
+// alertRateLimiter limits emits per trigger node, so a flapping alert
+// cannot start hundreds of workflow runs.
+var alertRateLimiter = ratelimit.NewLimiter()
+
+// OnAlertFiredNodeMetadata holds the triggers the node listens to, and the reference
+// the node uses for them in the shared webhook of the dataset.
+// TriggerID is the first of TriggerIDs, and the only one set on nodes
+// set up before a node could listen to several triggers.
+// SelectedBy records whether the triggers were found by name or set by ID.
 type OnAlertFiredNodeMetadata struct {
-	TriggerID string `json:"triggerId" mapstructure:"triggerId"`
+	TriggerID   string   `json:"triggerId" mapstructure:"triggerId"`
+	TriggerIDs  []string `json:"triggerIds,omitempty" mapstructure:"triggerIds"`
+	SelectedBy  string   `json:"selectedBy,omitempty" mapstructure:"selectedBy"`
+	ReferenceID string   `json:"referenceId,omitempty" mapstructure:"referenceId"`
+}
+
+func (m OnAlertFiredNodeMetadata) triggerIDs() []string {
+	if len(m.TriggerIDs) > 0 {
+		return m.TriggerIDs
+	}
+
+	if strings.TrimSpace(m.TriggerID) != "" {
+		return []string{m.TriggerID}
+	}
+
+	return nil
+}
+
+// webhookReferences returns the references of the node in the shared webhook, one per trigger.
+// The first trigger uses the reference of the node, so nodes set up with a single trigger keep theirs.
+func (m OnAlertFiredNodeMetadata) webhookReferences() map[string]string {
+	references := map[string]string{}
+	for i, tid := range m.triggerIDs() {
+		if i == 0 {
+			references[m.ReferenceID] = tid
+			continue
+		}
+
+		references[m.ReferenceID+referenceSeparator+tid] = tid
+	}
+
+	return references
 }
 
 func (t *OnAlertFired) Name() string {
@@ -49,17 +136,33 @@ Starts a workflow execution when a Honeycomb Trigger fires.
 
 **Configuration:**
 - **Dataset Slug**: The slug of the dataset that contains your Honeycomb trigger. Found in the dataset URL: honeycomb.io/<team>/datasets/<dataset-slug>.
-- **Trigger**: The exact name of the Honeycomb trigger to listen to (case-insensitive). Found in your dataset under Triggers.
+- **Select Trigger By**: Whether the trigger is selected by name (the default) or by ID.
+- **Trigger**: The exact name of the Honeycomb trigger to listen to (case-insensitive). Found in your dataset under Triggers. When several triggers of the dataset share the name, alerts of any of them start a workflow run.
+- **Trigger ID**: The ID of the Honeycomb trigger to listen to, when selecting by ID. The node keeps working when the trigger is renamed. Environment-wide triggers are selected by ID with the ` + "`__all__`" + ` dataset.
+- **Include Fields**: Optional list of payload fields to keep (e.g. ` + "`name`" + `, ` + "`status`" + `, ` + "`trigger.id`" + `). All other fields are dropped.
+- **Exclude Fields**: Optional list of payload fields to drop. When set, the untrimmed payload is still available under ` + "`raw`" + `.
+- **Statuses**: Optional list of alert statuses that start a workflow run (` + "`triggered`" + `, ` + "`resolved`" + `). All statuses emit when empty.
+- **Filter By Dataset**: Only emit alerts whose ` + "`dataset_slug`" + ` matches the configured dataset. Useful with environment-wide triggers, which fire across datasets. Alerts without a ` + "`dataset_slug`" + ` are always emitted.
+- **Max Emits Per Minute**: Optional cap on how many alerts start a workflow run per minute. Alerts above the cap are acknowledged and counted, but not emitted.
 
 **How it works:**
 SuperPlane automatically creates a webhook recipient in Honeycomb and attaches it to the selected trigger. No manual webhook setup is required.
 
-When the trigger fires, SuperPlane receives the webhook and starts a workflow execution with the full alert payload.
+When the trigger fires, SuperPlane receives the webhook and starts a workflow execution with the alert payload, trimmed according to the include and exclude fields.
+
+Honeycomb sends alerts either with the trigger and alert fields at the top level, or nested under ` + "`trigger`" + ` and ` + "`alert`" + `. Nested alerts are emitted with the top-level fields too, with the trigger ID as ` + "`id`" + ` and the ID of the alert as ` + "`alert_id`" + `, so workflows handle both the same way.
+
+Alerts with the ` + "`TRIGGERED`" + ` status are emitted as ` + "`honeycomb.alert.fired`" + ` events, and alerts with the ` + "`OK`" + ` or ` + "`RESOLVED`" + ` status, sent when the trigger recovers, as ` + "`honeycomb.alert.resolved`" + ` events.
+When a resolved alert carries both ` + "`triggered_at`" + ` and ` + "`recovered_at`" + ` timestamps (RFC 3339 or Unix seconds), the event also includes ` + "`recovery_duration_seconds`" + `, how long the alert was firing, so downstream steps can report MTTR. The field is left out when either timestamp is missing.
+
+**Test notifications:**
+Test notifications sent from Honeycomb are emitted as ` + "`honeycomb.alert.test`" + ` events instead of ` + "`honeycomb.alert.fired`" + `, so you can confirm the wiring end-to-end without a real alert.
 `
 }
 
 func (t *OnAlertFired) Configuration() []configuration.Field {
 	return []configuration.Field{
+		environmentField(),
 		{
 			Name:        "datasetSlug",
 			Label:       "Dataset Slug",
@@ -70,6 +173,20 @@ func (t *OnAlertFired) Configuration() []configuration.Field {
 				Resource: &configuration.ResourceTypeOptions{
 					Type:           "dataset",
 					UseNameAsValue: false,
+					Parameters:     []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+		{
+			Name:        "selectBy",
+			Label:       "Select Trigger By",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     TriggerSelectionName,
+			Description: "Select the trigger by name, or by ID so the node keeps working when the trigger is renamed.",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllTriggerSelections,
 				},
 			},
 		},
@@ -77,23 +194,102 @@ func (t *OnAlertFired) Configuration() []configuration.Field {
 			Name:        "trigger",
 			Label:       "Trigger",
 			Type:        configuration.FieldTypeIntegrationResource,
-			Required:    true,
+			Required:    false,
 			Description: "The name of the Honeycomb trigger to listen to (case-insensitive).",
 			TypeOptions: &configuration.TypeOptions{
 				Resource: &configuration.ResourceTypeOptions{
 					Type:           "trigger",
 					UseNameAsValue: true,
-					Parameters: []configuration.ParameterRef{
-						{
-							Name: "datasetSlug",
-							ValueFrom: &configuration.ParameterValueFrom{
-								Field: "datasetSlug",
-							},
-						},
+					Parameters:     triggerResourceParameters(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "selectBy", Values: []string{TriggerSelectionName}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "selectBy", Values: []string{TriggerSelectionName}},
+			},
+		},
+		{
+			Name:        "triggerId",
+			Label:       "Trigger ID",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "The ID of the Honeycomb trigger to listen to. Found in the trigger URL.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       "trigger",
+					Parameters: triggerResourceParameters(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "selectBy", Values: []string{TriggerSelectionID}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "selectBy", Values: []string{TriggerSelectionID}},
+			},
+		},
+		{
+			Name:        "includeFields",
+			Label:       "Include Fields",
+			Type:        configuration.FieldTypeList,
+			Togglable:   true,
+			Description: "Only keep these payload fields. Use dots for nested fields, e.g. trigger.id.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Field",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "excludeFields",
+			Label:       "Exclude Fields",
+			Type:        configuration.FieldTypeList,
+			Togglable:   true,
+			Description: "Drop these payload fields. The full payload is kept under raw.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Field",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
 					},
 				},
 			},
 		},
+		{
+			Name:        "statuses",
+			Label:       "Statuses",
+			Type:        configuration.FieldTypeMultiSelect,
+			Togglable:   true,
+			Description: "Only emit alerts with these statuses.",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: AllAlertStatuses,
+				},
+			},
+		},
+		{
+			Name:        "filterByDataset",
+			Label:       "Filter By Dataset",
+			Type:        configuration.FieldTypeBool,
+			Default:     false,
+			Description: "Only emit alerts whose dataset_slug matches the configured dataset.",
+		},
+		{
+			Name:        "maxEmitsPerMinute",
+			Label:       "Max Emits Per Minute",
+			Type:        configuration.FieldTypeNumber,
+			Togglable:   true,
+			Description: "Alerts above this rate are acknowledged but do not start a workflow run.",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+				},
+			},
+		},
 	}
 }
 
@@ -105,66 +301,223 @@ func (t *OnAlertFired) Setup(ctx core.TriggerContext) error {
 
 	cfg.DatasetSlug = strings.TrimSpace(cfg.DatasetSlug)
 	cfg.Trigger = strings.TrimSpace(cfg.Trigger)
-	triggerName := cfg.Trigger
+	cfg.TriggerID = strings.TrimSpace(cfg.TriggerID)
 
 	if cfg.DatasetSlug == "" {
 		return fmt.Errorf("datasetSlug is required")
 	}
-	if triggerName == "" {
-		return fmt.Errorf("trigger is required")
+	if err := validateTriggerSelection(cfg); err != nil {
+		return err
 	}
 
 	if ctx.Integration == nil {
 		return nil
 	}
 
-	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
-	teamAny, err := ctx.Integration.GetConfig("teamSlug")
-	if err == nil && strings.TrimSpace(string(teamAny)) != "" {
-		if err := client.EnsureConfigurationKey(strings.TrimSpace(string(teamAny))); err != nil {
-			return fmt.Errorf("failed to ensure configuration key: %w", err)
+	permissions := configurationKeyPermissions(ctx.Integration)
+	if err := requireAlertPermissions(permissions); err != nil {
+		return err
+	}
+
+	if ctx.DryRun {
+		planAlertSetup(ctx, client, cfg, permissions)
+		return nil
+	}
+
+	if err := ensureConfigurationKey(ctx, client, permissions); err != nil {
+		return err
+	}
+
+	triggerIDs, triggerDatasetSlug, err := selectTriggers(client, cfg)
+	if err != nil {
+		return err
+	}
+
+	metadata := OnAlertFiredNodeMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		metadata = OnAlertFiredNodeMetadata{}
+	}
+	if metadata.ReferenceID == "" {
+		metadata.ReferenceID = uuid.NewString()
+	}
+	metadata.TriggerID = triggerIDs[0]
+	metadata.TriggerIDs = triggerIDs
+	metadata.SelectedBy = triggerSelection(cfg)
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	if err := ctx.Integration.RequestWebhook(map[string]any{
+		"environment": client.webhookEnvironment(),
+		"datasetSlug": triggerDatasetSlug,
+		"references":  metadata.webhookReferences(),
+	}); err != nil {
+		return fmt.Errorf("failed to request webhook: %w", err)
+	}
+
+	return nil
+}
+
+func triggerSelection(cfg OnAlertFiredConfiguration) string {
+	if cfg.SelectBy == TriggerSelectionID {
+		return TriggerSelectionID
+	}
+
+	return TriggerSelectionName
+}
+
+func validateTriggerSelection(cfg OnAlertFiredConfiguration) error {
+	switch cfg.SelectBy {
+	case "", TriggerSelectionName:
+		if cfg.Trigger == "" {
+			return fmt.Errorf("trigger is required")
 		}
+	case TriggerSelectionID:
+		if cfg.TriggerID == "" {
+			return fmt.Errorf("triggerId is required")
+		}
+	default:
+		return fmt.Errorf("invalid selectBy %q", cfg.SelectBy)
+	}
+
+	return nil
+}
+
+// selectTriggers returns the triggers the node listens to, and the dataset they belong to.
+// Triggers selected by ID are used as they are, without looking them up.
+func selectTriggers(client *Client, cfg OnAlertFiredConfiguration) ([]string, string, error) {
+	if triggerSelection(cfg) == TriggerSelectionID {
+		return []string{cfg.TriggerID}, cfg.DatasetSlug, nil
 	}
 
 	triggers, err := listDatasetAndEnvironmentTriggers(client, cfg.DatasetSlug)
 	if err != nil {
-		return fmt.Errorf("failed to list triggers: %w", err)
+		return nil, "", fmt.Errorf("failed to list triggers: %w", err)
 	}
 
-	var triggerID string
+	//
+	// Honeycomb does not require trigger names to be unique, so the node
+	// listens to every trigger with the name in the dataset of the first one.
+	//
+	triggerIDs := []string{}
 	triggerDatasetSlug := cfg.DatasetSlug
 	for _, tr := range triggers {
-		if strings.EqualFold(strings.TrimSpace(tr.Name), triggerName) {
-			triggerID = tr.ID
-			if datasetFromTrigger, ok := tr.Raw["dataset_slug"].(string); ok && strings.TrimSpace(datasetFromTrigger) != "" {
-				triggerDatasetSlug = strings.TrimSpace(datasetFromTrigger)
-			}
-			break
+		if !strings.EqualFold(strings.TrimSpace(tr.Name), cfg.Trigger) {
+			continue
+		}
+
+		datasetFromTrigger, _ := tr.Raw["dataset_slug"].(string)
+		datasetFromTrigger = strings.TrimSpace(datasetFromTrigger)
+		if datasetFromTrigger == "" {
+			datasetFromTrigger = cfg.DatasetSlug
+		}
+
+		if len(triggerIDs) == 0 {
+			triggerDatasetSlug = datasetFromTrigger
+		}
+
+		if datasetFromTrigger == triggerDatasetSlug && !slices.Contains(triggerIDs, tr.ID) {
+			triggerIDs = append(triggerIDs, tr.ID)
 		}
 	}
 
-	if triggerID == "" {
-		return fmt.Errorf("trigger with name %q not found in dataset %q", triggerName, cfg.DatasetSlug)
+	if len(triggerIDs) == 0 {
+		return nil, "", fmt.Errorf("trigger with name %q not found in dataset %q", cfg.Trigger, cfg.DatasetSlug)
 	}
 
-	if err := ctx.Metadata.Set(OnAlertFiredNodeMetadata{TriggerID: triggerID}); err != nil {
-		return fmt.Errorf("failed to set metadata: %w", err)
+	return triggerIDs, triggerDatasetSlug, nil
+}
+
+// ensureConfigurationKey provisions the configuration key of the environment
+// before a trigger sets up its recipient, for integrations that manage the key.
+func ensureConfigurationKey(ctx core.TriggerContext, client *Client, permissions ConfigurationKeyPermissions) error {
+	teamAny, err := ctx.Integration.GetConfig("teamSlug")
+	if err != nil || strings.TrimSpace(string(teamAny)) == "" {
+		return nil
 	}
 
-	if err := ctx.Integration.RequestWebhook(map[string]any{
-		"datasetSlug": triggerDatasetSlug,
-		"triggerIds":  []string{triggerID},
-	}); err != nil {
-		return fmt.Errorf("failed to request webhook: %w", err)
+	if err := client.EnsureConfigurationKey(strings.TrimSpace(string(teamAny)), permissions); err != nil {
+		return fmt.Errorf("failed to ensure configuration key: %w", err)
 	}
 
 	return nil
 }
 
+// planConfigurationKey records the configuration key step of a dry-run Setup.
+func planConfigurationKey(ctx core.TriggerContext, client *Client, permissions ConfigurationKeyPermissions) {
+	teamAny, err := ctx.Integration.GetConfig("teamSlug")
+	if err != nil || strings.TrimSpace(string(teamAny)) == "" {
+		return
+	}
+
+	ctx.Plan.Add(core.SetupPlanStep{
+		Action:      core.SetupPlanActionEnsure,
+		Resource:    "configurationKey",
+		Description: fmt.Sprintf("Honeycomb configuration key for environment %s", client.environment),
+		Details: map[string]any{
+			"team":        strings.TrimSpace(string(teamAny)),
+			"environment": client.environment,
+			"permissions": permissions.Names(),
+		},
+	})
+}
+
+// planAlertSetup records what Setup provisions, without calling Honeycomb.
+// The trigger is not looked up, so the plan refers to it by name.
+func planAlertSetup(ctx core.TriggerContext, client *Client, cfg OnAlertFiredConfiguration, permissions ConfigurationKeyPermissions) {
+	planConfigurationKey(ctx, client, permissions)
+
+	ctx.Plan.Add(core.SetupPlanStep{
+		Action:      core.SetupPlanActionEnsure,
+		Resource:    "recipient",
+		Description: fmt.Sprintf("Honeycomb webhook recipient %s", recipientBaseName(client.environment, cfg.DatasetSlug)),
+		Details: map[string]any{
+			"environment": client.environment,
+			"datasetSlug": cfg.DatasetSlug,
+		},
+	})
+
+	details := map[string]any{"datasetSlug": cfg.DatasetSlug}
+	trigger := cfg.Trigger
+	if triggerSelection(cfg) == TriggerSelectionID {
+		trigger = cfg.TriggerID
+		details["triggerId"] = cfg.TriggerID
+	} else {
+		details["trigger"] = cfg.Trigger
+	}
+
+	ctx.Plan.Add(core.SetupPlanStep{
+		Action:      core.SetupPlanActionUpdate,
+		Resource:    "trigger",
+		Description: fmt.Sprintf("Attach the recipient to the Honeycomb trigger %q", trigger),
+		Details:     details,
+	})
+}
+
+// requireAlertPermissions checks that the configuration key can attach
+// the SuperPlane recipient to the Honeycomb trigger.
+func requireAlertPermissions(permissions ConfigurationKeyPermissions) error {
+	missing := []string{}
+	if !permissions.ManageRecipients {
+		missing = append(missing, PermissionManageRecipients)
+	}
+	if !permissions.ManageTriggers {
+		missing = append(missing, PermissionManageTriggers)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the Honeycomb configuration key is missing the %s permission(s) required by the On Alert Fired trigger; add them to the integration's configuration key permissions", strings.Join(missing, ", "))
+}
+
 func (t *OnAlertFired) Actions() []core.Action {
 	return []core.Action{}
 }
@@ -173,21 +526,34 @@ func (t *OnAlertFired) HandleAction(ctx core.TriggerActionContext) (map[string]a
 	return nil, nil
 }
 
+func (t *OnAlertFired) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (t *OnAlertFired) SupportsDryRun() bool {
+	return true
+}
+
 func (t *OnAlertFired) Cleanup(ctx core.TriggerContext) error {
 	return nil
 }
 
-func (t *OnAlertFired) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
-	cfg := OnAlertFiredConfiguration{}
-	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
-		return http.StatusInternalServerError, err
-	}
+// verifyWebhookRequest checks that a notification was sent by the recipient we created.
+// Signed recipients send an HMAC-SHA256 of the body in X-Honeycomb-Webhook-Signature.
+// Recipients created before signing was supported only send the secret as a token,
+// so the token is compared when the signature header is absent.
+// Verification results are recorded under the name of the trigger handling the request.
+func verifyWebhookRequest(ctx core.WebhookRequestContext, secret []byte, triggerName string) (int, error) {
+	signature := strings.TrimSpace(ctx.Headers.Get("X-Honeycomb-Webhook-Signature"))
+	if signature != "" {
+		signature = strings.TrimPrefix(signature, "sha256=")
+		if err := crypto.VerifySignature(secret, ctx.Body, signature); err != nil {
+			telemetry.RecordWebhookSignatureVerification(context.Background(), triggerName, telemetry.SignatureVerificationMismatch)
+			return http.StatusForbidden, fmt.Errorf("invalid webhook signature")
+		}
 
-	secretBytes, err := ctx.Webhook.GetSecret()
-	if err != nil {
-		return http.StatusInternalServerError, err
+		return http.StatusOK, nil
 	}
-	secret := string(secretBytes)
 
 	provided := strings.TrimSpace(ctx.Headers.Get("X-Honeycomb-Webhook-Token"))
 	if provided == "" {
@@ -198,52 +564,392 @@ func (t *OnAlertFired) HandleWebhook(ctx core.WebhookRequestContext) (int, error
 	}
 
 	if provided == "" {
+		telemetry.RecordWebhookSignatureVerification(context.Background(), triggerName, telemetry.SignatureVerificationMissing)
 		return http.StatusUnauthorized, fmt.Errorf("missing webhook token")
 	}
 
-	if subtle.ConstantTimeCompare([]byte(provided), []byte(secret)) != 1 {
+	if subtle.ConstantTimeCompare([]byte(provided), secret) != 1 {
+		telemetry.RecordWebhookSignatureVerification(context.Background(), triggerName, telemetry.SignatureVerificationMismatch)
 		return http.StatusForbidden, fmt.Errorf("invalid webhook token")
 	}
 
+	return http.StatusOK, nil
+}
+
+func (t *OnAlertFired) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	cfg := OnAlertFiredConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	secretBytes, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if code, err := verifyWebhookRequest(ctx, secretBytes, t.Name()); err != nil {
+		return code, err
+	}
+
+	telemetry.RecordWebhookSignatureVerification(context.Background(), t.Name(), telemetry.SignatureVerificationPass)
+
 	var payload map[string]any
 	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
 		payload = map[string]any{"raw": string(ctx.Body)}
 	}
 
-	meta := OnAlertFiredNodeMetadata{}
-	raw := ctx.Metadata.Get()
-	if err := mapstructure.Decode(raw, &meta); err == nil && meta.TriggerID != "" {
-		if !payloadHasTriggerID(payload, meta.TriggerID) {
+	payload = normalizeAlertPayload(payload)
+
+	//
+	// Test notifications sent from Honeycomb do not carry the ID of the real trigger,
+	// so they skip the trigger ID check and are emitted as a separate event type.
+	//
+	eventType := "honeycomb.alert.test"
+	if !isTestPayload(payload) {
+		meta := OnAlertFiredNodeMetadata{}
+		raw := ctx.Metadata.Get()
+		if err := mapstructure.Decode(raw, &meta); err == nil && len(meta.triggerIDs()) > 0 {
+			if !payloadHasTriggerID(payload, meta.triggerIDs()) {
+				return http.StatusOK, nil
+			}
+		}
+
+		if cfg.FilterByDataset && !payloadHasDataset(payload, cfg.DatasetSlug) {
+			ctx.Logger.Infof("alert dataset does not match the configured dataset %s", cfg.DatasetSlug)
+			return http.StatusOK, nil
+		}
+
+		status := alertStatus(payload)
+		if len(cfg.Statuses) > 0 && !slices.Contains(cfg.Statuses, status) {
+			ctx.Logger.Infof("alert status %s does not match the allowed statuses: %v", status, cfg.Statuses)
+			return http.StatusOK, nil
+		}
+
+		eventType = "honeycomb.alert.fired"
+		if status == AlertStatusResolved {
+			eventType = "honeycomb.alert.resolved"
+		}
+	}
+
+	if cfg.MaxEmitsPerMinute > 0 {
+		key := ctx.WorkflowID + "/" + ctx.NodeID
+		if !alertRateLimiter.Allow(key, cfg.MaxEmitsPerMinute, time.Minute) {
+			ctx.Logger.Warnf("alert rate limit of %d per minute exceeded, %d alerts dropped so far", cfg.MaxEmitsPerMinute, alertRateLimiter.Dropped(key))
 			return http.StatusOK, nil
 		}
 	}
 
-	if err := ctx.Events.Emit("honeycomb.alert.fired", payload); err != nil {
+	output := projectPayload(payload, cfg.IncludeFields, cfg.ExcludeFields)
+	if eventType == "honeycomb.alert.resolved" {
+		if duration, ok := recoveryDuration(payload); ok {
+			output = maps.Clone(output)
+			output["recovery_duration_seconds"] = duration.Seconds()
+		} else {
+			ctx.Logger.Infof("resolved alert has no valid triggered_at and recovered_at timestamps, recovery duration is not emitted")
+		}
+	}
+
+	if err := ctx.Events.Emit(eventType, output); err != nil {
 		return http.StatusInternalServerError, err
 	}
 
 	return http.StatusOK, nil
 }
 
-func payloadHasTriggerID(payload map[string]any, want string) bool {
-	want = strings.TrimSpace(want)
-	if want == "" {
-		return true
+// alertStatus normalizes the status of the alert payload.
+// Honeycomb sends TRIGGERED when a trigger fires, and OK or RESOLVED when it recovers.
+// Payloads without a known recovery status are treated as triggered.
+func alertStatus(payload map[string]any) string {
+	status, _ := payload["status"].(string)
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "OK", "RESOLVED":
+		return AlertStatusResolved
+	default:
+		return AlertStatusTriggered
+	}
+}
+
+// recoveryDuration returns how long the alert was firing,
+// from the triggered_at and recovered_at timestamps of the payload.
+func recoveryDuration(payload map[string]any) (time.Duration, bool) {
+	triggeredAt, ok := payloadTime(payload["triggered_at"])
+	if !ok {
+		return 0, false
+	}
+
+	recoveredAt, ok := payloadTime(payload["recovered_at"])
+	if !ok || recoveredAt.Before(triggeredAt) {
+		return 0, false
+	}
+
+	return recoveredAt.Sub(triggeredAt), true
+}
+
+// payloadTime parses a payload timestamp, sent as an RFC 3339 string or as Unix seconds.
+func payloadTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case float64:
+		return time.Unix(0, int64(v*float64(time.Second))), true
+	case string:
+		v = strings.TrimSpace(v)
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Unix(0, int64(seconds*float64(time.Second))), true
+		}
+
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// isTestPayload reports whether the payload is a test notification
+// sent from the Honeycomb UI, which marks it with "is_test" or "test".
+func isTestPayload(payload map[string]any) bool {
+	for _, key := range []string{"is_test", "test"} {
+		switch value := payload[key].(type) {
+		case bool:
+			if value {
+				return true
+			}
+		case string:
+			if strings.EqualFold(strings.TrimSpace(value), "true") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// payloadHasTriggerID reports whether the alert was fired by one of the triggers.
+func payloadHasTriggerID(payload map[string]any, wants []string) bool {
+	id, ok := payloadTriggerID(payload)
+	filtered := false
+	for _, want := range wants {
+		want = strings.TrimSpace(want)
+		if want == "" {
+			continue
+		}
+
+		if ok && strings.EqualFold(id, want) {
+			return true
+		}
+
+		filtered = true
+	}
+
+	return !filtered
+}
+
+func payloadTriggerID(payload map[string]any) (string, bool) {
+	//
+	// In nested payloads, the top-level id is the ID of the alert, not of the trigger.
+	//
+	if alertPayloadVersion(payload) == alertPayloadVersionNested {
+		tr := payload["trigger"].(map[string]any)
+		return strings.TrimSpace(tr["id"].(string)), true
 	}
 
 	if id, ok := payload["id"].(string); ok {
-		return strings.EqualFold(strings.TrimSpace(id), want)
+		return strings.TrimSpace(id), true
 	}
 
 	if id, ok := payload["trigger_id"].(string); ok {
-		return strings.EqualFold(strings.TrimSpace(id), want)
+		return strings.TrimSpace(id), true
 	}
 
 	if tr, ok := payload["trigger"].(map[string]any); ok {
 		if id, ok := tr["id"].(string); ok {
-			return strings.EqualFold(strings.TrimSpace(id), want)
+			return strings.TrimSpace(id), true
 		}
 	}
 
-	return false
+	return "", false
+}
+
+// Shapes of the alert payloads sent by Honeycomb.
+// Flat payloads have the fields of the trigger and of the alert at the top level,
+// with the trigger ID as id. Nested payloads have them under "trigger" and "alert",
+// with the ID of the alert as id.
+const (
+	alertPayloadVersionFlat   = "flat"
+	alertPayloadVersionNested = "nested"
+)
+
+// alertPayloadVersion detects the shape of an alert payload from the fields it has.
+func alertPayloadVersion(payload map[string]any) string {
+	tr, ok := payload["trigger"].(map[string]any)
+	if !ok {
+		return alertPayloadVersionFlat
+	}
+
+	if _, ok := tr["id"].(string); !ok {
+		return alertPayloadVersionFlat
+	}
+
+	if _, ok := payload["alert"].(map[string]any); ok {
+		return alertPayloadVersionNested
+	}
+
+	//
+	// Flat payloads with a trigger object keep the trigger ID in trigger_id or id.
+	//
+	_, hasTriggerID := payload["trigger_id"].(string)
+	_, hasID := payload["id"].(string)
+	if hasTriggerID || hasID {
+		return alertPayloadVersionFlat
+	}
+
+	return alertPayloadVersionNested
+}
+
+// nestedAlertTriggerFields maps the fields of the trigger object of nested payloads
+// to their names in flat payloads.
+var nestedAlertTriggerFields = map[string]string{
+	"id":           "id",
+	"name":         "name",
+	"description":  "trigger_description",
+	"url":          "trigger_url",
+	"dataset_slug": "dataset_slug",
+	"alert_type":   "alert_type",
+	"threshold":    "threshold",
+	"operator":     "operator",
+}
+
+// normalizeAlertPayload returns the payload in the flat shape, so filters and
+// workflows see the same fields whatever shape Honeycomb sent.
+// Nested payloads keep their trigger and alert objects, and the ID of the alert
+// is moved to alert_id, since id is the trigger ID in flat payloads.
+func normalizeAlertPayload(payload map[string]any) map[string]any {
+	if alertPayloadVersion(payload) != alertPayloadVersionNested {
+		return payload
+	}
+
+	normalized := maps.Clone(payload)
+	if id, ok := payload["id"]; ok {
+		normalized["alert_id"] = id
+	}
+
+	tr := payload["trigger"].(map[string]any)
+	for from, to := range nestedAlertTriggerFields {
+		if value, ok := tr[from]; ok {
+			normalized[to] = value
+		}
+	}
+
+	alert, _ := payload["alert"].(map[string]any)
+	for key, value := range alert {
+		if _, exists := normalized[key]; !exists {
+			normalized[key] = value
+		}
+	}
+
+	return normalized
+}
+
+// payloadHasDataset reports whether the alert was fired for the dataset.
+// Payloads without a dataset_slug cannot be told apart, so they match.
+func payloadHasDataset(payload map[string]any, datasetSlug string) bool {
+	slug, ok := payload["dataset_slug"].(string)
+	if !ok || strings.TrimSpace(slug) == "" {
+		return true
+	}
+
+	return strings.EqualFold(strings.TrimSpace(slug), strings.TrimSpace(datasetSlug))
+}
+
+// projectPayload trims the alert payload to the configured fields.
+// Fields are dot-separated paths into nested objects.
+// When fields are excluded, the original payload is kept under "raw".
+func projectPayload(payload map[string]any, include, exclude []string) map[string]any {
+	projected := payload
+	if len(include) > 0 {
+		projected = map[string]any{}
+		for _, field := range include {
+			path := splitFieldPath(field)
+			if value, ok := lookupPath(payload, path); ok {
+				setPath(projected, path, value)
+			}
+		}
+	}
+
+	if len(exclude) == 0 {
+		return projected
+	}
+
+	projected = maps.Clone(projected)
+	for _, field := range exclude {
+		deletePath(projected, splitFieldPath(field))
+	}
+
+	projected["raw"] = payload
+	return projected
+}
+
+func splitFieldPath(field string) []string {
+	path := []string{}
+	for _, part := range strings.Split(field, ".") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+
+	return path
+}
+
+func lookupPath(payload map[string]any, path []string) (any, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+
+	value, ok := payload[path[0]]
+	if !ok || len(path) == 1 {
+		return value, ok
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	return lookupPath(nested, path[1:])
+}
+
+func setPath(payload map[string]any, path []string, value any) {
+	if len(path) == 1 {
+		payload[path[0]] = value
+		return
+	}
+
+	nested, ok := payload[path[0]].(map[string]any)
+	if !ok {
+		nested = map[string]any{}
+		payload[path[0]] = nested
+	}
+
+	setPath(nested, path[1:], value)
+}
+
+// deletePath removes a field, copying nested objects on the way
+// so the original payload kept under "raw" is not modified.
+func deletePath(payload map[string]any, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	if len(path) == 1 {
+		delete(payload, path[0])
+		return
+	}
+
+	nested, ok := payload[path[0]].(map[string]any)
+	if !ok {
+		return
+	}
+
+	nested = maps.Clone(nested)
+	payload[path[0]] = nested
+	deletePath(nested, path[1:])
 }
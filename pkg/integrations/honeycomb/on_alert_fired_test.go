@@ -1,12 +1,17 @@
 package honeycomb
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 	contexts "github.com/superplanehq/superplane/test/support/contexts"
 )
 
@@ -35,6 +40,151 @@ func Test__OnAlertFired__Setup(t *testing.T) {
 		require.ErrorContains(t, err, "trigger is required")
 	})
 
+	t.Run("configuration key without manage_recipients -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"},
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"managementKey": "keyid:secret", "site": "api.honeycomb.io"},
+				Metadata:      Metadata{ConfigurationKeyPermissions: []string{PermissionManageTriggers, PermissionRunQueries}},
+			},
+			HTTP:     &contexts.HTTPContext{},
+			Metadata: &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "missing the manage_recipients permission(s) required by the On Alert Fired trigger")
+	})
+
+	t.Run("several triggers with the name -> listens to all of them", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"t1","name":"High Error Rate"},{"id":"t2","name":"Other"},{"id":"t3","name":"high error rate"}]`),
+				jsonResponse(`[]`),
+			},
+		}
+
+		integration := honeycombWebhookIntegration()
+
+		metadata := &contexts.MetadataContext{Metadata: map[string]any{"referenceId": "node-ref"}}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, OnAlertFiredNodeMetadata{
+			TriggerID:   "t1",
+			TriggerIDs:  []string{"t1", "t3"},
+			SelectedBy:  TriggerSelectionName,
+			ReferenceID: "node-ref",
+		}, metadata.Get())
+
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, map[string]string{
+			"node-ref":    "t1",
+			"node-ref:t3": "t3",
+		}, integration.WebhookRequests[0].(map[string]any)["references"])
+	})
+
+	t.Run("select by id without triggerId -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"datasetSlug": "production",
+				"selectBy":    TriggerSelectionID,
+				"trigger":     "High Error Rate",
+			},
+		})
+		require.ErrorContains(t, err, "triggerId is required")
+	})
+
+	t.Run("select by id -> listens to the trigger without listing triggers", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integration := honeycombWebhookIntegration()
+
+		metadata := &contexts.MetadataContext{Metadata: map[string]any{"referenceId": "node-ref"}}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "selectBy": TriggerSelectionID, "triggerId": " t2 "},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpCtx.Requests)
+		assert.Equal(t, OnAlertFiredNodeMetadata{
+			TriggerID:   "t2",
+			TriggerIDs:  []string{"t2"},
+			SelectedBy:  TriggerSelectionID,
+			ReferenceID: "node-ref",
+		}, metadata.Get())
+
+		require.Len(t, integration.WebhookRequests, 1)
+		request := integration.WebhookRequests[0].(map[string]any)
+		assert.Equal(t, "production", request["datasetSlug"])
+		assert.Equal(t, map[string]string{"node-ref": "t2"}, request["references"])
+	})
+
+	t.Run("select by name -> looks the trigger up by name", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"t1","name":"High Error Rate"}]`),
+				jsonResponse(`[]`),
+			},
+		}
+
+		integration := honeycombWebhookIntegration()
+		metadata := &contexts.MetadataContext{Metadata: map[string]any{"referenceId": "node-ref"}}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "selectBy": TriggerSelectionName, "trigger": "High Error Rate", "triggerId": "t9"},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, OnAlertFiredNodeMetadata{
+			TriggerID:   "t1",
+			TriggerIDs:  []string{"t1"},
+			SelectedBy:  TriggerSelectionName,
+			ReferenceID: "node-ref",
+		}, metadata.Get())
+	})
+
+	t.Run("dry run -> plans the setup without calling Honeycomb", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integration := honeycombWebhookIntegration()
+		integration.Configuration["teamSlug"] = "acme"
+		metadata := &contexts.MetadataContext{}
+		plan := &core.SetupPlan{}
+
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      metadata,
+			DryRun:        true,
+			Plan:          plan,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpCtx.Requests)
+		assert.Empty(t, integration.WebhookRequests)
+		assert.Nil(t, metadata.Get())
+
+		require.Len(t, plan.Steps, 3)
+		assert.Equal(t, "configurationKey", plan.Steps[0].Resource)
+		assert.Equal(t, "acme", plan.Steps[0].Details["team"])
+		assert.Equal(t, []string{PermissionManageMarkers, PermissionManageRecipients, PermissionManageTriggers, PermissionRunQueries}, plan.Steps[0].Details["permissions"])
+		assert.Equal(t, "recipient", plan.Steps[1].Resource)
+		assert.Equal(t, "Honeycomb webhook recipient SuperPlane (prod-env/production)", plan.Steps[1].Description)
+		assert.Equal(t, "trigger", plan.Steps[2].Resource)
+		assert.Equal(t, "High Error Rate", plan.Steps[2].Details["trigger"])
+	})
+
 	t.Run("no integration -> returns nil without requesting webhook", func(t *testing.T) {
 		err := trigger.Setup(core.TriggerContext{
 			Integration: nil,
@@ -48,6 +198,71 @@ func Test__OnAlertFired__Setup(t *testing.T) {
 	})
 }
 
+func Test__OnAlertFired__PayloadVersions(t *testing.T) {
+	trigger := &OnAlertFired{}
+
+	handle := func(t *testing.T, body string) *contexts.EventContext {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		meta := &contexts.MetadataContext{}
+		_ = meta.Set(OnAlertFiredNodeMetadata{TriggerID: "trigger-abc"})
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(body),
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "filterByDataset": true},
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      meta,
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		return events
+	}
+
+	t.Run("flat payload matching the trigger ID -> emitted as is", func(t *testing.T) {
+		events := handle(t, `{"version":"v0.1.0","id":"trigger-abc","name":"High Error Rate","status":"OK","dataset_slug":"production"}`)
+
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.resolved", events.Payloads[0].Type)
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "trigger-abc", payload["id"])
+		assert.NotContains(t, payload, "alert_id")
+	})
+
+	t.Run("nested payload matching the trigger ID -> emitted in the flat shape", func(t *testing.T) {
+		events := handle(t, `{
+			"version": "v2",
+			"id": "alert-123",
+			"trigger": {"id": "trigger-abc", "name": "High Error Rate", "url": "https://ui.honeycomb.io/t", "dataset_slug": "production"},
+			"alert": {"status": "OK", "summary": "Resolved: High Error Rate", "triggered_at": "2024-01-15T10:00:00Z", "recovered_at": "2024-01-15T10:30:00Z"}
+		}`)
+
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.resolved", events.Payloads[0].Type)
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "trigger-abc", payload["id"])
+		assert.Equal(t, "alert-123", payload["alert_id"])
+		assert.Equal(t, "High Error Rate", payload["name"])
+		assert.Equal(t, "https://ui.honeycomb.io/t", payload["trigger_url"])
+		assert.Equal(t, "production", payload["dataset_slug"])
+		assert.Equal(t, "OK", payload["status"])
+		assert.Equal(t, "Resolved: High Error Rate", payload["summary"])
+		assert.Equal(t, float64(1800), payload["recovery_duration_seconds"])
+		assert.Contains(t, payload, "trigger")
+		assert.Contains(t, payload, "alert")
+	})
+
+	t.Run("nested payload of another trigger -> no emit", func(t *testing.T) {
+		events := handle(t, `{"id":"trigger-abc","trigger":{"id":"trigger-xyz"},"alert":{"status":"TRIGGERED"}}`)
+		assert.Equal(t, 0, events.Count())
+	})
+}
+
 func Test__OnAlertFired__HandleWebhook(t *testing.T) {
 	trigger := &OnAlertFired{}
 
@@ -87,6 +302,70 @@ func Test__OnAlertFired__HandleWebhook(t *testing.T) {
 		assert.ErrorContains(t, err, "invalid webhook token")
 	})
 
+	t.Run("valid signature -> emits", func(t *testing.T) {
+		mac := hmac.New(sha256.New, []byte("test-secret"))
+		mac.Write(body)
+
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("signature with sha256 prefix -> emits", func(t *testing.T) {
+		mac := hmac.New(sha256.New, []byte("test-secret"))
+		mac.Write(body)
+
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("invalid signature -> 403 even with a valid token", func(t *testing.T) {
+		mac := hmac.New(sha256.New, []byte("another-secret"))
+		mac.Write(body)
+
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        &contexts.EventContext{},
+			Metadata:      &contexts.MetadataContext{},
+		})
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid webhook signature")
+	})
+
 	t.Run("invalid JSON body -> falls back to raw payload and emits", func(t *testing.T) {
 		h := http.Header{}
 		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
@@ -148,6 +427,91 @@ func Test__OnAlertFired__HandleWebhook(t *testing.T) {
 		assert.Equal(t, 0, events.Count())
 	})
 
+	t.Run("several trigger IDs, payload matches the second -> emits", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		meta := &contexts.MetadataContext{}
+		_ = meta.Set(OnAlertFiredNodeMetadata{TriggerID: "trigger-xyz", TriggerIDs: []string{"trigger-xyz", "trigger-abc"}})
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      meta,
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("several trigger IDs, payload matches none -> no emit", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		meta := &contexts.MetadataContext{}
+		_ = meta.Set(OnAlertFiredNodeMetadata{TriggerID: "trigger-xyz", TriggerIDs: []string{"trigger-xyz", "trigger-123"}})
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      meta,
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("test payload -> emits test event regardless of trigger ID", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		meta := &contexts.MetadataContext{}
+		_ = meta.Set(OnAlertFiredNodeMetadata{TriggerID: "trigger-abc"})
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(`{"version":"v0.1.0","is_test":true,"id":"test-trigger-id","name":"Test Trigger","status":"TRIGGERED"}`),
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      meta,
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.test", events.Payloads[0].Type)
+	})
+
+	t.Run("test marker set to false -> treated as a real alert", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		meta := &contexts.MetadataContext{}
+		_ = meta.Set(OnAlertFiredNodeMetadata{TriggerID: "trigger-abc"})
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(`{"is_test":false,"id":"other-trigger","status":"TRIGGERED"}`),
+			Configuration: validConfig,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      meta,
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
 	t.Run("valid token, no metadata -> emits without filter", func(t *testing.T) {
 		h := http.Header{}
 		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
@@ -187,3 +551,310 @@ func Test__OnAlertFired__HandleWebhook(t *testing.T) {
 		assert.Equal(t, 1, events.Count())
 	})
 }
+
+func Test__OnAlertFired__PayloadProjection(t *testing.T) {
+	trigger := &OnAlertFired{}
+	body := []byte(`{"id":"trigger-abc","name":"High Error Rate","status":"TRIGGERED","result_groups":[{"Result":8.5}],"trigger":{"id":"trigger-abc","description":"errors"}}`)
+
+	handle := func(t *testing.T, config map[string]any) map[string]any {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: config,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		return events.Payloads[0].Data.(map[string]any)
+	}
+
+	t.Run("include fields -> only selected fields are emitted", func(t *testing.T) {
+		payload := handle(t, map[string]any{
+			"datasetSlug":   "production",
+			"trigger":       "High Error Rate",
+			"includeFields": []any{"name", "trigger.id", "missing"},
+		})
+
+		assert.Equal(t, map[string]any{
+			"name":    "High Error Rate",
+			"trigger": map[string]any{"id": "trigger-abc"},
+		}, payload)
+	})
+
+	t.Run("exclude fields -> fields dropped and full payload kept under raw", func(t *testing.T) {
+		payload := handle(t, map[string]any{
+			"datasetSlug":   "production",
+			"trigger":       "High Error Rate",
+			"excludeFields": []any{"result_groups", "trigger.description"},
+		})
+
+		assert.NotContains(t, payload, "result_groups")
+		assert.Equal(t, "TRIGGERED", payload["status"])
+		assert.Equal(t, map[string]any{"id": "trigger-abc"}, payload["trigger"])
+
+		raw, ok := payload["raw"].(map[string]any)
+		require.True(t, ok)
+		assert.Contains(t, raw, "result_groups")
+		assert.Equal(t, map[string]any{"id": "trigger-abc", "description": "errors"}, raw["trigger"])
+	})
+
+	t.Run("no projection -> full payload is emitted", func(t *testing.T) {
+		payload := handle(t, map[string]any{
+			"datasetSlug": "production",
+			"trigger":     "High Error Rate",
+		})
+
+		assert.Contains(t, payload, "result_groups")
+		assert.NotContains(t, payload, "raw")
+	})
+}
+
+func Test__OnAlertFired__RateLimit(t *testing.T) {
+	trigger := &OnAlertFired{}
+	h := http.Header{}
+	h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+	send := func(nodeID string, config map[string]any, events *contexts.EventContext) {
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(`{"id":"trigger-abc","name":"High Error Rate","status":"TRIGGERED"}`),
+			WorkflowID:    "workflow-1",
+			NodeID:        nodeID,
+			Configuration: config,
+			Logger:        logrus.NewEntry(logrus.New()),
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+	}
+
+	t.Run("burst above max -> only allowed number emit", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "maxEmitsPerMinute": 3}
+		events := &contexts.EventContext{}
+		for range 10 {
+			send("rate-limited-node", config, events)
+		}
+
+		assert.Equal(t, 3, events.Count())
+		assert.Equal(t, int64(7), alertRateLimiter.Dropped("workflow-1/rate-limited-node"))
+	})
+
+	t.Run("limit is tracked per trigger node", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "maxEmitsPerMinute": 1}
+		events := &contexts.EventContext{}
+		send("node-a", config, events)
+		send("node-a", config, events)
+		send("node-b", config, events)
+
+		assert.Equal(t, 2, events.Count())
+	})
+
+	t.Run("no limit configured -> every alert emits", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"}
+		events := &contexts.EventContext{}
+		for range 10 {
+			send("unlimited-node", config, events)
+		}
+
+		assert.Equal(t, 10, events.Count())
+	})
+}
+
+func Test__OnAlertFired__SignatureVerificationMetrics(t *testing.T) {
+	trigger := &OnAlertFired{}
+	config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"}
+	body := []byte(`{"id":"trigger-abc","name":"High Error Rate","status":"TRIGGERED"}`)
+
+	count := func(outcome string) int64 {
+		return telemetry.WebhookSignatureVerificationCount(trigger.Name(), outcome)
+	}
+
+	handle := func(token string) {
+		h := http.Header{}
+		if token != "" {
+			h.Set("X-Honeycomb-Webhook-Token", token)
+		}
+
+		_, _ = trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          body,
+			Configuration: config,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        &contexts.EventContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+	}
+
+	missing := count(telemetry.SignatureVerificationMissing)
+	mismatch := count(telemetry.SignatureVerificationMismatch)
+	pass := count(telemetry.SignatureVerificationPass)
+
+	handle("")
+	handle("wrong-secret-xx")
+	handle("test-secret")
+
+	assert.Equal(t, missing+1, count(telemetry.SignatureVerificationMissing))
+	assert.Equal(t, mismatch+1, count(telemetry.SignatureVerificationMismatch))
+	assert.Equal(t, pass+1, count(telemetry.SignatureVerificationPass))
+}
+
+func Test__OnAlertFired__FilterByDataset(t *testing.T) {
+	trigger := &OnAlertFired{}
+
+	handle := func(t *testing.T, config map[string]any, body string) *contexts.EventContext {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(body),
+			Configuration: config,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		return events
+	}
+
+	filter := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "filterByDataset": true}
+
+	t.Run("matching dataset slug -> emits", func(t *testing.T) {
+		events := handle(t, filter, `{"id":"trigger-abc","status":"TRIGGERED","dataset_slug":"Production"}`)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("other dataset slug -> no emit", func(t *testing.T) {
+		events := handle(t, filter, `{"id":"trigger-abc","status":"TRIGGERED","dataset_slug":"staging"}`)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("payload without dataset slug -> emits", func(t *testing.T) {
+		events := handle(t, filter, `{"id":"trigger-abc","status":"TRIGGERED"}`)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("filter disabled -> other dataset slug emits", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"}
+		events := handle(t, config, `{"id":"trigger-abc","status":"TRIGGERED","dataset_slug":"staging"}`)
+		assert.Equal(t, 1, events.Count())
+	})
+}
+
+func Test__OnAlertFired__Statuses(t *testing.T) {
+	trigger := &OnAlertFired{}
+
+	handle := func(t *testing.T, config map[string]any, body string) *contexts.EventContext {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(body),
+			Configuration: config,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+			Logger:        logrus.NewEntry(logrus.New()),
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		return events
+	}
+
+	noFilter := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"}
+
+	t.Run("TRIGGERED -> emits honeycomb.alert.fired", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"TRIGGERED"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.fired", events.Payloads[0].Type)
+	})
+
+	t.Run("OK -> emits honeycomb.alert.resolved", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"OK"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.resolved", events.Payloads[0].Type)
+	})
+
+	t.Run("RESOLVED -> emits honeycomb.alert.resolved", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"resolved"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.resolved", events.Payloads[0].Type)
+	})
+
+	t.Run("missing status -> emits honeycomb.alert.fired", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.fired", events.Payloads[0].Type)
+	})
+
+	t.Run("status not in filter -> no emit", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "statuses": []string{AlertStatusTriggered}}
+		events := handle(t, config, `{"id":"trigger-abc","status":"OK"}`)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("status in filter -> emits", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "statuses": []string{AlertStatusResolved}}
+		events := handle(t, config, `{"id":"trigger-abc","status":"OK"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.resolved", events.Payloads[0].Type)
+	})
+
+	t.Run("resolved with RFC 3339 timestamps -> emits recovery duration", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"OK","triggered_at":"2026-02-27T10:00:00Z","recovered_at":"2026-02-27T10:12:30Z"}`)
+		require.Equal(t, 1, events.Count())
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, float64(750), data["recovery_duration_seconds"])
+	})
+
+	t.Run("resolved with Unix timestamps -> emits recovery duration", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"OK","triggered_at":1772186400,"recovered_at":"1772186490"}`)
+		require.Equal(t, 1, events.Count())
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, float64(90), data["recovery_duration_seconds"])
+	})
+
+	t.Run("resolved with include fields -> recovery duration is kept", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "includeFields": []string{"status"}}
+		events := handle(t, config, `{"id":"trigger-abc","status":"OK","triggered_at":"2026-02-27T10:00:00Z","recovered_at":"2026-02-27T10:01:00Z"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, map[string]any{"status": "OK", "recovery_duration_seconds": float64(60)}, events.Payloads[0].Data)
+	})
+
+	t.Run("resolved without triggered_at -> no recovery duration", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"OK","recovered_at":"2026-02-27T10:12:30Z"}`)
+		require.Equal(t, 1, events.Count())
+		assert.NotContains(t, events.Payloads[0].Data.(map[string]any), "recovery_duration_seconds")
+	})
+
+	t.Run("triggered with timestamps -> no recovery duration", func(t *testing.T) {
+		events := handle(t, noFilter, `{"id":"trigger-abc","status":"TRIGGERED","triggered_at":"2026-02-27T10:00:00Z","recovered_at":"2026-02-27T10:12:30Z"}`)
+		require.Equal(t, 1, events.Count())
+		assert.NotContains(t, events.Payloads[0].Data.(map[string]any), "recovery_duration_seconds")
+	})
+
+	t.Run("test notification -> emitted regardless of the status filter", func(t *testing.T) {
+		config := map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "statuses": []string{AlertStatusTriggered}}
+		events := handle(t, config, `{"is_test":true,"status":"OK"}`)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.test", events.Payloads[0].Type)
+	})
+}
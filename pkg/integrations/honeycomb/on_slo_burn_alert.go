@@ -0,0 +1,435 @@
+package honeycomb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
+)
+
+type OnSLOBurnAlert struct{}
+
+type OnSLOBurnAlertConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	DatasetSlug string `json:"datasetSlug" mapstructure:"datasetSlug"`
+	SLO         string `json:"slo" mapstructure:"slo"`
+}
+
+// OnSLOBurnAlertNodeMetadata holds the SLO the node listens to, its burn alerts,
+// and the reference the node uses for them in the shared webhook of the dataset.
+type OnSLOBurnAlertNodeMetadata struct {
+	SLOID        string   `json:"sloId" mapstructure:"sloId"`
+	SLOName      string   `json:"sloName" mapstructure:"sloName"`
+	BurnAlertIDs []string `json:"burnAlertIds" mapstructure:"burnAlertIds"`
+	ReferenceID  string   `json:"referenceId,omitempty" mapstructure:"referenceId"`
+}
+
+// webhookReferences returns the references of the node in the shared webhook, one per burn alert.
+func (m OnSLOBurnAlertNodeMetadata) webhookReferences() map[string]string {
+	references := map[string]string{}
+	for i, id := range m.BurnAlertIDs {
+		if i == 0 {
+			references[m.ReferenceID] = id
+			continue
+		}
+
+		references[m.ReferenceID+referenceSeparator+id] = id
+	}
+
+	return references
+}
+
+func (t *OnSLOBurnAlert) Name() string {
+	return "honeycomb.onSLOBurnAlert"
+}
+
+func (t *OnSLOBurnAlert) Label() string {
+	return "On SLO Burn Alert"
+}
+
+func (t *OnSLOBurnAlert) Description() string {
+	return "Triggers when a Honeycomb SLO burn alert fires"
+}
+
+func (t *OnSLOBurnAlert) Icon() string {
+	return "honeycomb"
+}
+
+func (t *OnSLOBurnAlert) Color() string {
+	return "yellow"
+}
+
+func (t *OnSLOBurnAlert) Documentation() string {
+	return `
+Starts a workflow execution when a burn alert of a Honeycomb SLO fires.
+
+**Configuration:**
+- **Dataset Slug**: The slug of the dataset the SLO is defined on.
+- **SLO**: The SLO to listen to. Alerts of all of its burn alerts start a workflow run.
+
+**How it works:**
+SuperPlane automatically attaches its webhook recipient for the dataset to the burn alerts of the SLO. The recipient is shared with the On Alert Fired triggers of the same dataset. The SLO needs at least one burn alert, created in Honeycomb.
+
+Burn alerts are emitted as ` + "`honeycomb.slo.burn`" + ` events with:
+- ` + "`sloId`" + ` and ` + "`sloName`" + `: The SLO the burn alert belongs to
+- ` + "`status`" + `: ` + "`triggered`" + ` when the alert fires, ` + "`resolved`" + ` when it recovers
+- ` + "`budgetRemaining`" + `: The remaining error budget, when Honeycomb sends it
+- ` + "`exhaustionMinutes`" + ` and ` + "`exhaustionTime`" + `: When the budget runs out at the current burn rate, for exhaustion time burn alerts
+- ` + "`payload`" + `: The full notification sent by Honeycomb
+
+**Test notifications:**
+Test notifications sent from Honeycomb are emitted as ` + "`honeycomb.slo.test`" + ` events.
+
+**Permissions:**
+The configuration key needs the Manage SLOs and Manage Recipients permissions.
+`
+}
+
+func (t *OnSLOBurnAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:        "datasetSlug",
+			Label:       "Dataset Slug",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The dataset slug the SLO is defined on.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       "dataset",
+					Parameters: []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+		{
+			Name:        "slo",
+			Label:       "SLO",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The SLO whose burn alerts start a workflow run.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "slo",
+					Parameters: []configuration.ParameterRef{
+						environmentParameter(),
+						{
+							Name:      "datasetSlug",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "datasetSlug"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnSLOBurnAlert) Setup(ctx core.TriggerContext) error {
+	cfg := OnSLOBurnAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	cfg.DatasetSlug = strings.TrimSpace(cfg.DatasetSlug)
+	cfg.SLO = strings.TrimSpace(cfg.SLO)
+
+	if cfg.DatasetSlug == "" {
+		return fmt.Errorf("datasetSlug is required")
+	}
+	if cfg.SLO == "" {
+		return fmt.Errorf("slo is required")
+	}
+
+	if ctx.Integration == nil {
+		return nil
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	permissions := configurationKeyPermissions(ctx.Integration)
+	if err := requireSLOBurnAlertPermissions(permissions); err != nil {
+		return err
+	}
+
+	if ctx.DryRun {
+		planSLOBurnAlertSetup(ctx, client, cfg, permissions)
+		return nil
+	}
+
+	if err := ensureConfigurationKey(ctx, client, permissions); err != nil {
+		return err
+	}
+
+	slo, err := findSLO(client, cfg.DatasetSlug, cfg.SLO)
+	if err != nil {
+		return err
+	}
+
+	burnAlerts, err := client.ListBurnAlerts(cfg.DatasetSlug, slo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list burn alerts: %w", err)
+	}
+
+	burnAlertIDs := []string{}
+	for _, alert := range burnAlerts {
+		if alert.ID != "" {
+			burnAlertIDs = append(burnAlertIDs, alert.ID)
+		}
+	}
+
+	if len(burnAlertIDs) == 0 {
+		return fmt.Errorf("SLO %q has no burn alerts; add one to it in Honeycomb", slo.Name)
+	}
+
+	metadata := OnSLOBurnAlertNodeMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		metadata = OnSLOBurnAlertNodeMetadata{}
+	}
+	if metadata.ReferenceID == "" {
+		metadata.ReferenceID = uuid.NewString()
+	}
+	metadata.SLOID = slo.ID
+	metadata.SLOName = slo.Name
+	metadata.BurnAlertIDs = burnAlertIDs
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	if err := ctx.Integration.RequestWebhook(map[string]any{
+		"environment":         client.webhookEnvironment(),
+		"datasetSlug":         cfg.DatasetSlug,
+		"burnAlertReferences": metadata.webhookReferences(),
+	}); err != nil {
+		return fmt.Errorf("failed to request webhook: %w", err)
+	}
+
+	return nil
+}
+
+// findSLO looks up the SLO by ID, or by name for SLOs configured before the picker listed them.
+func findSLO(client *Client, datasetSlug, slo string) (*SLO, error) {
+	slos, err := client.ListSLOs(datasetSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLOs: %w", err)
+	}
+
+	for _, candidate := range slos {
+		if candidate.ID == slo {
+			return &candidate, nil
+		}
+	}
+
+	for _, candidate := range slos {
+		if strings.EqualFold(strings.TrimSpace(candidate.Name), slo) {
+			return &candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("SLO %q not found in dataset %q", slo, datasetSlug)
+}
+
+// planSLOBurnAlertSetup records what Setup provisions, without calling Honeycomb.
+func planSLOBurnAlertSetup(ctx core.TriggerContext, client *Client, cfg OnSLOBurnAlertConfiguration, permissions ConfigurationKeyPermissions) {
+	planConfigurationKey(ctx, client, permissions)
+
+	ctx.Plan.Add(core.SetupPlanStep{
+		Action:      core.SetupPlanActionEnsure,
+		Resource:    "recipient",
+		Description: fmt.Sprintf("Honeycomb webhook recipient %s", recipientBaseName(client.environment, cfg.DatasetSlug)),
+		Details: map[string]any{
+			"environment": client.environment,
+			"datasetSlug": cfg.DatasetSlug,
+		},
+	})
+
+	ctx.Plan.Add(core.SetupPlanStep{
+		Action:      core.SetupPlanActionUpdate,
+		Resource:    "burnAlert",
+		Description: fmt.Sprintf("Attach the recipient to the burn alerts of the Honeycomb SLO %q", cfg.SLO),
+		Details: map[string]any{
+			"datasetSlug": cfg.DatasetSlug,
+			"slo":         cfg.SLO,
+		},
+	})
+}
+
+// requireSLOBurnAlertPermissions checks that the configuration key can attach
+// the SuperPlane recipient to the burn alerts of the SLO.
+func requireSLOBurnAlertPermissions(permissions ConfigurationKeyPermissions) error {
+	missing := []string{}
+	if !permissions.ManageSLOs {
+		missing = append(missing, PermissionManageSLOs)
+	}
+	if !permissions.ManageRecipients {
+		missing = append(missing, PermissionManageRecipients)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the Honeycomb configuration key is missing the %s permission(s) required by the On SLO Burn Alert trigger; add them to the integration's configuration key permissions", strings.Join(missing, ", "))
+}
+
+func (t *OnSLOBurnAlert) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnSLOBurnAlert) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnSLOBurnAlert) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (t *OnSLOBurnAlert) SupportsDryRun() bool {
+	return true
+}
+
+func (t *OnSLOBurnAlert) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func (t *OnSLOBurnAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	secretBytes, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if code, err := verifyWebhookRequest(ctx, secretBytes, t.Name()); err != nil {
+		return code, err
+	}
+
+	telemetry.RecordWebhookSignatureVerification(context.Background(), t.Name(), telemetry.SignatureVerificationPass)
+
+	var payload map[string]any
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		payload = map[string]any{"raw": string(ctx.Body)}
+	}
+
+	if isTestPayload(payload) {
+		if err := ctx.Events.Emit("honeycomb.slo.test", payload); err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		return http.StatusOK, nil
+	}
+
+	//
+	// The recipient is shared with the triggers and the other SLOs of the dataset,
+	// so notifications that are not about this SLO are acknowledged and dropped.
+	//
+	meta := OnSLOBurnAlertNodeMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &meta); err != nil || meta.SLOID == "" {
+		return http.StatusOK, nil
+	}
+
+	if !payloadIsForSLO(payload, meta) {
+		return http.StatusOK, nil
+	}
+
+	if err := ctx.Events.Emit("honeycomb.slo.burn", burnAlertOutput(payload, meta, time.Now())); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// payloadIsForSLO reports whether the notification was sent for the SLO or one of its burn alerts.
+// Burn alert notifications identify the SLO in slo_id or slo.id, or in id, depending on the template.
+func payloadIsForSLO(payload map[string]any, meta OnSLOBurnAlertNodeMetadata) bool {
+	ids := []any{payload["slo_id"], payload["id"], payload["burn_alert_id"]}
+	if slo, ok := payload["slo"].(map[string]any); ok {
+		ids = append(ids, slo["id"])
+	}
+	if alert, ok := payload["burn_alert"].(map[string]any); ok {
+		ids = append(ids, alert["id"])
+	}
+
+	for _, value := range ids {
+		id, ok := value.(string)
+		if !ok || strings.TrimSpace(id) == "" {
+			continue
+		}
+
+		id = strings.TrimSpace(id)
+		if id == meta.SLOID {
+			return true
+		}
+
+		for _, burnAlertID := range meta.BurnAlertIDs {
+			if id == burnAlertID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// burnAlertOutput builds the honeycomb.slo.burn event from the notification.
+// Fields missing from the notification are emitted as nil.
+func burnAlertOutput(payload map[string]any, meta OnSLOBurnAlertNodeMetadata, now time.Time) map[string]any {
+	name := meta.SLOName
+	if slo, ok := payload["slo"].(map[string]any); ok {
+		if n, ok := slo["name"].(string); ok && strings.TrimSpace(n) != "" {
+			name = strings.TrimSpace(n)
+		}
+	} else if n, ok := payload["slo_name"].(string); ok && strings.TrimSpace(n) != "" {
+		name = strings.TrimSpace(n)
+	}
+
+	output := map[string]any{
+		"sloId":             meta.SLOID,
+		"sloName":           name,
+		"status":            alertStatus(payload),
+		"alertType":         payload["alert_type"],
+		"budgetRemaining":   nil,
+		"exhaustionMinutes": nil,
+		"exhaustionTime":    nil,
+		"summary":           payload["summary"],
+		"payload":           payload,
+	}
+
+	for _, key := range []string{"budget_remaining", "remaining_budget", "budget_remaining_percent"} {
+		if budget, ok := payloadNumber(payload[key]); ok {
+			output["budgetRemaining"] = budget
+			break
+		}
+	}
+
+	if exhaustionTime, ok := payloadTime(payload["exhaustion_time"]); ok {
+		output["exhaustionTime"] = exhaustionTime.UTC().Format(time.RFC3339)
+		output["exhaustionMinutes"] = max(exhaustionTime.Sub(now).Minutes(), 0)
+	} else if minutes, ok := payloadNumber(payload["exhaustion_minutes"]); ok {
+		output["exhaustionMinutes"] = minutes
+		output["exhaustionTime"] = now.Add(time.Duration(minutes * float64(time.Minute))).UTC().Format(time.RFC3339)
+	}
+
+	return output
+}
+
+// payloadNumber reads a number sent as a JSON number or as a numeric string.
+func payloadNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
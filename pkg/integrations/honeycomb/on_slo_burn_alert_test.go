@@ -0,0 +1,296 @@
+package honeycomb
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func sloBurnAlertIntegration() *contexts.IntegrationContext {
+	integration := honeycombWebhookIntegration()
+	integration.Metadata = Metadata{ConfigurationKeyPermissions: []string{PermissionManageRecipients, PermissionManageSLOs}}
+	return integration
+}
+
+func Test__OnSLOBurnAlert__Setup(t *testing.T) {
+	trigger := OnSLOBurnAlert{}
+
+	t.Run("missing datasetSlug -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"slo": "slo-1"},
+		})
+		require.ErrorContains(t, err, "datasetSlug is required")
+	})
+
+	t.Run("missing slo -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"datasetSlug": "production"},
+		})
+		require.ErrorContains(t, err, "slo is required")
+	})
+
+	t.Run("configuration key without manage_slos -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-1"},
+			Integration:   honeycombWebhookIntegration(),
+			HTTP:          &contexts.HTTPContext{},
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "missing the manage_slos permission(s) required by the On SLO Burn Alert trigger")
+	})
+
+	t.Run("SLO with burn alerts -> requests webhook for each burn alert", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"slo-1","name":"API Availability"},{"id":"slo-2","name":"Latency"}]`),
+				jsonResponse(`[{"id":"ba-1","alert_type":"exhaustion_time"},{"id":"ba-2","alert_type":"budget_rate"}]`),
+			},
+		}
+
+		integration := sloBurnAlertIntegration()
+		metadata := &contexts.MetadataContext{Metadata: map[string]any{"referenceId": "node-ref"}}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-1"},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, OnSLOBurnAlertNodeMetadata{
+			SLOID:        "slo-1",
+			SLOName:      "API Availability",
+			BurnAlertIDs: []string{"ba-1", "ba-2"},
+			ReferenceID:  "node-ref",
+		}, metadata.Get())
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://api.honeycomb.io/1/slos/production", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "https://api.honeycomb.io/1/burn_alerts/production?slo_id=slo-1", httpCtx.Requests[1].URL.String())
+
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, map[string]any{
+			"environment": "",
+			"datasetSlug": "production",
+			"burnAlertReferences": map[string]string{
+				"node-ref":      "ba-1",
+				"node-ref:ba-2": "ba-2",
+			},
+		}, integration.WebhookRequests[0])
+	})
+
+	t.Run("SLO configured by name -> resolved to its ID", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"slo-1","name":"API Availability"}]`),
+				jsonResponse(`[{"id":"ba-1"}]`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "api availability"},
+			Integration:   sloBurnAlertIntegration(),
+			HTTP:          httpCtx,
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "slo-1", metadata.Get().(OnSLOBurnAlertNodeMetadata).SLOID)
+	})
+
+	t.Run("unknown SLO -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(`[{"id":"slo-1","name":"API Availability"}]`)},
+		}
+
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-9"},
+			Integration:   sloBurnAlertIntegration(),
+			HTTP:          httpCtx,
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, `SLO "slo-9" not found in dataset "production"`)
+	})
+
+	t.Run("SLO without burn alerts -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"slo-1","name":"API Availability"}]`),
+				jsonResponse(`[]`),
+			},
+		}
+
+		integration := sloBurnAlertIntegration()
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-1"},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, `SLO "API Availability" has no burn alerts`)
+		assert.Empty(t, integration.WebhookRequests)
+	})
+
+	t.Run("dry run -> plans the setup without calling Honeycomb", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integration := sloBurnAlertIntegration()
+		integration.Configuration["teamSlug"] = "acme"
+		plan := &core.SetupPlan{}
+
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-1"},
+			Integration:   integration,
+			HTTP:          httpCtx,
+			Metadata:      &contexts.MetadataContext{},
+			DryRun:        true,
+			Plan:          plan,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpCtx.Requests)
+		assert.Empty(t, integration.WebhookRequests)
+
+		require.Len(t, plan.Steps, 3)
+		assert.Equal(t, "configurationKey", plan.Steps[0].Resource)
+		assert.Equal(t, []string{PermissionManageRecipients, PermissionManageSLOs}, plan.Steps[0].Details["permissions"])
+		assert.Equal(t, "recipient", plan.Steps[1].Resource)
+		assert.Equal(t, "burnAlert", plan.Steps[2].Resource)
+		assert.Equal(t, "slo-1", plan.Steps[2].Details["slo"])
+	})
+
+	t.Run("no integration -> returns nil without requesting webhook", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-1"},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func Test__OnSLOBurnAlert__HandleWebhook(t *testing.T) {
+	trigger := &OnSLOBurnAlert{}
+
+	metadata := map[string]any{
+		"sloId":        "slo-1",
+		"sloName":      "API Availability",
+		"burnAlertIds": []any{"ba-1", "ba-2"},
+		"referenceId":  "node-ref",
+	}
+
+	handle := func(body string) (int, *contexts.EventContext, error) {
+		h := http.Header{}
+		h.Set("X-Honeycomb-Webhook-Token", "test-secret")
+
+		events := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       h,
+			Body:          []byte(body),
+			Configuration: map[string]any{"datasetSlug": "production", "slo": "slo-1"},
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{Metadata: metadata},
+		})
+
+		return code, events, err
+	}
+
+	t.Run("missing token -> 401", func(t *testing.T) {
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:  http.Header{},
+			Body:     []byte(`{"slo_id":"slo-1"}`),
+			Webhook:  &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:   &contexts.EventContext{},
+			Metadata: &contexts.MetadataContext{Metadata: metadata},
+		})
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.ErrorContains(t, err, "missing webhook token")
+	})
+
+	t.Run("burn alert of the SLO -> emits honeycomb.slo.burn", func(t *testing.T) {
+		code, events, err := handle(`{"id":"ba-1","slo_id":"slo-1","status":"TRIGGERED","alert_type":"exhaustion_time","budget_remaining":"42.5","exhaustion_time":"2124-01-15T14:30:00Z","summary":"Triggered: API Availability"}`)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.slo.burn", events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "slo-1", data["sloId"])
+		assert.Equal(t, "API Availability", data["sloName"])
+		assert.Equal(t, AlertStatusTriggered, data["status"])
+		assert.Equal(t, "exhaustion_time", data["alertType"])
+		assert.Equal(t, 42.5, data["budgetRemaining"])
+		assert.Equal(t, "2124-01-15T14:30:00Z", data["exhaustionTime"])
+		assert.Greater(t, data["exhaustionMinutes"], float64(0))
+	})
+
+	t.Run("burn alert from another SLO -> no emit", func(t *testing.T) {
+		code, events, err := handle(`{"id":"ba-9","slo_id":"slo-9","status":"TRIGGERED"}`)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("trigger alert on the shared recipient -> no emit", func(t *testing.T) {
+		code, events, err := handle(`{"id":"t1","name":"High Error Rate","status":"TRIGGERED"}`)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("nested SLO -> name taken from the payload", func(t *testing.T) {
+		_, events, err := handle(`{"slo":{"id":"slo-1","name":"API Availability v2"},"status":"OK"}`)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "API Availability v2", data["sloName"])
+		assert.Equal(t, AlertStatusResolved, data["status"])
+		assert.Nil(t, data["budgetRemaining"])
+		assert.Nil(t, data["exhaustionTime"])
+	})
+
+	t.Run("test notification -> emits honeycomb.slo.test", func(t *testing.T) {
+		_, events, err := handle(`{"is_test":true,"name":"Test"}`)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.slo.test", events.Payloads[0].Type)
+	})
+}
+
+func Test__OnSLOBurnAlert__BurnAlertOutput(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	meta := OnSLOBurnAlertNodeMetadata{SLOID: "slo-1", SLOName: "API Availability"}
+
+	t.Run("exhaustion minutes -> exhaustion time computed from now", func(t *testing.T) {
+		output := burnAlertOutput(map[string]any{"exhaustion_minutes": float64(240)}, meta, now)
+		assert.Equal(t, float64(240), output["exhaustionMinutes"])
+		assert.Equal(t, "2024-01-15T14:30:00Z", output["exhaustionTime"])
+	})
+
+	t.Run("exhaustion time in the past -> zero minutes", func(t *testing.T) {
+		output := burnAlertOutput(map[string]any{"exhaustion_time": "2024-01-15T10:00:00Z"}, meta, now)
+		assert.Equal(t, float64(0), output["exhaustionMinutes"])
+	})
+
+	t.Run("SLO name in slo_name -> used", func(t *testing.T) {
+		output := burnAlertOutput(map[string]any{"slo_name": "Checkout"}, meta, now)
+		assert.Equal(t, "Checkout", output["sloName"])
+	})
+}
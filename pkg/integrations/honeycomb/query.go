@@ -0,0 +1,169 @@
+package honeycomb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// queryPollInterval is how long RunQuerySync waits between query result polls.
+var queryPollInterval = time.Second
+
+// QueryResult is a parsed Honeycomb query result.
+type QueryResult struct {
+	ID       string           `json:"id"`
+	Complete bool             `json:"complete"`
+	Results  []map[string]any `json:"results"`
+	Series   []map[string]any `json:"series"`
+	QueryURL string           `json:"queryUrl"`
+}
+
+type queryResultResponse struct {
+	ID       string `json:"id"`
+	Complete bool   `json:"complete"`
+	Data     struct {
+		Results []struct {
+			Data map[string]any `json:"data"`
+		} `json:"results"`
+		Series []map[string]any `json:"series"`
+	} `json:"data"`
+	Links struct {
+		QueryURL string `json:"query_url"`
+	} `json:"links"`
+}
+
+func (r queryResultResponse) toQueryResult() *QueryResult {
+	results := make([]map[string]any, 0, len(r.Data.Results))
+	for _, result := range r.Data.Results {
+		results = append(results, result.Data)
+	}
+
+	series := r.Data.Series
+	if series == nil {
+		series = []map[string]any{}
+	}
+
+	return &QueryResult{
+		ID:       r.ID,
+		Complete: r.Complete,
+		Results:  results,
+		Series:   series,
+		QueryURL: r.Links.QueryURL,
+	}
+}
+
+// CreateQuery saves a query specification for a dataset and returns its ID.
+func (c *Client) CreateQuery(datasetSlug string, query map[string]any) (string, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	req, err := c.newReqV1(http.MethodPost, fmt.Sprintf("/1/queries/%s", url.PathEscape(datasetSlug)), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	respBody, code, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	if code < 200 || code >= 300 {
+		return "", c.newRequestError("create query failed", code, respBody)
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to parse created query: %w", err)
+	}
+	if strings.TrimSpace(created.ID) == "" {
+		return "", fmt.Errorf("create query response has no id")
+	}
+
+	return created.ID, nil
+}
+
+// CreateQueryResult starts running a saved query.
+// The returned result is usually not complete yet and must be polled with GetQueryResult.
+func (c *Client) CreateQueryResult(datasetSlug, queryID string) (*QueryResult, error) {
+	body, _ := json.Marshal(map[string]any{"query_id": queryID})
+	req, err := c.newReqV1(http.MethodPost, fmt.Sprintf("/1/query_results/%s", url.PathEscape(datasetSlug)), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doQueryResult(req, "create query result")
+}
+
+// GetQueryResult fetches the current state of a query result.
+func (c *Client) GetQueryResult(datasetSlug, resultID string) (*QueryResult, error) {
+	req, err := c.newReqV1(http.MethodGet, fmt.Sprintf("/1/query_results/%s/%s", url.PathEscape(datasetSlug), url.PathEscape(resultID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doQueryResult(req, "get query result")
+}
+
+func (c *Client) doQueryResult(req *http.Request, operation string) (*QueryResult, error) {
+	respBody, code, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if code < 200 || code >= 300 {
+		return nil, c.newRequestError(operation+" failed", code, respBody)
+	}
+
+	var response queryResultResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse query result: %w", err)
+	}
+
+	return response.toQueryResult(), nil
+}
+
+// RunQuerySync creates a query, runs it, and polls until the result is complete
+// or the timeout expires. Polling stops as soon as the context of the client is done.
+// Components that need query results should use this
+// instead of driving the create, run, and poll steps themselves.
+func (c *Client) RunQuerySync(datasetSlug string, query map[string]any, timeout time.Duration) (*QueryResult, error) {
+	datasetSlug = strings.TrimSpace(datasetSlug)
+	if datasetSlug == "" {
+		return nil, fmt.Errorf("dataset is required")
+	}
+
+	queryID, err := c.CreateQuery(datasetSlug, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.CreateQueryResult(datasetSlug, queryID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultID := result.ID
+	deadline := time.Now().Add(timeout)
+	for !result.Complete {
+		if !time.Now().Add(queryPollInterval).Before(deadline) {
+			return nil, fmt.Errorf("query result %s did not complete within %s", resultID, timeout)
+		}
+
+		if err := sleepContext(c.context(), queryPollInterval); err != nil {
+			return nil, fmt.Errorf("stopped polling query result %s: %w", resultID, err)
+		}
+
+		result, err = c.GetQueryResult(datasetSlug, resultID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,371 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	defaultQueryTimeoutSeconds = 60
+	maxQueryTimeoutSeconds     = 300
+)
+
+// What the Query Dataset component emits from the query result.
+const (
+	QueryModeAggregate = "aggregate"
+	QueryModeSeries    = "series"
+)
+
+var AllQueryModes = []configuration.FieldOption{
+	{Label: "Aggregate", Value: QueryModeAggregate},
+	{Label: "Time series", Value: QueryModeSeries},
+}
+
+type QueryDataset struct{}
+
+type QueryDatasetConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	Dataset     string `json:"dataset" mapstructure:"dataset"`
+	Query       any    `json:"query" mapstructure:"query"`
+	Timeout     int    `json:"timeout" mapstructure:"timeout"`
+
+	// Mode is "series" to also emit the time series of the query, empty or "aggregate" otherwise.
+	Mode string `json:"mode" mapstructure:"mode"`
+}
+
+func (c *QueryDataset) Name() string {
+	return "honeycomb.queryDataset"
+}
+
+func (c *QueryDataset) Label() string {
+	return "Query Dataset"
+}
+
+func (c *QueryDataset) Description() string {
+	return "Run a query on a Honeycomb dataset"
+}
+
+func (c *QueryDataset) Icon() string {
+	return "honeycomb"
+}
+
+func (c *QueryDataset) Color() string {
+	return "gray"
+}
+
+func (c *QueryDataset) Documentation() string {
+	return `
+Runs a query on a Honeycomb dataset and emits the resulting rows, so the workflow can branch on them.
+
+Notes:
+• The query is a Honeycomb query specification in JSON, for example {"time_range":3600,"calculations":[{"op":"COUNT"}]}
+• The query must have a time range (time_range, or start_time and end_time) and at least one calculation
+• Filters are optional, and each one needs a column and an op
+• The component waits for the query to complete, and fails if it does not complete within the timeout
+• In the Time series mode, the output also has a series list with the calculations of every time bucket, as {"time": ..., "data": {...}} entries. Set granularity in the query to choose the bucket size in seconds
+• The configuration key needs the "Run Queries" permission
+`
+}
+
+func (c *QueryDataset) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *QueryDataset) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:     "dataset",
+			Label:    "Dataset",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       "dataset",
+					Parameters: []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+		{
+			Name:     "query",
+			Label:    "Query JSON",
+			Type:     configuration.FieldTypeObject,
+			Required: true,
+			Default:  "{\"time_range\":3600,\"calculations\":[{\"op\":\"COUNT\"}]}",
+			Description: `Honeycomb query specification.
+							Example:
+							{"time_range":3600,"calculations":[{"op":"P99","column":"duration_ms"}],"filters":[{"column":"status_code","op":">=","value":500}]}`,
+		},
+		{
+			Name:        "mode",
+			Label:       "Mode",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Default:     QueryModeAggregate,
+			Description: "Emit only the aggregated results, or also their time series to feed trends to other steps",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllQueryModes,
+				},
+			},
+		},
+		{
+			Name:        "timeout",
+			Label:       "Timeout (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Default:     fmt.Sprintf("%d", defaultQueryTimeoutSeconds),
+			Description: "How long to wait for the query to complete",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxQueryTimeoutSeconds; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func (c *QueryDataset) Setup(ctx core.SetupContext) error {
+	var cfg QueryDatasetConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if strings.TrimSpace(cfg.Dataset) == "" {
+		return errors.New("dataset is required")
+	}
+
+	if _, err := queryTimeout(cfg.Timeout); err != nil {
+		return err
+	}
+
+	if err := validateQueryMode(cfg.Mode); err != nil {
+		return err
+	}
+
+	if ctx.Integration != nil && !configurationKeyPermissions(ctx.Integration).RunQueries {
+		return fmt.Errorf("the Honeycomb configuration key is missing the %s permission required by the Query Dataset component; add it to the integration's configuration key permissions", PermissionRunQueries)
+	}
+
+	//
+	// Queries built from expressions are only known at execution time.
+	//
+	if text, ok := cfg.Query.(string); ok && strings.Contains(text, "{{") {
+		return nil
+	}
+
+	_, err := parseQuerySpec(cfg.Query)
+	return err
+}
+
+func queryTimeout(seconds int) (time.Duration, error) {
+	if seconds == 0 {
+		seconds = defaultQueryTimeoutSeconds
+	}
+
+	if seconds < 1 || seconds > maxQueryTimeoutSeconds {
+		return 0, fmt.Errorf("timeout must be between 1 and %d seconds", maxQueryTimeoutSeconds)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func validateQueryMode(mode string) error {
+	switch mode {
+	case "", QueryModeAggregate, QueryModeSeries:
+		return nil
+	default:
+		return fmt.Errorf("invalid mode %q, must be %s or %s", mode, QueryModeAggregate, QueryModeSeries)
+	}
+}
+
+// querySeries returns the time series points of the result as time and data pairs,
+// in the order Honeycomb returns them.
+func querySeries(result *QueryResult) []map[string]any {
+	series := make([]map[string]any, 0, len(result.Series))
+	for _, point := range result.Series {
+		data, _ := point["data"].(map[string]any)
+		if data == nil {
+			data = map[string]any{}
+		}
+
+		series = append(series, map[string]any{
+			"time": point["time"],
+			"data": data,
+		})
+	}
+
+	return series
+}
+
+// parseQuerySpec accepts the query configuration as a decoded object or a JSON string,
+// and checks the parts of the specification Honeycomb needs to run it.
+func parseQuerySpec(value any) (map[string]any, error) {
+	if text, ok := value.(string); ok {
+		if strings.TrimSpace(text) == "" {
+			return nil, errors.New("query json is required")
+		}
+
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return nil, errors.New("query json must be a valid JSON object")
+		}
+	}
+
+	query, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("query json must be an object")
+	}
+
+	if err := validateQueryTimeRange(query); err != nil {
+		return nil, err
+	}
+
+	calculations, ok := query["calculations"].([]any)
+	if !ok || len(calculations) == 0 {
+		return nil, errors.New("query must have at least one calculation")
+	}
+
+	for i, item := range calculations {
+		calculation, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("calculation at index %d must be an object", i)
+		}
+
+		if op, _ := calculation["op"].(string); strings.TrimSpace(op) == "" {
+			return nil, fmt.Errorf("calculation at index %d must have an op", i)
+		}
+	}
+
+	if filtersValue, exists := query["filters"]; exists && filtersValue != nil {
+		filters, ok := filtersValue.([]any)
+		if !ok {
+			return nil, errors.New("query filters must be an array")
+		}
+
+		for i, item := range filters {
+			filter, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("filter at index %d must be an object", i)
+			}
+
+			if column, _ := filter["column"].(string); strings.TrimSpace(column) == "" {
+				return nil, fmt.Errorf("filter at index %d must have a column", i)
+			}
+
+			if op, _ := filter["op"].(string); strings.TrimSpace(op) == "" {
+				return nil, fmt.Errorf("filter at index %d must have an op", i)
+			}
+		}
+	}
+
+	return query, nil
+}
+
+func validateQueryTimeRange(query map[string]any) error {
+	if timeRange, exists := query["time_range"]; exists {
+		seconds, ok := timeRange.(float64)
+		if !ok || seconds <= 0 {
+			return errors.New("query time_range must be a positive number of seconds")
+		}
+
+		return nil
+	}
+
+	startTime, hasStart := query["start_time"].(float64)
+	endTime, hasEnd := query["end_time"].(float64)
+	if !hasStart || !hasEnd {
+		return errors.New("query must have a time_range, or a start_time and an end_time")
+	}
+
+	if endTime <= startTime {
+		return errors.New("query end_time must be after start_time")
+	}
+
+	return nil
+}
+
+func (c *QueryDataset) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *QueryDataset) Execute(ctx core.ExecutionContext) error {
+	var cfg QueryDatasetConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return err
+	}
+
+	dataset := strings.TrimSpace(cfg.Dataset)
+	if dataset == "" {
+		return errors.New("dataset is required")
+	}
+
+	query, err := parseQuerySpec(cfg.Query)
+	if err != nil {
+		return err
+	}
+
+	timeout, err := queryTimeout(cfg.Timeout)
+	if err != nil {
+		return err
+	}
+
+	if err := validateQueryMode(cfg.Mode); err != nil {
+		return err
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.WithContext(ctx.Context).RunQuerySync(dataset, query, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to run query on dataset %s: %w", dataset, err)
+	}
+
+	output := map[string]any{
+		"dataset":  dataset,
+		"resultId": result.ID,
+		"results":  result.Results,
+		"queryUrl": result.QueryURL,
+	}
+
+	if cfg.Mode == QueryModeSeries {
+		output["series"] = querySeries(result)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"honeycomb.query.result",
+		[]any{output},
+	)
+}
+
+func (c *QueryDataset) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *QueryDataset) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *QueryDataset) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *QueryDataset) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *QueryDataset) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
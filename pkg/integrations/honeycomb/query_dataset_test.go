@@ -0,0 +1,306 @@
+package honeycomb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__QueryDataset__Setup(t *testing.T) {
+	component := &QueryDataset{}
+
+	validQuery := map[string]any{
+		"time_range":   float64(3600),
+		"calculations": []any{map[string]any{"op": "COUNT"}},
+	}
+
+	t.Run("missing dataset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"query": validQuery},
+		})
+		require.ErrorContains(t, err, "dataset is required")
+	})
+
+	t.Run("invalid JSON string -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "api", "query": "{not json"},
+		})
+		require.ErrorContains(t, err, "query json must be a valid JSON object")
+	})
+
+	t.Run("missing time range -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   map[string]any{"calculations": []any{map[string]any{"op": "COUNT"}}},
+			},
+		})
+		require.ErrorContains(t, err, "query must have a time_range, or a start_time and an end_time")
+	})
+
+	t.Run("end time before start time -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query": map[string]any{
+					"start_time":   float64(200),
+					"end_time":     float64(100),
+					"calculations": []any{map[string]any{"op": "COUNT"}},
+				},
+			},
+		})
+		require.ErrorContains(t, err, "query end_time must be after start_time")
+	})
+
+	t.Run("no calculations -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   map[string]any{"time_range": float64(3600), "calculations": []any{}},
+			},
+		})
+		require.ErrorContains(t, err, "query must have at least one calculation")
+	})
+
+	t.Run("filter without column -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query": map[string]any{
+					"time_range":   float64(3600),
+					"calculations": []any{map[string]any{"op": "COUNT"}},
+					"filters":      []any{map[string]any{"op": "exists"}},
+				},
+			},
+		})
+		require.ErrorContains(t, err, "filter at index 0 must have a column")
+	})
+
+	t.Run("timeout over the limit -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "api", "query": validQuery, "timeout": 301},
+		})
+		require.ErrorContains(t, err, "timeout must be between 1 and 300 seconds")
+	})
+
+	t.Run("unknown mode -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "api", "query": validQuery, "mode": "histogram"},
+		})
+		require.ErrorContains(t, err, `invalid mode "histogram", must be aggregate or series`)
+	})
+
+	t.Run("configuration key cannot run queries -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{
+				Metadata: Metadata{ConfigurationKeyPermissions: []string{PermissionManageMarkers}},
+			},
+			Configuration: map[string]any{"dataset": "api", "query": validQuery},
+		})
+		require.ErrorContains(t, err, "missing the run_queries permission")
+	})
+
+	t.Run("query from expression -> validated at execution time", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"dataset": "api", "query": "{{ $['Build'].query }}"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("valid JSON string -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   `{"time_range":3600,"calculations":[{"op":"P99","column":"duration_ms"}],"filters":[{"column":"status_code","op":">=","value":500}]}`,
+			},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test__QueryDataset__Execute(t *testing.T) {
+	previousInterval := queryPollInterval
+	queryPollInterval = time.Millisecond
+	t.Cleanup(func() { queryPollInterval = previousInterval })
+
+	component := &QueryDataset{}
+
+	integrationCtx := func() *contexts.IntegrationContext {
+		return &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"managementKey": "keyid:secret",
+				"site":          "api.honeycomb.io",
+			},
+			Secrets: map[string]core.IntegrationSecret{
+				secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("test-config-key")},
+			},
+		}
+	}
+
+	t.Run("query completes -> emits results", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":false}`),
+				jsonResponse(`{"id":"result-1","complete":true,"data":{"results":[{"data":{"COUNT":42}}]},"links":{"query_url":"https://ui.honeycomb.io/q/result-1"}}`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   `{"time_range":3600,"calculations":[{"op":"COUNT"}]}`,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, core.DefaultOutputChannel.Name, execState.Channel)
+		assert.Equal(t, "honeycomb.query.result", execState.Type)
+
+		require.Len(t, execState.Payloads, 1)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "api", payload["dataset"])
+		assert.Equal(t, "result-1", payload["resultId"])
+		assert.Equal(t, []map[string]any{{"COUNT": float64(42)}}, payload["results"])
+		assert.Equal(t, "https://ui.honeycomb.io/q/result-1", payload["queryUrl"])
+
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, "https://api.honeycomb.io/1/queries/api", httpCtx.Requests[0].URL.String())
+		query := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[0].Body).Decode(&query))
+		assert.Equal(t, float64(3600), query["time_range"])
+		assert.Equal(t, "https://api.honeycomb.io/1/query_results/api/result-1", httpCtx.Requests[2].URL.String())
+	})
+
+	t.Run("series mode -> emits the time series", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":true,"data":{
+					"results":[{"data":{"COUNT":12}}],
+					"series":[
+						{"time":"2026-01-19T12:00:00Z","data":{"COUNT":5}},
+						{"time":"2026-01-19T12:05:00Z","data":{"COUNT":7}},
+						{"time":"2026-01-19T12:10:00Z"}
+					]
+				}}`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   `{"time_range":900,"granularity":300,"calculations":[{"op":"COUNT"}]}`,
+				"mode":    QueryModeSeries,
+			},
+		})
+
+		require.NoError(t, err)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []map[string]any{{"COUNT": float64(12)}}, payload["results"])
+		assert.Equal(t, []map[string]any{
+			{"time": "2026-01-19T12:00:00Z", "data": map[string]any{"COUNT": float64(5)}},
+			{"time": "2026-01-19T12:05:00Z", "data": map[string]any{"COUNT": float64(7)}},
+			{"time": "2026-01-19T12:10:00Z", "data": map[string]any{}},
+		}, payload["series"])
+	})
+
+	t.Run("aggregate mode -> series is not emitted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":true,"data":{"results":[{"data":{"COUNT":12}}],"series":[{"time":"2026-01-19T12:00:00Z","data":{"COUNT":12}}]}}`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   `{"time_range":3600,"calculations":[{"op":"COUNT"}]}`,
+				"mode":    QueryModeAggregate,
+			},
+		})
+
+		require.NoError(t, err)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.NotContains(t, payload, "series")
+	})
+
+	t.Run("query never completes -> error", func(t *testing.T) {
+		responses := []*http.Response{
+			jsonResponse(`{"id":"query-1"}`),
+		}
+		for i := 0; i < 2000; i++ {
+			responses = append(responses, jsonResponse(`{"id":"result-1","complete":false}`))
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           &contexts.HTTPContext{Responses: responses},
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   map[string]any{"time_range": float64(3600), "calculations": []any{map[string]any{"op": "COUNT"}}},
+				"timeout": 1,
+			},
+		})
+
+		require.ErrorContains(t, err, "query result result-1 did not complete within 1s")
+		assert.Empty(t, execState.Payloads)
+	})
+
+	t.Run("execution context done while polling -> stops without waiting for the next poll", func(t *testing.T) {
+		queryPollInterval = 30 * time.Second
+		t.Cleanup(func() { queryPollInterval = time.Millisecond })
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":false}`),
+				jsonResponse(`{"id":"result-1","complete":true}`),
+			},
+		}
+
+		runCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		start := time.Now()
+		err := component.Execute(core.ExecutionContext{
+			Context:        runCtx,
+			Integration:    integrationCtx(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration: map[string]any{
+				"dataset": "api",
+				"query":   `{"time_range":3600,"calculations":[{"op":"COUNT"}]}`,
+				"timeout": 60,
+			},
+		})
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 10*time.Second)
+		assert.Len(t, httpCtx.Requests, 2)
+		assert.Empty(t, execState.Payloads)
+	})
+}
@@ -0,0 +1,126 @@
+package honeycomb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Client__RunQuerySync(t *testing.T) {
+	previousInterval := queryPollInterval
+	queryPollInterval = time.Millisecond
+	t.Cleanup(func() { queryPollInterval = previousInterval })
+
+	query := map[string]any{
+		"time_range":   3600,
+		"calculations": []any{map[string]any{"op": "COUNT"}},
+	}
+
+	t.Run("result complete immediately -> no polling", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":true,"data":{"results":[{"data":{"COUNT":42}}]},"links":{"query_url":"https://ui.honeycomb.io/q/result-1"}}`),
+			},
+		}
+
+		result, err := newTestClient(t, httpCtx).RunQuerySync("api", query, time.Second)
+		require.NoError(t, err)
+		assert.True(t, result.Complete)
+		assert.Equal(t, []map[string]any{{"COUNT": float64(42)}}, result.Results)
+		assert.Equal(t, []map[string]any{}, result.Series)
+		assert.Equal(t, "https://ui.honeycomb.io/q/result-1", result.QueryURL)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, http.MethodPost, httpCtx.Requests[0].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/queries/api", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "test-config-key", httpCtx.Requests[0].Header.Get("X-Honeycomb-Team"))
+
+		assert.Equal(t, "https://api.honeycomb.io/1/query_results/api", httpCtx.Requests[1].URL.String())
+		body, err := io.ReadAll(httpCtx.Requests[1].Body)
+		require.NoError(t, err)
+		params := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &params))
+		assert.Equal(t, "query-1", params["query_id"])
+	})
+
+	t.Run("result completes after polling -> returns final result", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":false}`),
+				jsonResponse(`{"id":"result-1","complete":false}`),
+				jsonResponse(`{"id":"result-1","complete":true,"data":{"results":[{"data":{"COUNT":7}}],"series":[{"time":"2026-01-19T12:00:00Z","data":{"COUNT":7}}]}}`),
+			},
+		}
+
+		result, err := newTestClient(t, httpCtx).RunQuerySync("api", query, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]any{{"COUNT": float64(7)}}, result.Results)
+		assert.Len(t, result.Series, 1)
+
+		require.Len(t, httpCtx.Requests, 4)
+		for _, req := range httpCtx.Requests[2:] {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, "https://api.honeycomb.io/1/query_results/api/result-1", req.URL.String())
+		}
+	})
+
+	t.Run("result never completes -> timeout error", func(t *testing.T) {
+		queryPollInterval = 10 * time.Millisecond
+		t.Cleanup(func() { queryPollInterval = time.Millisecond })
+
+		responses := []*http.Response{jsonResponse(`{"id":"query-1"}`)}
+		for range 10 {
+			responses = append(responses, jsonResponse(`{"id":"result-1","complete":false}`))
+		}
+
+		httpCtx := &contexts.HTTPContext{Responses: responses}
+		_, err := newTestClient(t, httpCtx).RunQuerySync("api", query, 25*time.Millisecond)
+		require.ErrorContains(t, err, "query result result-1 did not complete within 25ms")
+		assert.Less(t, len(httpCtx.Requests), 11)
+	})
+
+	t.Run("context cancelled -> stops polling", func(t *testing.T) {
+		queryPollInterval = time.Hour
+		t.Cleanup(func() { queryPollInterval = time.Millisecond })
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`{"id":"query-1"}`),
+				jsonResponse(`{"id":"result-1","complete":false}`),
+				jsonResponse(`{"id":"result-1","complete":true}`),
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := newTestClient(t, httpCtx).WithContext(ctx).RunQuerySync("api", query, 2*time.Hour)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorContains(t, err, "stopped polling query result result-1")
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("query creation fails -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"error":"forbidden"}`)),
+				},
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).RunQuerySync("api", query, time.Second)
+		require.ErrorContains(t, err, "create query failed (http 403)")
+	})
+}
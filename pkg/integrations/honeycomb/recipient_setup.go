@@ -0,0 +1,91 @@
+package honeycomb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	defaultRecipientSetupConcurrency = 4
+	maxRecipientSetupConcurrency     = 20
+)
+
+// recipientSetupConcurrency is how many triggers or burn alerts the webhook handler
+// updates at once, from the Recipient Setup Concurrency of the integration configuration.
+// Missing or invalid values fall back to the default, and values above the maximum are capped.
+func recipientSetupConcurrency(config map[string]any) int {
+	var concurrency int
+	switch v := config["recipientSetupConcurrency"].(type) {
+	case float64:
+		concurrency = int(v)
+	case int:
+		concurrency = v
+	default:
+		return defaultRecipientSetupConcurrency
+	}
+
+	if concurrency < 1 {
+		return defaultRecipientSetupConcurrency
+	}
+
+	return min(concurrency, maxRecipientSetupConcurrency)
+}
+
+// syncedRecipientSetupConcurrency is the recipient setup concurrency
+// recorded in the metadata of the integration by its last Sync.
+func syncedRecipientSetupConcurrency(ctx core.IntegrationContext) int {
+	metadata := Metadata{}
+	_ = mapstructure.Decode(ctx.GetMetadata(), &metadata)
+	if metadata.RecipientSetupConcurrency < 1 {
+		return defaultRecipientSetupConcurrency
+	}
+
+	return min(metadata.RecipientSetupConcurrency, maxRecipientSetupConcurrency)
+}
+
+// forEachConcurrently calls fn for each of the IDs, with at most limit calls running at once.
+// The errors are returned in the order of the IDs, nil for the IDs fn succeeded for.
+func forEachConcurrently(ids []string, limit int, fn func(id string) error) []error {
+	errs := make([]error, len(ids))
+	slots := make(chan struct{}, max(limit, 1))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = fn(id)
+		}()
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// joinRecipientErrors aggregates the failures of forEachConcurrently into one error
+// naming every resource that failed, or returns nil if none did.
+// Attaching and detaching the recipient is idempotent, so the next setup retries all of them.
+func joinRecipientErrors(action, resource string, ids []string, errs []error) error {
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s %s: %w", resource, ids[i], err))
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	if len(ids) == 1 {
+		return fmt.Errorf("failed to %s %w", action, failed[0])
+	}
+
+	return fmt.Errorf("failed to %s %d of %d %ss: %w", action, len(failed), len(ids), resource, errors.Join(failed...))
+}
@@ -1,11 +1,62 @@
 package honeycomb
 
-import "github.com/superplanehq/superplane/pkg/core"
+import (
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
 
 const allDatasetsInEnvironmentScopeSlug = "__all__"
 
+// environmentField is the optional field selecting one of the environments of the integration.
+func environmentField() configuration.Field {
+	return configuration.Field{
+		Name:        "environment",
+		Label:       "Environment",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    false,
+		Description: "The Honeycomb environment. Defaults to the first environment of the integration.",
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: "environment",
+			},
+		},
+	}
+}
+
+// environmentParameter passes the selected environment to the dataset and trigger pickers.
+func environmentParameter() configuration.ParameterRef {
+	return configuration.ParameterRef{
+		Name:      "environment",
+		ValueFrom: &configuration.ParameterValueFrom{Field: "environment"},
+	}
+}
+
+// triggerResourceParameters passes the selected environment and dataset to the trigger pickers.
+func triggerResourceParameters() []configuration.ParameterRef {
+	return []configuration.ParameterRef{
+		environmentParameter(),
+		{
+			Name:      "datasetSlug",
+			ValueFrom: &configuration.ParameterValueFrom{Field: "datasetSlug"},
+		},
+	}
+}
+
 func (h *Honeycomb) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
-	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if resourceType == "environment" {
+		slugs := environmentSlugs(ctx.Integration)
+		resources := make([]core.IntegrationResource, 0, len(slugs))
+		for _, slug := range slugs {
+			resources = append(resources, core.IntegrationResource{
+				Type: resourceType,
+				Name: slug,
+				ID:   slug,
+			})
+		}
+		return resources, nil
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, ctx.Parameters["environment"])
 	if err != nil {
 		return nil, err
 	}
@@ -16,7 +67,15 @@ func (h *Honeycomb) ListResources(resourceType string, ctx core.ListResourcesCon
 		if err != nil {
 			return nil, err
 		}
-		resources := make([]core.IntegrationResource, 0, len(datasets))
+		resources := make([]core.IntegrationResource, 0, len(datasets)+1)
+		if ctx.Parameters["includeEnvironment"] == "true" {
+			resources = append(resources, core.IntegrationResource{
+				Type: resourceType,
+				Name: "All datasets (environment)",
+				ID:   allDatasetsInEnvironmentScopeSlug,
+			})
+		}
+
 		for _, d := range datasets {
 			resources = append(resources, core.IntegrationResource{
 				Type: resourceType,
@@ -31,7 +90,7 @@ func (h *Honeycomb) ListResources(resourceType string, ctx core.ListResourcesCon
 		if datasetSlug == "" {
 			return []core.IntegrationResource{}, nil
 		}
-		triggers, err := listDatasetAndEnvironmentTriggers(client, datasetSlug)
+		triggers, err := listDatasetAndEnvironmentTriggers(client.WithTriggersCache(), datasetSlug)
 		if err != nil {
 			return nil, err
 		}
@@ -45,6 +104,25 @@ func (h *Honeycomb) ListResources(resourceType string, ctx core.ListResourcesCon
 		}
 		return resources, nil
 
+	case "slo":
+		datasetSlug := ctx.Parameters["datasetSlug"]
+		if datasetSlug == "" {
+			return []core.IntegrationResource{}, nil
+		}
+		slos, err := client.ListSLOs(datasetSlug)
+		if err != nil {
+			return nil, err
+		}
+		resources := make([]core.IntegrationResource, 0, len(slos))
+		for _, s := range slos {
+			resources = append(resources, core.IntegrationResource{
+				Type: resourceType,
+				Name: s.Name,
+				ID:   s.ID,
+			})
+		}
+		return resources, nil
+
 	default:
 		return []core.IntegrationResource{}, nil
 	}
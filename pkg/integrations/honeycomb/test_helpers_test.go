@@ -0,0 +1,50 @@
+package honeycomb
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// newTestClient returns a client for the default environment that sends its requests
+// through httpCtx, with both keys of the integration set. Every client gets its own
+// integration ID, so clients of different tests do not share cached triggers.
+func newTestClient(t *testing.T, httpCtx *contexts.HTTPContext) *Client {
+	client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+		IntegrationID: uuid.NewString(),
+		Configuration: map[string]any{
+			"managementKey": "keyid:secret",
+			"site":          "api.honeycomb.io",
+		},
+		Secrets: map[string]core.IntegrationSecret{
+			secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("test-config-key")},
+			secretNameIngestKey:        {Name: secretNameIngestKey, Value: []byte("test-ingest-key")},
+		},
+	})
+
+	require.NoError(t, err)
+	return client
+}
+
+func statusResponse(code int, body string) *http.Response {
+	return &http.Response{StatusCode: code, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func jsonResponse(body string) *http.Response {
+	return statusResponse(http.StatusOK, body)
+}
+
+func rateLimitedResponse(retryAfter string) *http.Response {
+	response := statusResponse(http.StatusTooManyRequests, `{"error":"rate limited"}`)
+	if retryAfter != "" {
+		response.Header.Set("Retry-After", retryAfter)
+	}
+
+	return response
+}
@@ -0,0 +1,190 @@
+package honeycomb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type TestRecipient struct{}
+
+type TestRecipientConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	Dataset     string `json:"dataset" mapstructure:"dataset"`
+}
+
+func (c *TestRecipient) Name() string {
+	return "honeycomb.testRecipient"
+}
+
+func (c *TestRecipient) Label() string {
+	return "Test Recipient"
+}
+
+func (c *TestRecipient) Description() string {
+	return "Send a test notification to the SuperPlane recipient in Honeycomb"
+}
+
+func (c *TestRecipient) Icon() string {
+	return "honeycomb"
+}
+
+func (c *TestRecipient) Color() string {
+	return "gray"
+}
+
+func (c *TestRecipient) Documentation() string {
+	return `
+Sends a test notification through the webhook recipient SuperPlane created in Honeycomb for a dataset, to verify that alerts reach SuperPlane.
+
+Notes:
+• The recipient is created by the On Alert Fired trigger, so add one for the dataset first
+• The notification is signed with the recipient's secret, like the ones Honeycomb sends
+• On Alert Fired triggers for the dataset receive it as a "honeycomb.alert.test" event
+• Fails if the webhook does not accept the notification
+`
+}
+
+func (c *TestRecipient) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *TestRecipient) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:     "dataset",
+			Label:    "Dataset",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       "dataset",
+					Parameters: []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+	}
+}
+
+func (c *TestRecipient) Setup(ctx core.SetupContext) error {
+	var cfg TestRecipientConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if strings.TrimSpace(cfg.Dataset) == "" {
+		return errors.New("dataset is required")
+	}
+
+	return nil
+}
+
+func (c *TestRecipient) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *TestRecipient) Execute(ctx core.ExecutionContext) error {
+	var cfg TestRecipientConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return err
+	}
+
+	dataset := strings.TrimSpace(cfg.Dataset)
+	if dataset == "" {
+		return errors.New("dataset is required")
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := client.ListRecipients()
+	if err != nil {
+		return err
+	}
+
+	recipient, ok := findWebhookRecipient(recipients, client.environment, dataset)
+	if !ok {
+		return fmt.Errorf("recipient %q not found in Honeycomb, add an On Alert Fired trigger for dataset %s first", recipientBaseName(client.environment, dataset), dataset)
+	}
+
+	name, _ := recipient.Details["webhook_name"].(string)
+
+	code, err := client.SendTestNotification(recipient, testNotificationPayload(name))
+	if err != nil {
+		return err
+	}
+	if code < 200 || code >= 300 {
+		return client.newRequestError(fmt.Sprintf("test notification to recipient %s was rejected", recipient.ID), code, nil)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"honeycomb.recipient.tested",
+		[]any{
+			map[string]any{
+				core.ResultStatusField: core.ResultStatusSuccess,
+				"recipientId":          recipient.ID,
+				"recipientName":        name,
+				"dataset":              dataset,
+				"statusCode":           code,
+			},
+		},
+	)
+}
+
+func findWebhookRecipient(recipients []Recipient, environmentSlug, datasetSlug string) (Recipient, bool) {
+	for _, r := range recipients {
+		if r.Type != "webhook" {
+			continue
+		}
+
+		if webhookName, _ := r.Details["webhook_name"].(string); isRecipientNameFor(webhookName, environmentSlug, datasetSlug) {
+			return r, true
+		}
+	}
+
+	return Recipient{}, false
+}
+
+// testNotificationPayload mirrors the notification Honeycomb sends
+// when a recipient is tested from the Honeycomb UI.
+func testNotificationPayload(recipientName string) map[string]any {
+	return map[string]any{
+		"version":             "v0.1.0",
+		"is_test":             true,
+		"name":                "SuperPlane Test Notification",
+		"status":              "TRIGGERED",
+		"summary":             "Test notification sent by SuperPlane",
+		"trigger_description": fmt.Sprintf("Verifies that notifications sent to %s reach SuperPlane", recipientName),
+		"result_groups":       []any{},
+	}
+}
+
+func (c *TestRecipient) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *TestRecipient) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *TestRecipient) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *TestRecipient) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *TestRecipient) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,157 @@
+package honeycomb
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testRecipientsResponse = `[
+	{"id":"email-1","type":"email","details":{"email_address":"oncall@example.com"}},
+	{"id":"rec-other","type":"webhook","details":{"webhook_name":"SuperPlane (prod-env/checkout)","webhook_url":"https://hooks.example.com/other","webhook_secret":"other-secret"}},
+	{"id":"rec-1","type":"webhook","details":{"webhook_name":"SuperPlane (prod-env/production)","webhook_url":"https://hooks.example.com/api/v1/webhooks/abc","webhook_secret":"test-secret"}}
+]`
+
+func Test__TestRecipient__Setup(t *testing.T) {
+	component := &TestRecipient{}
+
+	t.Run("missing dataset -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"dataset": " "}})
+		require.ErrorContains(t, err, "dataset is required")
+	})
+
+	t.Run("dataset -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"dataset": "production"}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__TestRecipient__Execute(t *testing.T) {
+	component := &TestRecipient{}
+
+	t.Run("recipient found -> sends signed test notification and emits", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, testRecipientsResponse),
+				statusResponse(http.StatusOK, ``),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": "production"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients", httpCtx.Requests[0].URL.String())
+
+		send := httpCtx.Requests[1]
+		assert.Equal(t, http.MethodPost, send.Method)
+		assert.Equal(t, "https://hooks.example.com/api/v1/webhooks/abc", send.URL.String())
+		assert.Equal(t, "test-secret", send.Header.Get("X-Honeycomb-Webhook-Token"))
+		assert.NotEmpty(t, send.Header.Get("X-Honeycomb-Webhook-Signature"))
+
+		//
+		// The notification must be accepted by the trigger
+		// listening on the recipient, which closes the loop.
+		//
+		body, err := io.ReadAll(send.Body)
+		require.NoError(t, err)
+
+		events := &contexts.EventContext{}
+		code, err := (&OnAlertFired{}).HandleWebhook(core.WebhookRequestContext{
+			Headers:       send.Header,
+			Body:          body,
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"},
+			Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+			Events:        events,
+			Metadata:      &contexts.MetadataContext{},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "honeycomb.alert.test", events.Payloads[0].Type)
+
+		assert.Equal(t, "honeycomb.recipient.tested", execState.Type)
+		require.Len(t, execState.Payloads, 1)
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, core.ResultStatusSuccess, payload["status"])
+		assert.Equal(t, "rec-1", payload["recipientId"])
+		assert.Equal(t, "SuperPlane (prod-env/production)", payload["recipientName"])
+		assert.Equal(t, http.StatusOK, payload["statusCode"])
+	})
+
+	t.Run("recipient named with webhook ID -> found", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `[
+					{"id":"rec-eu","type":"webhook","details":{"webhook_name":"SuperPlane (prod-env/production-eu) #0f4c2a9e","webhook_url":"https://hooks.example.com/eu","webhook_secret":"eu-secret"}},
+					{"id":"rec-2","type":"webhook","details":{"webhook_name":"SuperPlane (prod-env/production) #a83d5e20","webhook_url":"https://hooks.example.com/2","webhook_secret":"test-secret"}}
+				]`),
+				statusResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": "production"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://hooks.example.com/2", httpCtx.Requests[1].URL.String())
+		payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "rec-2", payload["recipientId"])
+		assert.Equal(t, "SuperPlane (prod-env/production) #a83d5e20", payload["recipientName"])
+	})
+
+	t.Run("no recipient for dataset -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, testRecipientsResponse),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": "billing"},
+		})
+
+		require.ErrorContains(t, err, `recipient "SuperPlane (prod-env/billing)" not found`)
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("webhook rejects notification -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, testRecipientsResponse),
+				statusResponse(http.StatusForbidden, `invalid token`),
+			},
+		}
+
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			ExecutionState: execState,
+			HTTP:           httpCtx,
+			Configuration:  map[string]any{"dataset": "production"},
+		})
+
+		require.ErrorContains(t, err, "test notification to recipient rec-1 was rejected (http 403)")
+		assert.Empty(t, execState.Payloads)
+	})
+}
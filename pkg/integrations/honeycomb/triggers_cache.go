@@ -0,0 +1,66 @@
+package honeycomb
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const triggersCacheTTL = 30 * time.Second
+
+// sharedTriggersCache keeps the trigger lists requested by the trigger resource picker,
+// which is opened again and again while a node is being configured.
+// It is shared by all clients of the process, so updates to a trigger
+// made by any client invalidate the list the picker sees.
+var sharedTriggersCache = newTriggersCache(triggersCacheTTL)
+
+// triggersCache is a short-lived cache of trigger lists, keyed by
+// integration, environment and dataset.
+type triggersCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]triggersCacheEntry
+}
+
+type triggersCacheEntry struct {
+	triggers  []HoneycombTrigger
+	expiresAt time.Time
+}
+
+func newTriggersCache(ttl time.Duration) *triggersCache {
+	return &triggersCache{ttl: ttl, entries: map[string]triggersCacheEntry{}}
+}
+
+func (c *triggersCache) Get(key string) ([]HoneycombTrigger, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return slices.Clone(entry.triggers), true
+}
+
+func (c *triggersCache) Set(key string, triggers []HoneycombTrigger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = triggersCacheEntry{
+		triggers:  slices.Clone(triggers),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *triggersCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
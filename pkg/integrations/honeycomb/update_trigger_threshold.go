@@ -0,0 +1,316 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// Comparison operators of Honeycomb trigger thresholds.
+var AllThresholdOps = []configuration.FieldOption{
+	{Label: "Greater than (>)", Value: ">"},
+	{Label: "Greater than or equal (>=)", Value: ">="},
+	{Label: "Less than (<)", Value: "<"},
+	{Label: "Less than or equal (<=)", Value: "<="},
+}
+
+type UpdateTriggerThreshold struct{}
+
+type UpdateTriggerThresholdConfiguration struct {
+	Environment string `json:"environment" mapstructure:"environment"`
+	DatasetSlug string `json:"datasetSlug" mapstructure:"datasetSlug"`
+	Trigger     string `json:"trigger" mapstructure:"trigger"`
+
+	//
+	// ThresholdOp is left empty to keep the operator of the trigger.
+	//
+	ThresholdOp    string `json:"thresholdOp" mapstructure:"thresholdOp"`
+	ThresholdValue any    `json:"thresholdValue" mapstructure:"thresholdValue"`
+}
+
+func (c *UpdateTriggerThreshold) Name() string {
+	return "honeycomb.updateTriggerThreshold"
+}
+
+func (c *UpdateTriggerThreshold) Label() string {
+	return "Update Trigger Threshold"
+}
+
+func (c *UpdateTriggerThreshold) Description() string {
+	return "Change the threshold of a Honeycomb trigger"
+}
+
+func (c *UpdateTriggerThreshold) Icon() string {
+	return "honeycomb"
+}
+
+func (c *UpdateTriggerThreshold) Color() string {
+	return "gray"
+}
+
+func (c *UpdateTriggerThreshold) Documentation() string {
+	return `
+Changes the threshold of a Honeycomb trigger, for example to tighten or relax an alert during an incident.
+
+Notes:
+• The trigger is looked up by name (case-insensitive) in the dataset and in the environment-wide triggers
+• The update fails when several triggers share the name, so the wrong trigger is never changed
+• Leave "Operator" empty to keep the operator of the trigger and only change the value
+• Threshold can be an expression resolved from the execution input, and must resolve to a number
+• The output includes the threshold before and after the update, so the change can be audited
+• The configuration key needs the "Manage Triggers" permission
+`
+}
+
+func (c *UpdateTriggerThreshold) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *UpdateTriggerThreshold) Configuration() []configuration.Field {
+	return []configuration.Field{
+		environmentField(),
+		{
+			Name:        "datasetSlug",
+			Label:       "Dataset Slug",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The dataset slug containing the Honeycomb trigger.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:       "dataset",
+					Parameters: []configuration.ParameterRef{environmentParameter()},
+				},
+			},
+		},
+		{
+			Name:        "trigger",
+			Label:       "Trigger",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The name of the Honeycomb trigger to update (case-insensitive).",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "trigger",
+					UseNameAsValue: true,
+					Parameters:     triggerResourceParameters(),
+				},
+			},
+		},
+		{
+			Name:        "thresholdOp",
+			Label:       "Operator",
+			Type:        configuration.FieldTypeSelect,
+			Required:    false,
+			Description: "The comparison of the threshold. Keeps the operator of the trigger when empty.",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllThresholdOps,
+				},
+			},
+		},
+		{
+			Name:        "thresholdValue",
+			Label:       "Threshold",
+			Type:        configuration.FieldTypeNumber,
+			Required:    true,
+			Description: "The new threshold value of the trigger",
+		},
+	}
+}
+
+func (c *UpdateTriggerThreshold) Setup(ctx core.SetupContext) error {
+	var cfg UpdateTriggerThresholdConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if ctx.Integration != nil && !configurationKeyPermissions(ctx.Integration).ManageTriggers {
+		return fmt.Errorf("the Honeycomb configuration key is missing the %s permission required by the Update Trigger Threshold component; add it to the integration's configuration key permissions", PermissionManageTriggers)
+	}
+
+	//
+	// Thresholds built from expressions are only known at execution time.
+	//
+	if text, ok := cfg.ThresholdValue.(string); ok && strings.Contains(text, "{{") {
+		cfg.ThresholdValue = 0
+	}
+
+	_, err := validateTriggerThresholdConfiguration(cfg)
+	return err
+}
+
+// validateTriggerThresholdConfiguration checks the configuration and returns the threshold value.
+func validateTriggerThresholdConfiguration(cfg UpdateTriggerThresholdConfiguration) (float64, error) {
+	if strings.TrimSpace(cfg.DatasetSlug) == "" {
+		return 0, errors.New("datasetSlug is required")
+	}
+
+	if strings.TrimSpace(cfg.Trigger) == "" {
+		return 0, errors.New("trigger is required")
+	}
+
+	if cfg.ThresholdOp != "" && !slices.ContainsFunc(AllThresholdOps, func(o configuration.FieldOption) bool { return o.Value == cfg.ThresholdOp }) {
+		return 0, fmt.Errorf("invalid threshold operator %q", cfg.ThresholdOp)
+	}
+
+	return thresholdValue(cfg.ThresholdValue)
+}
+
+// thresholdValue reads the threshold, set as a number or as a numeric string by expressions.
+func thresholdValue(value any) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, errors.New("threshold is required")
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, errors.New("threshold is required")
+		}
+
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("threshold %q is not a number", v)
+		}
+
+		return n, nil
+	default:
+		return 0, fmt.Errorf("threshold %v is not a number", value)
+	}
+}
+
+func (c *UpdateTriggerThreshold) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *UpdateTriggerThreshold) Execute(ctx core.ExecutionContext) error {
+	var cfg UpdateTriggerThresholdConfiguration
+	if err := mapstructure.Decode(ctx.Configuration, &cfg); err != nil {
+		return err
+	}
+
+	value, err := validateTriggerThresholdConfiguration(cfg)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+
+	datasetSlug := strings.TrimSpace(cfg.DatasetSlug)
+	triggerName := strings.TrimSpace(cfg.Trigger)
+	match, err := findTriggerToUpdate(client, datasetSlug, triggerName)
+	if err != nil {
+		return err
+	}
+
+	triggerDatasetSlug, _ := match.Raw["dataset_slug"].(string)
+	triggerDatasetSlug = strings.TrimSpace(triggerDatasetSlug)
+	if triggerDatasetSlug == "" {
+		triggerDatasetSlug = datasetSlug
+	}
+
+	trigger, err := client.GetTrigger(triggerDatasetSlug, match.ID)
+	if err != nil {
+		return err
+	}
+
+	threshold, _ := trigger["threshold"].(map[string]any)
+	before := map[string]any{"op": threshold["op"], "value": threshold["value"]}
+
+	updated := maps.Clone(threshold)
+	if updated == nil {
+		updated = map[string]any{}
+	}
+	updated["value"] = value
+	if cfg.ThresholdOp != "" {
+		updated["op"] = cfg.ThresholdOp
+	}
+	if _, ok := updated["op"].(string); !ok {
+		return fmt.Errorf("trigger %q has no threshold operator; set the operator to update it", match.Name)
+	}
+
+	trigger["threshold"] = updated
+	stripTriggerForUpdate(trigger)
+	if err := client.UpdateTrigger(triggerDatasetSlug, match.ID, trigger); err != nil {
+		return err
+	}
+
+	output := map[string]any{
+		"status":      core.ResultStatusSuccess,
+		"dataset":     triggerDatasetSlug,
+		"triggerId":   match.ID,
+		"triggerName": match.Name,
+		"before":      before,
+		"after":       map[string]any{"op": updated["op"], "value": value},
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"honeycomb.trigger.updated",
+		[]any{output},
+	)
+}
+
+// findTriggerToUpdate returns the only trigger with the name in the dataset or in the environment.
+// Honeycomb does not require trigger names to be unique, and the update must not pick one at random.
+func findTriggerToUpdate(client *Client, datasetSlug, name string) (*HoneycombTrigger, error) {
+	triggers, err := listDatasetAndEnvironmentTriggers(client, datasetSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list triggers: %w", err)
+	}
+
+	var matches []HoneycombTrigger
+	for _, trigger := range triggers {
+		if strings.EqualFold(strings.TrimSpace(trigger.Name), name) {
+			matches = append(matches, trigger)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("trigger with name %q not found in dataset %q", name, datasetSlug)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d triggers are named %q in dataset %q; rename them so the trigger to update is unique", len(matches), name, datasetSlug)
+	}
+}
+
+func (c *UpdateTriggerThreshold) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *UpdateTriggerThreshold) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *UpdateTriggerThreshold) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *UpdateTriggerThreshold) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *UpdateTriggerThreshold) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,228 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__UpdateTriggerThreshold__Setup(t *testing.T) {
+	component := &UpdateTriggerThreshold{}
+
+	t.Run("missing datasetSlug -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"trigger": "High Error Rate", "thresholdValue": 10},
+		})
+		require.ErrorContains(t, err, "datasetSlug is required")
+	})
+
+	t.Run("missing trigger -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "thresholdValue": 10},
+		})
+		require.ErrorContains(t, err, "trigger is required")
+	})
+
+	t.Run("missing threshold -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate"},
+		})
+		require.ErrorContains(t, err, "threshold is required")
+	})
+
+	t.Run("non-numeric threshold -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": "high"},
+		})
+		require.ErrorContains(t, err, `threshold "high" is not a number`)
+	})
+
+	t.Run("unknown operator -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdOp": "!=", "thresholdValue": 10},
+		})
+		require.ErrorContains(t, err, `invalid threshold operator "!="`)
+	})
+
+	t.Run("configuration key without manage_triggers -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration: &contexts.IntegrationContext{
+				Metadata: Metadata{ConfigurationKeyPermissions: []string{PermissionRunQueries}},
+			},
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": 10},
+		})
+		require.ErrorContains(t, err, "missing the manage_triggers permission")
+	})
+
+	t.Run("threshold from expression -> validated at execution time", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": `{{ $["Incident"].data.threshold }}`},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("valid configuration -> success", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdOp": ">=", "thresholdValue": 7.5},
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test__UpdateTriggerThreshold__Execute(t *testing.T) {
+	component := &UpdateTriggerThreshold{}
+
+	trigger := `{"id":"t1","name":"High Error Rate","dataset_slug":"production","query_id":"q1","query":{"calculations":[{"op":"COUNT"}]},"threshold":{"op":">","value":5,"exceeded_limit":2},"frequency":300,"triggered":true,"created_at":"2024-01-01T00:00:00Z","recipients":[{"id":"rcp-1"}]}`
+
+	t.Run("trigger found -> threshold updated and before/after emitted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"t1","name":"High Error Rate","dataset_slug":"production"},{"id":"t2","name":"Latency"}]`),
+				jsonResponse(`[]`),
+				jsonResponse(trigger),
+				jsonResponse(`{}`),
+			},
+		}
+		execState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			HTTP:           httpCtx,
+			ExecutionState: execState,
+			Configuration: map[string]any{
+				"datasetSlug":    "production",
+				"trigger":        "high error rate",
+				"thresholdOp":    ">=",
+				"thresholdValue": "10",
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 4)
+		assert.Equal(t, "https://api.honeycomb.io/1/triggers/production/t1", httpCtx.Requests[2].URL.String())
+		assert.Equal(t, http.MethodPut, httpCtx.Requests[3].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/triggers/production/t1", httpCtx.Requests[3].URL.String())
+
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[3].Body).Decode(&body))
+		assert.Equal(t, map[string]any{"op": ">=", "value": float64(10), "exceeded_limit": float64(2)}, body["threshold"])
+		assert.Equal(t, float64(300), body["frequency"])
+		assert.Equal(t, []any{map[string]any{"id": "rcp-1"}}, body["recipients"])
+		assert.NotContains(t, body, "id")
+		assert.NotContains(t, body, "query")
+		assert.NotContains(t, body, "triggered")
+		assert.NotContains(t, body, "created_at")
+
+		require.Len(t, execState.Payloads, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "honeycomb.trigger.updated", execState.Type)
+		assert.Equal(t, "t1", data["triggerId"])
+		assert.Equal(t, "High Error Rate", data["triggerName"])
+		assert.Equal(t, map[string]any{"op": ">", "value": float64(5)}, data["before"])
+		assert.Equal(t, map[string]any{"op": ">=", "value": float64(10)}, data["after"])
+	})
+
+	t.Run("no operator -> operator of the trigger kept", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"t1","name":"High Error Rate"}]`),
+				jsonResponse(`[]`),
+				jsonResponse(trigger),
+				jsonResponse(`{}`),
+			},
+		}
+		execState := &contexts.ExecutionStateContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			HTTP:           httpCtx,
+			ExecutionState: execState,
+			Configuration:  map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": 2.5},
+		})
+
+		require.NoError(t, err)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, map[string]any{"op": ">", "value": 2.5}, data["after"])
+	})
+
+	t.Run("environment-wide trigger -> updated in the environment", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[]`),
+				jsonResponse(`[{"id":"t9","name":"High Error Rate","dataset_slug":"__all__"}]`),
+				jsonResponse(`{"id":"t9","name":"High Error Rate","threshold":{"op":">","value":5}}`),
+				jsonResponse(`{}`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			HTTP:           httpCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Configuration:  map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": 10},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.honeycomb.io/1/triggers/__all__/t9", httpCtx.Requests[3].URL.String())
+	})
+
+	t.Run("several triggers with the name -> error without updating", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(`[{"id":"t1","name":"High Error Rate"},{"id":"t3","name":"high error rate"}]`),
+				jsonResponse(`[]`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			HTTP:           httpCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Configuration:  map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": 10},
+		})
+
+		require.ErrorContains(t, err, `2 triggers are named "High Error Rate" in dataset "production"`)
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+
+	t.Run("unknown trigger -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(`[]`), jsonResponse(`[]`)},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			HTTP:           httpCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Configuration:  map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": 10},
+		})
+
+		require.ErrorContains(t, err, `trigger with name "High Error Rate" not found in dataset "production"`)
+	})
+
+	t.Run("threshold resolved to a non-number -> error before calling Honeycomb", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Integration:    honeycombWebhookIntegration(),
+			HTTP:           httpCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+			Configuration:  map[string]any{"datasetSlug": "production", "trigger": "High Error Rate", "thresholdValue": "ten"},
+		})
+
+		require.ErrorContains(t, err, `threshold "ten" is not a number`)
+		assert.Empty(t, httpCtx.Requests)
+	})
+}
@@ -2,20 +2,140 @@ package honeycomb
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/core"
 )
 
+const recipientNameIDLength = 8
+
+// webhookSetupRetryInterval is the minimum wait between attempts to provision a dataset webhook.
+// An attempt can create a recipient and update several triggers and burn alerts,
+// which count against the rate limits of the Honeycomb API.
+const webhookSetupRetryInterval = time.Minute
+
+// referenceSeparator separates the reference of a node from the trigger
+// in the references of a node that listens to several triggers.
+const referenceSeparator = ":"
+
+// referenceOwner returns the reference of the node a webhook reference belongs to.
+func referenceOwner(reference string) string {
+	owner, _, _ := strings.Cut(reference, referenceSeparator)
+	return owner
+}
+
+// WebhookConfiguration is the configuration of a webhook shared by the triggers of a dataset.
+// An empty Environment is the default environment of the integration.
+//
+// References maps the reference of each On Alert Fired node using the webhook to its
+// Honeycomb trigger, so a node can release its trigger without affecting the others.
+// BurnAlertReferences does the same for On SLO Burn Alert nodes and their burn alerts.
+// TriggerIDs are only set by nodes set up before references were tracked,
+// and are kept for as long as the webhook exists.
 type WebhookConfiguration struct {
-	DatasetSlug string   `json:"datasetSlug" mapstructure:"datasetSlug"`
-	TriggerIDs  []string `json:"triggerIds" mapstructure:"triggerIds"`
+	Environment         string            `json:"environment,omitempty" mapstructure:"environment"`
+	DatasetSlug         string            `json:"datasetSlug" mapstructure:"datasetSlug"`
+	TriggerIDs          []string          `json:"triggerIds" mapstructure:"triggerIds"`
+	References          map[string]string `json:"references,omitempty" mapstructure:"references"`
+	BurnAlertReferences map[string]string `json:"burnAlertReferences,omitempty" mapstructure:"burnAlertReferences"`
 }
 
+// triggerReferences counts the references to each trigger of the webhook.
+func (c WebhookConfiguration) triggerReferences() map[string]int {
+	references := map[string]int{}
+	for _, tid := range c.TriggerIDs {
+		tid = strings.TrimSpace(tid)
+		if tid != "" {
+			references[tid]++
+		}
+	}
+
+	for _, tid := range c.References {
+		tid = strings.TrimSpace(tid)
+		if tid != "" {
+			references[tid]++
+		}
+	}
+
+	return references
+}
+
+// burnAlertReferences counts the references to each burn alert of the webhook.
+func (c WebhookConfiguration) burnAlertReferences() map[string]int {
+	references := map[string]int{}
+	for _, id := range c.BurnAlertReferences {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			references[id]++
+		}
+	}
+
+	return references
+}
+
+// WebhookMetadata tracks the Honeycomb recipients created for a webhook, keyed by dataset slug.
+// RecipientID is only set on webhooks created before recipients were tracked per dataset.
 type WebhookMetadata struct {
-	RecipientID string `json:"recipientId" mapstructure:"recipientId"`
+	RecipientID string                       `json:"recipientId,omitempty" mapstructure:"recipientId"`
+	Recipients  map[string]RecipientMetadata `json:"recipients,omitempty" mapstructure:"recipients"`
+}
+
+// RecipientMetadata is a recipient and the triggers and burn alerts it is attached to.
+// References and BurnAlertReferences count the references to each of those. The recipient
+// is deleted once it had references and the last of them is released.
+type RecipientMetadata struct {
+	ID                  string         `json:"id" mapstructure:"id"`
+	Name                string         `json:"name" mapstructure:"name"`
+	Target              string         `json:"target" mapstructure:"target"`
+	TriggerIDs          []string       `json:"triggerIds" mapstructure:"triggerIds"`
+	References          map[string]int `json:"references,omitempty" mapstructure:"references"`
+	BurnAlertIDs        []string       `json:"burnAlertIds,omitempty" mapstructure:"burnAlertIds"`
+	BurnAlertReferences map[string]int `json:"burnAlertReferences,omitempty" mapstructure:"burnAlertReferences"`
+}
+
+func (r RecipientMetadata) hadReferences() bool {
+	return len(r.References) > 0 || len(r.BurnAlertReferences) > 0
+}
+
+// recipientName names the recipient after the environment and dataset it serves,
+// so recipients of different datasets can be told apart in Honeycomb.
+// The webhook ID suffix keeps the recipients of different canvases on the same
+// dataset from colliding, since Honeycomb rejects duplicate webhook recipients.
+func recipientName(environmentSlug, datasetSlug, webhookID string) string {
+	name := recipientBaseName(environmentSlug, datasetSlug)
+	if webhookID == "" {
+		return name
+	}
+
+	if len(webhookID) > recipientNameIDLength {
+		webhookID = webhookID[:recipientNameIDLength]
+	}
+
+	return fmt.Sprintf("%s #%s", name, webhookID)
+}
+
+func recipientBaseName(environmentSlug, datasetSlug string) string {
+	scope := datasetSlug
+	if datasetSlug == allDatasetsInEnvironmentScopeSlug {
+		scope = "all datasets"
+	}
+
+	if environmentSlug == "" {
+		return fmt.Sprintf("SuperPlane (%s)", scope)
+	}
+
+	return fmt.Sprintf("SuperPlane (%s/%s)", environmentSlug, scope)
+}
+
+// isRecipientNameFor reports whether a recipient name was given by recipientName
+// for the environment and dataset, with or without a webhook ID suffix.
+func isRecipientNameFor(name, environmentSlug, datasetSlug string) bool {
+	base := recipientBaseName(environmentSlug, datasetSlug)
+	return name == base || strings.HasPrefix(name, base+" #")
 }
 
 type HoneycombWebhookHandler struct{}
@@ -39,7 +159,7 @@ func (h *HoneycombWebhookHandler) CompareConfig(a, b any) (bool, error) {
 		return false, nil
 	}
 
-	return ca.DatasetSlug == cb.DatasetSlug, nil
+	return strings.TrimSpace(ca.Environment) == strings.TrimSpace(cb.Environment) && ca.DatasetSlug == cb.DatasetSlug, nil
 }
 
 func (h *HoneycombWebhookHandler) Merge(current, requested any) (any, bool, error) {
@@ -74,19 +194,90 @@ func (h *HoneycombWebhookHandler) Merge(current, requested any) (any, bool, erro
 		}
 	}
 
-	return cc, changed, nil
+	references, referencesChanged := mergeReferences(cc.References, rc.References)
+	burnAlertReferences, burnAlertReferencesChanged := mergeReferences(cc.BurnAlertReferences, rc.BurnAlertReferences)
+	cc.References = references
+	cc.BurnAlertReferences = burnAlertReferences
+
+	return cc, changed || referencesChanged || burnAlertReferencesChanged, nil
 }
 
-func (h *HoneycombWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
-	client, err := NewClient(ctx.HTTP, ctx.Integration)
-	if err != nil {
-		return nil, err
+// mergeReferences adds the requested references to the current ones.
+// A node always requests all of its current triggers or burn alerts,
+// so a node that moved to other ones releases the previous ones.
+func mergeReferences(current, requested map[string]string) (map[string]string, bool) {
+	changed := false
+	owners := map[string]bool{}
+	for reference := range requested {
+		owners[referenceOwner(reference)] = true
+	}
+
+	for reference := range current {
+		if _, ok := requested[reference]; !ok && owners[referenceOwner(reference)] {
+			delete(current, reference)
+			changed = true
+		}
+	}
+
+	for reference, id := range requested {
+		id = strings.TrimSpace(id)
+		if reference == "" || id == "" || current[reference] == id {
+			continue
+		}
+		if current == nil {
+			current = map[string]string{}
+		}
+		current[reference] = id
+		changed = true
+	}
+
+	return current, changed
+}
+
+// Release drops the references of an On Alert Fired or On SLO Burn Alert node
+// that no longer uses the webhook. Both keep their reference in referenceId.
+func (h *HoneycombWebhookHandler) Release(current, nodeMetadata any) (any, bool, error) {
+	cc := WebhookConfiguration{}
+	if err := mapstructure.Decode(current, &cc); err != nil {
+		return current, false, err
+	}
+
+	metadata := OnAlertFiredNodeMetadata{}
+	if err := mapstructure.Decode(nodeMetadata, &metadata); err != nil {
+		return current, false, nil
+	}
+
+	if metadata.ReferenceID == "" {
+		return current, false, nil
 	}
 
+	changed := false
+	for _, references := range []map[string]string{cc.References, cc.BurnAlertReferences} {
+		for reference := range references {
+			if referenceOwner(reference) == metadata.ReferenceID {
+				delete(references, reference)
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return current, false, nil
+	}
+
+	return cc, true, nil
+}
+
+func (h *HoneycombWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
 	cfg := WebhookConfiguration{}
 	if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &cfg); err != nil {
 		return nil, fmt.Errorf("error decoding webhook configuration: %w", err)
 	}
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return nil, err
+	}
 	cfg.DatasetSlug = strings.TrimSpace(cfg.DatasetSlug)
 	if cfg.DatasetSlug == "" {
 		return nil, fmt.Errorf("datasetSlug is required for webhook")
@@ -110,47 +301,187 @@ func (h *HoneycombWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, er
 		return nil, fmt.Errorf("webhook URL is empty")
 	}
 
-	var recipientID string
-	existingMeta := WebhookMetadata{}
-	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &existingMeta); err == nil && existingMeta.RecipientID != "" {
-		recipientID = existingMeta.RecipientID
-	} else {
-		recipient, err := client.CreateWebhookRecipient(webhookURL, secret)
+	meta := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &meta); err != nil {
+		meta = WebhookMetadata{}
+	}
+	if meta.Recipients == nil {
+		meta.Recipients = map[string]RecipientMetadata{}
+	}
+
+	recipient := meta.Recipients[cfg.DatasetSlug]
+	if recipient.ID == "" && meta.RecipientID != "" {
+		//
+		// Webhooks created before recipients were tracked per dataset
+		// only have the recipient ID, which is moved under the dataset.
+		//
+		recipient = RecipientMetadata{ID: meta.RecipientID, Target: webhookURL}
+		meta.RecipientID = ""
+	}
+
+	references := cfg.triggerReferences()
+	burnAlertReferences := cfg.burnAlertReferences()
+	if recipient.ID != "" && recipient.hadReferences() && len(references) == 0 && len(burnAlertReferences) == 0 {
+		//
+		// The last node referencing the recipient released it.
+		//
+		if err := detachRecipientFromBurnAlerts(client, cfg.DatasetSlug, recipient); err != nil {
+			return nil, err
+		}
+		if err := client.DeleteRecipient(recipient.ID, cfg.DatasetSlug); err != nil {
+			return nil, fmt.Errorf("failed to delete recipient %s: %w", recipient.ID, err)
+		}
+
+		delete(meta.Recipients, cfg.DatasetSlug)
+		return meta, nil
+	}
+
+	if recipient.ID == "" {
+		name := recipientName(client.environment, cfg.DatasetSlug, ctx.Webhook.GetID())
+		created, err := client.CreateWebhookRecipient(name, webhookURL, secret)
 		if err != nil {
 			return nil, err
 		}
-		recipientID = recipient.ID
+
+		recipient = RecipientMetadata{ID: created.ID, Name: name, Target: created.Target}
+	}
+
+	//
+	// Datasets can have dozens of triggers, so the recipient is attached to
+	// and detached from them concurrently, up to the concurrency of the integration.
+	// Each trigger is only updated once, and is recorded once it succeeded.
+	//
+	concurrency := syncedRecipientSetupConcurrency(ctx.Integration)
+	triggerIDs := slices.Sorted(maps.Keys(references))
+	errs := forEachConcurrently(triggerIDs, concurrency, func(tid string) error {
+		return client.EnsureRecipientOnTrigger(cfg.DatasetSlug, tid, recipient.ID, recipient.Name)
+	})
+	if err := joinRecipientErrors("attach recipient to", "trigger", triggerIDs, errs); err != nil {
+		return nil, err
+	}
+
+	for _, tid := range triggerIDs {
+		if !slices.Contains(recipient.TriggerIDs, tid) {
+			recipient.TriggerIDs = append(recipient.TriggerIDs, tid)
+		}
 	}
 
-	for _, tid := range cfg.TriggerIDs {
-		tid = strings.TrimSpace(tid)
-		if tid == "" {
-			continue
+	//
+	// Triggers no node references anymore stop notifying the recipient,
+	// while the triggers still referenced by other nodes keep it.
+	//
+	attached, released := partitionReferenced(recipient.TriggerIDs, references)
+	errs = forEachConcurrently(released, concurrency, func(tid string) error {
+		return client.RemoveRecipientFromTrigger(cfg.DatasetSlug, tid, recipient.ID)
+	})
+	if err := joinRecipientErrors("detach recipient from", "trigger", released, errs); err != nil {
+		return nil, err
+	}
+
+	recipient.TriggerIDs = attached
+	if len(references) > 0 {
+		recipient.References = references
+	}
+
+	burnAlertIDs := slices.Sorted(maps.Keys(burnAlertReferences))
+	errs = forEachConcurrently(burnAlertIDs, concurrency, func(id string) error {
+		return client.EnsureRecipientOnBurnAlert(cfg.DatasetSlug, id, recipient.ID)
+	})
+	if err := joinRecipientErrors("attach recipient to", "burn alert", burnAlertIDs, errs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range burnAlertIDs {
+		if !slices.Contains(recipient.BurnAlertIDs, id) {
+			recipient.BurnAlertIDs = append(recipient.BurnAlertIDs, id)
 		}
-		if err := client.EnsureRecipientOnTrigger(cfg.DatasetSlug, tid, recipientID); err != nil {
-			return nil, fmt.Errorf("failed to attach recipient to trigger %s: %w", tid, err)
+	}
+
+	attachedBurnAlerts, releasedBurnAlerts := partitionReferenced(recipient.BurnAlertIDs, burnAlertReferences)
+	errs = forEachConcurrently(releasedBurnAlerts, concurrency, func(id string) error {
+		return client.RemoveRecipientFromBurnAlert(cfg.DatasetSlug, id, recipient.ID)
+	})
+	if err := joinRecipientErrors("detach recipient from", "burn alert", releasedBurnAlerts, errs); err != nil {
+		return nil, err
+	}
+
+	if len(attachedBurnAlerts) == 0 {
+		attachedBurnAlerts = nil
+	}
+
+	recipient.BurnAlertIDs = attachedBurnAlerts
+	if len(burnAlertReferences) > 0 {
+		recipient.BurnAlertReferences = burnAlertReferences
+	}
+
+	meta.Recipients[cfg.DatasetSlug] = recipient
+	return meta, nil
+}
+
+// partitionReferenced splits the IDs the recipient is attached to into the ones still referenced
+// and the ones it is released from, keeping their order.
+func partitionReferenced(ids []string, references map[string]int) ([]string, []string) {
+	referenced := make([]string, 0, len(ids))
+	var released []string
+	for _, id := range ids {
+		if references[id] > 0 {
+			referenced = append(referenced, id)
+			continue
 		}
+
+		released = append(released, id)
 	}
 
-	return WebhookMetadata{RecipientID: recipientID}, nil
+	return referenced, released
 }
-func (h *HoneycombWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
-	client, err := NewClient(ctx.HTTP, ctx.Integration)
-	if err != nil {
-		return err
+
+// detachRecipientFromBurnAlerts removes the recipient from its burn alerts before it is deleted.
+// Triggers are not listed here, since DeleteRecipient looks them up in Honeycomb.
+func detachRecipientFromBurnAlerts(client *Client, datasetSlug string, recipient RecipientMetadata) error {
+	for _, id := range recipient.BurnAlertIDs {
+		if err := client.RemoveRecipientFromBurnAlert(datasetSlug, id, recipient.ID); err != nil {
+			return fmt.Errorf("failed to detach recipient %s from burn alert %s: %w", recipient.ID, id, err)
+		}
 	}
 
+	return nil
+}
+
+func (h *HoneycombWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
 	meta := WebhookMetadata{}
 	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &meta); err != nil {
 		return nil
 	}
-	if meta.RecipientID == "" {
-		return nil
-	}
 
 	cfg := WebhookConfiguration{}
 	if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &cfg); err != nil {
 		return nil
 	}
-	return client.DeleteRecipient(meta.RecipientID, cfg.DatasetSlug)
+
+	client, err := NewClientForEnvironment(ctx.HTTP, ctx.Integration, cfg.Environment)
+	if err != nil {
+		return err
+	}
+
+	if meta.RecipientID != "" {
+		if err := client.DeleteRecipient(meta.RecipientID, cfg.DatasetSlug); err != nil {
+			return err
+		}
+	}
+
+	datasets := slices.Sorted(maps.Keys(meta.Recipients))
+	for _, dataset := range datasets {
+		recipient := meta.Recipients[dataset]
+		if recipient.ID == "" {
+			continue
+		}
+		if err := detachRecipientFromBurnAlerts(client, dataset, recipient); err != nil {
+			return err
+		}
+		if err := client.DeleteRecipient(recipient.ID, dataset); err != nil {
+			return fmt.Errorf("failed to delete recipient %s for dataset %s: %w", recipient.ID, dataset, err)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,815 @@
+package honeycomb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+	workercontexts "github.com/superplanehq/superplane/pkg/workers/contexts"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func honeycombWebhookIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"managementKey":   "keyid:secret",
+			"site":            "api.honeycomb.io",
+			"environmentSlug": "prod-env",
+		},
+		Secrets: map[string]core.IntegrationSecret{
+			secretNameConfigurationKey: {Name: secretNameConfigurationKey, Value: []byte("test-config-key")},
+		},
+	}
+}
+
+// sequentialWebhookIntegration sets up recipients one trigger at a time,
+// for tests that answer the requests of the setup in order.
+func sequentialWebhookIntegration() *contexts.IntegrationContext {
+	integration := honeycombWebhookIntegration()
+	integration.Metadata = Metadata{RecipientSetupConcurrency: 1}
+	return integration
+}
+
+func Test__HoneycombWebhookHandler__Setup(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	t.Run("new dataset -> creates recipient named after environment and dataset", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusCreated, `{"id":"rcp-staging","type":"webhook"}`),
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[]}`),
+				statusResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:    "https://superplane.example.com/webhooks/1",
+				Secret: []byte("token"),
+				Metadata: map[string]any{
+					"recipients": map[string]any{
+						"production": map[string]any{"id": "rcp-production", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t0"}},
+					},
+				},
+				Configuration: map[string]any{"datasetSlug": "staging", "triggerIds": []any{"t1"}},
+			},
+		})
+
+		require.NoError(t, err)
+		meta := metadata.(WebhookMetadata)
+		require.Len(t, meta.Recipients, 2)
+		assert.Equal(t, "rcp-production", meta.Recipients["production"].ID)
+		assert.Equal(t, RecipientMetadata{
+			ID:         "rcp-staging",
+			Name:       "SuperPlane (prod-env/staging)",
+			Target:     "https://superplane.example.com/webhooks/1",
+			TriggerIDs: []string{"t1"},
+			References: map[string]int{"t1": 1},
+		}, meta.Recipients["staging"])
+
+		require.Len(t, httpCtx.Requests, 3)
+		createBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[0].Body).Decode(&createBody))
+		assert.Equal(t, "SuperPlane (prod-env/staging)", createBody["details"].(map[string]any)["webhook_name"])
+
+		assert.Equal(t, "https://api.honeycomb.io/1/triggers/staging/t1", httpCtx.Requests[1].URL.String())
+		updateBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[2].Body).Decode(&updateBody))
+		assert.Equal(t, []any{
+			map[string]any{"id": "rcp-staging", "type": "webhook", "target": "SuperPlane (prod-env/staging)"},
+		}, updateBody["recipients"])
+	})
+
+	t.Run("known dataset -> reuses recipient and records new triggers", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[{"id":"rcp-production"}]}`),
+				statusResponse(http.StatusOK, `{"id":"t2","recipients":[]}`),
+				statusResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: sequentialWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:    "https://superplane.example.com/webhooks/1",
+				Secret: []byte("token"),
+				Metadata: map[string]any{
+					"recipients": map[string]any{
+						"production": map[string]any{"id": "rcp-production", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t1"}},
+					},
+				},
+				Configuration: map[string]any{"datasetSlug": "production", "triggerIds": []any{"t1", "t2"}},
+			},
+		})
+
+		require.NoError(t, err)
+		meta := metadata.(WebhookMetadata)
+		require.Len(t, meta.Recipients, 1)
+		assert.Equal(t, "rcp-production", meta.Recipients["production"].ID)
+		assert.Equal(t, []string{"t1", "t2"}, meta.Recipients["production"].TriggerIDs)
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, http.MethodGet, httpCtx.Requests[0].Method)
+	})
+
+	t.Run("legacy recipient ID -> moved under the dataset without creating a recipient", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:           "https://superplane.example.com/webhooks/1",
+				Secret:        []byte("token"),
+				Metadata:      map[string]any{"recipientId": "rcp-legacy"},
+				Configuration: map[string]any{"datasetSlug": "production"},
+			},
+		})
+
+		require.NoError(t, err)
+		meta := metadata.(WebhookMetadata)
+		assert.Empty(t, meta.RecipientID)
+		assert.Equal(t, "rcp-legacy", meta.Recipients["production"].ID)
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("environment-wide dataset -> recipient named for all datasets", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusCreated, `{"id":"rcp-all","type":"webhook"}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:           "https://superplane.example.com/webhooks/2",
+				Secret:        []byte("token"),
+				Configuration: map[string]any{"datasetSlug": allDatasetsInEnvironmentScopeSlug},
+			},
+		})
+
+		require.NoError(t, err)
+		meta := metadata.(WebhookMetadata)
+		assert.Equal(t, "SuperPlane (prod-env/all datasets)", meta.Recipients[allDatasetsInEnvironmentScopeSlug].Name)
+	})
+
+	t.Run("other environment -> recipient is created with that environment's key", func(t *testing.T) {
+		integrationCtx := honeycombWebhookIntegration()
+		integrationCtx.Configuration["environmentSlug"] = "prod-env, staging-env"
+		integrationCtx.Secrets[secretNameConfigurationKey+"_staging-env"] = core.IntegrationSecret{
+			Name:  secretNameConfigurationKey + "_staging-env",
+			Value: []byte("staging-config-key"),
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusCreated, `{"id":"rcp-staging","type":"webhook"}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: integrationCtx,
+			Webhook: &contexts.WebhookContext{
+				URL:           "https://superplane.example.com/webhooks/3",
+				Secret:        []byte("token"),
+				Configuration: map[string]any{"environment": "staging-env", "datasetSlug": "api"},
+			},
+		})
+
+		require.NoError(t, err)
+		meta := metadata.(WebhookMetadata)
+		assert.Equal(t, "SuperPlane (staging-env/api)", meta.Recipients["api"].Name)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "staging-config-key", httpCtx.Requests[0].Header.Get("X-Honeycomb-Team"))
+	})
+}
+
+func Test__HoneycombWebhookHandler__Setup__RecipientNames(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	t.Run("two webhooks on the same dataset -> distinct recipient names", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusCreated, `{"id":"rcp-1","type":"webhook"}`),
+				statusResponse(http.StatusCreated, `{"id":"rcp-2","type":"webhook"}`),
+			},
+		}
+
+		names := []string{}
+		for _, webhookID := range []string{"0f4c2a9e-1111-4b7a-9d3e-6a2f1c8b7d01", "a83d5e20-2222-4c1b-8e4f-7b3a2d9c8e02"} {
+			metadata, err := handler.Setup(core.WebhookHandlerContext{
+				HTTP:        httpCtx,
+				Integration: honeycombWebhookIntegration(),
+				Webhook: &contexts.WebhookContext{
+					ID:            webhookID,
+					URL:           "https://superplane.example.com/webhooks/" + webhookID,
+					Secret:        []byte("token"),
+					Configuration: map[string]any{"datasetSlug": "production"},
+				},
+			})
+
+			require.NoError(t, err)
+			names = append(names, metadata.(WebhookMetadata).Recipients["production"].Name)
+		}
+
+		assert.Equal(t, []string{"SuperPlane (prod-env/production) #0f4c2a9e", "SuperPlane (prod-env/production) #a83d5e20"}, names)
+
+		require.Len(t, httpCtx.Requests, 2)
+		for i, request := range httpCtx.Requests {
+			assert.Equal(t, "test-config-key", request.Header.Get("X-Honeycomb-Team"))
+			createBody := map[string]any{}
+			require.NoError(t, json.NewDecoder(request.Body).Decode(&createBody))
+			assert.Equal(t, names[i], createBody["details"].(map[string]any)["webhook_name"])
+		}
+	})
+
+	t.Run("existing recipient for the webhook URL -> reused", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusConflict, `{"error":"recipient already exists"}`),
+				statusResponse(http.StatusOK, `[
+					{"id":"rcp-email","type":"email","details":{"email_address":"oncall@example.com"}},
+					{"id":"rcp-other","type":"webhook","details":{"webhook_name":"Other","webhook_url":"https://superplane.example.com/webhooks/2"}},
+					{"id":"rcp-existing","type":"webhook","details":{"webhook_name":"SuperPlane (prod-env/production) #0f4c2a9e","webhook_url":"https://superplane.example.com/webhooks/1"}}
+				]`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				ID:            "0f4c2a9e-1111-4b7a-9d3e-6a2f1c8b7d01",
+				URL:           "https://superplane.example.com/webhooks/1",
+				Secret:        []byte("token"),
+				Configuration: map[string]any{"datasetSlug": "production"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, http.MethodGet, httpCtx.Requests[1].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients", httpCtx.Requests[1].URL.String())
+
+		recipient := metadata.(WebhookMetadata).Recipients["production"]
+		assert.Equal(t, "rcp-existing", recipient.ID)
+		assert.Equal(t, "https://superplane.example.com/webhooks/1", recipient.Target)
+	})
+
+	t.Run("existing recipient name with another URL -> error names the recipient", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusConflict, `{"error":"recipient already exists"}`),
+				statusResponse(http.StatusOK, `[{"id":"rcp-other","type":"webhook","details":{"webhook_url":"https://superplane.example.com/webhooks/2"}}]`),
+			},
+		}
+
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				ID:            "0f4c2a9e-1111-4b7a-9d3e-6a2f1c8b7d01",
+				URL:           "https://superplane.example.com/webhooks/1",
+				Secret:        []byte("token"),
+				Configuration: map[string]any{"datasetSlug": "production"},
+			},
+		})
+
+		require.ErrorContains(t, err, `Delete the "SuperPlane (prod-env/production) #0f4c2a9e" recipient`)
+	})
+}
+
+func Test__HoneycombWebhookHandler__ReferenceCounting(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	setup := func(httpCtx *contexts.HTTPContext, recipient map[string]any, references map[string]any) (WebhookMetadata, error) {
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:           "https://superplane.example.com/webhooks/1",
+				Secret:        []byte("token"),
+				Metadata:      map[string]any{"recipients": map[string]any{"production": recipient}},
+				Configuration: map[string]any{"datasetSlug": "production", "references": references},
+			},
+		})
+
+		if err != nil {
+			return WebhookMetadata{}, err
+		}
+
+		return metadata.(WebhookMetadata), nil
+	}
+
+	t.Run("node moves to a trigger of another node -> previous trigger is detached", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[{"id":"rcp-1"}]}`),
+				statusResponse(http.StatusOK, `{"id":"t2","recipients":[{"id":"rcp-1"},{"id":"rcp-email"}]}`),
+				statusResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t1", "t2"}, "references": map[string]any{"t1": 1, "t2": 1}},
+			map[string]any{"node-a": "t1", "node-b": "t1"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t1"}, meta.Recipients["production"].TriggerIDs)
+		assert.Equal(t, map[string]int{"t1": 2}, meta.Recipients["production"].References)
+
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, "https://api.honeycomb.io/1/triggers/production/t2", httpCtx.Requests[2].URL.String())
+		updateBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[2].Body).Decode(&updateBody))
+		assert.Equal(t, []any{map[string]any{"id": "rcp-email"}}, updateBody["recipients"])
+	})
+
+	t.Run("one of two references released -> recipient stays on the trigger", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[{"id":"rcp-1"}]}`),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t1"}, "references": map[string]any{"t1": 2}},
+			map[string]any{"node-b": "t1"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t1"}, meta.Recipients["production"].TriggerIDs)
+		assert.Equal(t, map[string]int{"t1": 1}, meta.Recipients["production"].References)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, http.MethodGet, httpCtx.Requests[0].Method)
+	})
+
+	t.Run("last reference released -> recipient is deleted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `[{"id":"t1"}]`),
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[{"id":"rcp-1"}]}`),
+				statusResponse(http.StatusOK, `{}`),
+				statusResponse(http.StatusNoContent, ``),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t1"}, "references": map[string]any{"t1": 1}},
+			map[string]any{},
+		)
+
+		require.NoError(t, err)
+		assert.NotContains(t, meta.Recipients, "production")
+		require.Len(t, httpCtx.Requests, 4)
+		assert.Equal(t, http.MethodDelete, httpCtx.Requests[3].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-1", httpCtx.Requests[3].URL.String())
+	})
+}
+
+func Test__HoneycombWebhookHandler__MergeAndRelease(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	t.Run("new reference -> added", func(t *testing.T) {
+		merged, changed, err := handler.Merge(
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1"}},
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-b": "t1"}},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]int{"t1": 2}, merged.(WebhookConfiguration).triggerReferences())
+	})
+
+	t.Run("same reference and trigger -> unchanged", func(t *testing.T) {
+		_, changed, err := handler.Merge(
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1"}},
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1"}},
+		)
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("reference moved to another trigger -> previous trigger loses it", func(t *testing.T) {
+		merged, changed, err := handler.Merge(
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1", "node-b": "t2"}},
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-b": "t1"}},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]int{"t1": 2}, merged.(WebhookConfiguration).triggerReferences())
+	})
+
+	t.Run("legacy trigger IDs -> counted as one reference each", func(t *testing.T) {
+		merged, _, err := handler.Merge(
+			map[string]any{"datasetSlug": "api", "triggerIds": []any{"t1"}},
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1"}},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"t1": 2}, merged.(WebhookConfiguration).triggerReferences())
+	})
+
+	t.Run("node listens to fewer triggers -> dropped references are released", func(t *testing.T) {
+		merged, changed, err := handler.Merge(
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1", "node-a:t2": "t2", "node-b": "t2"}},
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1"}},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]string{"node-a": "t1", "node-b": "t2"}, merged.(WebhookConfiguration).References)
+	})
+
+	t.Run("release node with several triggers -> all of its references dropped", func(t *testing.T) {
+		released, changed, err := handler.Release(
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1", "node-a:t2": "t2", "node-b": "t2"}},
+			map[string]any{"triggerId": "t1", "triggerIds": []any{"t1", "t2"}, "referenceId": "node-a"},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]string{"node-b": "t2"}, released.(WebhookConfiguration).References)
+	})
+
+	t.Run("release node reference -> dropped", func(t *testing.T) {
+		released, changed, err := handler.Release(
+			map[string]any{"datasetSlug": "api", "references": map[string]any{"node-a": "t1", "node-b": "t1"}},
+			map[string]any{"triggerId": "t1", "referenceId": "node-a"},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]string{"node-b": "t1"}, released.(WebhookConfiguration).References)
+	})
+
+	t.Run("release node without reference -> unchanged", func(t *testing.T) {
+		current := map[string]any{"datasetSlug": "api", "triggerIds": []any{"t1"}}
+		released, changed, err := handler.Release(current, map[string]any{"triggerId": "t1"})
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, current, released)
+	})
+}
+
+func Test__HoneycombWebhookHandler__CompareConfig(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	t.Run("same dataset and environment -> equal", func(t *testing.T) {
+		equal, err := handler.CompareConfig(
+			map[string]any{"datasetSlug": "api"},
+			map[string]any{"datasetSlug": "api", "environment": ""},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, equal)
+	})
+
+	t.Run("same dataset in different environments -> not equal", func(t *testing.T) {
+		equal, err := handler.CompareConfig(
+			map[string]any{"datasetSlug": "api"},
+			map[string]any{"datasetSlug": "api", "environment": "staging"},
+		)
+
+		require.NoError(t, err)
+		assert.False(t, equal)
+	})
+}
+
+func Test__HoneycombWebhookHandler__Cleanup(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	t.Run("multiple datasets -> deletes every recipient", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `[]`),
+				statusResponse(http.StatusNoContent, ``),
+				statusResponse(http.StatusOK, `[]`),
+				statusResponse(http.StatusNoContent, ``),
+			},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				Metadata: map[string]any{
+					"recipients": map[string]any{
+						"staging":    map[string]any{"id": "rcp-staging"},
+						"production": map[string]any{"id": "rcp-production"},
+					},
+				},
+				Configuration: map[string]any{"datasetSlug": "production"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 4)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-production/triggers", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, http.MethodDelete, httpCtx.Requests[1].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-production", httpCtx.Requests[1].URL.String())
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-staging/triggers", httpCtx.Requests[2].URL.String())
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-staging", httpCtx.Requests[3].URL.String())
+	})
+
+	t.Run("legacy recipient ID -> deletes it", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `[]`),
+				statusResponse(http.StatusNoContent, ``),
+			},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				Metadata:      map[string]any{"recipientId": "rcp-legacy"},
+				Configuration: map[string]any{"datasetSlug": "production"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-legacy", httpCtx.Requests[1].URL.String())
+	})
+}
+
+func Test__HoneycombWebhookHandler__BurnAlerts(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	setup := func(httpCtx *contexts.HTTPContext, recipient map[string]any, configuration map[string]any) (WebhookMetadata, error) {
+		configuration["datasetSlug"] = "production"
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: honeycombWebhookIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:           "https://superplane.example.com/webhooks/1",
+				Secret:        []byte("token"),
+				Metadata:      map[string]any{"recipients": map[string]any{"production": recipient}},
+				Configuration: configuration,
+			},
+		})
+
+		if err != nil {
+			return WebhookMetadata{}, err
+		}
+
+		return metadata.(WebhookMetadata), nil
+	}
+
+	t.Run("burn alert reference -> recipient attached to the burn alert", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[{"id":"rcp-1"}]}`),
+				statusResponse(http.StatusOK, `{"id":"ba-1","alert_type":"exhaustion_time","exhaustion_minutes":240,"slo":{"id":"slo-1"},"recipients":[{"id":"rcp-email"}],"created_at":"2024-01-01T00:00:00Z"}`),
+				statusResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t1"}, "references": map[string]any{"t1": 1}},
+			map[string]any{"references": map[string]any{"node-a": "t1"}, "burnAlertReferences": map[string]any{"node-b": "ba-1"}},
+		)
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, "https://api.honeycomb.io/1/triggers/production/t1", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "https://api.honeycomb.io/1/burn_alerts/production/ba-1", httpCtx.Requests[1].URL.String())
+		assert.Equal(t, http.MethodPut, httpCtx.Requests[2].Method)
+
+		updateBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[2].Body).Decode(&updateBody))
+		assert.Equal(t, []any{map[string]any{"id": "rcp-email"}, map[string]any{"id": "rcp-1"}}, updateBody["recipients"])
+		assert.Equal(t, float64(240), updateBody["exhaustion_minutes"])
+		assert.NotContains(t, updateBody, "id")
+		assert.NotContains(t, updateBody, "created_at")
+
+		assert.Equal(t, []string{"ba-1"}, meta.Recipients["production"].BurnAlertIDs)
+		assert.Equal(t, map[string]int{"ba-1": 1}, meta.Recipients["production"].BurnAlertReferences)
+		assert.Equal(t, map[string]int{"t1": 1}, meta.Recipients["production"].References)
+	})
+
+	t.Run("burn alert no longer referenced -> recipient detached from it", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `{"id":"t1","recipients":[{"id":"rcp-1"}]}`),
+				statusResponse(http.StatusOK, `{"id":"ba-1","recipients":[{"id":"rcp-1"},{"id":"rcp-email"}]}`),
+				statusResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "triggerIds": []any{"t1"}, "burnAlertIds": []any{"ba-1"}, "references": map[string]any{"t1": 1}, "burnAlertReferences": map[string]any{"ba-1": 1}},
+			map[string]any{"references": map[string]any{"node-a": "t1"}},
+		)
+
+		require.NoError(t, err)
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, "https://api.honeycomb.io/1/burn_alerts/production/ba-1", httpCtx.Requests[2].URL.String())
+		updateBody := map[string]any{}
+		require.NoError(t, json.NewDecoder(httpCtx.Requests[2].Body).Decode(&updateBody))
+		assert.Equal(t, []any{map[string]any{"id": "rcp-email"}}, updateBody["recipients"])
+		assert.Nil(t, meta.Recipients["production"].BurnAlertIDs)
+	})
+
+	t.Run("last burn alert reference released -> recipient detached and deleted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusOK, `{"id":"ba-1","recipients":[{"id":"rcp-1"}]}`),
+				statusResponse(http.StatusOK, `{}`),
+				statusResponse(http.StatusOK, `[]`),
+				statusResponse(http.StatusNoContent, ``),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "burnAlertIds": []any{"ba-1"}, "burnAlertReferences": map[string]any{"ba-1": 1}},
+			map[string]any{},
+		)
+
+		require.NoError(t, err)
+		assert.NotContains(t, meta.Recipients, "production")
+		require.Len(t, httpCtx.Requests, 4)
+		assert.Equal(t, http.MethodPut, httpCtx.Requests[1].Method)
+		assert.Equal(t, http.MethodDelete, httpCtx.Requests[3].Method)
+		assert.Equal(t, "https://api.honeycomb.io/1/recipients/rcp-1", httpCtx.Requests[3].URL.String())
+	})
+
+	t.Run("burn alert removed in Honeycomb -> detaching it succeeds", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				statusResponse(http.StatusNotFound, `{"error":"not found"}`),
+				statusResponse(http.StatusOK, `[]`),
+				statusResponse(http.StatusNoContent, ``),
+			},
+		}
+
+		meta, err := setup(httpCtx,
+			map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)", "burnAlertIds": []any{"ba-1"}, "burnAlertReferences": map[string]any{"ba-1": 1}},
+			map[string]any{},
+		)
+
+		require.NoError(t, err)
+		assert.NotContains(t, meta.Recipients, "production")
+		require.Len(t, httpCtx.Requests, 3)
+	})
+}
+
+// concurrentTriggersHTTPContext answers the trigger requests of concurrent setups by URL,
+// recording the most requests it had in flight at once. Updates of the triggers in
+// failing are rejected.
+type concurrentTriggersHTTPContext struct {
+	mu          sync.Mutex
+	failing     map[string]bool
+	inFlight    int
+	maxInFlight int
+	updated     map[string]int
+}
+
+func (c *concurrentTriggersHTTPContext) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+
+	triggerID := path.Base(req.URL.Path)
+	if req.Method == http.MethodGet {
+		return statusResponse(http.StatusOK, fmt.Sprintf(`{"id":%q,"recipients":[]}`, triggerID)), nil
+	}
+
+	if c.failing[triggerID] {
+		return statusResponse(http.StatusInternalServerError, `{"error":"unavailable"}`), nil
+	}
+
+	if c.updated == nil {
+		c.updated = map[string]int{}
+	}
+	c.updated[triggerID]++
+	return statusResponse(http.StatusOK, `{}`), nil
+}
+
+func Test__HoneycombWebhookHandler__ConcurrentSetup(t *testing.T) {
+	handler := &HoneycombWebhookHandler{}
+
+	references := map[string]any{}
+	for i := 1; i <= 12; i++ {
+		references[fmt.Sprintf("node-%d", i)] = fmt.Sprintf("t%02d", i)
+	}
+
+	setup := func(httpCtx core.HTTPContext, concurrency int) (any, error) {
+		integration := honeycombWebhookIntegration()
+		integration.Metadata = Metadata{RecipientSetupConcurrency: concurrency}
+
+		return handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpCtx,
+			Integration: integration,
+			Webhook: &contexts.WebhookContext{
+				URL:    "https://superplane.example.com/webhooks/1",
+				Secret: []byte("token"),
+				Metadata: map[string]any{
+					"recipients": map[string]any{
+						"production": map[string]any{"id": "rcp-1", "name": "SuperPlane (prod-env/production)"},
+					},
+				},
+				Configuration: map[string]any{"datasetSlug": "production", "references": references},
+			},
+		})
+	}
+
+	t.Run("many triggers -> attached with at most the configured concurrency", func(t *testing.T) {
+		httpCtx := &concurrentTriggersHTTPContext{}
+		metadata, err := setup(httpCtx, 3)
+
+		require.NoError(t, err)
+		assert.LessOrEqual(t, httpCtx.maxInFlight, 3)
+		assert.Greater(t, httpCtx.maxInFlight, 1)
+
+		recipient := metadata.(WebhookMetadata).Recipients["production"]
+		require.Len(t, recipient.TriggerIDs, 12)
+		assert.Equal(t, "t01", recipient.TriggerIDs[0])
+		assert.Equal(t, "t12", recipient.TriggerIDs[11])
+		for _, tid := range recipient.TriggerIDs {
+			assert.Equal(t, 1, httpCtx.updated[tid], "trigger %s", tid)
+		}
+	})
+
+	t.Run("no concurrency configured -> default limit", func(t *testing.T) {
+		httpCtx := &concurrentTriggersHTTPContext{}
+		_, err := setup(httpCtx, 0)
+
+		require.NoError(t, err)
+		assert.LessOrEqual(t, httpCtx.maxInFlight, defaultRecipientSetupConcurrency)
+	})
+
+	t.Run("concurrency above the maximum -> capped", func(t *testing.T) {
+		assert.Equal(t, maxRecipientSetupConcurrency, recipientSetupConcurrency(map[string]any{"recipientSetupConcurrency": float64(500)}))
+		assert.Equal(t, maxRecipientSetupConcurrency, syncedRecipientSetupConcurrency(&contexts.IntegrationContext{
+			Metadata: Metadata{RecipientSetupConcurrency: 500},
+		}))
+	})
+
+	t.Run("concurrency not a positive number -> default limit", func(t *testing.T) {
+		assert.Equal(t, defaultRecipientSetupConcurrency, recipientSetupConcurrency(map[string]any{"recipientSetupConcurrency": "8"}))
+		assert.Equal(t, defaultRecipientSetupConcurrency, recipientSetupConcurrency(map[string]any{"recipientSetupConcurrency": float64(0)}))
+		assert.Equal(t, defaultRecipientSetupConcurrency, recipientSetupConcurrency(map[string]any{}))
+	})
+
+	t.Run("concurrency recorded on the integration context of the workers -> configured limit", func(t *testing.T) {
+		integration := workercontexts.NewIntegrationContext(nil, nil, &models.Integration{}, nil, nil)
+		integration.SetMetadata(Metadata{
+			RecipientSetupConcurrency: recipientSetupConcurrency(map[string]any{"recipientSetupConcurrency": float64(7)}),
+		})
+
+		assert.Equal(t, 7, syncedRecipientSetupConcurrency(integration))
+	})
+
+	t.Run("some triggers fail -> error names all of them and the others are attached", func(t *testing.T) {
+		httpCtx := &concurrentTriggersHTTPContext{failing: map[string]bool{"t03": true, "t09": true}}
+		_, err := setup(httpCtx, 4)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to attach recipient to 2 of 12 triggers")
+		assert.Contains(t, err.Error(), "trigger t03: update trigger failed (http 500)")
+		assert.Contains(t, err.Error(), "trigger t09: update trigger failed (http 500)")
+		assert.Len(t, httpCtx.updated, 10)
+		assert.NotContains(t, httpCtx.updated, "t03")
+	})
+
+	t.Run("setup after a partial failure -> every trigger attached once", func(t *testing.T) {
+		httpCtx := &concurrentTriggersHTTPContext{failing: map[string]bool{"t03": true}}
+		_, err := setup(httpCtx, 4)
+		require.Error(t, err)
+
+		httpCtx.failing = nil
+		metadata, err := setup(httpCtx, 4)
+
+		require.NoError(t, err)
+		assert.Len(t, metadata.(WebhookMetadata).Recipients["production"].TriggerIDs, 12)
+		assert.Equal(t, 1, httpCtx.updated["t03"])
+	})
+}
@@ -0,0 +1,334 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type AddFlagPrerequisite struct{}
+
+type AddFlagPrerequisiteSpec struct {
+	ProjectKey          string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey             string `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey      string `json:"environmentKey" mapstructure:"environmentKey"`
+	PrerequisiteFlagKey string `json:"prerequisiteFlagKey" mapstructure:"prerequisiteFlagKey"`
+	Variation           *int   `json:"variation,omitempty" mapstructure:"variation,omitempty"`
+	Comment             string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *AddFlagPrerequisite) Name() string {
+	return "launchdarkly.addFlagPrerequisite"
+}
+
+func (c *AddFlagPrerequisite) Label() string {
+	return "Add Flag Prerequisite"
+}
+
+func (c *AddFlagPrerequisite) Description() string {
+	return "Make a LaunchDarkly feature flag depend on another flag in an environment"
+}
+
+func (c *AddFlagPrerequisite) Documentation() string {
+	return `The Add Flag Prerequisite component adds a prerequisite to a feature flag in one environment. The flag is only evaluated normally when the prerequisite flag serves the required variation; otherwise it serves its off variation.
+
+## Use Cases
+
+- **Dependent features**: Only enable a feature when the feature it builds on is enabled
+- **Staged releases**: Gate the flags of a release behind a single release flag
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flags
+- **Feature Flag**: The key of the feature flag that gets the prerequisite
+- **Environment**: The environment where the prerequisite is added
+- **Prerequisite Flag**: The key of the flag that must serve the required variation
+- **Variation**: Index of the prerequisite flag's variation that must be served (0 is the first variation)
+- **Comment**: Optional comment stored with the change
+
+The prerequisite flag must exist in the project, and the variation index is validated against its variations.
+
+## Output
+
+Returns the project, flag, and environment keys and the flag's prerequisites in the environment after the update.`
+}
+
+func (c *AddFlagPrerequisite) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *AddFlagPrerequisite) Color() string {
+	return "gray"
+}
+
+func (c *AddFlagPrerequisite) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AddFlagPrerequisite) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag that gets the prerequisite",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment where the prerequisite is added",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "prerequisiteFlagKey",
+			Label:       "Prerequisite Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The flag that must serve the required variation",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "variation",
+			Label:       "Variation",
+			Type:        configuration.FieldTypeNumber,
+			Required:    true,
+			Default:     0,
+			Description: "Index of the prerequisite flag's variation that must be served",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+				},
+			},
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional comment stored with the change",
+		},
+	}
+}
+
+func (c *AddFlagPrerequisite) Setup(ctx core.SetupContext) error {
+	spec := AddFlagPrerequisiteSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateAddFlagPrerequisiteSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateAddFlagPrerequisiteSpec(spec AddFlagPrerequisiteSpec) error {
+	if err := validatePrerequisiteKeys(spec.ProjectKey, spec.FlagKey, spec.EnvironmentKey, spec.PrerequisiteFlagKey); err != nil {
+		return err
+	}
+
+	if spec.Variation == nil {
+		return errors.New("variation is required")
+	}
+
+	if *spec.Variation < 0 {
+		return errors.New("variation must not be negative")
+	}
+
+	return nil
+}
+
+func validatePrerequisiteKeys(projectKey, flagKey, environmentKey, prerequisiteFlagKey string) error {
+	if strings.TrimSpace(projectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(flagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if strings.TrimSpace(environmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	if strings.TrimSpace(prerequisiteFlagKey) == "" {
+		return errors.New("prerequisite flag key is required")
+	}
+
+	if prerequisiteFlagKey == flagKey {
+		return errors.New("a flag cannot be its own prerequisite")
+	}
+
+	return nil
+}
+
+func (c *AddFlagPrerequisite) Execute(ctx core.ExecutionContext) error {
+	spec := AddFlagPrerequisiteSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateAddFlagPrerequisiteSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	prerequisite, err := client.GetFeatureFlag(spec.ProjectKey, spec.PrerequisiteFlagKey)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("prerequisite flag %s not found in project %s", spec.PrerequisiteFlagKey, spec.ProjectKey)
+		}
+
+		return fmt.Errorf("failed to get prerequisite flag: %w", err)
+	}
+
+	//
+	// The instruction references the variation by ID,
+	// so the index is resolved against the prerequisite flag's variations.
+	//
+	variations, _ := prerequisite["variations"].([]any)
+	id, err := variationID(variations, *spec.Variation)
+	if err != nil {
+		return fmt.Errorf("invalid variation: %w", err)
+	}
+
+	updated, err := client.PatchFeatureFlag(spec.ProjectKey, spec.FlagKey, SemanticPatchRequest{
+		EnvironmentKey: spec.EnvironmentKey,
+		Comment:        strings.TrimSpace(spec.Comment),
+		Instructions: []SemanticPatchInstruction{
+			{
+				"kind":        "addPrerequisite",
+				"key":         spec.PrerequisiteFlagKey,
+				"variationId": id,
+			},
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to add prerequisite: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.prerequisiteAdded",
+		[]any{
+			map[string]any{
+				"projectKey":     spec.ProjectKey,
+				"flagKey":        spec.FlagKey,
+				"environmentKey": spec.EnvironmentKey,
+				"prerequisites":  environmentPrerequisites(updated, spec.EnvironmentKey),
+			},
+		},
+	)
+}
+
+func variationID(variations []any, index int) (string, error) {
+	if index < 0 || index >= len(variations) {
+		return "", fmt.Errorf("index %d is out of range, the flag has %d variations", index, len(variations))
+	}
+
+	variation, ok := variations[index].(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("variation %d has an unexpected format", index)
+	}
+
+	id, _ := variation["_id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("variation %d has no ID", index)
+	}
+
+	return id, nil
+}
+
+// environmentPrerequisites returns the prerequisites of a flag in an environment.
+func environmentPrerequisites(flag map[string]any, environmentKey string) []any {
+	environments, _ := flag["environments"].(map[string]any)
+	environment, _ := environments[environmentKey].(map[string]any)
+	prerequisites, _ := environment["prerequisites"].([]any)
+	if prerequisites == nil {
+		return []any{}
+	}
+
+	return prerequisites
+}
+
+func (c *AddFlagPrerequisite) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *AddFlagPrerequisite) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *AddFlagPrerequisite) Actions() []core.Action {
+	return nil
+}
+
+func (c *AddFlagPrerequisite) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *AddFlagPrerequisite) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AddFlagPrerequisite) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,188 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const prerequisiteFlagResponse = `{
+	"key": "checkout-v2-release",
+	"variations": [
+		{"_id": "var-on", "value": true},
+		{"_id": "var-off", "value": false}
+	]
+}`
+
+func Test__AddFlagPrerequisite__Setup(t *testing.T) {
+	component := &AddFlagPrerequisite{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":          "default",
+				"flagKey":             "new-checkout",
+				"environmentKey":      "production",
+				"prerequisiteFlagKey": "checkout-v2-release",
+				"variation":           0,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing prerequisite flag returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"variation":      0,
+			},
+		})
+
+		require.ErrorContains(t, err, "prerequisite flag key is required")
+	})
+
+	t.Run("flag as its own prerequisite returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":          "default",
+				"flagKey":             "new-checkout",
+				"environmentKey":      "production",
+				"prerequisiteFlagKey": "new-checkout",
+				"variation":           0,
+			},
+		})
+
+		require.ErrorContains(t, err, "a flag cannot be its own prerequisite")
+	})
+}
+
+func Test__AddFlagPrerequisite__Execute(t *testing.T) {
+	component := &AddFlagPrerequisite{}
+	configuration := map[string]any{
+		"projectKey":          "default",
+		"flagKey":             "new-checkout",
+		"environmentKey":      "production",
+		"prerequisiteFlagKey": "checkout-v2-release",
+		"variation":           0,
+		"comment":             "gate behind release flag",
+	}
+
+	t.Run("prerequisite exists -> sends addPrerequisite with variation ID", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(prerequisiteFlagResponse)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"key": "new-checkout",
+						"environments": {"production": {"prerequisites": [{"key": "checkout-v2-release", "variation": 0}]}}
+					}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/checkout-v2-release", httpContext.Requests[0].URL.String())
+
+		req := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", req.URL.String())
+		assert.Equal(t, "application/json; domain-model=launchdarkly.semanticpatch", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		patch := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &patch))
+		assert.Equal(t, map[string]any{
+			"environmentKey": "production",
+			"comment":        "gate behind release flag",
+			"instructions": []any{
+				map[string]any{
+					"kind":        "addPrerequisite",
+					"key":         "checkout-v2-release",
+					"variationId": "var-on",
+				},
+			},
+		}, patch)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.prerequisiteAdded", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, []any{map[string]any{"key": "checkout-v2-release", "variation": float64(0)}}, data["prerequisites"])
+	})
+
+	t.Run("missing prerequisite flag -> error before patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"code": "not_found", "message": "Unknown resource"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "prerequisite flag checkout-v2-release not found in project default")
+		assert.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("out of range variation -> error before patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(prerequisiteFlagResponse)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":          "default",
+				"flagKey":             "new-checkout",
+				"environmentKey":      "production",
+				"prerequisiteFlagKey": "checkout-v2-release",
+				"variation":           2,
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "invalid variation: index 2 is out of range, the flag has 2 variations")
+		assert.Len(t, httpContext.Requests, 1)
+	})
+}
@@ -0,0 +1,199 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type ArchiveFeatureFlag struct{}
+
+type ArchiveFeatureFlagSpec struct {
+	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey    string `json:"flagKey" mapstructure:"flagKey"`
+}
+
+func (c *ArchiveFeatureFlag) Name() string {
+	return "launchdarkly.archiveFeatureFlag"
+}
+
+func (c *ArchiveFeatureFlag) Label() string {
+	return "Archive Feature Flag"
+}
+
+func (c *ArchiveFeatureFlag) Description() string {
+	return "Archive a feature flag in LaunchDarkly"
+}
+
+func (c *ArchiveFeatureFlag) Documentation() string {
+	return `The Archive Feature Flag component archives a feature flag in a LaunchDarkly project. Unlike deleting it, archiving hides the flag from the flag list while keeping it and its targeting, so it can be restored later.
+
+## Use Cases
+
+- **Flag lifecycle**: Retire flags once a rollout is complete, without losing their history
+- **Cleanup workflows**: Archive temporary flags after the release that used them
+- **Staged removal**: Archive flags first, and delete them with Delete Feature Flag once nothing evaluates them
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Flag Key**: The key of the feature flag to archive (supports expressions)
+
+## Output
+
+Returns the project and flag keys, the archive date, and a ` + "`status`" + `:
+- ` + "`success`" + `: The flag was archived
+- ` + "`skipped`" + `: The flag was already archived, so it was left unchanged`
+}
+
+func (c *ArchiveFeatureFlag) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ArchiveFeatureFlag) Color() string {
+	return "gray"
+}
+
+func (c *ArchiveFeatureFlag) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ArchiveFeatureFlag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to archive",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *ArchiveFeatureFlag) Setup(ctx core.SetupContext) error {
+	spec := ArchiveFeatureFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateArchiveFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateArchiveFeatureFlagSpec(spec ArchiveFeatureFlagSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	return nil
+}
+
+func (c *ArchiveFeatureFlag) Execute(ctx core.ExecutionContext) error {
+	spec := ArchiveFeatureFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateArchiveFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	result := map[string]any{
+		"projectKey":           spec.ProjectKey,
+		"flagKey":              spec.FlagKey,
+		"archived":             true,
+		core.ResultStatusField: core.ResultStatusSuccess,
+	}
+
+	//
+	// An archived flag is what this component wants to achieve,
+	// so archiving it again is reported as skipped instead of patching it.
+	//
+	if archived, _ := flag["archived"].(bool); archived {
+		result["archivedDate"] = flag["archivedDate"]
+		result[core.ResultStatusField] = core.ResultStatusSkipped
+	} else {
+		updated, err := client.ArchiveFeatureFlag(spec.ProjectKey, spec.FlagKey)
+		if err != nil {
+			return fmt.Errorf("failed to archive feature flag: %w", err)
+		}
+
+		result["archivedDate"] = updated["archivedDate"]
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.archived",
+		[]any{result},
+	)
+}
+
+func (c *ArchiveFeatureFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ArchiveFeatureFlag) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ArchiveFeatureFlag) Actions() []core.Action {
+	return nil
+}
+
+func (c *ArchiveFeatureFlag) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ArchiveFeatureFlag) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ArchiveFeatureFlag) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ArchiveFeatureFlag__Setup(t *testing.T) {
+	component := &ArchiveFeatureFlag{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "old-feature"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"flagKey": "old-feature"},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing flag key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("reader token -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "old-feature"},
+			Integration:   &contexts.IntegrationContext{Metadata: Metadata{TokenRole: TokenRoleReader}},
+		})
+
+		require.ErrorContains(t, err, "the API access token has the reader role")
+	})
+}
+
+func Test__ArchiveFeatureFlag__Execute(t *testing.T) {
+	component := &ArchiveFeatureFlag{}
+
+	execute := func(httpContext *contexts.HTTPContext) (*contexts.ExecutionStateContext, error) {
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "old-feature"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return execStateCtx, err
+	}
+
+	t.Run("active flag -> archived with JSON Patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"old-feature","archived":false}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"old-feature","archived":true,"archivedDate":1768824000000}`))},
+			},
+		}
+
+		execStateCtx, err := execute(httpContext)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+
+		patch := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, patch.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/old-feature", patch.URL.String())
+		operations := []map[string]any{}
+		require.NoError(t, json.NewDecoder(patch.Body).Decode(&operations))
+		assert.Equal(t, []map[string]any{{"op": "replace", "path": "/archived", "value": true}}, operations)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.archived", payload["type"])
+		assert.Equal(t, map[string]any{
+			"projectKey":   "default",
+			"flagKey":      "old-feature",
+			"archived":     true,
+			"archivedDate": float64(1768824000000),
+			"status":       core.ResultStatusSuccess,
+		}, payload["data"])
+	})
+
+	t.Run("archived flag -> skipped without patching", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"old-feature","archived":true,"archivedDate":1768000000000}`))},
+			},
+		}
+
+		execStateCtx, err := execute(httpContext)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["archived"])
+		assert.Equal(t, float64(1768000000000), data["archivedDate"])
+		assert.Equal(t, core.ResultStatusSkipped, data["status"])
+	})
+
+	t.Run("flag not found -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found","message":"Unknown resource"}`))},
+			},
+		}
+
+		_, err := execute(httpContext)
+		require.ErrorContains(t, err, "failed to get feature flag")
+	})
+
+	t.Run("patch rejected -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"old-feature","archived":false}`))},
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+			},
+		}
+
+		_, err := execute(httpContext)
+		require.ErrorContains(t, err, "failed to archive feature flag")
+	})
+}
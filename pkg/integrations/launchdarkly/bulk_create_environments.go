@@ -0,0 +1,262 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const defaultEnvironmentColor = "7B42BC"
+
+type BulkCreateEnvironments struct{}
+
+type BulkCreateEnvironmentsSpec struct {
+	ProjectKey   string                `json:"projectKey" mapstructure:"projectKey"`
+	Environments []EnvironmentTemplate `json:"environments" mapstructure:"environments"`
+}
+
+type EnvironmentTemplate struct {
+	Key   string `json:"key" mapstructure:"key"`
+	Name  string `json:"name" mapstructure:"name"`
+	Color string `json:"color" mapstructure:"color"`
+}
+
+func (c *BulkCreateEnvironments) Name() string {
+	return "launchdarkly.bulkCreateEnvironments"
+}
+
+func (c *BulkCreateEnvironments) Label() string {
+	return "Bulk Create Environments"
+}
+
+func (c *BulkCreateEnvironments) Description() string {
+	return "Create several environments in a LaunchDarkly project"
+}
+
+func (c *BulkCreateEnvironments) Documentation() string {
+	return `The Bulk Create Environments component creates a list of environments in a LaunchDarkly project in one step.
+
+## Use Cases
+
+- **Project bootstrap**: Create the standard set of environments (e.g. staging, QA, production) for a new project
+- **Standardization**: Keep environment keys, names, and colors consistent across projects
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project where environments are created
+- **Environments**: The environments to create, each with a key, a name, and an optional hex color (e.g. ` + "`417505`" + `)
+
+## Output
+
+Returns the project key, the environments that were created (ID, key, name, and color), and the keys of the environments that already existed in the project.
+
+Environments that already exist are skipped instead of failing the whole run. Any other error stops the execution.`
+}
+
+func (c *BulkCreateEnvironments) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *BulkCreateEnvironments) Color() string {
+	return "gray"
+}
+
+func (c *BulkCreateEnvironments) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *BulkCreateEnvironments) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "environments",
+			Label:       "Environments",
+			Type:        configuration.FieldTypeList,
+			Required:    true,
+			Description: "The environments to create",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Environment",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:               "key",
+								Label:              "Key",
+								Type:               configuration.FieldTypeString,
+								Required:           true,
+								DisallowExpression: true,
+							},
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:        "color",
+								Label:       "Color",
+								Type:        configuration.FieldTypeString,
+								Description: "Hex color without the leading #",
+								Default:     defaultEnvironmentColor,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *BulkCreateEnvironments) Setup(ctx core.SetupContext) error {
+	spec := BulkCreateEnvironmentsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateBulkCreateEnvironmentsSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateBulkCreateEnvironmentsSpec(spec BulkCreateEnvironmentsSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if len(spec.Environments) == 0 {
+		return errors.New("at least one environment is required")
+	}
+
+	keys := map[string]bool{}
+	for i, environment := range spec.Environments {
+		key := strings.TrimSpace(environment.Key)
+		if key == "" {
+			return fmt.Errorf("environment %d: key is required", i+1)
+		}
+
+		if strings.TrimSpace(environment.Name) == "" {
+			return fmt.Errorf("environment %s: name is required", key)
+		}
+
+		if keys[key] {
+			return fmt.Errorf("environment %s is listed more than once", key)
+		}
+
+		keys[key] = true
+	}
+
+	return nil
+}
+
+func (c *BulkCreateEnvironments) Execute(ctx core.ExecutionContext) error {
+	spec := BulkCreateEnvironmentsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateBulkCreateEnvironmentsSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	created := []any{}
+	existing := []any{}
+	for _, environment := range spec.Environments {
+		color := strings.TrimPrefix(strings.TrimSpace(environment.Color), "#")
+		if color == "" {
+			color = defaultEnvironmentColor
+		}
+
+		key := strings.TrimSpace(environment.Key)
+		result, err := client.CreateEnvironment(spec.ProjectKey, CreateEnvironmentRequest{
+			Key:   key,
+			Name:  strings.TrimSpace(environment.Name),
+			Color: color,
+		})
+
+		if err != nil {
+			//
+			// LaunchDarkly returns 409 when the environment key is already used in the project.
+			// Those environments are reported back instead of failing the whole run.
+			//
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+				existing = append(existing, key)
+				continue
+			}
+
+			return fmt.Errorf("failed to create environment %s: %w", key, err)
+		}
+
+		//
+		// The API response includes the SDK and mobile keys of the new environment,
+		// which should not end up in the execution output.
+		//
+		created = append(created, map[string]any{
+			"_id":   result["_id"],
+			"key":   result["key"],
+			"name":  result["name"],
+			"color": result["color"],
+		})
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.environments.created",
+		[]any{
+			map[string]any{
+				"projectKey": spec.ProjectKey,
+				"created":    created,
+				"existing":   existing,
+			},
+		},
+	)
+}
+
+func (c *BulkCreateEnvironments) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *BulkCreateEnvironments) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *BulkCreateEnvironments) Actions() []core.Action {
+	return nil
+}
+
+func (c *BulkCreateEnvironments) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *BulkCreateEnvironments) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *BulkCreateEnvironments) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
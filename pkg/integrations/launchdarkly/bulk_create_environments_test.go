@@ -0,0 +1,195 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__BulkCreateEnvironments__Setup(t *testing.T) {
+	component := &BulkCreateEnvironments{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey": "checkout",
+				"environments": []any{
+					map[string]any{"key": "staging", "name": "Staging"},
+					map[string]any{"key": "qa", "name": "QA", "color": "417505"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"environments": []any{map[string]any{"key": "staging", "name": "Staging"}},
+			},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("no environments returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "checkout"},
+		})
+
+		require.ErrorContains(t, err, "at least one environment is required")
+	})
+
+	t.Run("duplicate environment key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey": "checkout",
+				"environments": []any{
+					map[string]any{"key": "staging", "name": "Staging"},
+					map[string]any{"key": "staging", "name": "Staging 2"},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "environment staging is listed more than once")
+	})
+
+	t.Run("missing environment name returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":   "checkout",
+				"environments": []any{map[string]any{"key": "staging"}},
+			},
+		})
+
+		require.ErrorContains(t, err, "environment staging: name is required")
+	})
+}
+
+func Test__BulkCreateEnvironments__Execute(t *testing.T) {
+	component := &BulkCreateEnvironments{}
+	configuration := map[string]any{
+		"projectKey": "checkout",
+		"environments": []any{
+			map[string]any{"key": "staging", "name": "Staging", "color": "#417505"},
+			map[string]any{"key": "production", "name": "Production"},
+		},
+	}
+
+	t.Run("all environments created -> emits created environments", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"_id":"env-1","key":"staging","name":"Staging","color":"417505","apiKey":"sdk-1"}`)),
+				},
+				{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"_id":"env-2","key":"production","name":"Production","color":"7B42BC","apiKey":"sdk-2"}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects/checkout/environments", httpContext.Requests[0].URL.String())
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		request := CreateEnvironmentRequest{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, CreateEnvironmentRequest{Key: "staging", Name: "Staging", Color: "417505"}, request)
+
+		body, err = io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, defaultEnvironmentColor, request.Color)
+
+		assert.True(t, execStateCtx.Passed)
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.environments.created", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "checkout", data["projectKey"])
+		assert.Empty(t, data["existing"])
+
+		created := data["created"].([]any)
+		require.Len(t, created, 2)
+		assert.Equal(t, map[string]any{"_id": "env-1", "key": "staging", "name": "Staging", "color": "417505"}, created[0])
+		assert.Equal(t, "production", created[1].(map[string]any)["key"])
+	})
+
+	t.Run("existing environment -> reported and others still created", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"_id":"env-1","key":"staging","name":"Staging","color":"417505"}`)),
+				},
+				{
+					StatusCode: http.StatusConflict,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"conflict","message":"an environment with that key already exists"}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		require.Len(t, execStateCtx.Payloads, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Len(t, data["created"], 1)
+		assert.Equal(t, []any{"production"}, data["existing"])
+	})
+
+	t.Run("API error -> execution fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"forbidden"}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.ErrorContains(t, err, "failed to create environment staging")
+		assert.Len(t, httpContext.Requests, 1)
+		assert.Empty(t, execStateCtx.Payloads)
+	})
+}
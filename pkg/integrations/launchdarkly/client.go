@@ -2,27 +2,29 @@ package launchdarkly
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 )
 
+// BaseURL is the base URL of the LaunchDarkly API used when the integration does not configure one.
 const BaseURL = "https://app.launchdarkly.com"
 
 // Project represents a LaunchDarkly project.
 type Project struct {
-	Key  string `json:"key"`
-	Name string `json:"name"`
-}
-
-// ProjectListResponse is the API response for listing projects.
-type ProjectListResponse struct {
-	Items      []Project `json:"items"`
-	TotalCount int       `json:"totalCount"`
+	Key          string                   `json:"key"`
+	Name         string                   `json:"name"`
+	Environments *EnvironmentListResponse `json:"environments,omitempty"`
 }
 
 // FeatureFlag represents a LaunchDarkly feature flag.
@@ -36,12 +38,6 @@ type FeatureFlag struct {
 	Temporary    bool   `json:"temporary"`
 }
 
-// FeatureFlagListResponse is the API response for listing feature flags.
-type FeatureFlagListResponse struct {
-	Items      []FeatureFlag `json:"items"`
-	TotalCount int           `json:"totalCount"`
-}
-
 // Environment represents a LaunchDarkly environment within a project.
 type Environment struct {
 	Key  string `json:"key"`
@@ -67,6 +63,25 @@ type Client struct {
 	Token   string
 	BaseURL string
 	http    core.HTTPContext
+
+	// ctx stops requests and rate limit waits when it is done. See WithContext.
+	ctx context.Context
+}
+
+// WithContext returns a copy of the client whose requests, and the waits
+// between rate limited attempts, stop as soon as ctx is done.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
 }
 
 func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
@@ -80,67 +95,236 @@ func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, err
 		return nil, fmt.Errorf("api key is required")
 	}
 
+	//
+	// The base URL is optional, and integrations saved before it existed do not have it.
+	//
+	configuredBaseURL, _ := ctx.GetConfig("baseURL")
+	baseURL, err := ParseBaseURL(string(configuredBaseURL))
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		Token:   token,
-		BaseURL: BaseURL,
+		BaseURL: baseURL,
 		http:    http,
 	}, nil
 }
 
+// ParseBaseURL returns the base URL requests are sent to, BaseURL when the value is empty.
+// Other instances of LaunchDarkly, such as the federal one or a proxy in front of the API,
+// must be reached over https.
+func ParseBaseURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return BaseURL, nil
+	}
+
+	baseURL, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	if baseURL.Scheme != "https" {
+		return "", fmt.Errorf("invalid base URL %q: must use https", value)
+	}
+
+	if baseURL.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: must have a host", value)
+	}
+
+	if baseURL.RawQuery != "" || baseURL.Fragment != "" || baseURL.User != nil {
+		return "", fmt.Errorf("invalid base URL %q: must not have credentials, a query or a fragment", value)
+	}
+
+	return strings.TrimSuffix(baseURL.String(), "/"), nil
+}
+
 func (c *Client) execRequest(method, path string, body io.Reader) ([]byte, error) {
+	return c.execRequestWithContentType(method, path, "application/json", body)
+}
+
+const (
+	maxRateLimitAttempts = 3
+	rateLimitBaseBackoff = time.Second
+	maxRateLimitWait     = 30 * time.Second
+
+	// maxRateLimitTotalWait caps the time a request spends waiting for rate limits across all its attempts.
+	maxRateLimitTotalWait = 45 * time.Second
+)
+
+// rateLimitSleep is replaced in tests, so retries do not wait.
+var rateLimitSleep = sleepContext
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// execRequestWithContentType sends the request and returns the response body.
+// LaunchDarkly rejects requests that exceed its rate limits with 429 without processing them,
+// so those are sent again once the limit resets, for up to maxRateLimitAttempts attempts
+// and maxRateLimitTotalWait of waiting. Other non-2xx responses are returned as an APIError.
+func (c *Client) execRequestWithContentType(method, path, contentType string, body io.Reader) ([]byte, error) {
+	var payload []byte
+	if body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		payload = b
+	}
+
+	var waited time.Duration
+	for attempt := 1; ; attempt++ {
+		responseBody, res, err := c.sendRequest(method, path, contentType, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode == http.StatusTooManyRequests && attempt < maxRateLimitAttempts {
+			wait := rateLimitWait(res.Header, attempt)
+			if waited+wait <= maxRateLimitTotalWait {
+				if err := rateLimitSleep(c.context(), wait); err != nil {
+					return nil, fmt.Errorf("stopped waiting for the rate limit: %w", err)
+				}
+
+				waited += wait
+				continue
+			}
+		}
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, &APIError{StatusCode: res.StatusCode, Body: string(responseBody)}
+		}
+
+		return responseBody, nil
+	}
+}
+
+func (c *Client) sendRequest(method, path, contentType string, payload []byte) ([]byte, *http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
 	url := c.BaseURL + path
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(c.context(), method, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %w", err)
+		return nil, nil, fmt.Errorf("error building request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", c.Token)
 
 	res, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %w", err)
+		registry.TraceHTTP(c.http, req, 0, nil, err, c.Token)
+		return nil, nil, fmt.Errorf("error executing request: %w", err)
 	}
 	defer res.Body.Close()
 
 	responseBody, err := io.ReadAll(res.Body)
+	registry.TraceHTTP(c.http, req, res.StatusCode, responseBody, err, c.Token)
 	if err != nil {
-		return nil, fmt.Errorf("error reading body: %w", err)
+		return nil, nil, fmt.Errorf("error reading body: %w", err)
 	}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, &APIError{StatusCode: res.StatusCode, Body: string(responseBody)}
+	return responseBody, res, nil
+}
+
+// rateLimitWait returns how long to wait before sending a rate limited request again, up to maxRateLimitWait.
+// X-Ratelimit-Reset holds the time the limit resets, in milliseconds since the epoch.
+// Without it, Retry-After in seconds is used, and otherwise the wait doubles on every attempt.
+func rateLimitWait(header http.Header, attempt int) time.Duration {
+	wait := rateLimitBaseBackoff << (attempt - 1)
+	if reset, err := strconv.ParseInt(strings.TrimSpace(header.Get("X-Ratelimit-Reset")), 10, 64); err == nil && reset > 0 {
+		wait = max(time.Until(time.UnixMilli(reset)), 0)
+	} else if seconds, err := strconv.Atoi(strings.TrimSpace(header.Get("Retry-After"))); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
 	}
 
-	return responseBody, nil
+	return min(wait, maxRateLimitWait)
 }
 
-// ListProjects returns all projects in the LaunchDarkly account.
-func (c *Client) ListProjects() ([]Project, error) {
-	const limit = 200
-	var all []Project
-	for offset := 0; ; offset += limit {
-		path := fmt.Sprintf("/api/v2/projects?limit=%d&offset=%d", limit, offset)
+// PaginationLinks are the links LaunchDarkly returns with a page of a list.
+// Next is missing on the last page.
+type PaginationLinks struct {
+	Next *struct {
+		Href string `json:"href"`
+	} `json:"next"`
+}
+
+// listAll returns the items of every page of a list, starting at path.
+// Pages are followed through their next link instead of offsets, so items
+// added or removed between requests do not truncate the list or repeat pages.
+func listAll[T any](c *Client, path, resource string) ([]T, error) {
+	var all []T
+	visited := map[string]bool{}
+	for path != "" {
+		if visited[path] {
+			return nil, fmt.Errorf("pagination of %s returned %s again", resource, path)
+		}
+		visited[path] = true
+
 		responseBody, err := c.execRequest(http.MethodGet, path, nil)
 		if err != nil {
 			return nil, err
 		}
 
-		var response ProjectListResponse
+		var response struct {
+			Items []T             `json:"items"`
+			Links PaginationLinks `json:"_links"`
+		}
 		if err := json.Unmarshal(responseBody, &response); err != nil {
-			return nil, fmt.Errorf("error parsing projects response: %w", err)
+			return nil, fmt.Errorf("error parsing %s response: %w", resource, err)
 		}
 
 		all = append(all, response.Items...)
-		if len(response.Items) == 0 || len(all) >= response.TotalCount {
-			break
+		path, err = nextPagePath(response.Links)
+		if err != nil {
+			return nil, fmt.Errorf("error following %s pagination: %w", resource, err)
 		}
 	}
 
 	return all, nil
 }
 
+// nextPagePath returns the path and query of the next link, or "" on the last page.
+// LaunchDarkly returns relative links, but absolute ones are accepted too.
+func nextPagePath(links PaginationLinks) (string, error) {
+	if links.Next == nil || strings.TrimSpace(links.Next.Href) == "" {
+		return "", nil
+	}
+
+	next, err := url.Parse(strings.TrimSpace(links.Next.Href))
+	if err != nil {
+		return "", fmt.Errorf("invalid next link %q: %w", links.Next.Href, err)
+	}
+
+	return next.RequestURI(), nil
+}
+
+// ListProjects returns all projects in the LaunchDarkly account.
+func (c *Client) ListProjects() ([]Project, error) {
+	return listAll[Project](c, "/api/v2/projects?limit=200", "projects")
+}
+
+// ListProjectsWithEnvironments returns all projects, each with its environments expanded,
+// so projects and environments can be listed without one request per project.
+func (c *Client) ListProjectsWithEnvironments() ([]Project, error) {
+	return listAll[Project](c, "/api/v2/projects?limit=200&expand=environments", "projects")
+}
+
 // GetFeatureFlag returns a feature flag by project key and flag key.
 func (c *Client) GetFeatureFlag(projectKey, flagKey string) (map[string]any, error) {
 	path := fmt.Sprintf("/api/v2/flags/%s/%s", projectKey, flagKey)
@@ -157,54 +341,408 @@ func (c *Client) GetFeatureFlag(projectKey, flagKey string) (map[string]any, err
 	return result, nil
 }
 
-// ListFeatureFlags returns all feature flags in a LaunchDarkly project.
-func (c *Client) ListFeatureFlags(projectKey string) ([]FeatureFlag, error) {
-	const limit = 200
-	var all []FeatureFlag
-	for offset := 0; ; offset += limit {
-		path := fmt.Sprintf("/api/v2/flags/%s?limit=%d&offset=%d", projectKey, limit, offset)
-		responseBody, err := c.execRequest(http.MethodGet, path, nil)
-		if err != nil {
-			return nil, err
-		}
+// FlagVariation is a variation of a feature flag.
+type FlagVariation struct {
+	Value       any    `json:"value"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
 
-		var response FeatureFlagListResponse
-		if err := json.Unmarshal(responseBody, &response); err != nil {
-			return nil, fmt.Errorf("error parsing feature flags response: %w", err)
-		}
+// FlagDefaults are the indexes of the variations served by default when targeting is on and off.
+type FlagDefaults struct {
+	OnVariation  int `json:"onVariation"`
+	OffVariation int `json:"offVariation"`
+}
 
-		all = append(all, response.Items...)
-		if len(response.Items) == 0 || len(all) >= response.TotalCount {
-			break
+// CreateFeatureFlagRequest is the request body for creating a feature flag.
+// LaunchDarkly creates a boolean flag when no variations are given.
+type CreateFeatureFlagRequest struct {
+	Key         string          `json:"key"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Temporary   bool            `json:"temporary"`
+	Tags        []string        `json:"tags,omitempty"`
+	Variations  []FlagVariation `json:"variations,omitempty"`
+	Defaults    *FlagDefaults   `json:"defaults,omitempty"`
+}
+
+// FlagAlreadyExistsError is returned when a flag is created with the key of an existing flag of the project.
+type FlagAlreadyExistsError struct {
+	ProjectKey string
+	FlagKey    string
+	apiErr     *APIError
+}
+
+func (e *FlagAlreadyExistsError) Error() string {
+	return fmt.Sprintf("feature flag %s already exists in project %s", e.FlagKey, e.ProjectKey)
+}
+
+func (e *FlagAlreadyExistsError) Unwrap() error {
+	return e.apiErr
+}
+
+// CreateFeatureFlag creates a feature flag in the given project and returns the created flag.
+// A FlagAlreadyExistsError is returned when the project already has a flag with the key.
+func (c *Client) CreateFeatureFlag(projectKey string, req CreateFeatureFlagRequest) (map[string]any, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v2/flags/%s", projectKey)
+	responseBody, err := c.execRequest(http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			return nil, &FlagAlreadyExistsError{ProjectKey: projectKey, FlagKey: req.Key, apiErr: apiErr}
 		}
+
+		return nil, err
 	}
 
-	return all, nil
+	var result map[string]any
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("error parsing feature flag response: %w", err)
+	}
+
+	return result, nil
+}
+
+// FlagStatus is the evaluation status of a feature flag in one environment.
+// LastRequested is empty for flags that were never evaluated in the environment.
+type FlagStatus struct {
+	Name          string `json:"name"`
+	LastRequested string `json:"lastRequested"`
+}
+
+// GetFlagStatuses returns the status of a feature flag in every environment, by environment key.
+func (c *Client) GetFlagStatuses(projectKey, flagKey string) (map[string]FlagStatus, error) {
+	path := fmt.Sprintf("/api/v2/flag-status/%s/%s", url.PathEscape(projectKey), url.PathEscape(flagKey))
+	responseBody, err := c.execRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Environments map[string]FlagStatus `json:"environments"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing flag status response: %w", err)
+	}
+
+	return response.Environments, nil
+}
+
+// ListFeatureFlags returns all feature flags in a LaunchDarkly project.
+func (c *Client) ListFeatureFlags(projectKey string) ([]FeatureFlag, error) {
+	return listAll[FeatureFlag](c, fmt.Sprintf("/api/v2/flags/%s?limit=200", projectKey), "feature flags")
+}
+
+// ListFeatureFlagsDetailed returns all feature flags in a LaunchDarkly project
+// with their full detail, including targeting rules for every environment.
+func (c *Client) ListFeatureFlagsDetailed(projectKey string) ([]map[string]any, error) {
+	return listAll[map[string]any](c, fmt.Sprintf("/api/v2/flags/%s?summary=0&limit=100", projectKey), "feature flags")
 }
 
 // ListEnvironments returns all environments in a LaunchDarkly project.
 func (c *Client) ListEnvironments(projectKey string) ([]Environment, error) {
-	const limit = 200
-	var all []Environment
-	for offset := 0; ; offset += limit {
-		path := fmt.Sprintf("/api/v2/projects/%s/environments?limit=%d&offset=%d", projectKey, limit, offset)
-		responseBody, err := c.execRequest(http.MethodGet, path, nil)
-		if err != nil {
-			return nil, err
-		}
+	return listAll[Environment](c, fmt.Sprintf("/api/v2/projects/%s/environments?limit=200", projectKey), "environments")
+}
 
-		var response EnvironmentListResponse
-		if err := json.Unmarshal(responseBody, &response); err != nil {
-			return nil, fmt.Errorf("error parsing environments response: %w", err)
-		}
+// Member represents a member of the LaunchDarkly account.
+type Member struct {
+	ID          string   `json:"_id"`
+	Email       string   `json:"email"`
+	FirstName   string   `json:"firstName"`
+	LastName    string   `json:"lastName"`
+	Role        string   `json:"role"`
+	CustomRoles []string `json:"customRoles,omitempty"`
+}
 
-		all = append(all, response.Items...)
-		if len(response.Items) == 0 || len(all) >= response.TotalCount {
-			break
-		}
+// ListMembers returns all members of the LaunchDarkly account.
+func (c *Client) ListMembers() ([]Member, error) {
+	return listAll[Member](c, "/api/v2/members?limit=200", "members")
+}
+
+// GetMember returns a member of the LaunchDarkly account by ID.
+func (c *Client) GetMember(memberID string) (*Member, error) {
+	path := fmt.Sprintf("/api/v2/members/%s", url.PathEscape(memberID))
+	responseBody, err := c.execRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return all, nil
+	var member Member
+	if err := json.Unmarshal(responseBody, &member); err != nil {
+		return nil, fmt.Errorf("error parsing member response: %w", err)
+	}
+
+	return &member, nil
+}
+
+// CallerIdentity is the identity of the token requests are authenticated with.
+type CallerIdentity struct {
+	TokenID      string `json:"tokenId"`
+	TokenName    string `json:"tokenName"`
+	TokenKind    string `json:"tokenKind"`
+	MemberID     string `json:"memberId"`
+	ServiceToken bool   `json:"serviceToken"`
+}
+
+// GetCallerIdentity returns the identity of the API access token of the client.
+func (c *Client) GetCallerIdentity() (*CallerIdentity, error) {
+	responseBody, err := c.execRequest(http.MethodGet, "/api/v2/caller-identity", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var identity CallerIdentity
+	if err := json.Unmarshal(responseBody, &identity); err != nil {
+		return nil, fmt.Errorf("error parsing caller identity response: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// AccessToken is an API access token of the LaunchDarkly account. Role is the built-in role
+// of the token, and is empty for tokens with custom or inline roles.
+type AccessToken struct {
+	ID            string   `json:"_id"`
+	Name          string   `json:"name"`
+	Role          string   `json:"role"`
+	CustomRoleIDs []string `json:"customRoleIds"`
+	InlineRole    []any    `json:"inlineRole"`
+	ServiceToken  bool     `json:"serviceToken"`
+}
+
+// GetToken returns an API access token of the account.
+func (c *Client) GetToken(tokenID string) (*AccessToken, error) {
+	path := fmt.Sprintf("/api/v2/tokens/%s", url.PathEscape(tokenID))
+	responseBody, err := c.execRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var token AccessToken
+	if err := json.Unmarshal(responseBody, &token); err != nil {
+		return nil, fmt.Errorf("error parsing token response: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Team represents a team of the LaunchDarkly account.
+type Team struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// ListTeams returns all teams of the LaunchDarkly account.
+func (c *Client) ListTeams() ([]Team, error) {
+	return listAll[Team](c, "/api/v2/teams?limit=100", "teams")
+}
+
+// GetTeam returns a team of the LaunchDarkly account by key.
+func (c *Client) GetTeam(teamKey string) (*Team, error) {
+	path := fmt.Sprintf("/api/v2/teams/%s", url.PathEscape(teamKey))
+	responseBody, err := c.execRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var team Team
+	if err := json.Unmarshal(responseBody, &team); err != nil {
+		return nil, fmt.Errorf("error parsing team response: %w", err)
+	}
+
+	return &team, nil
+}
+
+// CreateEnvironmentRequest is the request body for creating an environment in a project.
+type CreateEnvironmentRequest struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// CreateEnvironment creates a new environment in the given project and returns the
+// created environment as returned by the API.
+func (c *Client) CreateEnvironment(projectKey string, req CreateEnvironmentRequest) (map[string]any, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v2/projects/%s/environments", projectKey)
+	responseBody, err := c.execRequest(http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("error parsing environment response: %w", err)
+	}
+
+	return result, nil
+}
+
+// ScheduledChangeInstruction is a single flag change applied when a scheduled change runs.
+type ScheduledChangeInstruction struct {
+	Kind string `json:"kind"`
+}
+
+// CreateScheduledChangeRequest is the request body for scheduling a flag change.
+// ExecutionDate is a Unix timestamp in milliseconds.
+type CreateScheduledChangeRequest struct {
+	ExecutionDate int64                        `json:"executionDate"`
+	Comment       string                       `json:"comment,omitempty"`
+	Instructions  []ScheduledChangeInstruction `json:"instructions"`
+}
+
+// CreateScheduledChange schedules a future change of a flag in one environment
+// and returns the scheduled change as returned by the API.
+func (c *Client) CreateScheduledChange(projectKey, flagKey, environmentKey string, req CreateScheduledChangeRequest) (map[string]any, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v2/projects/%s/flags/%s/environments/%s/scheduled-changes", projectKey, flagKey, environmentKey)
+	responseBody, err := c.execRequest(http.MethodPost, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("error parsing scheduled change response: %w", err)
+	}
+
+	return result, nil
+}
+
+// AuditLogEntry is an entry of the LaunchDarkly audit log.
+type AuditLogEntry struct {
+	ID          string           `json:"_id"`
+	Date        int64            `json:"date"`
+	Kind        string           `json:"kind"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Title       string           `json:"title"`
+	TitleVerb   string           `json:"titleVerb"`
+	Comment     string           `json:"comment"`
+	Accesses    []AuditLogAccess `json:"accesses"`
+	Member      *AuditLogMember  `json:"member"`
+}
+
+// AuditLogAccess is an action applied to a resource in an audit log entry.
+type AuditLogAccess struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+}
+
+// AuditLogMember is the account member who made the change.
+type AuditLogMember struct {
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// AuditLogListResponse is the API response for listing audit log entries.
+type AuditLogListResponse struct {
+	Items []AuditLogEntry `json:"items"`
+}
+
+// GetFlagAuditEntries returns the most recent audit log entries for a flag,
+// across all environments of the project, newest first.
+func (c *Client) GetFlagAuditEntries(projectKey, flagKey string, limit int) ([]AuditLogEntry, error) {
+	query := url.Values{}
+	query.Set("spec", fmt.Sprintf("proj/%s:env/*:flag/%s", projectKey, flagKey))
+	query.Set("limit", strconv.Itoa(limit))
+
+	responseBody, err := c.execRequest(http.MethodGet, "/api/v2/auditlog?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AuditLogListResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing audit log response: %w", err)
+	}
+
+	return response.Items, nil
+}
+
+// SemanticPatchInstruction is a single semantic patch instruction.
+// Instruction parameters are sent next to the kind.
+type SemanticPatchInstruction map[string]any
+
+// SemanticPatchRequest is the request body for updating a flag with semantic patch.
+// EnvironmentKey is required by instructions that change environment-specific flag settings.
+type SemanticPatchRequest struct {
+	EnvironmentKey string                     `json:"environmentKey,omitempty"`
+	Comment        string                     `json:"comment,omitempty"`
+	Instructions   []SemanticPatchInstruction `json:"instructions"`
+}
+
+// PatchFeatureFlag updates a feature flag with semantic patch instructions
+// and returns the updated flag.
+func (c *Client) PatchFeatureFlag(projectKey, flagKey string, req SemanticPatchRequest) (map[string]any, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v2/flags/%s/%s", projectKey, flagKey)
+	responseBody, err := c.execRequestWithContentType(http.MethodPatch, path, "application/json; domain-model=launchdarkly.semanticpatch", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("error parsing feature flag response: %w", err)
+	}
+
+	return result, nil
+}
+
+// JSONPatchOperation is a single JSON Patch (RFC 6902) operation.
+type JSONPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// JSONPatchFeatureFlag updates a feature flag with JSON Patch operations
+// and returns the updated flag.
+func (c *Client) JSONPatchFeatureFlag(projectKey, flagKey string, operations []JSONPatchOperation) (map[string]any, error) {
+	bodyBytes, err := json.Marshal(operations)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v2/flags/%s/%s", projectKey, flagKey)
+	responseBody, err := c.execRequest(http.MethodPatch, path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("error parsing feature flag response: %w", err)
+	}
+
+	return result, nil
+}
+
+// ArchiveFeatureFlag archives a feature flag, so it is hidden from the flag list
+// and can be restored later, unlike a deleted flag.
+func (c *Client) ArchiveFeatureFlag(projectKey, flagKey string) (map[string]any, error) {
+	return c.JSONPatchFeatureFlag(projectKey, flagKey, []JSONPatchOperation{
+		{Op: "replace", Path: "/archived", Value: true},
+	})
 }
 
 // DeleteFeatureFlag deletes a feature flag by project key and flag key.
@@ -214,6 +752,28 @@ func (c *Client) DeleteFeatureFlag(projectKey, flagKey string) error {
 	return err
 }
 
+// FollowFlag subscribes a member to the change notifications of a flag in an environment.
+func (c *Client) FollowFlag(projectKey, flagKey, environmentKey, memberID string) error {
+	_, err := c.execRequest(http.MethodPut, flagFollowerPath(projectKey, flagKey, environmentKey, memberID), nil)
+	return err
+}
+
+// UnfollowFlag unsubscribes a member from the change notifications of a flag in an environment.
+func (c *Client) UnfollowFlag(projectKey, flagKey, environmentKey, memberID string) error {
+	_, err := c.execRequest(http.MethodDelete, flagFollowerPath(projectKey, flagKey, environmentKey, memberID), nil)
+	return err
+}
+
+func flagFollowerPath(projectKey, flagKey, environmentKey, memberID string) string {
+	return fmt.Sprintf(
+		"/api/v2/projects/%s/flags/%s/environments/%s/followers/%s",
+		url.PathEscape(projectKey),
+		url.PathEscape(flagKey),
+		url.PathEscape(environmentKey),
+		url.PathEscape(memberID),
+	)
+}
+
 // WebhookStatement is a policy statement that filters which resource/action combinations
 // the webhook responds to.
 type WebhookStatement struct {
@@ -259,6 +819,35 @@ func (c *Client) CreateWebhook(req CreateWebhookRequest) (*LDWebhook, error) {
 	return &result, nil
 }
 
+// Webhook is a webhook of the LaunchDarkly account.
+type Webhook struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	On   bool   `json:"on"`
+}
+
+// WebhookListResponse is the API response for listing webhooks.
+type WebhookListResponse struct {
+	Items []Webhook `json:"items"`
+}
+
+// ListWebhooks returns all webhooks of the LaunchDarkly account.
+// The webhooks API is not paginated.
+func (c *Client) ListWebhooks() ([]Webhook, error) {
+	responseBody, err := c.execRequest(http.MethodGet, "/api/v2/webhooks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response WebhookListResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("error parsing webhooks response: %w", err)
+	}
+
+	return response.Items, nil
+}
+
 // DeleteWebhook deletes a webhook from LaunchDarkly by its ID.
 func (c *Client) DeleteWebhook(id string) error {
 	_, err := c.execRequest(http.MethodDelete, "/api/v2/webhooks/"+id, nil)
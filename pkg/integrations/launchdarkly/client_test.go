@@ -0,0 +1,314 @@
+package launchdarkly
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Client__Trace(t *testing.T) {
+	t.Run("tracing HTTP context -> records the request without the API key", func(t *testing.T) {
+		httpCtx := &contexts.TracingHTTPContext{
+			HTTPContext: contexts.HTTPContext{
+				Responses: []*http.Response{
+					{
+						StatusCode: http.StatusForbidden,
+						Body:       io.NopCloser(strings.NewReader(`{"code":"forbidden","message":"api-test-key cannot read flags"}`)),
+					},
+				},
+			},
+		}
+
+		client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "api-test-key"},
+		})
+		require.NoError(t, err)
+
+		_, err = client.GetFeatureFlag("default", "new-checkout")
+		require.Error(t, err)
+
+		require.Len(t, httpCtx.Traces, 1)
+		assert.Equal(t, core.HTTPTrace{
+			Method:       http.MethodGet,
+			URL:          "https://app.launchdarkly.com/api/v2/flags/default/new-checkout",
+			Status:       http.StatusForbidden,
+			ResponseBody: `{"code":"forbidden","message":"[REDACTED] cannot read flags"}`,
+		}, httpCtx.Traces[0])
+	})
+}
+
+func Test__Client__RateLimit(t *testing.T) {
+	waits := []time.Duration{}
+	rateLimitSleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { rateLimitSleep = sleepContext })
+
+	rateLimited := func(header http.Header) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{"code":"rate_limited","message":"You've exceeded the API rate limit"}`)),
+		}
+	}
+
+	newClient := func(httpCtx *contexts.HTTPContext) *Client {
+		client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "api-test-key"},
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("429 in the middle of pagination -> waits until the reset and continues", func(t *testing.T) {
+		waits = []time.Duration{}
+		reset := time.Now().Add(2 * time.Second).UnixMilli()
+		page := func(key, links string) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"items":[{"key":%q}],"_links":%s,"totalCount":2}`, key, links))),
+			}
+		}
+
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				page("flag-1", `{"next":{"href":"/api/v2/flags/default?limit=200&offset=1"}}`),
+				rateLimited(http.Header{"X-Ratelimit-Reset": []string{fmt.Sprint(reset)}}),
+				page("flag-2", `{}`),
+			},
+		}
+
+		flags, err := newClient(httpCtx).ListFeatureFlags("default")
+		require.NoError(t, err)
+		require.Len(t, flags, 2)
+		assert.Equal(t, "flag-2", flags[1].Key)
+
+		require.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, httpCtx.Requests[1].URL.String(), httpCtx.Requests[2].URL.String())
+		require.Len(t, waits, 1)
+		assert.Greater(t, waits[0], time.Duration(0))
+		assert.LessOrEqual(t, waits[0], 2*time.Second)
+	})
+
+	t.Run("reset far in the future -> wait is capped", func(t *testing.T) {
+		waits = []time.Duration{}
+		reset := time.Now().Add(time.Hour).UnixMilli()
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimited(http.Header{"X-Ratelimit-Reset": []string{fmt.Sprint(reset)}}),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout"}`))},
+			},
+		}
+
+		_, err := newClient(httpCtx).GetFeatureFlag("default", "new-checkout")
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{maxRateLimitWait}, waits)
+	})
+
+	t.Run("waits over the total cap -> APIError without waiting again", func(t *testing.T) {
+		waits = []time.Duration{}
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimited(http.Header{"Retry-After": []string{"3600"}}),
+				rateLimited(http.Header{"Retry-After": []string{"3600"}}),
+			},
+		}
+
+		_, err := newClient(httpCtx).GetFeatureFlag("default", "new-checkout")
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, []time.Duration{maxRateLimitWait}, waits)
+	})
+
+	t.Run("context canceled while waiting -> error without sending again", func(t *testing.T) {
+		waits = []time.Duration{}
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimited(http.Header{"Retry-After": []string{"1"}}),
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := newClient(httpCtx).WithContext(ctx).GetFeatureFlag("default", "new-checkout")
+		require.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "stopped waiting for the rate limit")
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("429 with a body -> the body is sent again", func(t *testing.T) {
+		waits = []time.Duration{}
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimited(http.Header{"Retry-After": []string{"1"}}),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout"}`))},
+			},
+		}
+
+		_, err := newClient(httpCtx).JSONPatchFeatureFlag("default", "new-checkout", []JSONPatchOperation{
+			{Op: "replace", Path: "/environments/production/on", Value: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{time.Second}, waits)
+
+		require.Len(t, httpCtx.Requests, 2)
+		first, _ := io.ReadAll(httpCtx.Requests[0].Body)
+		second, _ := io.ReadAll(httpCtx.Requests[1].Body)
+		assert.NotEmpty(t, second)
+		assert.Equal(t, string(first), string(second))
+	})
+
+	t.Run("429 on every attempt -> APIError after max attempts", func(t *testing.T) {
+		waits = []time.Duration{}
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				rateLimited(http.Header{}),
+				rateLimited(http.Header{}),
+				rateLimited(http.Header{}),
+			},
+		}
+
+		_, err := newClient(httpCtx).GetFeatureFlag("default", "new-checkout")
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.Len(t, httpCtx.Requests, maxRateLimitAttempts)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+	})
+
+	t.Run("other errors -> not retried", func(t *testing.T) {
+		waits = []time.Duration{}
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+			},
+		}
+
+		_, err := newClient(httpCtx).GetFeatureFlag("default", "missing")
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Len(t, httpCtx.Requests, 1)
+		assert.Empty(t, waits)
+	})
+}
+
+func Test__Client__Pagination(t *testing.T) {
+	newClient := func(httpCtx *contexts.HTTPContext) *Client {
+		client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "api-test-key"},
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	page := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	t.Run("next link -> follows it until the last page", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				page(`{"items":[{"key":"production"}],"totalCount":1,"_links":{"next":{"href":"/api/v2/projects/default/environments?limit=200&offset=200"}}}`),
+				page(`{"items":[{"key":"staging"}],"totalCount":2,"_links":{"self":{"href":"/api/v2/projects/default/environments?limit=200&offset=200"}}}`),
+			},
+		}
+
+		environments, err := newClient(httpCtx).ListEnvironments("default")
+		require.NoError(t, err)
+		assert.Equal(t, []Environment{{Key: "production"}, {Key: "staging"}}, environments)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects/default/environments?limit=200", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects/default/environments?limit=200&offset=200", httpCtx.Requests[1].URL.String())
+	})
+
+	t.Run("absolute next link -> requested on the client base URL", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				page(`{"items":[{"key":"default"}],"_links":{"next":{"href":"https://app.launchdarkly.com/api/v2/projects?limit=200&offset=200"}}}`),
+				page(`{"items":[{"key":"mobile"}],"_links":{}}`),
+			},
+		}
+
+		projects, err := newClient(httpCtx).ListProjects()
+		require.NoError(t, err)
+		require.Len(t, projects, 2)
+		assert.Equal(t, "mobile", projects[1].Key)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects?limit=200&offset=200", httpCtx.Requests[1].URL.String())
+	})
+
+	t.Run("next link to a visited page -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				page(`{"items":[{"key":"flag-1"}],"_links":{"next":{"href":"/api/v2/flags/default?limit=200&offset=200"}}}`),
+				page(`{"items":[{"key":"flag-2"}],"_links":{"next":{"href":"/api/v2/flags/default?limit=200&offset=200"}}}`),
+			},
+		}
+
+		_, err := newClient(httpCtx).ListFeatureFlags("default")
+		require.ErrorContains(t, err, "pagination of feature flags returned /api/v2/flags/default?limit=200&offset=200 again")
+		assert.Len(t, httpCtx.Requests, 2)
+	})
+}
+
+func Test__NewClient__BaseURL(t *testing.T) {
+	newClient := func(config map[string]any) (*Client, error) {
+		return NewClient(&contexts.HTTPContext{}, &contexts.IntegrationContext{Configuration: config})
+	}
+
+	t.Run("no base URL -> default LaunchDarkly API", func(t *testing.T) {
+		client, err := newClient(map[string]any{"apiKey": "api-test-key"})
+		require.NoError(t, err)
+		assert.Equal(t, BaseURL, client.BaseURL)
+
+		client, err = newClient(map[string]any{"apiKey": "api-test-key", "baseURL": " "})
+		require.NoError(t, err)
+		assert.Equal(t, BaseURL, client.BaseURL)
+	})
+
+	t.Run("configured base URL -> requests are sent to it", func(t *testing.T) {
+		client, err := newClient(map[string]any{"apiKey": "api-test-key", "baseURL": "https://app.launchdarkly.us/"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://app.launchdarkly.us", client.BaseURL)
+
+		client, err = newClient(map[string]any{"apiKey": "api-test-key", "baseURL": "https://proxy.example.com/launchdarkly"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://proxy.example.com/launchdarkly", client.BaseURL)
+	})
+
+	t.Run("invalid base URL -> error", func(t *testing.T) {
+		_, err := newClient(map[string]any{"apiKey": "api-test-key", "baseURL": "http://app.launchdarkly.us"})
+		require.ErrorContains(t, err, "must use https")
+
+		_, err = newClient(map[string]any{"apiKey": "api-test-key", "baseURL": "https://"})
+		require.ErrorContains(t, err, "must have a host")
+
+		_, err = newClient(map[string]any{"apiKey": "api-test-key", "baseURL": "https://app.launchdarkly.us?region=us"})
+		require.ErrorContains(t, err, "must not have credentials, a query or a fragment")
+	})
+}
+
+func Test__Client__SleepContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := sleepContext(ctx, time.Hour)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
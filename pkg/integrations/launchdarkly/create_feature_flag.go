@@ -0,0 +1,430 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	FlagKindBoolean      = "boolean"
+	FlagKindMultivariate = "multivariate"
+)
+
+type CreateFeatureFlag struct{}
+
+type CreateFeatureFlagSpec struct {
+	ProjectKey  string   `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey     string   `json:"flagKey" mapstructure:"flagKey"`
+	Name        string   `json:"name" mapstructure:"name"`
+	Description string   `json:"description" mapstructure:"description"`
+	Tags        []string `json:"tags" mapstructure:"tags"`
+	Temporary   bool     `json:"temporary" mapstructure:"temporary"`
+
+	//
+	// Kind selects between a boolean flag and a multivariate flag
+	// serving the given string variations. It is ignored when cloning.
+	//
+	Kind       string   `json:"kind" mapstructure:"kind"`
+	Variations []string `json:"variations" mapstructure:"variations"`
+
+	//
+	// CloneFrom is the key of a flag of the project whose variations,
+	// defaults and tags the new flag is created with.
+	//
+	CloneFrom string `json:"cloneFrom" mapstructure:"cloneFrom"`
+}
+
+func (c *CreateFeatureFlag) Name() string {
+	return "launchdarkly.createFeatureFlag"
+}
+
+func (c *CreateFeatureFlag) Label() string {
+	return "Create Feature Flag"
+}
+
+func (c *CreateFeatureFlag) Description() string {
+	return "Create a feature flag in LaunchDarkly, optionally as a clone of another flag"
+}
+
+func (c *CreateFeatureFlag) Documentation() string {
+	return `The Create Feature Flag component creates a feature flag in a LaunchDarkly project.
+
+## Use Cases
+
+- **Release workflows**: Create the flag of a feature as part of its release
+- **Consistent flags**: Clone a template flag so new flags get the same variations, defaults and tags
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project to create the flag in
+- **Key**: The key of the new flag (supports expressions)
+- **Name**: The name of the new flag
+- **Description**: Optional description of the flag
+- **Tags**: Optional tags of the flag
+- **Temporary**: Whether the flag is temporary
+- **Kind**: Boolean, or multivariate to serve string variations
+- **Variations**: The values of a multivariate flag. The first one is served when targeting is on, and the last one when it is off
+- **Clone From**: Optional flag of the project to copy the variations, default variations and tags from
+
+When cloning, the kind and variations of the source flag are used, the tags of the source flag are kept and the configured tags are added to them. Targeting rules are not copied.
+
+## Output
+
+Returns the flag as created by LaunchDarkly, with the project and flag keys. When the flag was cloned, ` + "`clonedFrom`" + ` is the key of the source flag.
+
+## Errors
+
+When the project already has a flag with the key, the execution fails with the ` + "`flag_already_exists`" + ` error code. Enable **Continue On Error** to handle it on the error channel instead, e.g. to reuse the existing flag.`
+}
+
+func (c *CreateFeatureFlag) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *CreateFeatureFlag) Color() string {
+	return "gray"
+}
+
+func (c *CreateFeatureFlag) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateFeatureFlag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Key",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The key of the new flag",
+		},
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The name of the new flag",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Optional description of the flag",
+		},
+		{
+			Name:        "tags",
+			Label:       "Tags",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Optional tags of the flag",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "temporary",
+			Label:       "Temporary",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Mark the flag as temporary, to be removed once rolled out",
+		},
+		{
+			Name:     "kind",
+			Label:    "Kind",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  FlagKindBoolean,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Boolean", Value: FlagKindBoolean},
+						{Label: "Multivariate", Value: FlagKindMultivariate},
+					},
+				},
+			},
+		},
+		{
+			Name:        "variations",
+			Label:       "Variations",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "The string values of the flag. The first one is served when targeting is on, the last one when it is off",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variation",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "kind", Values: []string{FlagKindMultivariate}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "kind", Values: []string{FlagKindMultivariate}},
+			},
+		},
+		{
+			Name:        "cloneFrom",
+			Label:       "Clone From",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Togglable:   true,
+			Description: "Copy the variations, default variations and tags of this flag",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *CreateFeatureFlag) Setup(ctx core.SetupContext) error {
+	spec := CreateFeatureFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateCreateFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateCreateFeatureFlagSpec(spec CreateFeatureFlagSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if strings.TrimSpace(spec.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if strings.TrimSpace(spec.CloneFrom) != "" {
+		if strings.TrimSpace(spec.CloneFrom) == strings.TrimSpace(spec.FlagKey) {
+			return errors.New("a flag cannot be cloned from itself")
+		}
+
+		return nil
+	}
+
+	switch spec.Kind {
+	case "", FlagKindBoolean:
+		return nil
+	case FlagKindMultivariate:
+		_, err := multivariateVariations(spec.Variations)
+		return err
+	default:
+		return fmt.Errorf("invalid kind %q: must be %s or %s", spec.Kind, FlagKindBoolean, FlagKindMultivariate)
+	}
+}
+
+// multivariateVariations returns the variations of a multivariate flag,
+// which needs at least two distinct values.
+func multivariateVariations(values []string) ([]FlagVariation, error) {
+	variations := []FlagVariation{}
+	seen := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if slices.Contains(seen, value) {
+			return nil, fmt.Errorf("variation %q is duplicated", value)
+		}
+
+		seen = append(seen, value)
+		variations = append(variations, FlagVariation{Value: value})
+	}
+
+	if len(variations) < 2 {
+		return nil, errors.New("a multivariate flag needs at least two variations")
+	}
+
+	return variations, nil
+}
+
+func (c *CreateFeatureFlag) Execute(ctx core.ExecutionContext) error {
+	spec := CreateFeatureFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateCreateFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	request := CreateFeatureFlagRequest{
+		Key:         strings.TrimSpace(spec.FlagKey),
+		Name:        strings.TrimSpace(spec.Name),
+		Description: strings.TrimSpace(spec.Description),
+		Temporary:   spec.Temporary,
+	}
+
+	cloneFrom := strings.TrimSpace(spec.CloneFrom)
+	if cloneFrom != "" {
+		source, err := client.GetFeatureFlag(spec.ProjectKey, cloneFrom)
+		if err != nil {
+			return fmt.Errorf("failed to get source feature flag %s: %w", cloneFrom, err)
+		}
+
+		if err := cloneFlagSettings(&request, source); err != nil {
+			return fmt.Errorf("failed to clone feature flag %s: %w", cloneFrom, err)
+		}
+	} else if spec.Kind == FlagKindMultivariate {
+		variations, err := multivariateVariations(spec.Variations)
+		if err != nil {
+			return err
+		}
+
+		request.Variations = variations
+		request.Defaults = &FlagDefaults{OnVariation: 0, OffVariation: len(variations) - 1}
+	}
+
+	request.Tags = appendTags(request.Tags, spec.Tags)
+
+	created, err := client.CreateFeatureFlag(spec.ProjectKey, request)
+	if err != nil {
+		return fmt.Errorf("failed to create feature flag: %w", err)
+	}
+
+	output := maps.Clone(created)
+	if output == nil {
+		output = map[string]any{}
+	}
+
+	output["projectKey"] = spec.ProjectKey
+	output["flagKey"] = request.Key
+
+	if cloneFrom != "" {
+		output["clonedFrom"] = cloneFrom
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.created",
+		[]any{output},
+	)
+}
+
+// cloneFlagSettings copies the variations, default variations and tags of the source flag into the request.
+// Variation IDs are left out, since LaunchDarkly assigns new ones to the created flag.
+func cloneFlagSettings(request *CreateFeatureFlagRequest, source map[string]any) error {
+	variations, _ := source["variations"].([]any)
+	if len(variations) == 0 {
+		return errors.New("the flag has no variations")
+	}
+
+	for i, item := range variations {
+		variation, ok := item.(map[string]any)
+		if !ok {
+			return fmt.Errorf("variation %d has an unexpected format", i)
+		}
+
+		name, _ := variation["name"].(string)
+		description, _ := variation["description"].(string)
+		request.Variations = append(request.Variations, FlagVariation{
+			Value:       variation["value"],
+			Name:        name,
+			Description: description,
+		})
+	}
+
+	if defaults, ok := source["defaults"].(map[string]any); ok {
+		on, onOK := defaults["onVariation"].(float64)
+		off, offOK := defaults["offVariation"].(float64)
+		if onOK && offOK {
+			request.Defaults = &FlagDefaults{OnVariation: int(on), OffVariation: int(off)}
+		}
+	}
+
+	tags, _ := source["tags"].([]any)
+	for _, tag := range tags {
+		if value, ok := tag.(string); ok {
+			request.Tags = append(request.Tags, value)
+		}
+	}
+
+	return nil
+}
+
+// appendTags adds the tags that are not already present, keeping their order.
+func appendTags(tags []string, more []string) []string {
+	for _, tag := range more {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func (c *CreateFeatureFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *CreateFeatureFlag) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *CreateFeatureFlag) Actions() []core.Action {
+	return nil
+}
+
+func (c *CreateFeatureFlag) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *CreateFeatureFlag) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateFeatureFlag) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,284 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func flagResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func Test__CreateFeatureFlag__Setup(t *testing.T) {
+	component := &CreateFeatureFlag{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "name": "New checkout"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing flag key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "name": "New checkout"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("missing name returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "name is required")
+	})
+
+	t.Run("clone from the same flag returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "name": "New checkout", "cloneFrom": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "cannot be cloned from itself")
+	})
+
+	t.Run("invalid kind returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "name": "New checkout", "kind": "number"},
+		})
+
+		require.ErrorContains(t, err, `invalid kind "number"`)
+	})
+
+	t.Run("multivariate with a single variation returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"flagKey":    "new-checkout",
+				"name":       "New checkout",
+				"kind":       FlagKindMultivariate,
+				"variations": []any{"control", " "},
+			},
+		})
+
+		require.ErrorContains(t, err, "at least two variations")
+	})
+
+	t.Run("multivariate with duplicated variations returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"flagKey":    "new-checkout",
+				"name":       "New checkout",
+				"kind":       FlagKindMultivariate,
+				"variations": []any{"control", "control"},
+			},
+		})
+
+		require.ErrorContains(t, err, `variation "control" is duplicated`)
+	})
+}
+
+func Test__CreateFeatureFlag__Execute(t *testing.T) {
+	component := &CreateFeatureFlag{}
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{"apiKey": "test-api-key"},
+	}
+
+	t.Run("no clone -> creates a boolean flag", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				flagResponse(http.StatusCreated, `{"key":"new-checkout","name":"New checkout","kind":"boolean","variations":[{"value":true},{"value":false}],"tags":["checkout"]}`),
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "new-checkout", "name": "New checkout", "tags": []any{"checkout"}},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default", req.URL.String())
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"key":"new-checkout","name":"New checkout","temporary":false,"tags":["checkout"]}`, string(body))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.created", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "new-checkout", data["flagKey"])
+		assert.Equal(t, "boolean", data["kind"])
+		assert.NotContains(t, data, "clonedFrom")
+	})
+
+	t.Run("clone -> created flag mirrors the variations, defaults and tags of the source", func(t *testing.T) {
+		source := `{
+			"key": "experiment-template",
+			"kind": "multivariate",
+			"variations": [
+				{"_id": "v1", "value": "control", "name": "Control", "description": "Current experience"},
+				{"_id": "v2", "value": "treatment", "name": "Treatment"}
+			],
+			"defaults": {"onVariation": 1, "offVariation": 0},
+			"tags": ["experiment", "checkout"]
+		}`
+
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				flagResponse(http.StatusOK, source),
+				flagResponse(http.StatusCreated, `{"key":"new-checkout","name":"New checkout","kind":"multivariate","variations":[{"_id":"n1","value":"control","name":"Control"},{"_id":"n2","value":"treatment","name":"Treatment"}],"tags":["experiment","checkout","q3"]}`),
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"flagKey":    "new-checkout",
+				"name":       "New checkout",
+				"tags":       []any{"checkout", "q3"},
+				"cloneFrom":  "experiment-template",
+			},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/experiment-template", httpContext.Requests[0].URL.String())
+
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		var created CreateFeatureFlagRequest
+		require.NoError(t, json.Unmarshal(body, &created))
+		assert.Equal(t, CreateFeatureFlagRequest{
+			Key:  "new-checkout",
+			Name: "New checkout",
+			Tags: []string{"experiment", "checkout", "q3"},
+			Variations: []FlagVariation{
+				{Value: "control", Name: "Control", Description: "Current experience"},
+				{Value: "treatment", Name: "Treatment"},
+			},
+			Defaults: &FlagDefaults{OnVariation: 1, OffVariation: 0},
+		}, created)
+		assert.NotContains(t, string(body), "_id")
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "experiment-template", data["clonedFrom"])
+		assert.Equal(t, []any{"experiment", "checkout", "q3"}, data["tags"])
+	})
+
+	t.Run("source flag not found -> error without creating the flag", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				flagResponse(http.StatusNotFound, `{"code":"not_found","message":"Unknown resource"}`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "new-checkout", "name": "New checkout", "cloneFrom": "missing"},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to get source feature flag missing")
+		assert.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("multivariate -> creates the flag with the variations and defaults", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				flagResponse(http.StatusCreated, `{"key":"new-checkout","name":"New checkout","kind":"multivariate","_version":1,"variations":[{"value":"control"},{"value":"treatment"}]}`),
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"flagKey":    "new-checkout",
+				"name":       "New checkout",
+				"kind":       FlagKindMultivariate,
+				"variations": []any{"control", "treatment"},
+			},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"key":"new-checkout",
+			"name":"New checkout",
+			"temporary":false,
+			"variations":[{"value":"control"},{"value":"treatment"}],
+			"defaults":{"onVariation":0,"offVariation":1}
+		}`, string(body))
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "default", data["projectKey"])
+		assert.Equal(t, "new-checkout", data["flagKey"])
+		assert.Equal(t, "multivariate", data["kind"])
+		assert.Equal(t, float64(1), data["_version"])
+	})
+
+	t.Run("flag already exists -> typed error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				flagResponse(http.StatusConflict, `{"code":"conflict","message":"A feature flag with that key already exists"}`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "new-checkout", "name": "New checkout"},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		var existsErr *FlagAlreadyExistsError
+		require.ErrorAs(t, err, &existsErr)
+		assert.Equal(t, "default", existsErr.ProjectKey)
+		assert.Equal(t, "new-checkout", existsErr.FlagKey)
+		assert.ErrorContains(t, err, "feature flag new-checkout already exists in project default")
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+
+		assert.Equal(t, ErrorCodeFlagAlreadyExists, (&LaunchDarkly{}).DescribeError(err).Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -15,8 +16,9 @@ import (
 type DeleteFeatureFlag struct{}
 
 type DeleteFeatureFlagSpec struct {
-	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
-	FlagKey    string `json:"flagKey" mapstructure:"flagKey"`
+	ProjectKey  string   `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey     string   `json:"flagKey" mapstructure:"flagKey"`
+	ProjectKeys []string `json:"projectKeys,omitempty" mapstructure:"projectKeys"`
 }
 
 func (c *DeleteFeatureFlag) Name() string {
@@ -32,22 +34,28 @@ func (c *DeleteFeatureFlag) Description() string {
 }
 
 func (c *DeleteFeatureFlag) Documentation() string {
-	return `The Delete Feature Flag component permanently deletes a feature flag from a LaunchDarkly project.
+	return `The Delete Feature Flag component permanently deletes a feature flag from a LaunchDarkly project, or from several projects that use the same flag key.
 
 ## Use Cases
 
 - **Flag cleanup**: Remove stale or temporary flags after rollout is complete
 - **Automated lifecycle**: Delete flags as part of a release workflow
 - **Maintenance workflows**: Clean up archived flags that are no longer needed
+- **Shared flag keys**: Remove a flag that every project defines under the same key in one step
 
 ## Configuration
 
 - **Project Key**: The key of the LaunchDarkly project containing the flag
 - **Flag Key**: The key of the feature flag to delete (supports expressions)
+- **Additional Projects**: Optional keys of other projects to delete the flag with the same key from
 
 ## Output
 
-Returns a confirmation payload with the deleted flag's project and flag keys.
+Returns the project and flag keys, and a ` + "`status`" + `:
+- ` + "`success`" + `: The flag was deleted from at least one project
+- ` + "`skipped`" + `: The flag did not exist in any project, so there was nothing to delete
+
+The ` + "`projects`" + ` list holds the result of every project, with the same ` + "`deleted`" + ` and ` + "`status`" + ` fields. Projects without the flag do not fail the run. If deleting fails in some projects, the others are still deleted and the run fails naming the projects that failed.
 
 **Warning**: This action is irreversible. Once deleted, the flag and all its targeting rules are permanently removed.`
 }
@@ -96,6 +104,22 @@ func (c *DeleteFeatureFlag) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "projectKeys",
+			Label:       "Additional Projects",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Keys of other projects to delete the flag with the same key from",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Project Key",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -105,6 +129,14 @@ func (c *DeleteFeatureFlag) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
+	if err := validateDeleteFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateDeleteFeatureFlagSpec(spec DeleteFeatureFlagSpec) error {
 	if strings.TrimSpace(spec.ProjectKey) == "" {
 		return errors.New("project key is required")
 	}
@@ -116,18 +148,28 @@ func (c *DeleteFeatureFlag) Setup(ctx core.SetupContext) error {
 	return nil
 }
 
+// deleteFeatureFlagProjects returns the project and the additional projects to delete the flag from,
+// without blank or repeated keys.
+func deleteFeatureFlagProjects(spec DeleteFeatureFlagSpec) []string {
+	projects := []string{strings.TrimSpace(spec.ProjectKey)}
+	for _, key := range spec.ProjectKeys {
+		key = strings.TrimSpace(key)
+		if key != "" && !slices.Contains(projects, key) {
+			projects = append(projects, key)
+		}
+	}
+
+	return projects
+}
+
 func (c *DeleteFeatureFlag) Execute(ctx core.ExecutionContext) error {
 	spec := DeleteFeatureFlagSpec{}
 	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
-	if strings.TrimSpace(spec.ProjectKey) == "" {
-		return errors.New("project key is required")
-	}
-
-	if strings.TrimSpace(spec.FlagKey) == "" {
-		return errors.New("flag key is required")
+	if err := validateDeleteFeatureFlagSpec(spec); err != nil {
+		return err
 	}
 
 	client, err := NewClient(ctx.HTTP, ctx.Integration)
@@ -135,14 +177,48 @@ func (c *DeleteFeatureFlag) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
 	}
 
-	if err := client.DeleteFeatureFlag(spec.ProjectKey, spec.FlagKey); err != nil {
-		return fmt.Errorf("failed to delete feature flag: %w", err)
+	projectKeys := deleteFeatureFlagProjects(spec)
+	projects := make([]map[string]any, 0, len(projectKeys))
+	var failed []error
+	deletedAny := false
+	for _, projectKey := range projectKeys {
+		deleted, err := deleteFeatureFlagFromProject(client, projectKey, spec.FlagKey)
+		if err != nil {
+			failed = append(failed, fmt.Errorf("project %s: %w", projectKey, err))
+			continue
+		}
+
+		status := core.ResultStatusSuccess
+		if !deleted {
+			status = core.ResultStatusSkipped
+		}
+
+		deletedAny = deletedAny || deleted
+		projects = append(projects, map[string]any{
+			"projectKey":           projectKey,
+			"deleted":              deleted,
+			core.ResultStatusField: status,
+		})
+	}
+
+	if len(failed) > 0 {
+		if len(projectKeys) == 1 {
+			return fmt.Errorf("failed to delete feature flag: %w", failed[0])
+		}
+
+		return fmt.Errorf("failed to delete feature flag from %d of %d projects: %w", len(failed), len(projectKeys), errors.Join(failed...))
 	}
 
 	result := map[string]any{
-		"projectKey": spec.ProjectKey,
-		"flagKey":    spec.FlagKey,
-		"deleted":    true,
+		"projectKey":           spec.ProjectKey,
+		"flagKey":              spec.FlagKey,
+		"deleted":              deletedAny,
+		"projects":             projects,
+		core.ResultStatusField: core.ResultStatusSuccess,
+	}
+
+	if !deletedAny {
+		result[core.ResultStatusField] = core.ResultStatusSkipped
 	}
 
 	return ctx.ExecutionState.Emit(
@@ -152,6 +228,25 @@ func (c *DeleteFeatureFlag) Execute(ctx core.ExecutionContext) error {
 	)
 }
 
+// deleteFeatureFlagFromProject deletes the flag from the project, and reports whether it existed.
+func deleteFeatureFlagFromProject(client *Client, projectKey, flagKey string) (bool, error) {
+	err := client.DeleteFeatureFlag(projectKey, flagKey)
+	if err == nil {
+		return true, nil
+	}
+
+	//
+	// A flag that no longer exists is what this component wants to achieve,
+	// so it is reported as skipped instead of failing the run.
+	//
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	return false, err
+}
+
 func (c *DeleteFeatureFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
 	return ctx.DefaultProcessing()
 }
@@ -27,6 +27,26 @@ func Test__DeleteFeatureFlag__Setup(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("reader token -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "my-feature"},
+			Integration:   &contexts.IntegrationContext{Metadata: map[string]any{"tokenRole": "reader"}},
+		})
+
+		require.ErrorContains(t, err, "the API access token has the reader role and cannot make changes in LaunchDarkly")
+	})
+
+	t.Run("writer or unknown token -> no error", func(t *testing.T) {
+		for _, role := range []string{TokenRoleWriter, TokenRoleCustom, TokenRoleUnknown} {
+			err := component.Setup(core.SetupContext{
+				Configuration: map[string]any{"projectKey": "default", "flagKey": "my-feature"},
+				Integration:   &contexts.IntegrationContext{Metadata: Metadata{TokenRole: role}},
+			})
+
+			require.NoError(t, err, role)
+		}
+	})
+
 	t.Run("missing project key returns error", func(t *testing.T) {
 		err := component.Setup(core.SetupContext{
 			Configuration: map[string]any{
@@ -109,6 +129,54 @@ func Test__DeleteFeatureFlag__Execute(t *testing.T) {
 		assert.Equal(t, "default", data["projectKey"])
 		assert.Equal(t, "old-feature", data["flagKey"])
 		assert.Equal(t, true, data["deleted"])
+		assert.Equal(t, core.ResultStatusSuccess, data["status"])
+	})
+
+	t.Run("flag not found -> emits skipped status", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"not_found","message":"Unknown resource"}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "old-feature"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execStateCtx.Payloads, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["deleted"])
+		assert.Equal(t, core.ResultStatusSkipped, data["status"])
+	})
+
+	t.Run("API error -> returns error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"forbidden"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "old-feature"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to delete feature flag")
 	})
 
 	t.Run("missing project key returns error before API call", func(t *testing.T) {
@@ -146,4 +214,87 @@ func Test__DeleteFeatureFlag__Execute(t *testing.T) {
 		require.ErrorContains(t, err, "flag key is required")
 		assert.Empty(t, httpContext.Requests)
 	})
+
+	t.Run("multiple projects with and without the flag -> aggregates per-project results", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":  "default",
+				"flagKey":     "old-feature",
+				"projectKeys": []any{"mobile", " web ", "default", ""},
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/old-feature", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/mobile/old-feature", httpContext.Requests[1].URL.String())
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/web/old-feature", httpContext.Requests[2].URL.String())
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["deleted"])
+		assert.Equal(t, core.ResultStatusSuccess, data["status"])
+		assert.Equal(t, []map[string]any{
+			{"projectKey": "default", "deleted": true, "status": core.ResultStatusSuccess},
+			{"projectKey": "mobile", "deleted": false, "status": core.ResultStatusSkipped},
+			{"projectKey": "web", "deleted": true, "status": core.ResultStatusSuccess},
+		}, data["projects"])
+	})
+
+	t.Run("flag absent from every project -> skipped", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "old-feature", "projectKeys": []any{"mobile"}},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["deleted"])
+		assert.Equal(t, core.ResultStatusSkipped, data["status"])
+	})
+
+	t.Run("delete fails in one of several projects -> deletes the others and returns error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "old-feature", "projectKeys": []any{"mobile"}},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.ErrorContains(t, err, "failed to delete feature flag from 1 of 2 projects: project default: request failed with 403")
+		assert.Len(t, httpContext.Requests, 2)
+		assert.Empty(t, execStateCtx.Payloads)
+	})
 }
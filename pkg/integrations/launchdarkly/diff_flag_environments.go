@@ -0,0 +1,339 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type DiffFlagEnvironments struct{}
+
+type DiffFlagEnvironmentsSpec struct {
+	ProjectKey        string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey           string `json:"flagKey" mapstructure:"flagKey"`
+	SourceEnvironment string `json:"sourceEnvironment" mapstructure:"sourceEnvironment"`
+	TargetEnvironment string `json:"targetEnvironment" mapstructure:"targetEnvironment"`
+}
+
+// diffedTargetingFields are the targeting settings compared between environments, in output order.
+// Rules are compared one by one, after them.
+var diffedTargetingFields = []string{"on", "offVariation", "fallthrough", "prerequisites", "targets", "contextTargets"}
+
+func (c *DiffFlagEnvironments) Name() string {
+	return "launchdarkly.diffFlagEnvironments"
+}
+
+func (c *DiffFlagEnvironments) Label() string {
+	return "Diff Flag Environments"
+}
+
+func (c *DiffFlagEnvironments) Description() string {
+	return "Compare the targeting of a LaunchDarkly feature flag between two environments"
+}
+
+func (c *DiffFlagEnvironments) Documentation() string {
+	return `The Diff Flag Environments component compares the targeting of a feature flag in two environments, e.g. staging and production, and returns the differences.
+
+## Use Cases
+
+- **Promotion workflows**: Show what changes when the targeting of staging is promoted to production
+- **Drift detection**: Alert when the targeting of environments that should match has drifted apart
+- **Release reviews**: Attach the targeting differences of a flag to a release approval
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to compare
+- **Source Environment**: The environment compared from, e.g. staging
+- **Target Environment**: The environment compared to, e.g. production
+
+## Output
+
+Returns the project, flag and environment keys, whether the targeting is identical, and the list of differences. Each difference has:
+- The field that differs: ` + "`on`, `offVariation`, `fallthrough`, `prerequisites`, `targets`, `contextTargets`" + ` or a rule, as ` + "`rules[<index>]`" + `
+- Its value in the source environment and in the target environment, empty when a rule only exists in one of them
+
+Rules are compared in evaluation order by their clauses and what they serve, so rules that only differ by ID are identical.`
+}
+
+func (c *DiffFlagEnvironments) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *DiffFlagEnvironments) Color() string {
+	return "gray"
+}
+
+func (c *DiffFlagEnvironments) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *DiffFlagEnvironments) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to compare",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "sourceEnvironment",
+			Label:       "Source Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment compared from, e.g. staging",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "targetEnvironment",
+			Label:       "Target Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment compared to, e.g. production",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *DiffFlagEnvironments) Setup(ctx core.SetupContext) error {
+	spec := DiffFlagEnvironmentsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateDiffFlagEnvironmentsSpec(spec)
+}
+
+func validateDiffFlagEnvironmentsSpec(spec DiffFlagEnvironmentsSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	source := strings.TrimSpace(spec.SourceEnvironment)
+	if source == "" {
+		return errors.New("source environment is required")
+	}
+
+	target := strings.TrimSpace(spec.TargetEnvironment)
+	if target == "" {
+		return errors.New("target environment is required")
+	}
+
+	if source == target {
+		return errors.New("source and target environments must be different")
+	}
+
+	return nil
+}
+
+func (c *DiffFlagEnvironments) Execute(ctx core.ExecutionContext) error {
+	spec := DiffFlagEnvironmentsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateDiffFlagEnvironmentsSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	sourceKey := strings.TrimSpace(spec.SourceEnvironment)
+	targetKey := strings.TrimSpace(spec.TargetEnvironment)
+	differences, err := diffFlagEnvironments(flag, sourceKey, targetKey)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.diff",
+		[]any{
+			map[string]any{
+				"projectKey":        spec.ProjectKey,
+				"flagKey":           spec.FlagKey,
+				"sourceEnvironment": sourceKey,
+				"targetEnvironment": targetKey,
+				"identical":         len(differences) == 0,
+				"differences":       differences,
+			},
+		},
+	)
+}
+
+// diffFlagEnvironments lists the targeting settings of the flag that differ between two environments.
+func diffFlagEnvironments(flag map[string]any, sourceKey, targetKey string) ([]map[string]any, error) {
+	environments, _ := flag["environments"].(map[string]any)
+	source, ok := environments[sourceKey].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("environment %s not found for flag", sourceKey)
+	}
+
+	target, ok := environments[targetKey].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("environment %s not found for flag", targetKey)
+	}
+
+	differences := []map[string]any{}
+	for _, field := range diffedTargetingFields {
+		sourceValue := targetingValue(source, field)
+		targetValue := targetingValue(target, field)
+		if !reflect.DeepEqual(sourceValue, targetValue) {
+			differences = append(differences, targetingDifference(field, sourceValue, targetValue))
+		}
+	}
+
+	variations, _ := flag["variations"].([]any)
+	sourceRules, err := comparableFlagRules(source["rules"], variations)
+	if err != nil {
+		return nil, err
+	}
+
+	targetRules, err := comparableFlagRules(target["rules"], variations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < max(len(sourceRules), len(targetRules)); i++ {
+		var sourceRule, targetRule any
+		if i < len(sourceRules) {
+			sourceRule = sourceRules[i]
+		}
+
+		if i < len(targetRules) {
+			targetRule = targetRules[i]
+		}
+
+		if !reflect.DeepEqual(sourceRule, targetRule) {
+			differences = append(differences, targetingDifference(fmt.Sprintf("rules[%d]", i), sourceRule, targetRule))
+		}
+	}
+
+	return differences, nil
+}
+
+// targetingValue reads a targeting setting, treating missing lists as empty
+// so an environment without targets matches one with an empty list of them.
+func targetingValue(environment map[string]any, field string) any {
+	value := environment[field]
+	if value != nil {
+		return value
+	}
+
+	switch field {
+	case "prerequisites", "targets", "contextTargets":
+		return []any{}
+	default:
+		return nil
+	}
+}
+
+func targetingDifference(field string, source, target any) map[string]any {
+	return map[string]any{
+		"field":  field,
+		"source": source,
+		"target": target,
+	}
+}
+
+// comparableFlagRules normalizes the rules of an environment without their IDs,
+// which are generated per environment and never match.
+func comparableFlagRules(rawRules any, variations []any) ([]map[string]any, error) {
+	rules, err := normalizeFlagRules(rawRules, variations)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		delete(rule, "id")
+	}
+
+	return rules, nil
+}
+
+func (c *DiffFlagEnvironments) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *DiffFlagEnvironments) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *DiffFlagEnvironments) Actions() []core.Action {
+	return nil
+}
+
+func (c *DiffFlagEnvironments) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *DiffFlagEnvironments) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *DiffFlagEnvironments) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
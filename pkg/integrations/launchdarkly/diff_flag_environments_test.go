@@ -0,0 +1,202 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DiffFlagEnvironments__Setup(t *testing.T) {
+	component := &DiffFlagEnvironments{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":        "default",
+				"flagKey":           "new-checkout",
+				"sourceEnvironment": "staging",
+				"targetEnvironment": "production",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"flagKey": "new-checkout", "sourceEnvironment": "staging", "targetEnvironment": "production"},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing flag key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "sourceEnvironment": "staging", "targetEnvironment": "production"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("missing source environment -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "targetEnvironment": "production"},
+		})
+
+		require.ErrorContains(t, err, "source environment is required")
+	})
+
+	t.Run("missing target environment -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "sourceEnvironment": "staging"},
+		})
+
+		require.ErrorContains(t, err, "target environment is required")
+	})
+
+	t.Run("same source and target environment -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":        "default",
+				"flagKey":           "new-checkout",
+				"sourceEnvironment": "production",
+				"targetEnvironment": "production",
+			},
+		})
+
+		require.ErrorContains(t, err, "source and target environments must be different")
+	})
+}
+
+func Test__DiffFlagEnvironments__Execute(t *testing.T) {
+	component := &DiffFlagEnvironments{}
+
+	execute := func(t *testing.T, flagResponse string) (*contexts.ExecutionStateContext, *contexts.HTTPContext, error) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":        "default",
+				"flagKey":           "new-checkout",
+				"sourceEnvironment": "staging",
+				"targetEnvironment": "production",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return execStateCtx, httpContext, err
+	}
+
+	t.Run("identical targeting -> no differences", func(t *testing.T) {
+		execStateCtx, httpContext, err := execute(t, `{
+			"key": "new-checkout",
+			"variations": [{"value": true}, {"value": false}],
+			"environments": {
+				"staging": {
+					"on": true,
+					"offVariation": 1,
+					"fallthrough": {"variation": 0},
+					"rules": [
+						{"_id": "staging-rule", "variation": 0, "clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"]}]}
+					]
+				},
+				"production": {
+					"on": true,
+					"offVariation": 1,
+					"fallthrough": {"variation": 0},
+					"targets": [],
+					"rules": [
+						{"_id": "production-rule", "variation": 0, "clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"]}]}
+					]
+				}
+			}
+		}`)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", httpContext.Requests[0].URL.String())
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.diff", payload["type"])
+
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "default", data["projectKey"])
+		assert.Equal(t, "new-checkout", data["flagKey"])
+		assert.Equal(t, "staging", data["sourceEnvironment"])
+		assert.Equal(t, "production", data["targetEnvironment"])
+		assert.Equal(t, true, data["identical"])
+		assert.Equal(t, []map[string]any{}, data["differences"])
+	})
+
+	t.Run("differing targeting -> lists the differences", func(t *testing.T) {
+		execStateCtx, _, err := execute(t, `{
+			"key": "new-checkout",
+			"variations": [{"value": true}, {"value": false}],
+			"environments": {
+				"staging": {
+					"on": true,
+					"offVariation": 1,
+					"fallthrough": {"variation": 0},
+					"targets": [{"variation": 0, "values": ["user-1"]}],
+					"rules": [
+						{"_id": "rule-1", "variation": 0, "clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"]}]},
+						{"_id": "rule-2", "variation": 1, "clauses": [{"attribute": "country", "op": "in", "values": ["DE"]}]}
+					]
+				},
+				"production": {
+					"on": false,
+					"offVariation": 1,
+					"fallthrough": {"variation": 1},
+					"rules": [
+						{"_id": "rule-3", "variation": 0, "clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"]}]}
+					]
+				}
+			}
+		}`)
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["identical"])
+
+		assert.Equal(t, []map[string]any{
+			{"field": "on", "source": true, "target": false},
+			{"field": "fallthrough", "source": map[string]any{"variation": float64(0)}, "target": map[string]any{"variation": float64(1)}},
+			{"field": "targets", "source": []any{map[string]any{"variation": float64(0), "values": []any{"user-1"}}}, "target": []any{}},
+			{
+				"field": "rules[1]",
+				"source": map[string]any{
+					"index":       1,
+					"description": "",
+					"clauses": []map[string]any{
+						{"attribute": "country", "contextKind": "", "op": "in", "values": []any{"DE"}, "negate": false},
+					},
+					"trackEvents": false,
+					"variation":   map[string]any{"index": 1, "value": false},
+					"rollout":     nil,
+				},
+				"target": nil,
+			},
+		}, data["differences"])
+	})
+
+	t.Run("unknown environment -> error", func(t *testing.T) {
+		_, _, err := execute(t, `{"key":"new-checkout","environments":{"staging":{"on":true}}}`)
+		require.ErrorContains(t, err, "environment production not found for flag")
+	})
+}
@@ -0,0 +1,766 @@
+package launchdarkly
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const defaultContextKind = "user"
+
+// How the context a flag is evaluated for is given.
+const (
+	ContextModeSingle = "single"
+	ContextModeObject = "object"
+)
+
+var AllContextModes = []configuration.FieldOption{
+	{Label: "Key and kind", Value: ContextModeSingle},
+	{Label: "Context object", Value: ContextModeObject},
+}
+
+// Evaluation reasons, named after the reasons reported by the LaunchDarkly SDKs.
+const (
+	EvaluationReasonOff         = "OFF"
+	EvaluationReasonTargetMatch = "TARGET_MATCH"
+	EvaluationReasonRuleMatch   = "RULE_MATCH"
+	EvaluationReasonFallthrough = "FALLTHROUGH"
+)
+
+// bucketScale is the largest value of the 15 hex digits used for bucketing.
+const bucketScale = float64(0xFFFFFFFFFFFFFFF)
+
+type EvaluateFlag struct{}
+
+type EvaluateFlagSpec struct {
+	ProjectKey        string         `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey           string         `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey    string         `json:"environmentKey" mapstructure:"environmentKey"`
+	ContextKey        string         `json:"contextKey" mapstructure:"contextKey"`
+	ContextKind       string         `json:"contextKind" mapstructure:"contextKind"`
+	ContextAttributes map[string]any `json:"contextAttributes" mapstructure:"contextAttributes"`
+
+	// ContextMode is "object" to evaluate the flag for Context, a LaunchDarkly context object
+	// that can be a multi-context. Nodes set up before it was added use the context key.
+	ContextMode string `json:"contextMode" mapstructure:"contextMode"`
+	Context     any    `json:"context" mapstructure:"context"`
+}
+
+// flagEnvironmentConfig is the targeting of a flag in one environment, as returned by the API.
+type flagEnvironmentConfig struct {
+	On             bool            `json:"on"`
+	OffVariation   *int            `json:"offVariation"`
+	Fallthrough    flagFallthrough `json:"fallthrough"`
+	Targets        []flagTarget    `json:"targets"`
+	ContextTargets []flagTarget    `json:"contextTargets"`
+	Rules          []flagRule      `json:"rules"`
+	Salt           string          `json:"salt"`
+}
+
+type flagFallthrough struct {
+	Variation *int         `json:"variation"`
+	Rollout   *flagRollout `json:"rollout"`
+}
+
+type flagTarget struct {
+	ContextKind string   `json:"contextKind"`
+	Values      []string `json:"values"`
+	Variation   int      `json:"variation"`
+}
+
+// evaluationContext is the context a flag is evaluated for.
+type evaluationContext struct {
+	Kind       string
+	Key        string
+	Attributes map[string]any
+}
+
+func (c evaluationContext) attribute(name string) (any, bool) {
+	switch name {
+	case "key":
+		return c.Key, true
+	case "kind":
+		return c.Kind, true
+	}
+
+	value, ok := c.Attributes[name]
+	return value, ok
+}
+
+// flagEvaluation is the variation served to a context, and why.
+type flagEvaluation struct {
+	VariationIndex *int
+	Reason         map[string]any
+	Bucket         *float64
+}
+
+func (c *EvaluateFlag) Name() string {
+	return "launchdarkly.evaluateFlag"
+}
+
+func (c *EvaluateFlag) Label() string {
+	return "Evaluate Flag"
+}
+
+func (c *EvaluateFlag) Description() string {
+	return "Evaluate a LaunchDarkly feature flag for a context"
+}
+
+func (c *EvaluateFlag) Documentation() string {
+	return `The Evaluate Flag component computes the variation a feature flag serves to a context in one environment, the same way the LaunchDarkly SDKs do.
+
+## Use Cases
+
+- **Canary gates**: Check whether a host, tenant or user is in the canary percentage of a rollout
+- **Rollout previews**: See which variation a context gets before changing the targeting
+- **Debugging**: Find out which target or rule serves a variation to a context
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to evaluate
+- **Environment**: The environment whose targeting is evaluated
+- **Context**: Give the context by key and kind, or as a LaunchDarkly context object
+- **Context Key**: The key of the context (supports expressions)
+- **Context Kind**: The kind of the context, ` + "`user`" + ` by default
+- **Context Attributes**: Optional JSON object with the attributes used by targeting rules and rollouts
+- **Context Object**: A LaunchDarkly context, for example ` + "`" + `{"kind": "user", "key": "u1", "email": "dev@example.com"}` + "`" + `, or a multi-context with one context per kind, for example ` + "`" + `{"kind": "multi", "user": {"key": "u1"}, "device": {"key": "d1"}, "organization": {"key": "acme"}}` + "`" + `
+
+Context objects are validated like LaunchDarkly does: every context needs a key and a kind made of letters, numbers, ` + "`.`" + `, ` + "`_`" + ` and ` + "`-`" + `, and the contexts of a multi-context must not have their own kind.
+With a multi-context, targets, rules and rollouts use the context of their kind, and the ones without a kind use the ` + "`user`" + ` context.
+
+## Evaluation
+
+Targeting is evaluated in order: the off variation when targeting is off, individual targets, rules, and the default rule.
+Percentage rollouts use LaunchDarkly's bucketing algorithm, so a context lands in the same bucket as in the SDKs for the same flag key, salt or seed, and bucketing attribute.
+Segment and semantic version operators are not supported, and fail the evaluation instead of serving a different variation than the SDKs.
+
+## Output
+
+Returns the project, flag and environment keys, the context, the served value and variation index, the reason, and the bucket of the context when the variation comes from a rollout.`
+}
+
+func (c *EvaluateFlag) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *EvaluateFlag) Color() string {
+	return "gray"
+}
+
+func (c *EvaluateFlag) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *EvaluateFlag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to evaluate",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment whose targeting is evaluated",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:     "contextMode",
+			Label:    "Context",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  ContextModeSingle,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllContextModes,
+				},
+			},
+		},
+		{
+			Name:        "contextKey",
+			Label:       "Context Key",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "The key of the context to evaluate the flag for",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "contextMode", Values: []string{ContextModeSingle}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "contextMode", Values: []string{ContextModeSingle}},
+			},
+		},
+		{
+			Name:        "contextKind",
+			Label:       "Context Kind",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     defaultContextKind,
+			Description: "The kind of the context",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "contextMode", Values: []string{ContextModeSingle}},
+			},
+		},
+		{
+			Name:        "contextAttributes",
+			Label:       "Context Attributes",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Togglable:   true,
+			Description: "Attributes of the context used by targeting rules and rollouts",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "contextMode", Values: []string{ContextModeSingle}},
+			},
+		},
+		{
+			Name:        "context",
+			Label:       "Context Object",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Default:     "{\"kind\":\"multi\",\"user\":{\"key\":\"user-key\"},\"organization\":{\"key\":\"org-key\"}}",
+			Description: "A LaunchDarkly context or multi-context, with a kind and a key for every context",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "contextMode", Values: []string{ContextModeObject}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "contextMode", Values: []string{ContextModeObject}},
+			},
+		},
+	}
+}
+
+func (c *EvaluateFlag) Setup(ctx core.SetupContext) error {
+	spec := EvaluateFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateEvaluateFlagSpec(spec); err != nil {
+		return err
+	}
+
+	//
+	// Context objects built from expressions are only known at execution time.
+	//
+	if spec.ContextMode != ContextModeObject {
+		return nil
+	}
+
+	if text, ok := spec.Context.(string); ok && strings.Contains(text, "{{") {
+		return nil
+	}
+
+	_, err := parseFlagContext(spec.Context)
+	return err
+}
+
+func validateEvaluateFlagSpec(spec EvaluateFlagSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if strings.TrimSpace(spec.EnvironmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	switch spec.ContextMode {
+	case "", ContextModeSingle:
+		if strings.TrimSpace(spec.ContextKey) == "" {
+			return errors.New("context key is required")
+		}
+	case ContextModeObject:
+		if spec.Context == nil {
+			return errors.New("context is required")
+		}
+	default:
+		return fmt.Errorf("invalid context mode %q", spec.ContextMode)
+	}
+
+	return nil
+}
+
+// specFlagContext returns the context the configuration evaluates the flag for.
+func specFlagContext(spec EvaluateFlagSpec) (flagContext, error) {
+	if spec.ContextMode == ContextModeObject {
+		return parseFlagContext(spec.Context)
+	}
+
+	evalCtx := evaluationContext{
+		Kind:       strings.TrimSpace(spec.ContextKind),
+		Key:        strings.TrimSpace(spec.ContextKey),
+		Attributes: spec.ContextAttributes,
+	}
+	if evalCtx.Kind == "" {
+		evalCtx.Kind = defaultContextKind
+	}
+
+	return singleFlagContext(evalCtx), nil
+}
+
+func (c *EvaluateFlag) Execute(ctx core.ExecutionContext) error {
+	spec := EvaluateFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateEvaluateFlagSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	environmentKey := strings.TrimSpace(spec.EnvironmentKey)
+	config, err := flagEnvironment(flag, environmentKey)
+	if err != nil {
+		return err
+	}
+
+	flagCtx, err := specFlagContext(spec)
+	if err != nil {
+		return err
+	}
+
+	evaluation, err := evaluateFlag(spec.FlagKey, config, flagCtx)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate flag: %w", err)
+	}
+
+	output := map[string]any{
+		"projectKey":     spec.ProjectKey,
+		"flagKey":        spec.FlagKey,
+		"environmentKey": environmentKey,
+		"context":        flagCtx.summary(),
+		"value":          nil,
+		"variationIndex": nil,
+		"reason":         evaluation.Reason,
+	}
+
+	if evaluation.VariationIndex != nil {
+		variations, _ := flag["variations"].([]any)
+		value, err := variationValue(variations, *evaluation.VariationIndex)
+		if err != nil {
+			return fmt.Errorf("flag serves an unknown variation: %w", err)
+		}
+
+		output["value"] = value
+		output["variationIndex"] = *evaluation.VariationIndex
+	}
+
+	if evaluation.Bucket != nil {
+		output["bucket"] = *evaluation.Bucket
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.evaluation",
+		[]any{output},
+	)
+}
+
+// flagEnvironment decodes the targeting of the flag in an environment.
+// The salt is per environment in the API, with the flag salt as a fallback.
+func flagEnvironment(flag map[string]any, environmentKey string) (flagEnvironmentConfig, error) {
+	environments, _ := flag["environments"].(map[string]any)
+	environment, ok := environments[environmentKey].(map[string]any)
+	if !ok {
+		return flagEnvironmentConfig{}, fmt.Errorf("environment %s not found for flag", environmentKey)
+	}
+
+	body, err := json.Marshal(environment)
+	if err != nil {
+		return flagEnvironmentConfig{}, fmt.Errorf("failed to encode flag environment: %w", err)
+	}
+
+	config := flagEnvironmentConfig{}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return flagEnvironmentConfig{}, fmt.Errorf("failed to parse flag environment: %w", err)
+	}
+
+	if config.Salt == "" {
+		config.Salt, _ = flag["salt"].(string)
+	}
+
+	return config, nil
+}
+
+// evaluateFlag serves the variation of the flag for the context,
+// following the evaluation order of the LaunchDarkly SDKs.
+func evaluateFlag(flagKey string, config flagEnvironmentConfig, flagCtx flagContext) (flagEvaluation, error) {
+	if !config.On {
+		return flagEvaluation{
+			VariationIndex: config.OffVariation,
+			Reason:         map[string]any{"kind": EvaluationReasonOff},
+		}, nil
+	}
+
+	if variation, ok := targetVariation(config, flagCtx); ok {
+		return flagEvaluation{
+			VariationIndex: &variation,
+			Reason:         map[string]any{"kind": EvaluationReasonTargetMatch},
+		}, nil
+	}
+
+	for i, rule := range config.Rules {
+		matched, err := ruleMatches(rule, flagCtx)
+		if err != nil {
+			return flagEvaluation{}, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		evaluation, err := servedVariation(rule.Variation, rule.Rollout, flagKey, config.Salt, flagCtx)
+		if err != nil {
+			return flagEvaluation{}, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		evaluation.Reason = map[string]any{
+			"kind":      EvaluationReasonRuleMatch,
+			"ruleIndex": i,
+			"ruleId":    rule.ID,
+			"inRollout": rule.Rollout != nil,
+		}
+
+		return evaluation, nil
+	}
+
+	evaluation, err := servedVariation(config.Fallthrough.Variation, config.Fallthrough.Rollout, flagKey, config.Salt, flagCtx)
+	if err != nil {
+		return flagEvaluation{}, fmt.Errorf("default rule: %w", err)
+	}
+
+	evaluation.Reason = map[string]any{
+		"kind":      EvaluationReasonFallthrough,
+		"inRollout": config.Fallthrough.Rollout != nil,
+	}
+
+	return evaluation, nil
+}
+
+// targetVariation returns the variation of the individual target the context is in.
+// Targets without a context kind are user targets.
+func targetVariation(config flagEnvironmentConfig, flagCtx flagContext) (int, bool) {
+	for _, targets := range [][]flagTarget{config.Targets, config.ContextTargets} {
+		for _, target := range targets {
+			kind := target.ContextKind
+			if kind == "" {
+				kind = defaultContextKind
+			}
+
+			evalCtx, ok := flagCtx.ofKind(kind)
+			if ok && slices.Contains(target.Values, evalCtx.Key) {
+				return target.Variation, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func servedVariation(variation *int, rollout *flagRollout, flagKey, salt string, flagCtx flagContext) (flagEvaluation, error) {
+	if variation != nil {
+		return flagEvaluation{VariationIndex: variation}, nil
+	}
+
+	if rollout == nil || len(rollout.Variations) == 0 {
+		return flagEvaluation{}, errors.New("rule serves neither a variation nor a rollout")
+	}
+
+	//
+	// A context without the kind of the rollout is placed in bucket 0, as in the SDKs.
+	//
+	bucket := 0.0
+	if evalCtx, ok := flagCtx.ofKind(rollout.ContextKind); ok {
+		bucket = contextBucket(*rollout, flagKey, salt, evalCtx)
+	}
+
+	index := rolloutVariation(*rollout, bucket)
+	return flagEvaluation{VariationIndex: &index, Bucket: &bucket}, nil
+}
+
+// rolloutVariation returns the variation of the rollout the bucket falls in.
+// Weights are in thousandths of a percent, so they add up to 100000.
+func rolloutVariation(rollout flagRollout, bucket float64) int {
+	sum := 0.0
+	for _, weighted := range rollout.Variations {
+		sum += float64(weighted.Weight) / 100000
+		if bucket < sum {
+			return weighted.Variation
+		}
+	}
+
+	//
+	// Weights that do not add up to 100000 leave the last buckets
+	// uncovered, and the SDKs serve the last variation for them.
+	//
+	return rollout.Variations[len(rollout.Variations)-1].Variation
+}
+
+// contextBucket places the context in a bucket between 0 and 1, as the LaunchDarkly SDKs do:
+// the first 15 hex digits of the SHA-1 of "<flag key>.<salt>.<attribute value>",
+// or "<seed>.<attribute value>" for rollouts with a seed.
+// Contexts of another kind, or without a string or integer value to bucket by, get bucket 0.
+func contextBucket(rollout flagRollout, flagKey, salt string, evalCtx evaluationContext) float64 {
+	if rollout.ContextKind != "" && rollout.ContextKind != evalCtx.Kind {
+		return 0
+	}
+
+	bucketBy := rollout.BucketBy
+	if bucketBy == "" {
+		bucketBy = "key"
+	}
+
+	value, ok := evalCtx.attribute(bucketBy)
+	if !ok {
+		return 0
+	}
+
+	id, ok := bucketableValue(value)
+	if !ok {
+		return 0
+	}
+
+	prefix := flagKey + "." + salt
+	if rollout.Seed != nil {
+		prefix = strconv.FormatInt(*rollout.Seed, 10)
+	}
+
+	sum := sha1.Sum([]byte(prefix + "." + id))
+	hash, err := strconv.ParseInt(hex.EncodeToString(sum[:])[:15], 16, 64)
+	if err != nil {
+		return 0
+	}
+
+	return float64(hash) / bucketScale
+}
+
+func bucketableValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		if v != math.Trunc(v) {
+			return "", false
+		}
+		return strconv.FormatInt(int64(v), 10), true
+	default:
+		return "", false
+	}
+}
+
+func ruleMatches(rule flagRule, flagCtx flagContext) (bool, error) {
+	for _, clause := range rule.Clauses {
+		matched, err := clauseMatches(clause, flagCtx)
+		if err != nil {
+			return false, err
+		}
+
+		if !matched {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// clauseMatches reports whether the context matches a clause. A clause on another
+// context kind, or on an attribute the context does not have, does not match,
+// even when it is negated. A clause on the kind attribute matches the kinds of all the contexts.
+func clauseMatches(clause flagClause, flagCtx flagContext) (bool, error) {
+	var value any
+	if clause.Attribute == "kind" && flagCtx.Multi {
+		value = flagCtx.kinds()
+	} else {
+		evalCtx, ok := flagCtx.ofKind(clause.ContextKind)
+		if !ok {
+			return false, nil
+		}
+
+		value, ok = evalCtx.attribute(clause.Attribute)
+		if !ok || value == nil {
+			return false, nil
+		}
+	}
+
+	values := []any{value}
+	if list, isList := value.([]any); isList {
+		values = list
+	}
+
+	for _, v := range values {
+		for _, clauseValue := range clause.Values {
+			matched, err := operatorMatches(clause.Op, v, clauseValue)
+			if err != nil {
+				return false, err
+			}
+
+			if matched {
+				return !clause.Negate, nil
+			}
+		}
+	}
+
+	return clause.Negate, nil
+}
+
+func operatorMatches(op string, value, clauseValue any) (bool, error) {
+	switch op {
+	case "in":
+		return valuesEqual(value, clauseValue), nil
+	case "startsWith", "endsWith", "contains", "matches":
+		s, ok := value.(string)
+		c, isString := clauseValue.(string)
+		if !ok || !isString {
+			return false, nil
+		}
+
+		switch op {
+		case "startsWith":
+			return strings.HasPrefix(s, c), nil
+		case "endsWith":
+			return strings.HasSuffix(s, c), nil
+		case "contains":
+			return strings.Contains(s, c), nil
+		default:
+			re, err := regexp.Compile(c)
+			if err != nil {
+				return false, nil
+			}
+			return re.MatchString(s), nil
+		}
+	case "lessThan", "lessThanOrEqual", "greaterThan", "greaterThanOrEqual":
+		a, ok := numericValue(value)
+		b, isNumber := numericValue(clauseValue)
+		if !ok || !isNumber {
+			return false, nil
+		}
+
+		switch op {
+		case "lessThan":
+			return a < b, nil
+		case "lessThanOrEqual":
+			return a <= b, nil
+		case "greaterThan":
+			return a > b, nil
+		default:
+			return a >= b, nil
+		}
+	default:
+		return false, fmt.Errorf("operator %s is not supported", op)
+	}
+}
+
+func valuesEqual(a, b any) bool {
+	x, aIsNumber := numericValue(a)
+	y, bIsNumber := numericValue(b)
+	if aIsNumber && bIsNumber {
+		return x == y
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func numericValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func (c *EvaluateFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *EvaluateFlag) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *EvaluateFlag) Actions() []core.Action {
+	return nil
+}
+
+func (c *EvaluateFlag) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *EvaluateFlag) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *EvaluateFlag) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
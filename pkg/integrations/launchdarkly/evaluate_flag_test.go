@@ -0,0 +1,338 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__EvaluateFlag__Setup(t *testing.T) {
+	component := &EvaluateFlag{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"contextKey":     "user-123",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing environment key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "contextKey": "user-123"},
+		})
+
+		require.ErrorContains(t, err, "environment key is required")
+	})
+
+	t.Run("missing context key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "environmentKey": "production"},
+		})
+
+		require.ErrorContains(t, err, "context key is required")
+	})
+
+	contextObjectSetup := func(context any) error {
+		return component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"contextMode":    ContextModeObject,
+				"context":        context,
+			},
+		})
+	}
+
+	t.Run("multi-context object -> no error", func(t *testing.T) {
+		err := contextObjectSetup(`{"kind":"multi","user":{"key":"user-123"},"organization":{"key":"acme"}}`)
+		require.NoError(t, err)
+	})
+
+	t.Run("context object from an expression -> validated at execution", func(t *testing.T) {
+		require.NoError(t, contextObjectSetup(`{{ $["Trigger"].data.context }}`))
+	})
+
+	t.Run("invalid context objects -> error", func(t *testing.T) {
+		require.ErrorContains(t, contextObjectSetup(`{"key":"user-123"}`), "context must have a kind")
+		require.ErrorContains(t, contextObjectSetup(`{"kind":"user"}`), "context of kind user must have a key")
+		require.ErrorContains(t, contextObjectSetup(`{"kind":"user account","key":"user-123"}`), `invalid context kind "user account"`)
+		require.ErrorContains(t, contextObjectSetup(`{"kind":"multi"}`), "multi-context must have at least one context")
+		require.ErrorContains(t, contextObjectSetup(`{"kind":"multi","user":"user-123"}`), "context of kind user must be an object")
+		require.ErrorContains(t, contextObjectSetup(`{"kind":"multi","user":{"kind":"user","key":"user-123"}}`), "must not have its own kind")
+		require.ErrorContains(t, contextObjectSetup(`{"kind":"user","key":"user-123","anonymous":"yes"}`), "must be a boolean")
+		require.ErrorContains(t, contextObjectSetup(`not json`), "context must be a valid JSON object")
+	})
+}
+
+func Test__EvaluateFlag__ContextBucket(t *testing.T) {
+	rollout := flagRollout{}
+
+	//
+	// Expected buckets are the ones of the LaunchDarkly SDK test suites.
+	//
+	t.Run("flag key and salt -> same bucket as the SDKs", func(t *testing.T) {
+		assert.InDelta(t, 0.42157587, contextBucket(rollout, "hashKey", "saltyA", evaluationContext{Kind: "user", Key: "userKeyA"}), 0.0000001)
+		assert.InDelta(t, 0.6708485, contextBucket(rollout, "hashKey", "saltyA", evaluationContext{Kind: "user", Key: "userKeyB"}), 0.0000001)
+		assert.InDelta(t, 0.10343106, contextBucket(rollout, "hashKey", "saltyA", evaluationContext{Kind: "user", Key: "userKeyC"}), 0.0000001)
+	})
+
+	t.Run("seed -> bucket ignores flag key and salt", func(t *testing.T) {
+		seed := int64(61)
+		seeded := flagRollout{Seed: &seed}
+
+		assert.InDelta(t, 0.09801207, contextBucket(seeded, "hashKey", "saltyA", evaluationContext{Kind: "user", Key: "userKeyA"}), 0.0000001)
+		assert.InDelta(t, 0.14483777, contextBucket(seeded, "hashKey", "saltyA", evaluationContext{Kind: "user", Key: "userKeyB"}), 0.0000001)
+		assert.InDelta(t, 0.9242641, contextBucket(seeded, "other", "saltyB", evaluationContext{Kind: "user", Key: "userKeyC"}), 0.0000001)
+	})
+
+	t.Run("integer attribute -> bucketed as its string", func(t *testing.T) {
+		ctx := evaluationContext{Kind: "user", Key: "userKeyA", Attributes: map[string]any{"intAttr": float64(33333)}}
+		assert.InDelta(t, 0.54771423, contextBucket(flagRollout{BucketBy: "intAttr"}, "hashKey", "saltyA", ctx), 0.0000001)
+	})
+
+	t.Run("float or missing attribute -> bucket 0", func(t *testing.T) {
+		ctx := evaluationContext{Kind: "user", Key: "userKeyA", Attributes: map[string]any{"floatAttr": 999.999}}
+		assert.Equal(t, 0.0, contextBucket(flagRollout{BucketBy: "floatAttr"}, "hashKey", "saltyA", ctx))
+		assert.Equal(t, 0.0, contextBucket(flagRollout{BucketBy: "missing"}, "hashKey", "saltyA", ctx))
+	})
+
+	t.Run("rollout for another context kind -> bucket 0", func(t *testing.T) {
+		ctx := evaluationContext{Kind: "user", Key: "userKeyA"}
+		assert.Equal(t, 0.0, contextBucket(flagRollout{ContextKind: "organization"}, "hashKey", "saltyA", ctx))
+	})
+}
+
+func Test__EvaluateFlag__Execute(t *testing.T) {
+	component := &EvaluateFlag{}
+
+	flagResponse := `{
+		"key": "hashKey",
+		"variations": [{"value": "control"}, {"value": "treatment"}],
+		"environments": {
+			"production": {
+				"on": true,
+				"offVariation": 0,
+				"salt": "saltyA",
+				"targets": [{"values": ["vip"], "variation": 1}],
+				"contextTargets": [{"contextKind": "organization", "values": ["acme"], "variation": 1}],
+				"rules": [
+					{
+						"_id": "rule-1",
+						"clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"], "negate": false}],
+						"variation": 1
+					},
+					{
+						"_id": "rule-2",
+						"clauses": [{"attribute": "plan", "op": "segmentMatch", "values": ["beta"], "negate": false}],
+						"variation": 1
+					}
+				],
+				"fallthrough": {
+					"rollout": {"variations": [{"variation": 0, "weight": 50000}, {"variation": 1, "weight": 50000}]}
+				}
+			},
+			"staging": {
+				"on": false,
+				"offVariation": 0
+			}
+		}
+	}`
+
+	execute := func(t *testing.T, configuration map[string]any) (*contexts.HTTPContext, *contexts.ExecutionStateContext, error) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		config := map[string]any{
+			"projectKey":     "default",
+			"flagKey":        "hashKey",
+			"environmentKey": "production",
+		}
+		for key, value := range configuration {
+			config[key] = value
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  config,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return httpContext, execStateCtx, err
+	}
+
+	output := func(t *testing.T, execStateCtx *contexts.ExecutionStateContext) map[string]any {
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		return payload["data"].(map[string]any)
+	}
+
+	t.Run("context in the default rollout -> variation of its bucket", func(t *testing.T) {
+		httpContext, execStateCtx, err := execute(t, map[string]any{"contextKey": "userKeyA"})
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/hashKey", httpContext.Requests[0].URL.String())
+		assert.Equal(t, core.DefaultOutputChannel.Name, execStateCtx.Channel)
+		assert.Equal(t, "launchdarkly.flag.evaluation", execStateCtx.Type)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "production", data["environmentKey"])
+		assert.Equal(t, map[string]any{"kind": "user", "key": "userKeyA"}, data["context"])
+		assert.Equal(t, "control", data["value"])
+		assert.Equal(t, 0, data["variationIndex"])
+		assert.Equal(t, map[string]any{"kind": EvaluationReasonFallthrough, "inRollout": true}, data["reason"])
+		assert.InDelta(t, 0.42157587, data["bucket"], 0.0000001)
+	})
+
+	t.Run("context in the other half of the rollout -> other variation", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{"contextKey": "userKeyB"})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "treatment", data["value"])
+		assert.Equal(t, 1, data["variationIndex"])
+		assert.InDelta(t, 0.6708485, data["bucket"], 0.0000001)
+	})
+
+	t.Run("individual target -> target match", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{"contextKey": "vip"})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "treatment", data["value"])
+		assert.Equal(t, map[string]any{"kind": EvaluationReasonTargetMatch}, data["reason"])
+		assert.NotContains(t, data, "bucket")
+	})
+
+	t.Run("context target of another kind -> target match", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{"contextKey": "acme", "contextKind": "organization"})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, map[string]any{"kind": "organization", "key": "acme"}, data["context"])
+		assert.Equal(t, map[string]any{"kind": EvaluationReasonTargetMatch}, data["reason"])
+	})
+
+	t.Run("matching rule -> rule match", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{
+			"contextKey":        "userKeyA",
+			"contextAttributes": map[string]any{"email": "dev@example.com"},
+		})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "treatment", data["value"])
+		assert.Equal(t, map[string]any{
+			"kind":      EvaluationReasonRuleMatch,
+			"ruleIndex": 0,
+			"ruleId":    "rule-1",
+			"inRollout": false,
+		}, data["reason"])
+	})
+
+	t.Run("unsupported operator reached -> error", func(t *testing.T) {
+		_, _, err := execute(t, map[string]any{
+			"contextKey":        "userKeyA",
+			"contextAttributes": map[string]any{"plan": "beta"},
+		})
+
+		require.ErrorContains(t, err, "operator segmentMatch is not supported")
+	})
+
+	t.Run("targeting off -> off variation", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{"contextKey": "vip", "environmentKey": "staging"})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "control", data["value"])
+		assert.Equal(t, map[string]any{"kind": EvaluationReasonOff}, data["reason"])
+	})
+
+	t.Run("single-kind context object -> evaluated for its kind and attributes", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{
+			"contextMode": ContextModeObject,
+			"context":     map[string]any{"kind": "user", "key": "userKeyA", "email": "dev@example.com", "_meta": map[string]any{"privateAttributes": []any{"email"}}},
+		})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, map[string]any{"kind": "user", "key": "userKeyA"}, data["context"])
+		assert.Equal(t, "treatment", data["value"])
+		assert.Equal(t, EvaluationReasonRuleMatch, data["reason"].(map[string]any)["kind"])
+	})
+
+	t.Run("multi-kind context object -> targets match the context of their kind", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{
+			"contextMode": ContextModeObject,
+			"context":     `{"kind":"multi","user":{"key":"userKeyA"},"organization":{"key":"acme"}}`,
+		})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, map[string]any{
+			"kind":         "multi",
+			"organization": map[string]any{"key": "acme"},
+			"user":         map[string]any{"key": "userKeyA"},
+		}, data["context"])
+		assert.Equal(t, "treatment", data["value"])
+		assert.Equal(t, map[string]any{"kind": EvaluationReasonTargetMatch}, data["reason"])
+	})
+
+	t.Run("multi-kind context object -> rollout buckets the user context", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{
+			"contextMode": ContextModeObject,
+			"context":     `{"kind":"multi","user":{"key":"userKeyA"},"organization":{"key":"globex"}}`,
+		})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "control", data["value"])
+		assert.InDelta(t, 0.42157587, data["bucket"], 0.0000001)
+	})
+
+	t.Run("multi-kind context object without a user -> rollout bucket 0", func(t *testing.T) {
+		_, execStateCtx, err := execute(t, map[string]any{
+			"contextMode": ContextModeObject,
+			"context":     `{"kind":"multi","device":{"key":"d-1"},"organization":{"key":"globex"}}`,
+		})
+		require.NoError(t, err)
+
+		data := output(t, execStateCtx)
+		assert.Equal(t, "control", data["value"])
+		assert.Equal(t, 0.0, data["bucket"])
+	})
+
+	t.Run("invalid context object -> error", func(t *testing.T) {
+		_, _, err := execute(t, map[string]any{
+			"contextMode": ContextModeObject,
+			"context":     `{"kind":"multi","user":{}}`,
+		})
+
+		require.ErrorContains(t, err, "context of kind user must have a key")
+	})
+
+	t.Run("unknown environment -> error", func(t *testing.T) {
+		_, _, err := execute(t, map[string]any{"contextKey": "userKeyA", "environmentKey": "qa"})
+		require.ErrorContains(t, err, "environment qa not found for flag")
+	})
+}
@@ -0,0 +1,169 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// multiContextKind is the kind of a LaunchDarkly context made of several contexts.
+const multiContextKind = "multi"
+
+// contextKindPattern is the pattern LaunchDarkly requires for context kinds.
+var contextKindPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// flagContext is what a flag is evaluated for: a single context,
+// or a multi-context with one context per kind.
+type flagContext struct {
+	Contexts []evaluationContext
+	Multi    bool
+}
+
+func singleFlagContext(evalCtx evaluationContext) flagContext {
+	return flagContext{Contexts: []evaluationContext{evalCtx}}
+}
+
+// ofKind returns the context of a kind. Targeting without a kind applies
+// to a single context of any kind, and to the user context of a multi-context.
+func (c flagContext) ofKind(kind string) (evaluationContext, bool) {
+	if kind == "" {
+		if !c.Multi {
+			return c.Contexts[0], true
+		}
+
+		kind = defaultContextKind
+	}
+
+	for _, evalCtx := range c.Contexts {
+		if evalCtx.Kind == kind {
+			return evalCtx, true
+		}
+	}
+
+	return evaluationContext{}, false
+}
+
+func (c flagContext) kinds() []any {
+	kinds := make([]any, 0, len(c.Contexts))
+	for _, evalCtx := range c.Contexts {
+		kinds = append(kinds, evalCtx.Kind)
+	}
+
+	return kinds
+}
+
+// summary returns the kinds and keys of the context, in the shape of a LaunchDarkly context.
+func (c flagContext) summary() map[string]any {
+	if !c.Multi {
+		return map[string]any{"kind": c.Contexts[0].Kind, "key": c.Contexts[0].Key}
+	}
+
+	summary := map[string]any{"kind": multiContextKind}
+	for _, evalCtx := range c.Contexts {
+		summary[evalCtx.Kind] = map[string]any{"key": evalCtx.Key}
+	}
+
+	return summary
+}
+
+// parseFlagContext reads a LaunchDarkly context object, given as a decoded object or a JSON string.
+// Single contexts have a kind, a key and attributes, like {"kind": "user", "key": "u1", "email": "..."}.
+// Multi-contexts have the multi kind and one context per kind, without their own kind,
+// like {"kind": "multi", "user": {"key": "u1"}, "organization": {"key": "acme"}}.
+func parseFlagContext(value any) (flagContext, error) {
+	if text, ok := value.(string); ok {
+		if strings.TrimSpace(text) == "" {
+			return flagContext{}, errors.New("context is required")
+		}
+
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return flagContext{}, errors.New("context must be a valid JSON object")
+		}
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return flagContext{}, errors.New("context must be an object")
+	}
+
+	kind, ok := object["kind"].(string)
+	if !ok || strings.TrimSpace(kind) == "" {
+		return flagContext{}, errors.New("context must have a kind")
+	}
+
+	if kind != multiContextKind {
+		evalCtx, err := parseSingleContext(kind, object)
+		if err != nil {
+			return flagContext{}, err
+		}
+
+		return singleFlagContext(evalCtx), nil
+	}
+
+	contexts := []evaluationContext{}
+	for key, nested := range object {
+		if key == "kind" {
+			continue
+		}
+
+		nestedObject, ok := nested.(map[string]any)
+		if !ok {
+			return flagContext{}, fmt.Errorf("context of kind %s must be an object", key)
+		}
+
+		if _, hasKind := nestedObject["kind"]; hasKind {
+			return flagContext{}, fmt.Errorf("context of kind %s must not have its own kind in a multi-context", key)
+		}
+
+		evalCtx, err := parseSingleContext(key, nestedObject)
+		if err != nil {
+			return flagContext{}, err
+		}
+
+		contexts = append(contexts, evalCtx)
+	}
+
+	if len(contexts) == 0 {
+		return flagContext{}, errors.New("multi-context must have at least one context")
+	}
+
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Kind < contexts[j].Kind
+	})
+
+	return flagContext{Contexts: contexts, Multi: true}, nil
+}
+
+func parseSingleContext(kind string, object map[string]any) (evaluationContext, error) {
+	if !contextKindPattern.MatchString(kind) || kind == "kind" || kind == multiContextKind {
+		return evaluationContext{}, fmt.Errorf("invalid context kind %q", kind)
+	}
+
+	key, ok := object["key"].(string)
+	if !ok || strings.TrimSpace(key) == "" {
+		return evaluationContext{}, fmt.Errorf("context of kind %s must have a key", kind)
+	}
+
+	if anonymous, exists := object["anonymous"]; exists {
+		if _, ok := anonymous.(bool); !ok {
+			return evaluationContext{}, fmt.Errorf("anonymous of the context of kind %s must be a boolean", kind)
+		}
+	}
+
+	//
+	// _meta holds private attribute names, which do not change the evaluation.
+	//
+	attributes := map[string]any{}
+	for name, value := range object {
+		if name == "kind" || name == "key" || name == "_meta" {
+			continue
+		}
+
+		attributes[name] = value
+	}
+
+	return evaluationContext{Kind: kind, Key: key, Attributes: attributes}, nil
+}
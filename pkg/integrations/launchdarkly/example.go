@@ -19,12 +19,150 @@ var exampleOutputDeleteFeatureFlagBytes []byte
 var exampleOutputDeleteFeatureFlagOnce sync.Once
 var exampleOutputDeleteFeatureFlag map[string]any
 
+//go:embed example_output_bulk_create_environments.json
+var exampleOutputBulkCreateEnvironmentsBytes []byte
+
+var exampleOutputBulkCreateEnvironmentsOnce sync.Once
+var exampleOutputBulkCreateEnvironments map[string]any
+
+//go:embed example_output_schedule_flag_change.json
+var exampleOutputScheduleFlagChangeBytes []byte
+
+var exampleOutputScheduleFlagChangeOnce sync.Once
+var exampleOutputScheduleFlagChange map[string]any
+
+//go:embed example_output_get_flag_history.json
+var exampleOutputGetFlagHistoryBytes []byte
+
+var exampleOutputGetFlagHistoryOnce sync.Once
+var exampleOutputGetFlagHistory map[string]any
+
+//go:embed example_output_export_flags.json
+var exampleOutputExportFlagsBytes []byte
+
+var exampleOutputExportFlagsOnce sync.Once
+var exampleOutputExportFlags map[string]any
+
+//go:embed example_output_set_flag_defaults.json
+var exampleOutputSetFlagDefaultsBytes []byte
+
+var exampleOutputSetFlagDefaultsOnce sync.Once
+var exampleOutputSetFlagDefaults map[string]any
+
+//go:embed example_output_add_flag_prerequisite.json
+var exampleOutputAddFlagPrerequisiteBytes []byte
+
+var exampleOutputAddFlagPrerequisiteOnce sync.Once
+var exampleOutputAddFlagPrerequisite map[string]any
+
+//go:embed example_output_list_webhooks.json
+var exampleOutputListWebhooksBytes []byte
+
+var exampleOutputListWebhooksOnce sync.Once
+var exampleOutputListWebhooks map[string]any
+
+//go:embed example_output_prune_webhooks.json
+var exampleOutputPruneWebhooksBytes []byte
+
+var exampleOutputPruneWebhooksOnce sync.Once
+var exampleOutputPruneWebhooks map[string]any
+
+//go:embed example_output_create_feature_flag.json
+var exampleOutputCreateFeatureFlagBytes []byte
+
+var exampleOutputCreateFeatureFlagOnce sync.Once
+var exampleOutputCreateFeatureFlag map[string]any
+
+//go:embed example_output_diff_flag_environments.json
+var exampleOutputDiffFlagEnvironmentsBytes []byte
+
+var exampleOutputDiffFlagEnvironmentsOnce sync.Once
+var exampleOutputDiffFlagEnvironments map[string]any
+
+//go:embed example_output_archive_feature_flag.json
+var exampleOutputArchiveFeatureFlagBytes []byte
+
+var exampleOutputArchiveFeatureFlagOnce sync.Once
+var exampleOutputArchiveFeatureFlag map[string]any
+
+//go:embed example_output_get_flag_status.json
+var exampleOutputGetFlagStatusBytes []byte
+
+var exampleOutputGetFlagStatusOnce sync.Once
+var exampleOutputGetFlagStatus map[string]any
+
+//go:embed example_output_follow_flag.json
+var exampleOutputFollowFlagBytes []byte
+
+var exampleOutputFollowFlagOnce sync.Once
+var exampleOutputFollowFlag map[string]any
+
+//go:embed example_output_update_flag_targeting.json
+var exampleOutputUpdateFlagTargetingBytes []byte
+
+var exampleOutputUpdateFlagTargetingOnce sync.Once
+var exampleOutputUpdateFlagTargeting map[string]any
+
+//go:embed example_output_set_flag_temporary.json
+var exampleOutputSetFlagTemporaryBytes []byte
+
+var exampleOutputSetFlagTemporaryOnce sync.Once
+var exampleOutputSetFlagTemporary map[string]any
+
+//go:embed example_output_toggle_feature_flag.json
+var exampleOutputToggleFeatureFlagBytes []byte
+
+var exampleOutputToggleFeatureFlagOnce sync.Once
+var exampleOutputToggleFeatureFlag map[string]any
+
+//go:embed example_output_remove_prerequisite.json
+var exampleOutputRemovePrerequisiteBytes []byte
+
+var exampleOutputRemovePrerequisiteOnce sync.Once
+var exampleOutputRemovePrerequisite map[string]any
+
+//go:embed example_output_list_members.json
+var exampleOutputListMembersBytes []byte
+
+var exampleOutputListMembersOnce sync.Once
+var exampleOutputListMembers map[string]any
+
+//go:embed example_output_set_flag_maintainer.json
+var exampleOutputSetFlagMaintainerBytes []byte
+
+var exampleOutputSetFlagMaintainerOnce sync.Once
+var exampleOutputSetFlagMaintainer map[string]any
+
+//go:embed example_output_get_flag_rules.json
+var exampleOutputGetFlagRulesBytes []byte
+
+var exampleOutputGetFlagRulesOnce sync.Once
+var exampleOutputGetFlagRules map[string]any
+
+//go:embed example_output_validate_flag.json
+var exampleOutputValidateFlagBytes []byte
+
+var exampleOutputValidateFlagOnce sync.Once
+var exampleOutputValidateFlag map[string]any
+
+//go:embed example_output_evaluate_flag.json
+var exampleOutputEvaluateFlagBytes []byte
+
+var exampleOutputEvaluateFlagOnce sync.Once
+var exampleOutputEvaluateFlag map[string]any
+
 //go:embed example_data_on_feature_flag_change.json
 var exampleDataOnFeatureFlagChangeBytes []byte
 
 var exampleDataOnFeatureFlagChangeOnce sync.Once
 var exampleDataOnFeatureFlagChange map[string]any
 
+//go:embed example_data_on_segment_change.json
+var exampleDataOnSegmentChangeBytes []byte
+
+var exampleDataOnSegmentChangeOnce sync.Once
+var exampleDataOnSegmentChange map[string]any
+
 func (c *GetFeatureFlag) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetFeatureFlagOnce, exampleOutputGetFeatureFlagBytes, &exampleOutputGetFeatureFlag)
 }
@@ -33,6 +171,98 @@ func (c *DeleteFeatureFlag) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputDeleteFeatureFlagOnce, exampleOutputDeleteFeatureFlagBytes, &exampleOutputDeleteFeatureFlag)
 }
 
+func (c *BulkCreateEnvironments) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputBulkCreateEnvironmentsOnce, exampleOutputBulkCreateEnvironmentsBytes, &exampleOutputBulkCreateEnvironments)
+}
+
+func (c *ScheduleFlagChange) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputScheduleFlagChangeOnce, exampleOutputScheduleFlagChangeBytes, &exampleOutputScheduleFlagChange)
+}
+
+func (c *GetFlagHistory) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetFlagHistoryOnce, exampleOutputGetFlagHistoryBytes, &exampleOutputGetFlagHistory)
+}
+
+func (c *ExportFlags) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputExportFlagsOnce, exampleOutputExportFlagsBytes, &exampleOutputExportFlags)
+}
+
+func (c *SetFlagDefaults) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetFlagDefaultsOnce, exampleOutputSetFlagDefaultsBytes, &exampleOutputSetFlagDefaults)
+}
+
+func (c *AddFlagPrerequisite) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputAddFlagPrerequisiteOnce, exampleOutputAddFlagPrerequisiteBytes, &exampleOutputAddFlagPrerequisite)
+}
+
+func (c *RemovePrerequisite) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRemovePrerequisiteOnce, exampleOutputRemovePrerequisiteBytes, &exampleOutputRemovePrerequisite)
+}
+
+func (c *ListMembers) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputListMembersOnce, exampleOutputListMembersBytes, &exampleOutputListMembers)
+}
+
+func (c *SetFlagMaintainer) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetFlagMaintainerOnce, exampleOutputSetFlagMaintainerBytes, &exampleOutputSetFlagMaintainer)
+}
+
+func (c *GetFlagRules) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetFlagRulesOnce, exampleOutputGetFlagRulesBytes, &exampleOutputGetFlagRules)
+}
+
+func (c *ValidateFlag) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputValidateFlagOnce, exampleOutputValidateFlagBytes, &exampleOutputValidateFlag)
+}
+
+func (c *EvaluateFlag) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputEvaluateFlagOnce, exampleOutputEvaluateFlagBytes, &exampleOutputEvaluateFlag)
+}
+
+func (c *ListWebhooks) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputListWebhooksOnce, exampleOutputListWebhooksBytes, &exampleOutputListWebhooks)
+}
+
+func (c *PruneWebhooks) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputPruneWebhooksOnce, exampleOutputPruneWebhooksBytes, &exampleOutputPruneWebhooks)
+}
+
+func (c *ToggleFeatureFlag) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputToggleFeatureFlagOnce, exampleOutputToggleFeatureFlagBytes, &exampleOutputToggleFeatureFlag)
+}
+
+func (c *CreateFeatureFlag) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateFeatureFlagOnce, exampleOutputCreateFeatureFlagBytes, &exampleOutputCreateFeatureFlag)
+}
+
+func (c *DiffFlagEnvironments) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputDiffFlagEnvironmentsOnce, exampleOutputDiffFlagEnvironmentsBytes, &exampleOutputDiffFlagEnvironments)
+}
+
+func (c *ArchiveFeatureFlag) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputArchiveFeatureFlagOnce, exampleOutputArchiveFeatureFlagBytes, &exampleOutputArchiveFeatureFlag)
+}
+
+func (c *GetFlagStatus) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetFlagStatusOnce, exampleOutputGetFlagStatusBytes, &exampleOutputGetFlagStatus)
+}
+
+func (c *FollowFlag) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputFollowFlagOnce, exampleOutputFollowFlagBytes, &exampleOutputFollowFlag)
+}
+
+func (c *UpdateFlagTargeting) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputUpdateFlagTargetingOnce, exampleOutputUpdateFlagTargetingBytes, &exampleOutputUpdateFlagTargeting)
+}
+
+func (c *SetFlagTemporary) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetFlagTemporaryOnce, exampleOutputSetFlagTemporaryBytes, &exampleOutputSetFlagTemporary)
+}
+
 func (t *OnFeatureFlagChange) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnFeatureFlagChangeOnce, exampleDataOnFeatureFlagChangeBytes, &exampleDataOnFeatureFlagChange)
 }
+
+func (t *OnSegmentChange) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnSegmentChangeOnce, exampleDataOnSegmentChangeBytes, &exampleDataOnSegmentChange)
+}
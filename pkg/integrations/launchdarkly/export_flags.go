@@ -0,0 +1,180 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type ExportFlags struct{}
+
+type ExportFlagsSpec struct {
+	ProjectKey          string `json:"projectKey" mapstructure:"projectKey"`
+	IncludeEnvironments *bool  `json:"includeEnvironments,omitempty" mapstructure:"includeEnvironments,omitempty"`
+}
+
+func (c *ExportFlags) Name() string {
+	return "launchdarkly.exportFlags"
+}
+
+func (c *ExportFlags) Label() string {
+	return "Export Flags"
+}
+
+func (c *ExportFlags) Description() string {
+	return "Export all feature flags of a LaunchDarkly project as JSON"
+}
+
+func (c *ExportFlags) Documentation() string {
+	return `The Export Flags component returns every feature flag in a LaunchDarkly project, with full detail, as a single array.
+
+## Use Cases
+
+- **Backups**: Store a snapshot of all flags on a schedule
+- **GitOps**: Commit the flag definitions to a repository and review changes as diffs
+- **Drift detection**: Compare the exported flags against a known-good definition
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project to export
+- **Include Environments**: Whether each flag includes its per-environment data (targeting, rules, on/off state). Disable it to only export the flag definitions.
+
+## Output
+
+Returns the project key, the number of exported flags, and the flags sorted by key. All pages of the flag list are fetched, so large projects are exported completely.`
+}
+
+func (c *ExportFlags) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ExportFlags) Color() string {
+	return "gray"
+}
+
+func (c *ExportFlags) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ExportFlags) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project to export",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "includeEnvironments",
+			Label:       "Include Environments",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Include the per-environment data of each flag",
+		},
+	}
+}
+
+func (c *ExportFlags) Setup(ctx core.SetupContext) error {
+	spec := ExportFlagsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	return nil
+}
+
+func (c *ExportFlags) Execute(ctx core.ExecutionContext) error {
+	spec := ExportFlagsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flags, err := client.ListFeatureFlagsDetailed(spec.ProjectKey)
+	if err != nil {
+		return fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	includeEnvironments := spec.IncludeEnvironments == nil || *spec.IncludeEnvironments
+
+	//
+	// Flags are sorted by key so consecutive exports of the same project
+	// produce stable output that can be diffed.
+	//
+	sort.Slice(flags, func(i, j int) bool {
+		keyI, _ := flags[i]["key"].(string)
+		keyJ, _ := flags[j]["key"].(string)
+		return keyI < keyJ
+	})
+
+	exported := make([]any, 0, len(flags))
+	for _, flag := range flags {
+		if !includeEnvironments {
+			delete(flag, "environments")
+		}
+
+		exported = append(exported, flag)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flags.exported",
+		[]any{
+			map[string]any{
+				"projectKey": spec.ProjectKey,
+				"count":      len(exported),
+				"flags":      exported,
+			},
+		},
+	)
+}
+
+func (c *ExportFlags) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ExportFlags) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ExportFlags) Actions() []core.Action {
+	return nil
+}
+
+func (c *ExportFlags) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ExportFlags) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ExportFlags) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,178 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const exportFlagsResponse = `{
+	"totalCount": 2,
+	"items": [
+		{
+			"key": "new-checkout",
+			"name": "New Checkout",
+			"variations": [{"value": true}, {"value": false}],
+			"environments": {"production": {"on": true, "rules": []}}
+		},
+		{
+			"key": "dark-mode",
+			"name": "Dark Mode",
+			"variations": [{"value": true}, {"value": false}],
+			"environments": {"production": {"on": false, "rules": []}}
+		}
+	]
+}`
+
+func Test__ExportFlags__Setup(t *testing.T) {
+	component := &ExportFlags{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"includeEnvironments": false},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+}
+
+func Test__ExportFlags__Execute(t *testing.T) {
+	component := &ExportFlags{}
+
+	t.Run("with environments -> emits sorted flags with environment data", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(exportFlagsResponse)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/api/v2/flags/default", req.URL.Path)
+		assert.Equal(t, "0", req.URL.Query().Get("summary"))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flags.exported", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "default", data["projectKey"])
+		assert.Equal(t, 2, data["count"])
+
+		flags := data["flags"].([]any)
+		require.Len(t, flags, 2)
+		assert.Equal(t, "dark-mode", flags[0].(map[string]any)["key"])
+		assert.Equal(t, "new-checkout", flags[1].(map[string]any)["key"])
+		assert.Contains(t, flags[0], "environments")
+	})
+
+	t.Run("without environments -> strips environment data", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(exportFlagsResponse)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "includeEnvironments": false},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execStateCtx.Payloads, 1)
+		flags := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)["flags"].([]any)
+		require.Len(t, flags, 2)
+		for _, flag := range flags {
+			assert.NotContains(t, flag, "environments")
+			assert.Contains(t, flag, "variations")
+		}
+	})
+
+	t.Run("multiple pages -> all flags are exported", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"totalCount": 2, "items": [{"key": "flag-b"}], "_links": {"next": {"href": "/api/v2/flags/default?summary=0&limit=100&offset=100"}}}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"totalCount": 2, "items": [{"key": "flag-a"}]}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "100", httpContext.Requests[1].URL.Query().Get("offset"))
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 2, data["count"])
+		assert.Equal(t, "flag-a", data["flags"].([]any)[0].(map[string]any)["key"])
+	})
+
+	t.Run("API error -> execution fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"not_found"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "missing"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to list feature flags")
+	})
+}
@@ -0,0 +1,275 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	FollowActionFollow   = "follow"
+	FollowActionUnfollow = "unfollow"
+)
+
+var AllFollowActions = []configuration.FieldOption{
+	{Label: "Follow", Value: FollowActionFollow},
+	{Label: "Unfollow", Value: FollowActionUnfollow},
+}
+
+type FollowFlag struct{}
+
+type FollowFlagSpec struct {
+	ProjectKey     string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey        string `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey string `json:"environmentKey" mapstructure:"environmentKey"`
+	MemberID       string `json:"memberId" mapstructure:"memberId"`
+	Action         string `json:"action" mapstructure:"action"`
+}
+
+func (c *FollowFlag) Name() string {
+	return "launchdarkly.followFlag"
+}
+
+func (c *FollowFlag) Label() string {
+	return "Follow Feature Flag"
+}
+
+func (c *FollowFlag) Description() string {
+	return "Make a member follow or unfollow a LaunchDarkly feature flag"
+}
+
+func (c *FollowFlag) Documentation() string {
+	return `The Follow Feature Flag component subscribes a member to the change notifications of a feature flag in an environment, or unsubscribes them.
+
+## Use Cases
+
+- **Onboarding**: Make the owners of a new flag follow it, so they are notified when it changes
+- **Handover**: Move the notifications of a flag from a member who left to the new owner
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag (supports expressions)
+- **Environment**: The environment whose changes the member is notified about
+- **Member**: The member who follows or unfollows the flag
+- **Action**: Follow or unfollow the flag
+
+The member is looked up first, so an unknown member fails without changing the followers of the flag.
+
+## Output
+
+Returns the project, flag and environment keys, the member, and whether the member now follows the flag.`
+}
+
+func (c *FollowFlag) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *FollowFlag) Color() string {
+	return "gray"
+}
+
+func (c *FollowFlag) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *FollowFlag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to follow or unfollow",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment whose flag changes the member is notified about",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "memberId",
+			Label:       "Member",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The member who follows or unfollows the flag",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "member",
+				},
+			},
+		},
+		{
+			Name:     "action",
+			Label:    "Action",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  FollowActionFollow,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllFollowActions,
+				},
+			},
+		},
+	}
+}
+
+func (c *FollowFlag) Setup(ctx core.SetupContext) error {
+	spec := FollowFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateFollowFlagSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateFollowFlagSpec(spec FollowFlagSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if strings.TrimSpace(spec.EnvironmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	if strings.TrimSpace(spec.MemberID) == "" {
+		return errors.New("member is required")
+	}
+
+	if !slices.Contains([]string{FollowActionFollow, FollowActionUnfollow}, spec.Action) {
+		return fmt.Errorf("invalid action %q", spec.Action)
+	}
+
+	return nil
+}
+
+func (c *FollowFlag) Execute(ctx core.ExecutionContext) error {
+	spec := FollowFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateFollowFlagSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	memberID := strings.TrimSpace(spec.MemberID)
+	member, err := client.GetMember(memberID)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("member %s not found", memberID)
+		}
+
+		return fmt.Errorf("failed to get member: %w", err)
+	}
+
+	following := spec.Action == FollowActionFollow
+	if following {
+		err = client.FollowFlag(spec.ProjectKey, spec.FlagKey, spec.EnvironmentKey, member.ID)
+	} else {
+		err = client.UnfollowFlag(spec.ProjectKey, spec.FlagKey, spec.EnvironmentKey, member.ID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to %s feature flag: %w", spec.Action, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.followers",
+		[]any{
+			map[string]any{
+				"projectKey":     spec.ProjectKey,
+				"flagKey":        spec.FlagKey,
+				"environmentKey": spec.EnvironmentKey,
+				"member": map[string]any{
+					"id":    member.ID,
+					"email": member.Email,
+					"name":  memberName(*member),
+				},
+				"following": following,
+			},
+		},
+	)
+}
+
+func (c *FollowFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *FollowFlag) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *FollowFlag) Actions() []core.Action {
+	return nil
+}
+
+func (c *FollowFlag) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *FollowFlag) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *FollowFlag) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
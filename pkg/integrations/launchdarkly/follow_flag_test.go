@@ -0,0 +1,166 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__FollowFlag__Setup(t *testing.T) {
+	component := &FollowFlag{}
+
+	validConfiguration := func() map[string]any {
+		return map[string]any{
+			"projectKey":     "default",
+			"flagKey":        "new-checkout",
+			"environmentKey": "production",
+			"memberId":       "m1",
+			"action":         FollowActionFollow,
+		}
+	}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: validConfiguration()})
+		require.NoError(t, err)
+	})
+
+	t.Run("missing environment key -> error", func(t *testing.T) {
+		configuration := validConfiguration()
+		delete(configuration, "environmentKey")
+
+		err := component.Setup(core.SetupContext{Configuration: configuration})
+		require.ErrorContains(t, err, "environment key is required")
+	})
+
+	t.Run("missing member -> error", func(t *testing.T) {
+		configuration := validConfiguration()
+		configuration["memberId"] = " "
+
+		err := component.Setup(core.SetupContext{Configuration: configuration})
+		require.ErrorContains(t, err, "member is required")
+	})
+
+	t.Run("invalid action -> error", func(t *testing.T) {
+		configuration := validConfiguration()
+		configuration["action"] = "mute"
+
+		err := component.Setup(core.SetupContext{Configuration: configuration})
+		require.ErrorContains(t, err, `invalid action "mute"`)
+	})
+
+	t.Run("reader token -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: validConfiguration(),
+			Integration:   &contexts.IntegrationContext{Metadata: Metadata{TokenRole: TokenRoleReader}},
+		})
+
+		require.ErrorContains(t, err, "the API access token has the reader role")
+	})
+}
+
+func Test__FollowFlag__Execute(t *testing.T) {
+	component := &FollowFlag{}
+	memberResponse := `{"_id":"m1","email":"ada@example.com","firstName":"Ada","lastName":"Lovelace"}`
+
+	execute := func(action string, httpContext *contexts.HTTPContext) (*contexts.ExecutionStateContext, error) {
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"memberId":       "m1",
+				"action":         action,
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return execStateCtx, err
+	}
+
+	t.Run("follow -> PUT follower", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(memberResponse))},
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		execStateCtx, err := execute(FollowActionFollow, httpContext)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/members/m1", httpContext.Requests[0].URL.String())
+		assert.Equal(t, http.MethodPut, httpContext.Requests[1].Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects/default/flags/new-checkout/environments/production/followers/m1", httpContext.Requests[1].URL.String())
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.followers", payload["type"])
+		assert.Equal(t, map[string]any{
+			"projectKey":     "default",
+			"flagKey":        "new-checkout",
+			"environmentKey": "production",
+			"member": map[string]any{
+				"id":    "m1",
+				"email": "ada@example.com",
+				"name":  "Ada Lovelace",
+			},
+			"following": true,
+		}, payload["data"])
+	})
+
+	t.Run("unfollow -> DELETE follower", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(memberResponse))},
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		execStateCtx, err := execute(FollowActionUnfollow, httpContext)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[1].Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects/default/flags/new-checkout/environments/production/followers/m1", httpContext.Requests[1].URL.String())
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["following"])
+	})
+
+	t.Run("unknown member -> error without changing followers", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+			},
+		}
+
+		_, err := execute(FollowActionFollow, httpContext)
+
+		require.ErrorContains(t, err, "member m1 not found")
+		require.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("follow rejected -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(memberResponse))},
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+			},
+		}
+
+		_, err := execute(FollowActionFollow, httpContext)
+		require.ErrorContains(t, err, "failed to follow feature flag")
+	})
+}
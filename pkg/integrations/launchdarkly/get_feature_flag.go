@@ -15,8 +15,9 @@ import (
 type GetFeatureFlag struct{}
 
 type GetFeatureFlagSpec struct {
-	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
-	FlagKey    string `json:"flagKey" mapstructure:"flagKey"`
+	ProjectKey     string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey        string `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey string `json:"environmentKey" mapstructure:"environmentKey"`
 }
 
 func (c *GetFeatureFlag) Name() string {
@@ -45,6 +46,7 @@ func (c *GetFeatureFlag) Documentation() string {
 
 - **Project Key**: The key of the LaunchDarkly project containing the flag
 - **Flag Key**: The key of the feature flag to retrieve (supports expressions)
+- **Environment**: Optional environment whose targeting should be projected into the output
 
 ## Output
 
@@ -53,7 +55,9 @@ Returns the complete feature flag object including:
 - Kind (boolean, multivariate)
 - Creation date
 - Archived and temporary status
-- Variations, environments, and targeting rules`
+- Variations, environments, and targeting rules
+
+When an environment is selected, the output also includes a top-level ` + "`targeting`" + ` object with that environment's on/off state, off variation, fallthrough, rules, and individual targets.`
 }
 
 func (c *GetFeatureFlag) Icon() string {
@@ -100,6 +104,24 @@ func (c *GetFeatureFlag) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Optional environment whose targeting is included in the output",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -146,6 +168,17 @@ func (c *GetFeatureFlag) Execute(ctx core.ExecutionContext) error {
 
 	flag["projectKey"] = spec.ProjectKey
 
+	environmentKey := strings.TrimSpace(spec.EnvironmentKey)
+	if environmentKey != "" {
+		targeting, err := projectEnvironmentTargeting(flag, environmentKey)
+		if err != nil {
+			return err
+		}
+
+		flag["environmentKey"] = environmentKey
+		flag["targeting"] = targeting
+	}
+
 	return ctx.ExecutionState.Emit(
 		core.DefaultOutputChannel.Name,
 		"launchdarkly.flag",
@@ -153,6 +186,35 @@ func (c *GetFeatureFlag) Execute(ctx core.ExecutionContext) error {
 	)
 }
 
+// projectEnvironmentTargeting extracts the targeting of a single environment
+// from the flag's environments map into a flat object.
+func projectEnvironmentTargeting(flag map[string]any, environmentKey string) (map[string]any, error) {
+	environments, _ := flag["environments"].(map[string]any)
+	environment, ok := environments[environmentKey].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("environment %s not found for flag", environmentKey)
+	}
+
+	targeting := map[string]any{
+		"environmentKey": environmentKey,
+		"on":             environment["on"],
+		"offVariation":   environment["offVariation"],
+		"fallthrough":    environment["fallthrough"],
+		"rules":          environment["rules"],
+		"targets":        environment["targets"],
+	}
+
+	if targeting["rules"] == nil {
+		targeting["rules"] = []any{}
+	}
+
+	if targeting["targets"] == nil {
+		targeting["targets"] = []any{}
+	}
+
+	return targeting, nil
+}
+
 func (c *GetFeatureFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
 	return ctx.DefaultProcessing()
 }
@@ -148,4 +148,111 @@ func Test__GetFeatureFlag__Execute(t *testing.T) {
 		require.ErrorContains(t, err, "flag key is required")
 		assert.Empty(t, httpContext.Requests)
 	})
+
+	t.Run("environment key -> projects environment targeting", func(t *testing.T) {
+		response := `{
+			"key": "my-feature",
+			"name": "My Feature",
+			"kind": "boolean",
+			"environments": {
+				"production": {
+					"on": true,
+					"offVariation": 1,
+					"fallthrough": {"variation": 0},
+					"rules": [{"_id": "rule-1", "variation": 1, "clauses": [{"attribute": "email", "op": "endsWith", "values": ["@example.com"]}]}],
+					"targets": [{"values": ["user-1"], "variation": 0}]
+				},
+				"staging": {
+					"on": false,
+					"offVariation": 1,
+					"fallthrough": {"variation": 1}
+				}
+			}
+		}`
+
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(response)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "my-feature", "environmentKey": "production"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, execStateCtx.Payloads, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "production", data["environmentKey"])
+		assert.NotNil(t, data["environments"])
+
+		targeting, ok := data["targeting"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "production", targeting["environmentKey"])
+		assert.Equal(t, true, targeting["on"])
+		assert.Equal(t, float64(1), targeting["offVariation"])
+		assert.Equal(t, map[string]any{"variation": float64(0)}, targeting["fallthrough"])
+
+		rules, ok := targeting["rules"].([]any)
+		require.True(t, ok)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "rule-1", rules[0].(map[string]any)["_id"])
+
+		targets, ok := targeting["targets"].([]any)
+		require.True(t, ok)
+		assert.Len(t, targets, 1)
+	})
+
+	t.Run("environment without rules -> empty rules and targets", func(t *testing.T) {
+		response := `{"key": "my-feature", "environments": {"staging": {"on": false, "offVariation": 1, "fallthrough": {"variation": 1}}}}`
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(response))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "my-feature", "environmentKey": "staging"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		targeting := data["targeting"].(map[string]any)
+		assert.Equal(t, false, targeting["on"])
+		assert.Equal(t, []any{}, targeting["rules"])
+		assert.Equal(t, []any{}, targeting["targets"])
+	})
+
+	t.Run("unknown environment key -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "my-feature", "environmentKey": "production"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.ErrorContains(t, err, "environment production not found")
+		assert.False(t, execStateCtx.Finished)
+	})
 }
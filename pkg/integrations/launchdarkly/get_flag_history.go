@@ -0,0 +1,246 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	defaultFlagHistoryLimit = 10
+	maxFlagHistoryLimit     = 20
+)
+
+type GetFlagHistory struct{}
+
+type GetFlagHistorySpec struct {
+	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey    string `json:"flagKey" mapstructure:"flagKey"`
+	Limit      *int   `json:"limit,omitempty" mapstructure:"limit,omitempty"`
+}
+
+func (c *GetFlagHistory) Name() string {
+	return "launchdarkly.getFlagHistory"
+}
+
+func (c *GetFlagHistory) Label() string {
+	return "Get Flag History"
+}
+
+func (c *GetFlagHistory) Description() string {
+	return "Get the recent change history of a LaunchDarkly feature flag"
+}
+
+func (c *GetFlagHistory) Documentation() string {
+	return `The Get Flag History component returns the most recent changes made to a feature flag, based on the LaunchDarkly audit log.
+
+## Use Cases
+
+- **Rollback decisions**: See what changed on a flag right before an incident
+- **Change review**: Include recent flag changes in notifications or approvals
+- **Audit workflows**: Collect who changed a flag and when
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag (supports expressions)
+- **Limit**: How many entries to return (1-20, defaults to 10)
+
+## Output
+
+Returns a timeline of audit entries for the flag, newest first. Each entry includes the date, title, the member who made the change, the actions, and the environment they applied to.
+
+The timeline is empty when the flag has no recorded changes.`
+}
+
+func (c *GetFlagHistory) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *GetFlagHistory) Color() string {
+	return "gray"
+}
+
+func (c *GetFlagHistory) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetFlagHistory) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag whose history is returned",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "limit",
+			Label:       "Limit",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     defaultFlagHistoryLimit,
+			Description: "Number of entries to return",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxFlagHistoryLimit; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+func (c *GetFlagHistory) Setup(ctx core.SetupContext) error {
+	spec := GetFlagHistorySpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	_, err := validateGetFlagHistorySpec(spec)
+	return err
+}
+
+func validateGetFlagHistorySpec(spec GetFlagHistorySpec) (int, error) {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return 0, errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return 0, errors.New("flag key is required")
+	}
+
+	if spec.Limit == nil {
+		return defaultFlagHistoryLimit, nil
+	}
+
+	if *spec.Limit < 1 || *spec.Limit > maxFlagHistoryLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxFlagHistoryLimit)
+	}
+
+	return *spec.Limit, nil
+}
+
+func (c *GetFlagHistory) Execute(ctx core.ExecutionContext) error {
+	spec := GetFlagHistorySpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	limit, err := validateGetFlagHistorySpec(spec)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	entries, err := client.GetFlagAuditEntries(spec.ProjectKey, spec.FlagKey, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get flag history: %w", err)
+	}
+
+	timeline := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		timeline = append(timeline, buildHistoryEntry(entry))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.history",
+		[]any{
+			map[string]any{
+				"projectKey": spec.ProjectKey,
+				"flagKey":    spec.FlagKey,
+				"entries":    timeline,
+			},
+		},
+	)
+}
+
+func buildHistoryEntry(entry AuditLogEntry) map[string]any {
+	actions := []any{}
+	environment := ""
+	for _, access := range entry.Accesses {
+		actions = append(actions, access.Action)
+		if environment == "" {
+			environment, _ = parseResourceEnvAndKey(access.Resource, KindFlag)
+		}
+	}
+
+	result := map[string]any{
+		"id":          entry.ID,
+		"date":        time.UnixMilli(entry.Date).UTC().Format(time.RFC3339),
+		"title":       entry.Title,
+		"titleVerb":   entry.TitleVerb,
+		"comment":     entry.Comment,
+		"actions":     actions,
+		"environment": environment,
+	}
+
+	if entry.Member != nil {
+		result["member"] = map[string]any{
+			"email":     entry.Member.Email,
+			"firstName": entry.Member.FirstName,
+			"lastName":  entry.Member.LastName,
+		}
+	}
+
+	return result
+}
+
+func (c *GetFlagHistory) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetFlagHistory) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *GetFlagHistory) Actions() []core.Action {
+	return nil
+}
+
+func (c *GetFlagHistory) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetFlagHistory) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetFlagHistory) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
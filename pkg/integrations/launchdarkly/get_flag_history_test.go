@@ -0,0 +1,171 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetFlagHistory__Setup(t *testing.T) {
+	component := &GetFlagHistory{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"flagKey":    "new-checkout",
+				"limit":      5,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing flag key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("limit out of range returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"flagKey":    "new-checkout",
+				"limit":      50,
+			},
+		})
+
+		require.ErrorContains(t, err, "limit must be between 1 and 20")
+	})
+}
+
+func Test__GetFlagHistory__Execute(t *testing.T) {
+	component := &GetFlagHistory{}
+
+	t.Run("flag with history -> emits timeline", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"items": [
+							{
+								"_id": "entry-2",
+								"date": 1768822930000,
+								"kind": "flag",
+								"title": "John Doe turned off the flag New Checkout in Production",
+								"titleVerb": "turned off the flag",
+								"comment": "rollback",
+								"accesses": [{"action": "updateOn", "resource": "proj/default:env/production:flag/new-checkout"}],
+								"member": {"email": "user@example.com", "firstName": "John", "lastName": "Doe"}
+							},
+							{
+								"_id": "entry-1",
+								"date": 1768727700000,
+								"kind": "flag",
+								"title": "John Doe created the flag New Checkout",
+								"titleVerb": "created the flag",
+								"accesses": [{"action": "createFlag", "resource": "proj/default:env/test:flag/new-checkout"}]
+							}
+						]
+					}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "new-checkout", "limit": 5},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/api/v2/auditlog", req.URL.Path)
+		assert.Equal(t, "proj/default:env/*:flag/new-checkout", req.URL.Query().Get("spec"))
+		assert.Equal(t, "5", req.URL.Query().Get("limit"))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.history", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "new-checkout", data["flagKey"])
+
+		entries := data["entries"].([]any)
+		require.Len(t, entries, 2)
+		latest := entries[0].(map[string]any)
+		assert.Equal(t, "entry-2", latest["id"])
+		assert.Equal(t, "2026-01-19T11:42:10Z", latest["date"])
+		assert.Equal(t, "production", latest["environment"])
+		assert.Equal(t, []any{"updateOn"}, latest["actions"])
+		assert.Equal(t, "user@example.com", latest["member"].(map[string]any)["email"])
+
+		oldest := entries[1].(map[string]any)
+		assert.Equal(t, []any{"createFlag"}, oldest["actions"])
+		assert.NotContains(t, oldest, "member")
+	})
+
+	t.Run("flag without history -> emits empty timeline", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"items": []}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "untouched-flag"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "10", httpContext.Requests[0].URL.Query().Get("limit"))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []any{}, data["entries"])
+	})
+
+	t.Run("API error -> execution fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"forbidden"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to get flag history")
+	})
+}
@@ -0,0 +1,337 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type GetFlagRules struct{}
+
+type GetFlagRulesSpec struct {
+	ProjectKey     string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey        string `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey string `json:"environmentKey" mapstructure:"environmentKey"`
+}
+
+// flagRule is the shape of a targeting rule in the LaunchDarkly API.
+type flagRule struct {
+	ID          string       `json:"_id"`
+	Description string       `json:"description"`
+	Variation   *int         `json:"variation"`
+	Rollout     *flagRollout `json:"rollout"`
+	Clauses     []flagClause `json:"clauses"`
+	TrackEvents bool         `json:"trackEvents"`
+}
+
+type flagClause struct {
+	Attribute   string `json:"attribute"`
+	ContextKind string `json:"contextKind"`
+	Op          string `json:"op"`
+	Values      []any  `json:"values"`
+	Negate      bool   `json:"negate"`
+}
+
+type flagRollout struct {
+	BucketBy    string                  `json:"bucketBy"`
+	ContextKind string                  `json:"contextKind"`
+	Seed        *int64                  `json:"seed"`
+	Variations  []flagWeightedVariation `json:"variations"`
+}
+
+type flagWeightedVariation struct {
+	Variation int `json:"variation"`
+	Weight    int `json:"weight"`
+}
+
+func (c *GetFlagRules) Name() string {
+	return "launchdarkly.getFlagRules"
+}
+
+func (c *GetFlagRules) Label() string {
+	return "Get Flag Rules"
+}
+
+func (c *GetFlagRules) Description() string {
+	return "Get the targeting rules of a LaunchDarkly feature flag in an environment"
+}
+
+func (c *GetFlagRules) Documentation() string {
+	return `The Get Flag Rules component returns the targeting rules of a feature flag in one environment as a normalized list.
+
+## Use Cases
+
+- **Rule analysis**: Inspect which attributes and operators a flag targets
+- **Rollout review**: Check the percentage rollouts of a flag before changing it
+- **Compliance checks**: Verify that a flag only serves a variation to the expected segments
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to inspect
+- **Environment**: The environment whose rules are returned
+
+## Output
+
+Returns the project, flag and environment keys, whether targeting is on, and the rules in evaluation order. Each rule includes:
+- Its index, ID and description
+- Its clauses, with attribute, context kind, operator, values and negation
+- The variation it serves, with its value, or the rollout, with the weight and percentage of each variation`
+}
+
+func (c *GetFlagRules) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *GetFlagRules) Color() string {
+	return "gray"
+}
+
+func (c *GetFlagRules) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetFlagRules) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to inspect",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment whose rules are returned",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *GetFlagRules) Setup(ctx core.SetupContext) error {
+	spec := GetFlagRulesSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateGetFlagRulesSpec(spec)
+}
+
+func validateGetFlagRulesSpec(spec GetFlagRulesSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if strings.TrimSpace(spec.EnvironmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	return nil
+}
+
+func (c *GetFlagRules) Execute(ctx core.ExecutionContext) error {
+	spec := GetFlagRulesSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateGetFlagRulesSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	environmentKey := strings.TrimSpace(spec.EnvironmentKey)
+	targeting, err := projectEnvironmentTargeting(flag, environmentKey)
+	if err != nil {
+		return err
+	}
+
+	variations, _ := flag["variations"].([]any)
+	rules, err := normalizeFlagRules(targeting["rules"], variations)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.rules",
+		[]any{
+			map[string]any{
+				"projectKey":     spec.ProjectKey,
+				"flagKey":        spec.FlagKey,
+				"environmentKey": environmentKey,
+				"on":             targeting["on"],
+				"rules":          rules,
+			},
+		},
+	)
+}
+
+// normalizeFlagRules converts the raw rules of an environment into a list
+// with a stable shape, resolving variation indexes into their values.
+func normalizeFlagRules(rawRules any, variations []any) ([]map[string]any, error) {
+	body, err := json.Marshal(rawRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode flag rules: %w", err)
+	}
+
+	var rules []flagRule
+	if err := json.Unmarshal(body, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse flag rules: %w", err)
+	}
+
+	normalized := make([]map[string]any, 0, len(rules))
+	for i, rule := range rules {
+		clauses := make([]map[string]any, 0, len(rule.Clauses))
+		for _, clause := range rule.Clauses {
+			values := clause.Values
+			if values == nil {
+				values = []any{}
+			}
+
+			clauses = append(clauses, map[string]any{
+				"attribute":   clause.Attribute,
+				"contextKind": clause.ContextKind,
+				"op":          clause.Op,
+				"values":      values,
+				"negate":      clause.Negate,
+			})
+		}
+
+		item := map[string]any{
+			"index":       i,
+			"id":          rule.ID,
+			"description": rule.Description,
+			"clauses":     clauses,
+			"trackEvents": rule.TrackEvents,
+			"variation":   nil,
+			"rollout":     nil,
+		}
+
+		if rule.Variation != nil {
+			item["variation"] = map[string]any{
+				"index": *rule.Variation,
+				"value": ruleVariationValue(variations, *rule.Variation),
+			}
+		}
+
+		if rule.Rollout != nil {
+			item["rollout"] = normalizeRollout(rule.Rollout, variations)
+		}
+
+		normalized = append(normalized, item)
+	}
+
+	return normalized, nil
+}
+
+// normalizeRollout converts a percentage rollout into its bucketing and weighted variations,
+// with the weights, given in thousandths of a percent, also as percentages.
+func normalizeRollout(rollout *flagRollout, variations []any) map[string]any {
+	weightedVariations := make([]map[string]any, 0, len(rollout.Variations))
+	for _, weighted := range rollout.Variations {
+		weightedVariations = append(weightedVariations, map[string]any{
+			"index":      weighted.Variation,
+			"value":      ruleVariationValue(variations, weighted.Variation),
+			"weight":     weighted.Weight,
+			"percentage": float64(weighted.Weight) / 1000,
+		})
+	}
+
+	return map[string]any{
+		"bucketBy":    rollout.BucketBy,
+		"contextKind": rollout.ContextKind,
+		"variations":  weightedVariations,
+	}
+}
+
+// ruleVariationValue resolves a variation index into its value, leaving it
+// empty for an index the flag does not have instead of failing the whole list.
+func ruleVariationValue(variations []any, index int) any {
+	value, err := variationValue(variations, index)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
+
+func (c *GetFlagRules) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetFlagRules) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *GetFlagRules) Actions() []core.Action {
+	return nil
+}
+
+func (c *GetFlagRules) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetFlagRules) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetFlagRules) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
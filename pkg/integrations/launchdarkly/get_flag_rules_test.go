@@ -0,0 +1,211 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetFlagRules__Setup(t *testing.T) {
+	component := &GetFlagRules{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"flagKey": "new-checkout", "environmentKey": "production"},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing flag key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "environmentKey": "production"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("missing environment key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "environment key is required")
+	})
+}
+
+func Test__GetFlagRules__Execute(t *testing.T) {
+	component := &GetFlagRules{}
+
+	flagResponse := `{
+		"key": "new-checkout",
+		"variations": [{"value": true}, {"value": false}],
+		"environments": {
+			"production": {
+				"on": true,
+				"rules": [
+					{
+						"_id": "rule-1",
+						"description": "Internal users",
+						"variation": 0,
+						"clauses": [
+							{"_id": "clause-1", "attribute": "email", "contextKind": "user", "op": "endsWith", "values": ["@example.com"], "negate": false}
+						],
+						"trackEvents": true
+					},
+					{
+						"_id": "rule-2",
+						"description": "EU rollout",
+						"clauses": [
+							{"attribute": "country", "contextKind": "user", "op": "in", "values": ["DE", "FR"], "negate": false},
+							{"attribute": "plan", "contextKind": "organization", "op": "in", "values": ["free"], "negate": true}
+						],
+						"rollout": {
+							"bucketBy": "key",
+							"contextKind": "user",
+							"variations": [{"variation": 0, "weight": 25000}, {"variation": 1, "weight": 75000}]
+						}
+					}
+				]
+			}
+		}
+	}`
+
+	t.Run("flag with multiple rules -> emits normalized rules", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", httpContext.Requests[0].URL.String())
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.rules", payload["type"])
+
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "default", data["projectKey"])
+		assert.Equal(t, "new-checkout", data["flagKey"])
+		assert.Equal(t, "production", data["environmentKey"])
+		assert.Equal(t, true, data["on"])
+
+		assert.Equal(t, []map[string]any{
+			{
+				"index":       0,
+				"id":          "rule-1",
+				"description": "Internal users",
+				"clauses": []map[string]any{
+					{"attribute": "email", "contextKind": "user", "op": "endsWith", "values": []any{"@example.com"}, "negate": false},
+				},
+				"trackEvents": true,
+				"variation":   map[string]any{"index": 0, "value": true},
+				"rollout":     nil,
+			},
+			{
+				"index":       1,
+				"id":          "rule-2",
+				"description": "EU rollout",
+				"clauses": []map[string]any{
+					{"attribute": "country", "contextKind": "user", "op": "in", "values": []any{"DE", "FR"}, "negate": false},
+					{"attribute": "plan", "contextKind": "organization", "op": "in", "values": []any{"free"}, "negate": true},
+				},
+				"trackEvents": false,
+				"variation":   nil,
+				"rollout": map[string]any{
+					"bucketBy":    "key",
+					"contextKind": "user",
+					"variations": []map[string]any{
+						{"index": 0, "value": true, "weight": 25000, "percentage": 25.0},
+						{"index": 1, "value": false, "weight": 75000, "percentage": 75.0},
+					},
+				},
+			},
+		}, data["rules"])
+	})
+
+	t.Run("environment without rules -> emits empty list", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"new-checkout","environments":{"production":{"on":false}}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []map[string]any{}, data["rules"])
+	})
+
+	t.Run("unknown environment -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "staging",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "environment staging not found for flag")
+	})
+}
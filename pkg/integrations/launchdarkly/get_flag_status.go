@@ -0,0 +1,238 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type GetFlagStatus struct{}
+
+type GetFlagStatusSpec struct {
+	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey    string `json:"flagKey" mapstructure:"flagKey"`
+}
+
+func (c *GetFlagStatus) Name() string {
+	return "launchdarkly.getFlagStatus"
+}
+
+func (c *GetFlagStatus) Label() string {
+	return "Get Flag Status"
+}
+
+func (c *GetFlagStatus) Description() string {
+	return "Get the status of a LaunchDarkly feature flag in every environment"
+}
+
+func (c *GetFlagStatus) Documentation() string {
+	return `The Get Flag Status component returns the state of a feature flag in every environment of its LaunchDarkly project.
+
+## Use Cases
+
+- **Release promotion**: Check that a flag is on and fully rolled out in staging before promoting a release to production
+- **Rollout tracking**: Report the rollout percentage of a flag in every environment
+- **Stale flag detection**: Find environments where a flag has not been evaluated recently
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Flag Key**: The key of the feature flag (supports expressions)
+
+## Output
+
+Returns the project and flag keys, and an ` + "`environments`" + ` object with the status of the flag in each environment, by environment key:
+- ` + "`on`" + `: Whether targeting is on
+- ` + "`status`" + `: The LaunchDarkly flag status, such as new, active, inactive or launched
+- ` + "`lastRequested`" + `: When the flag was last evaluated in the environment, empty if it never was
+- ` + "`variation`" + `: The variation served by default when targeting is on, if it is a fixed variation
+- ` + "`rollout`" + `: The percentage rollout served by default when targeting is on, with the percentage of every variation, if there is one`
+}
+
+func (c *GetFlagStatus) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *GetFlagStatus) Color() string {
+	return "gray"
+}
+
+func (c *GetFlagStatus) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetFlagStatus) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to get the status of",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *GetFlagStatus) Setup(ctx core.SetupContext) error {
+	spec := GetFlagStatusSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateGetFlagStatusSpec(spec)
+}
+
+func validateGetFlagStatusSpec(spec GetFlagStatusSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	return nil
+}
+
+func (c *GetFlagStatus) Execute(ctx core.ExecutionContext) error {
+	spec := GetFlagStatusSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateGetFlagStatusSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	statuses, err := client.GetFlagStatuses(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get flag statuses: %w", err)
+	}
+
+	environments, err := flagEnvironmentStatuses(flag, statuses)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.status",
+		[]any{
+			map[string]any{
+				"projectKey":   spec.ProjectKey,
+				"flagKey":      spec.FlagKey,
+				"environments": environments,
+			},
+		},
+	)
+}
+
+// flagEnvironmentStatuses combines the targeting of every environment of the flag
+// with its evaluation status there, by environment key.
+func flagEnvironmentStatuses(flag map[string]any, statuses map[string]FlagStatus) (map[string]any, error) {
+	body, err := json.Marshal(flag["environments"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode flag environments: %w", err)
+	}
+
+	var configs map[string]flagEnvironmentConfig
+	if err := json.Unmarshal(body, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse flag environments: %w", err)
+	}
+
+	variations, _ := flag["variations"].([]any)
+	environments := make(map[string]any, len(configs))
+	for key, config := range configs {
+		status := statuses[key]
+		environment := map[string]any{
+			"on":            config.On,
+			"status":        status.Name,
+			"lastRequested": nil,
+			"variation":     nil,
+			"rollout":       nil,
+		}
+
+		if status.LastRequested != "" {
+			environment["lastRequested"] = status.LastRequested
+		}
+
+		if config.Fallthrough.Variation != nil {
+			environment["variation"] = map[string]any{
+				"index": *config.Fallthrough.Variation,
+				"value": ruleVariationValue(variations, *config.Fallthrough.Variation),
+			}
+		}
+
+		if config.Fallthrough.Rollout != nil {
+			environment["rollout"] = normalizeRollout(config.Fallthrough.Rollout, variations)
+		}
+
+		environments[key] = environment
+	}
+
+	return environments, nil
+}
+
+func (c *GetFlagStatus) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *GetFlagStatus) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *GetFlagStatus) Actions() []core.Action {
+	return nil
+}
+
+func (c *GetFlagStatus) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *GetFlagStatus) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetFlagStatus) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,153 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetFlagStatus__Setup(t *testing.T) {
+	component := &GetFlagStatus{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing flag key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+}
+
+func Test__GetFlagStatus__Execute(t *testing.T) {
+	component := &GetFlagStatus{}
+
+	execute := func(httpContext *contexts.HTTPContext) (*contexts.ExecutionStateContext, error) {
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return execStateCtx, err
+	}
+
+	t.Run("flag in several environments -> emits the status of each", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{
+					"key": "new-checkout",
+					"variations": [{"value": true}, {"value": false}],
+					"environments": {
+						"production": {
+							"on": true,
+							"fallthrough": {"rollout": {"bucketBy": "key", "contextKind": "user", "variations": [{"variation": 0, "weight": 25000}, {"variation": 1, "weight": 75000}]}}
+						},
+						"staging": {"on": true, "fallthrough": {"variation": 0}},
+						"test": {"on": false, "fallthrough": {"variation": 0}}
+					}
+				}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{
+					"key": "new-checkout",
+					"environments": {
+						"production": {"name": "active", "lastRequested": "2026-01-19T11:58:12.345Z"},
+						"staging": {"name": "launched", "lastRequested": "2026-01-19T11:59:40.001Z"}
+					}
+				}`))},
+			},
+		}
+
+		execStateCtx, err := execute(httpContext)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flag-status/default/new-checkout", httpContext.Requests[1].URL.String())
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.status", payload["type"])
+
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "default", data["projectKey"])
+		assert.Equal(t, "new-checkout", data["flagKey"])
+		assert.Equal(t, map[string]any{
+			"production": map[string]any{
+				"on":            true,
+				"status":        "active",
+				"lastRequested": "2026-01-19T11:58:12.345Z",
+				"variation":     nil,
+				"rollout": map[string]any{
+					"bucketBy":    "key",
+					"contextKind": "user",
+					"variations": []map[string]any{
+						{"index": 0, "value": true, "weight": 25000, "percentage": float64(25)},
+						{"index": 1, "value": false, "weight": 75000, "percentage": float64(75)},
+					},
+				},
+			},
+			"staging": map[string]any{
+				"on":            true,
+				"status":        "launched",
+				"lastRequested": "2026-01-19T11:59:40.001Z",
+				"variation":     map[string]any{"index": 0, "value": true},
+				"rollout":       nil,
+			},
+			"test": map[string]any{
+				"on":            false,
+				"status":        "",
+				"lastRequested": nil,
+				"variation":     map[string]any{"index": 0, "value": true},
+				"rollout":       nil,
+			},
+		}, data["environments"])
+	})
+
+	t.Run("flag not found -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+			},
+		}
+
+		_, err := execute(httpContext)
+		require.ErrorContains(t, err, "failed to get feature flag")
+	})
+
+	t.Run("flag statuses unavailable -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","environments":{"production":{"on":true}}}`))},
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+			},
+		}
+
+		_, err := execute(httpContext)
+		require.ErrorContains(t, err, "failed to get flag statuses")
+	})
+}
@@ -1,7 +1,9 @@
 package launchdarkly
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -17,7 +19,8 @@ func init() {
 type LaunchDarkly struct{}
 
 type Configuration struct {
-	APIKey string `json:"apiKey"`
+	APIKey  string `json:"apiKey"`
+	BaseURL string `json:"baseURL"`
 }
 
 func (l *LaunchDarkly) Name() string {
@@ -41,8 +44,20 @@ func (l *LaunchDarkly) Instructions() string {
 
 1. In the [LaunchDarkly Account settings > Authorization](https://app.launchdarkly.com/settings/authorization), click **Create token**.
 2. Give the token a name and select a role with at least **Reader** permissions for feature flags.
-   - For the **Delete Feature Flag** action, the role must also include **Writer** permissions.
-3. Create the token and **paste the API access token** in the Configuration section below.`
+   - For the **Delete Feature Flag**, **Archive Feature Flag**, **Toggle Feature Flag** and **Create Feature Flag** actions, the role must also include **Writer** permissions.
+   - For the **Bulk Create Environments** action, the role must be allowed to create environments in the project.
+   - For the **Schedule Flag Change** action, the role must be allowed to manage scheduled changes of the flag.
+   - For the **Set Flag Defaults** action, the role must be allowed to update the flag's default variations.
+   - For the **Add Flag Prerequisite** and **Remove Flag Prerequisite** actions, the role must be allowed to update the flag's prerequisites in the environment.
+   - For the **List Members** action and the member resource, the role must be allowed to view account members.
+   - For the **Set Flag Maintainer** action, the role must be allowed to update the flag's maintainer and to view account members and teams.
+   - For the **Update Flag Targeting** action, the role must be allowed to update the flag's targeting in the environment.
+   - For the **Set Flag Temporary** action, the role must be allowed to update whether the flag is temporary.
+   - For the **Follow Feature Flag** action, the role must be allowed to view account members and to manage the followers of the flag.
+3. Create the token and **paste the API access token** in the Configuration section below.
+4. If your account is on the LaunchDarkly federal instance, or the API is only reachable through a proxy, set the **Base URL** to its https address, for example ` + "`https://app.launchdarkly.us`" + `. Webhooks are created and removed through the same address.
+
+SuperPlane reads the role of the token when the integration is saved. Actions that make changes in LaunchDarkly report an error when they are configured with a token that has the **Reader** or **No access** role.`
 }
 
 func (l *LaunchDarkly) Configuration() []configuration.Field {
@@ -55,6 +70,16 @@ func (l *LaunchDarkly) Configuration() []configuration.Field {
 			Sensitive:   true,
 			Description: "API access token from LaunchDarkly. Create one in Account settings > Authorization with appropriate role permissions.",
 		},
+		{
+			Name:        "baseURL",
+			Label:       "Base URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Default:     BaseURL,
+			Placeholder: BaseURL,
+			Description: "Override only for the LaunchDarkly federal instance (https://app.launchdarkly.us) or a proxy in front of the LaunchDarkly API",
+		},
 	}
 }
 
@@ -62,12 +87,62 @@ func (l *LaunchDarkly) Components() []core.Component {
 	return []core.Component{
 		&GetFeatureFlag{},
 		&DeleteFeatureFlag{},
+		&BulkCreateEnvironments{},
+		&ScheduleFlagChange{},
+		&GetFlagHistory{},
+		&ExportFlags{},
+		&SetFlagDefaults{},
+		&AddFlagPrerequisite{},
+		&RemovePrerequisite{},
+		&ListMembers{},
+		&SetFlagMaintainer{},
+		&GetFlagRules{},
+		&ValidateFlag{},
+		&EvaluateFlag{},
+		&ListWebhooks{},
+		&PruneWebhooks{},
+		&ToggleFeatureFlag{},
+		&CreateFeatureFlag{},
+		&DiffFlagEnvironments{},
+		&ArchiveFeatureFlag{},
+		&GetFlagStatus{},
+		&FollowFlag{},
+		&UpdateFlagTargeting{},
+		&SetFlagTemporary{},
+	}
+}
+
+// ErrorCodeFlagAlreadyExists is the error code of flags created with the key of an existing flag.
+const ErrorCodeFlagAlreadyExists = "flag_already_exists"
+
+// DescribeError describes the failures of the components that continue on error.
+func (l *LaunchDarkly) DescribeError(err error) core.ErrorPayload {
+	var existsErr *FlagAlreadyExistsError
+	if errors.As(err, &existsErr) {
+		return core.ErrorPayload{Code: ErrorCodeFlagAlreadyExists, Message: err.Error()}
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return core.HTTPErrorPayload(apiErr.StatusCode, err.Error())
 	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return core.ErrorPayload{Code: core.ErrorCodeRequestTimeout, Message: err.Error(), Retryable: true}
+		}
+
+		return core.ErrorPayload{Code: core.ErrorCodeNetworkError, Message: err.Error(), Retryable: true}
+	}
+
+	return core.ErrorPayload{Code: core.ErrorCodeFailed, Message: err.Error()}
 }
 
 func (l *LaunchDarkly) Triggers() []core.Trigger {
 	return []core.Trigger{
 		&OnFeatureFlagChange{},
+		&OnSegmentChange{},
 	}
 }
 
@@ -86,17 +161,36 @@ func (l *LaunchDarkly) Sync(ctx core.SyncContext) error {
 		return fmt.Errorf("API access token is required")
 	}
 
+	if _, err := ParseBaseURL(config.BaseURL); err != nil {
+		return err
+	}
+
 	client, err := NewClient(ctx.HTTP, ctx.Integration)
 	if err != nil {
 		return fmt.Errorf("error creating client: %w", err)
 	}
 
-	// Validate API key by listing projects
-	_, err = client.ListProjects()
+	//
+	// The role of the token is recorded, so components that make changes
+	// can report a token that can only read. Tokens that may not read
+	// themselves are only validated by listing projects.
+	//
+	metadata := Metadata{TokenRole: TokenRoleUnknown}
+	token, err := introspectToken(client)
 	if err != nil {
-		return fmt.Errorf("error validating API access token (listing projects): %w", err)
+		if ctx.Logger != nil {
+			ctx.Logger.Warnf("Could not read the role of the API access token, validating it by listing projects: %v", err)
+		}
+
+		if _, err := client.ListProjects(); err != nil {
+			return fmt.Errorf("error validating API access token (listing projects): %w", err)
+		}
+	} else {
+		metadata.TokenRole = tokenRole(token)
+		metadata.TokenName = token.Name
 	}
 
+	ctx.Integration.SetMetadata(metadata)
 	ctx.Integration.Ready()
 	return nil
 }
@@ -111,6 +205,61 @@ func (l *LaunchDarkly) HandleAction(ctx core.IntegrationActionContext) error {
 	return nil
 }
 
+func (l *LaunchDarkly) ListResourcesWithChildren(resourceType, childType string, ctx core.ListResourcesContext) ([]core.IntegrationResourceWithChildren, error) {
+	if resourceType != "project" || childType != "environment" {
+		return nil, fmt.Errorf("listing %s resources with %s children is not supported", resourceType, childType)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	projects, err := client.ListProjectsWithEnvironments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	resources := make([]core.IntegrationResourceWithChildren, 0, len(projects))
+	for _, p := range projects {
+		environments := []Environment{}
+		if p.Environments != nil {
+			environments = p.Environments.Items
+		}
+
+		//
+		// The expanded environments can be truncated for projects with many environments.
+		// Only for those we fall back to listing the environments of the project.
+		//
+		if p.Environments != nil && len(environments) < p.Environments.TotalCount {
+			environments, err = client.ListEnvironments(p.Key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list environments for project %s: %w", p.Key, err)
+			}
+		}
+
+		children := make([]core.IntegrationResource, 0, len(environments))
+		for _, e := range environments {
+			children = append(children, core.IntegrationResource{
+				Type: "environment",
+				Name: e.Name,
+				ID:   e.Key,
+			})
+		}
+
+		resources = append(resources, core.IntegrationResourceWithChildren{
+			IntegrationResource: core.IntegrationResource{
+				Type: "project",
+				Name: p.Name,
+				ID:   p.Key,
+			},
+			Children: children,
+		})
+	}
+
+	return resources, nil
+}
+
 func (l *LaunchDarkly) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
 	switch resourceType {
 	case "project":
@@ -186,6 +335,48 @@ func (l *LaunchDarkly) ListResources(resourceType string, ctx core.ListResources
 		}
 		return resources, nil
 
+	case "member":
+		client, err := NewClient(ctx.HTTP, ctx.Integration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client: %w", err)
+		}
+
+		members, err := client.ListMembers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(members))
+		for _, m := range members {
+			resources = append(resources, core.IntegrationResource{
+				Type: "member",
+				Name: memberName(m),
+				ID:   m.ID,
+			})
+		}
+		return resources, nil
+
+	case "team":
+		client, err := NewClient(ctx.HTTP, ctx.Integration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client: %w", err)
+		}
+
+		teams, err := client.ListTeams()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(teams))
+		for _, team := range teams {
+			resources = append(resources, core.IntegrationResource{
+				Type: "team",
+				Name: team.Name,
+				ID:   team.Key,
+			})
+		}
+		return resources, nil
+
 	default:
 		return []core.IntegrationResource{}, nil
 	}
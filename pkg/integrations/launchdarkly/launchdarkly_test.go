@@ -1,6 +1,8 @@
 package launchdarkly
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
@@ -28,16 +31,46 @@ func Test__LaunchDarkly__Sync(t *testing.T) {
 		require.ErrorContains(t, err, "API access token is required")
 	})
 
-	t.Run("successful sync -> ready", func(t *testing.T) {
+	t.Run("base URL without https -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "api-test-key", "baseURL": "http://app.launchdarkly.us"},
+		}
+
+		err := i.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, `invalid base URL "http://app.launchdarkly.us": must use https`)
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("configured base URL -> validated against it", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{
 			Responses: []*http.Response{
-				{
-					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(strings.NewReader(`{"items":[{"key":"default","name":"Default Project"}]}`)),
-				},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"tokenId":"tok-1"}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"_id":"tok-1","role":"writer"}`))},
 			},
 		}
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{"apiKey": "api-test-key", "baseURL": "https://app.launchdarkly.us"},
+		}
+
+		err := i.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
 
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.us/api/v2/caller-identity", httpContext.Requests[0].URL.String())
+	})
+
+	sync := func(responses ...*http.Response) (*contexts.IntegrationContext, *contexts.HTTPContext, error) {
+		httpContext := &contexts.HTTPContext{Responses: responses}
 		integrationCtx := &contexts.IntegrationContext{
 			Configuration: map[string]any{"apiKey": "api-test-key"},
 		}
@@ -48,10 +81,68 @@ func Test__LaunchDarkly__Sync(t *testing.T) {
 			Integration:   integrationCtx,
 		})
 
+		return integrationCtx, httpContext, err
+	}
+
+	response := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	t.Run("writer token -> ready with the token role", func(t *testing.T) {
+		integrationCtx, httpContext, err := sync(
+			response(http.StatusOK, `{"tokenId":"tok-1","tokenName":"SuperPlane","tokenKind":"auth"}`),
+			response(http.StatusOK, `{"_id":"tok-1","name":"SuperPlane","role":"writer"}`),
+		)
+
 		require.NoError(t, err)
 		assert.Equal(t, "ready", integrationCtx.State)
-		require.Len(t, httpContext.Requests, 1)
-		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects?limit=200&offset=0", httpContext.Requests[0].URL.String())
+		assert.Equal(t, Metadata{TokenRole: TokenRoleWriter, TokenName: "SuperPlane"}, integrationCtx.Metadata)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/caller-identity", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/tokens/tok-1", httpContext.Requests[1].URL.String())
+	})
+
+	t.Run("reader token -> ready with the reader role", func(t *testing.T) {
+		integrationCtx, _, err := sync(
+			response(http.StatusOK, `{"tokenId":"tok-1"}`),
+			response(http.StatusOK, `{"_id":"tok-1","name":"Read only","role":"reader"}`),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{TokenRole: TokenRoleReader, TokenName: "Read only"}, integrationCtx.Metadata)
+	})
+
+	t.Run("token with custom roles -> custom role", func(t *testing.T) {
+		integrationCtx, _, err := sync(
+			response(http.StatusOK, `{"tokenId":"tok-1"}`),
+			response(http.StatusOK, `{"_id":"tok-1","name":"Custom","customRoleIds":["role-1"]}`),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, TokenRoleCustom, integrationCtx.Metadata.(Metadata).TokenRole)
+	})
+
+	t.Run("introspection unavailable -> validated by listing projects", func(t *testing.T) {
+		integrationCtx, httpContext, err := sync(
+			response(http.StatusForbidden, `{"code":"forbidden","message":"Access to the requested resource was denied"}`),
+			response(http.StatusOK, `{"items":[{"key":"default","name":"Default Project"}]}`),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{TokenRole: TokenRoleUnknown}, integrationCtx.Metadata)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects?limit=200", httpContext.Requests[1].URL.String())
+	})
+
+	t.Run("invalid token -> error", func(t *testing.T) {
+		_, _, err := sync(
+			response(http.StatusUnauthorized, `{"code":"unauthorized","message":"Invalid access token"}`),
+			response(http.StatusUnauthorized, `{"code":"unauthorized","message":"Invalid access token"}`),
+		)
+
+		require.ErrorContains(t, err, "error validating API access token (listing projects)")
 	})
 }
 
@@ -94,4 +185,184 @@ func Test__LaunchDarkly__ListResources(t *testing.T) {
 		assert.Equal(t, "Mobile App", resources[1].Name)
 		assert.Equal(t, "mobile", resources[1].ID)
 	})
+
+	t.Run("member -> list from API", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"totalCount":2,"items":[{"_id":"m1","email":"ada@example.com","firstName":"Ada","lastName":"Lovelace"},{"_id":"m2","email":"bot@example.com"}]}`)),
+				},
+			},
+		}
+
+		resources, err := i.ListResources("member", core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "member", resources[0].Type)
+		assert.Equal(t, "Ada Lovelace", resources[0].Name)
+		assert.Equal(t, "m1", resources[0].ID)
+		assert.Equal(t, "bot@example.com", resources[1].Name)
+	})
+
+	t.Run("team -> list from API", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"totalCount":1,"items":[{"key":"payments","name":"Payments"}]}`)),
+				},
+			},
+		}
+
+		resources, err := i.ListResources("team", core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-key"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, core.IntegrationResource{Type: "team", Name: "Payments", ID: "payments"}, resources[0])
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/teams?limit=100", httpContext.Requests[0].URL.String())
+	})
+}
+
+func Test__LaunchDarkly__ListResourcesWithChildren(t *testing.T) {
+	i := &LaunchDarkly{}
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{"apiKey": "test-key"},
+	}
+
+	t.Run("projects and environments -> single batched call", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"items": [
+							{"key":"default","name":"Default Project","environments":{"items":[{"key":"production","name":"Production"},{"key":"test","name":"Test"}],"totalCount":2}},
+							{"key":"mobile","name":"Mobile App","environments":{"items":[],"totalCount":0}}
+						],
+						"totalCount": 2
+					}`)),
+				},
+			},
+		}
+
+		resources, err := i.ListResourcesWithChildren("project", "environment", core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "environments", httpContext.Requests[0].URL.Query().Get("expand"))
+
+		require.Len(t, resources, 2)
+		assert.Equal(t, core.IntegrationResource{Type: "project", Name: "Default Project", ID: "default"}, resources[0].IntegrationResource)
+		assert.Equal(t, []core.IntegrationResource{
+			{Type: "environment", Name: "Production", ID: "production"},
+			{Type: "environment", Name: "Test", ID: "test"},
+		}, resources[0].Children)
+		assert.Equal(t, "mobile", resources[1].ID)
+		assert.Empty(t, resources[1].Children)
+	})
+
+	t.Run("truncated environments -> lists remaining environments of that project", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"items":[{"key":"default","name":"Default Project","environments":{"items":[{"key":"production","name":"Production"}],"totalCount":2}}],"totalCount":1}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"items":[{"key":"production","name":"Production"},{"key":"test","name":"Test"}],"totalCount":2}`)),
+				},
+			},
+		}
+
+		resources, err := i.ListResourcesWithChildren("project", "environment", core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/api/v2/projects/default/environments", httpContext.Requests[1].URL.Path)
+		require.Len(t, resources, 1)
+		assert.Len(t, resources[0].Children, 2)
+	})
+
+	t.Run("unsupported combination -> error", func(t *testing.T) {
+		_, err := i.ListResourcesWithChildren("flag", "environment", core.ListResourcesContext{
+			Integration: integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "listing flag resources with environment children is not supported")
+	})
+}
+
+func Test__LaunchDarkly__DescribeError(t *testing.T) {
+	i := &LaunchDarkly{}
+
+	t.Run("API error -> code and retryable from the status", func(t *testing.T) {
+		err := fmt.Errorf("failed to get feature flag: %w", &APIError{StatusCode: http.StatusServiceUnavailable, Body: "unavailable"})
+		assert.Equal(t, core.ErrorPayload{
+			Code:      core.ErrorCodeServerError,
+			Message:   err.Error(),
+			Retryable: true,
+		}, i.DescribeError(err))
+	})
+
+	t.Run("other error -> not retryable", func(t *testing.T) {
+		assert.Equal(t,
+			core.ErrorPayload{Code: core.ErrorCodeFailed, Message: "flag key is required"},
+			i.DescribeError(errors.New("flag key is required")),
+		)
+	})
+}
+
+func Test__LaunchDarkly__ContinueOnError(t *testing.T) {
+	var component core.Component
+	for _, c := range registry.NewPanicableIntegration(&LaunchDarkly{}).Components() {
+		if c.Name() == "launchdarkly.toggleFeatureFlag" {
+			component = c
+		}
+	}
+	require.NotNil(t, component)
+
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+		},
+	}
+
+	execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+	err := component.Execute(core.ExecutionContext{
+		Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "api-test-key"}},
+		ExecutionState: execState,
+		HTTP:           httpCtx,
+		Configuration: map[string]any{
+			"projectKey":              "default",
+			"environmentKey":          "production",
+			"flagKey":                 "new-checkout",
+			"on":                      true,
+			core.ContinueOnErrorField: true,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, execState.FailureReason)
+	assert.Equal(t, core.ErrorOutputChannel.Name, execState.Channel)
+	require.Len(t, execState.Payloads, 1)
+
+	payload := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, core.ErrorCodeForbidden, payload["code"])
+	assert.Equal(t, false, payload["retryable"])
+	assert.Contains(t, payload["message"], "use a token with a role that has Writer permissions")
 }
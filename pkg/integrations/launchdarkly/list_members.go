@@ -0,0 +1,209 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// Built-in LaunchDarkly member roles.
+const (
+	MemberRoleReader   = "reader"
+	MemberRoleWriter   = "writer"
+	MemberRoleAdmin    = "admin"
+	MemberRoleOwner    = "owner"
+	MemberRoleNoAccess = "no_access"
+)
+
+var AllMemberRoles = []configuration.FieldOption{
+	{Label: "Reader", Value: MemberRoleReader},
+	{Label: "Writer", Value: MemberRoleWriter},
+	{Label: "Admin", Value: MemberRoleAdmin},
+	{Label: "Owner", Value: MemberRoleOwner},
+	{Label: "No access", Value: MemberRoleNoAccess},
+}
+
+type ListMembers struct{}
+
+type ListMembersSpec struct {
+	Roles []string `json:"roles" mapstructure:"roles"`
+}
+
+func (c *ListMembers) Name() string {
+	return "launchdarkly.listMembers"
+}
+
+func (c *ListMembers) Label() string {
+	return "List Members"
+}
+
+func (c *ListMembers) Description() string {
+	return "List the members of the LaunchDarkly account"
+}
+
+func (c *ListMembers) Documentation() string {
+	return `The List Members component returns the members of the LaunchDarkly account, with their email and role.
+
+## Use Cases
+
+- **Notification routing**: Send a message to the maintainers or approvers of a flag
+- **Access reviews**: Report which members have admin or owner access
+
+## Configuration
+
+- **Roles**: Optional list of roles to include (for example ` + "`admin`" + `, ` + "`owner`" + `). All members are returned when no role is selected.
+
+## Output
+
+Returns the number of members and the members sorted by email. Each member includes its ID, email, name, role and custom roles. All pages of the member list are fetched.`
+}
+
+func (c *ListMembers) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ListMembers) Color() string {
+	return "gray"
+}
+
+func (c *ListMembers) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ListMembers) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "roles",
+			Label:       "Roles",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only include members with one of these roles",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: AllMemberRoles,
+				},
+			},
+		},
+	}
+}
+
+func (c *ListMembers) Setup(ctx core.SetupContext) error {
+	spec := ListMembersSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateMemberRoles(spec.Roles)
+}
+
+func validateMemberRoles(roles []string) error {
+	for _, role := range roles {
+		if !slices.ContainsFunc(AllMemberRoles, func(o configuration.FieldOption) bool { return o.Value == role }) {
+			return fmt.Errorf("invalid role %q", role)
+		}
+	}
+
+	return nil
+}
+
+func (c *ListMembers) Execute(ctx core.ExecutionContext) error {
+	spec := ListMembersSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateMemberRoles(spec.Roles); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	members, err := client.ListMembers()
+	if err != nil {
+		return fmt.Errorf("failed to list members: %w", err)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Email < members[j].Email
+	})
+
+	listed := make([]any, 0, len(members))
+	for _, member := range members {
+		if len(spec.Roles) > 0 && !slices.Contains(spec.Roles, member.Role) {
+			continue
+		}
+
+		listed = append(listed, memberToMap(member))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.members.listed",
+		[]any{
+			map[string]any{
+				"count":   len(listed),
+				"members": listed,
+			},
+		},
+	)
+}
+
+func memberToMap(member Member) map[string]any {
+	customRoles := member.CustomRoles
+	if customRoles == nil {
+		customRoles = []string{}
+	}
+
+	return map[string]any{
+		"id":          member.ID,
+		"email":       member.Email,
+		"name":        memberName(member),
+		"role":        member.Role,
+		"customRoles": customRoles,
+	}
+}
+
+// memberName returns the full name of a member, falling back
+// to the email for members that have not set their name.
+func memberName(member Member) string {
+	name := strings.TrimSpace(member.FirstName + " " + member.LastName)
+	if name == "" {
+		return member.Email
+	}
+
+	return name
+}
+
+func (c *ListMembers) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ListMembers) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ListMembers) Actions() []core.Action {
+	return nil
+}
+
+func (c *ListMembers) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ListMembers) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ListMembers) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,140 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func listMembersResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func Test__ListMembers__Setup(t *testing.T) {
+	component := &ListMembers{}
+
+	t.Run("no roles -> valid", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{}})
+		require.NoError(t, err)
+	})
+
+	t.Run("known roles -> valid", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"roles": []string{"admin", "owner"}},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown role -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"roles": []string{"superuser"}},
+		})
+
+		require.ErrorContains(t, err, `invalid role "superuser"`)
+	})
+}
+
+func Test__ListMembers__Execute(t *testing.T) {
+	component := &ListMembers{}
+
+	t.Run("multiple pages -> emits all members sorted by email", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				listMembersResponse(`{"totalCount":3,"items":[
+					{"_id":"m2","email":"grace@example.com","firstName":"Grace","lastName":"Hopper","role":"writer","customRoles":["flag-approvers"]},
+					{"_id":"m1","email":"ada@example.com","firstName":"Ada","lastName":"Lovelace","role":"admin"}
+				],"_links":{"next":{"href":"/api/v2/members?limit=200&offset=200"}}}`),
+				listMembersResponse(`{"totalCount":3,"items":[
+					{"_id":"m3","email":"bot@example.com","role":"reader"}
+				]}`),
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/api/v2/members", httpContext.Requests[0].URL.Path)
+		assert.False(t, httpContext.Requests[0].URL.Query().Has("offset"))
+		assert.Equal(t, "200", httpContext.Requests[1].URL.Query().Get("offset"))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.members.listed", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, 3, data["count"])
+
+		members := data["members"].([]any)
+		require.Len(t, members, 3)
+		assert.Equal(t, map[string]any{
+			"id":          "m1",
+			"email":       "ada@example.com",
+			"name":        "Ada Lovelace",
+			"role":        "admin",
+			"customRoles": []string{},
+		}, members[0])
+		assert.Equal(t, "bot@example.com", members[1].(map[string]any)["name"])
+		assert.Equal(t, []string{"flag-approvers"}, members[2].(map[string]any)["customRoles"])
+	})
+
+	t.Run("roles filter -> only matching members", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				listMembersResponse(`{"totalCount":2,"items":[
+					{"_id":"m1","email":"ada@example.com","role":"admin"},
+					{"_id":"m2","email":"grace@example.com","role":"writer"}
+				]}`),
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"roles": []string{"admin", "owner"}},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1, data["count"])
+		assert.Equal(t, "m1", data["members"].([]any)[0].(map[string]any)["id"])
+	})
+
+	t.Run("API error -> returns error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"message":"forbidden"}`))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to list members")
+	})
+}
@@ -0,0 +1,288 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// superPlaneWebhookName is the name of the webhooks SuperPlane creates in LaunchDarkly.
+const superPlaneWebhookName = "SuperPlane"
+
+// Statuses of the SuperPlane webhooks of the LaunchDarkly account.
+const (
+	// The target URL is a webhook SuperPlane still has.
+	WebhookStatusActive = "active"
+	// The target URL is a webhook of this SuperPlane installation that no longer exists.
+	WebhookStatusStale = "stale"
+	// The target URL is not a webhook of this SuperPlane installation, so its status is unknown.
+	WebhookStatusExternal = "external"
+)
+
+var AllWebhookStatuses = []configuration.FieldOption{
+	{Label: "Active", Value: WebhookStatusActive},
+	{Label: "Stale", Value: WebhookStatusStale},
+	{Label: "External", Value: WebhookStatusExternal},
+}
+
+type ListWebhooks struct{}
+
+type ListWebhooksSpec struct {
+	Statuses []string `json:"statuses" mapstructure:"statuses"`
+}
+
+// classifiedWebhook is a SuperPlane webhook of the LaunchDarkly account with its status.
+type classifiedWebhook struct {
+	Webhook Webhook
+	Status  string
+}
+
+func (c *ListWebhooks) Name() string {
+	return "launchdarkly.listWebhooks"
+}
+
+func (c *ListWebhooks) Label() string {
+	return "List Webhooks"
+}
+
+func (c *ListWebhooks) Description() string {
+	return "List the SuperPlane webhooks of the LaunchDarkly account and whether they are still used"
+}
+
+func (c *ListWebhooks) Documentation() string {
+	return `The List Webhooks component returns the webhooks SuperPlane created in the LaunchDarkly account, with whether SuperPlane still uses them.
+
+## Use Cases
+
+- **Housekeeping**: Find webhooks left behind by triggers that were removed, before pruning them with Prune Webhooks
+- **Audits**: Report which LaunchDarkly webhooks send flag changes to SuperPlane
+
+## Configuration
+
+- **Statuses**: Optional list of statuses to include. All SuperPlane webhooks are returned when no status is selected.
+
+## Statuses
+
+- ` + "`active`" + `: The webhook sends flag changes to a SuperPlane webhook that still exists
+- ` + "`stale`" + `: The webhook targets a SuperPlane webhook of this installation that no longer exists
+- ` + "`external`" + `: The webhook targets another SuperPlane installation, or a URL that is not a SuperPlane webhook
+
+## Output
+
+Returns the number of webhooks, the number of webhooks per status, and the webhooks sorted by ID. Only webhooks named "SuperPlane" are listed.`
+}
+
+func (c *ListWebhooks) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ListWebhooks) Color() string {
+	return "gray"
+}
+
+func (c *ListWebhooks) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ListWebhooks) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "statuses",
+			Label:       "Statuses",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only include webhooks with one of these statuses",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: AllWebhookStatuses,
+				},
+			},
+		},
+	}
+}
+
+func (c *ListWebhooks) Setup(ctx core.SetupContext) error {
+	spec := ListWebhooksSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateWebhookStatuses(spec.Statuses)
+}
+
+func validateWebhookStatuses(statuses []string) error {
+	for _, status := range statuses {
+		if !slices.ContainsFunc(AllWebhookStatuses, func(o configuration.FieldOption) bool { return o.Value == status }) {
+			return fmt.Errorf("invalid status %q", status)
+		}
+	}
+
+	return nil
+}
+
+func (c *ListWebhooks) Execute(ctx core.ExecutionContext) error {
+	spec := ListWebhooksSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateWebhookStatuses(spec.Statuses); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	webhooks, err := classifyWebhooks(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	listed := make([]any, 0, len(webhooks))
+	counts := map[string]int{WebhookStatusActive: 0, WebhookStatusStale: 0, WebhookStatusExternal: 0}
+	for _, webhook := range webhooks {
+		if len(spec.Statuses) > 0 && !slices.Contains(spec.Statuses, webhook.Status) {
+			continue
+		}
+
+		counts[webhook.Status]++
+		listed = append(listed, webhookToMap(webhook))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.webhooks.listed",
+		[]any{
+			map[string]any{
+				"count":    len(listed),
+				"statuses": counts,
+				"webhooks": listed,
+			},
+		},
+	)
+}
+
+// classifyWebhooks returns the SuperPlane webhooks of the LaunchDarkly account, sorted by ID,
+// with whether the SuperPlane webhook they target still exists.
+func classifyWebhooks(ctx core.ExecutionContext, client *Client) ([]classifiedWebhook, error) {
+	registry, ok := ctx.Integration.(core.WebhookRegistry)
+	if !ok {
+		return nil, fmt.Errorf("the integration cannot look up SuperPlane webhooks")
+	}
+
+	webhooks, err := client.ListWebhooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	host := ""
+	if ctx.Webhook != nil {
+		host = urlHost(ctx.Webhook.GetBaseURL())
+	}
+
+	classified := []classifiedWebhook{}
+	for _, webhook := range webhooks {
+		if strings.TrimSpace(webhook.Name) != superPlaneWebhookName {
+			continue
+		}
+
+		id, ok := superPlaneWebhookID(webhook.URL, host)
+		if !ok {
+			classified = append(classified, classifiedWebhook{Webhook: webhook, Status: WebhookStatusExternal})
+			continue
+		}
+
+		exists, err := registry.WebhookExists(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up webhook %s: %w", id, err)
+		}
+
+		status := WebhookStatusStale
+		if exists {
+			status = WebhookStatusActive
+		}
+
+		classified = append(classified, classifiedWebhook{Webhook: webhook, Status: status})
+	}
+
+	return classified, nil
+}
+
+// superPlaneWebhookID returns the ID of the SuperPlane webhook a target URL points to.
+// Targets on another host belong to another SuperPlane installation, and are not matched.
+func superPlaneWebhookID(target, host string) (uuid.UUID, bool) {
+	u, err := url.Parse(strings.TrimSpace(target))
+	if err != nil || host == "" || !strings.EqualFold(u.Host, host) {
+		return uuid.Nil, false
+	}
+
+	dir, last := path.Split(strings.TrimSuffix(u.Path, "/"))
+	if path.Base(dir) != "webhooks" {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(last)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func urlHost(baseURL string) string {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
+
+func webhookToMap(webhook classifiedWebhook) map[string]any {
+	return map[string]any{
+		"id":     webhook.Webhook.ID,
+		"name":   webhook.Webhook.Name,
+		"url":    webhook.Webhook.URL,
+		"on":     webhook.Webhook.On,
+		"status": webhook.Status,
+	}
+}
+
+func (c *ListWebhooks) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ListWebhooks) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ListWebhooks) Actions() []core.Action {
+	return nil
+}
+
+func (c *ListWebhooks) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ListWebhooks) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ListWebhooks) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
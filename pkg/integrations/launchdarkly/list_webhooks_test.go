@@ -0,0 +1,130 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+var (
+	activeWebhookID = uuid.MustParse("6f1c1a9e-52f6-4b5f-9d5a-0f7c6b0c2f11")
+	staleWebhookID  = uuid.MustParse("0d6a8f2e-8c3b-4c1e-a3a4-7fa4c1e2b9d0")
+)
+
+// webhooksResponse lists an active, a stale, and an external SuperPlane webhook,
+// next to a webhook that was not created by SuperPlane.
+func webhooksResponse() string {
+	return `{"items":[
+		{"_id":"wh-stale","name":"SuperPlane","url":"http://localhost:3000/api/v1/webhooks/` + staleWebhookID.String() + `","on":true},
+		{"_id":"wh-active","name":"SuperPlane","url":"http://localhost:3000/api/v1/webhooks/` + activeWebhookID.String() + `","on":true},
+		{"_id":"wh-other-install","name":"SuperPlane","url":"https://staging.example.com/api/v1/webhooks/` + staleWebhookID.String() + `","on":true},
+		{"_id":"wh-slack","name":"Slack","url":"http://localhost:3000/api/v1/webhooks/` + staleWebhookID.String() + `","on":true}
+	]}`
+}
+
+func webhooksIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{"apiKey": "test-api-key"},
+		Webhooks:      []uuid.UUID{activeWebhookID},
+	}
+}
+
+func Test__ListWebhooks__Setup(t *testing.T) {
+	component := &ListWebhooks{}
+
+	t.Run("no statuses -> valid", func(t *testing.T) {
+		require.NoError(t, component.Setup(core.SetupContext{Configuration: map[string]any{}}))
+	})
+
+	t.Run("unknown status -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"statuses": []string{"orphaned"}},
+		})
+
+		require.ErrorContains(t, err, `invalid status "orphaned"`)
+	})
+}
+
+func Test__ListWebhooks__Execute(t *testing.T) {
+	component := &ListWebhooks{}
+
+	t.Run("SuperPlane webhooks -> emitted with their status", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{listMembersResponse(webhooksResponse())},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{},
+			HTTP:           httpContext,
+			Integration:    webhooksIntegration(),
+			Webhook:        &contexts.NodeWebhookContext{},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/api/v2/webhooks", httpContext.Requests[0].URL.Path)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 3, data["count"])
+		assert.Equal(t, map[string]int{"active": 1, "stale": 1, "external": 1}, data["statuses"])
+
+		webhooks := data["webhooks"].([]any)
+		assert.Equal(t, "wh-active", webhooks[0].(map[string]any)["id"])
+		assert.Equal(t, WebhookStatusActive, webhooks[0].(map[string]any)["status"])
+		assert.Equal(t, "wh-other-install", webhooks[1].(map[string]any)["id"])
+		assert.Equal(t, WebhookStatusExternal, webhooks[1].(map[string]any)["status"])
+		assert.Equal(t, "wh-stale", webhooks[2].(map[string]any)["id"])
+		assert.Equal(t, WebhookStatusStale, webhooks[2].(map[string]any)["status"])
+	})
+
+	t.Run("status filter -> only matching webhooks emitted", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{listMembersResponse(webhooksResponse())},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"statuses": []string{WebhookStatusStale}},
+			HTTP:           httpContext,
+			Integration:    webhooksIntegration(),
+			Webhook:        &contexts.NodeWebhookContext{},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1, data["count"])
+		assert.Equal(t, "wh-stale", data["webhooks"].([]any)[0].(map[string]any)["id"])
+	})
+}
+
+func Test__SuperPlaneWebhookID(t *testing.T) {
+	t.Run("webhook URL on the host -> ID", func(t *testing.T) {
+		id, ok := superPlaneWebhookID("https://app.superplane.com/api/v1/webhooks/"+staleWebhookID.String(), "app.superplane.com")
+		require.True(t, ok)
+		assert.Equal(t, staleWebhookID, id)
+	})
+
+	t.Run("trailing slash -> ID", func(t *testing.T) {
+		_, ok := superPlaneWebhookID("https://app.superplane.com/api/v1/webhooks/"+staleWebhookID.String()+"/", "app.superplane.com")
+		assert.True(t, ok)
+	})
+
+	t.Run("other host -> not matched", func(t *testing.T) {
+		_, ok := superPlaneWebhookID("https://staging.superplane.com/api/v1/webhooks/"+staleWebhookID.String(), "app.superplane.com")
+		assert.False(t, ok)
+	})
+
+	t.Run("path without webhook ID -> not matched", func(t *testing.T) {
+		_, ok := superPlaneWebhookID("https://app.superplane.com/api/v1/integrations/launchdarkly", "app.superplane.com")
+		assert.False(t, ok)
+	})
+}
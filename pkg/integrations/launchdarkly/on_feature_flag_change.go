@@ -1,16 +1,21 @@
 package launchdarkly
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/telemetry"
+	"github.com/superplanehq/superplane/pkg/utils"
 )
 
 // LaunchDarkly webhook "kind" value for feature flag events.
@@ -34,8 +39,19 @@ type OnFeatureFlagChangeConfiguration struct {
 	Environments []string                  `json:"environments" mapstructure:"environments"`
 	Flags        []configuration.Predicate `json:"flags" mapstructure:"flags"`
 	Actions      []string                  `json:"actions" mapstructure:"actions"`
+	Enrich       bool                      `json:"enrich" mapstructure:"enrich"`
+
+	// CombineAccesses emits a single event for all the matched accesses of a webhook,
+	// instead of one event per matched access.
+	CombineAccesses bool `json:"combineAccesses" mapstructure:"combineAccesses"`
+
+	EventTypePrefix string                    `json:"eventTypePrefix" mapstructure:"eventTypePrefix"`
+	OutputMapping   []core.OutputMappingEntry `json:"outputMapping" mapstructure:"outputMapping"`
 }
 
+// flagEventTypePrefix is the prefix of the emitted event types that can be customized.
+const flagEventTypePrefix = "launchdarkly.flag"
+
 func (t *OnFeatureFlagChange) Name() string {
 	return "launchdarkly.onFeatureFlagChange"
 }
@@ -49,7 +65,7 @@ func (t *OnFeatureFlagChange) Description() string {
 }
 
 func (t *OnFeatureFlagChange) Documentation() string {
-	return `The On Feature Flag Change trigger starts a workflow execution when LaunchDarkly sends webhooks for feature flags in a project.
+	return utils.RenderDocumentation(`The On Feature Flag Change trigger starts a workflow execution when LaunchDarkly sends webhooks for feature flags in a project.
 
 ## Use Cases
 
@@ -64,12 +80,22 @@ func (t *OnFeatureFlagChange) Documentation() string {
 - **Environments**: Optionally filter by environment(s). Leave empty to receive events for all environments.
 - **Feature Flags**: Optionally filter by specific flags or patterns. Leave empty to receive events for all flags.
 - **Actions**: Optionally filter by specific actions (e.g. only when a flag is turned on or off). Leave empty to receive all actions.
+- **Combine Batched Changes**: LaunchDarkly can batch several changes, like turning a flag on and changing its rules, in one webhook. Each change that passes the filters is emitted as its own event, with its action in `+"`action`"+`. When enabled, a single event is emitted instead, typed after the first matching change, with the actions of all of them in `+"`actions`"+`.
+- **Include Current Flag**: Optionally fetch the flag when a change is received, and add its current variations and targeting to the payload under `+"`flag`"+`. Deleted flags are not fetched. If the flag cannot be fetched, the event is still emitted, with the reason in `+"`enrichmentError`"+`.
+- **Event Type Prefix**: Optionally replace `+"`launchdarkly.flag`"+` in the event type, for example with `+"`payments.flag`"+` to namespace events by team. The original type is kept in the `+"`canonicalType`"+` field of the payload.
+- **Output Mapping**: Optionally emit only the listed fields, each set to an expression on the event payload, available as `+"`$`"+`. For example, `+"`flag`"+` set to `+"`$.flagKey`"+` and `+"`changedBy`"+` set to `+"`$.member?.email`"+` emits just those two fields.
 
 ## Webhook Setup
 
-The webhook is automatically created in LaunchDarkly when you save the canvas. No manual setup is required.
+The webhook is automatically created in LaunchDarkly when you save the canvas. No manual setup is required. When environments, or flags that all use the Equals operator, are selected, the webhook only receives the changes of those environments and flags. Other flag filters are applied to the changes of all flags.
+
+SuperPlane uses the LaunchDarkly API (via your configured API access token) to create a signed webhook scoped to the selected project, and securely stores the auto-generated signing secret. Events received while the webhook is still being created are answered with a 503, so LaunchDarkly retries them. When LaunchDarkly sends events, SuperPlane verifies the signature and filters to the configured environments, flags, and actions automatically.
+
+## Event Data
 
-SuperPlane uses the LaunchDarkly API (via your configured API access token) to create a signed webhook scoped to the selected project, and securely stores the auto-generated signing secret. When LaunchDarkly sends events, SuperPlane verifies the signature and filters to the configured environments, flags, and actions automatically.`
+Each event carries the LaunchDarkly audit log entry for the change. The `+"`accesses`"+` list holds the action and the affected resource, which is what the environment, flag, and action filters are matched against:
+
+{{example:data.accesses}}`, t.ExampleData())
 }
 
 func (t *OnFeatureFlagChange) Icon() string {
@@ -145,6 +171,24 @@ func (t *OnFeatureFlagChange) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "combineAccesses",
+			Label:       "Combine Batched Changes",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Emit one event for all the matching changes of a webhook, instead of one event per change",
+		},
+		{
+			Name:        "enrich",
+			Label:       "Include Current Flag",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Fetch the changed flag and add its current state to the event",
+		},
+		core.EventTypePrefixConfiguration(flagEventTypePrefix),
+		core.OutputMappingConfiguration(),
 	}
 }
 
@@ -158,9 +202,58 @@ func (t *OnFeatureFlagChange) Setup(ctx core.TriggerContext) error {
 		return fmt.Errorf("project key is required")
 	}
 
-	return ctx.Integration.RequestWebhook(WebhookConfiguration{
-		ProjectKey: config.ProjectKey,
-	})
+	if err := core.ValidateEventTypePrefix(config.EventTypePrefix); err != nil {
+		return err
+	}
+
+	if err := core.ValidateOutputMapping(config.OutputMapping); err != nil {
+		return err
+	}
+
+	webhookConfig := webhookConfiguration(config)
+	if ctx.DryRun {
+		ctx.Plan.Add(core.SetupPlanStep{
+			Action:      core.SetupPlanActionEnsure,
+			Resource:    "webhook",
+			Description: fmt.Sprintf("Signed LaunchDarkly webhook for the flag changes of project %s", config.ProjectKey),
+			Details: map[string]any{
+				"projectKey": config.ProjectKey,
+				"statements": webhookStatements(webhookConfig),
+			},
+		})
+
+		return nil
+	}
+
+	return requestWebhook(ctx, webhookConfig)
+}
+
+// webhookConfiguration scopes the webhook to the environments and flags the trigger filters on.
+// Flags can only be scoped when every filter is an exact key; other predicates,
+// like patterns, cannot be expressed as LaunchDarkly resources, so all flags are sent
+// and filtered when received.
+func webhookConfiguration(config OnFeatureFlagChangeConfiguration) WebhookConfiguration {
+	return WebhookConfiguration{
+		ProjectKey:   config.ProjectKey,
+		Environments: normalizeWebhookKeys(config.Environments),
+		Flags:        exactPredicateKeys(config.Flags),
+	}
+}
+
+// exactPredicateKeys returns the keys the predicates select, when every predicate is an exact key.
+// Otherwise, it returns no keys, so the webhook is not scoped by them.
+func exactPredicateKeys(predicates []configuration.Predicate) []string {
+	keys := []string{}
+	for _, predicate := range predicates {
+		value := strings.TrimSpace(predicate.Value)
+		if predicate.Type != configuration.PredicateTypeEquals || value == "" || strings.Contains(value, "*") {
+			return []string{}
+		}
+
+		keys = append(keys, value)
+	}
+
+	return normalizeWebhookKeys(keys)
 }
 
 func (t *OnFeatureFlagChange) Actions() []core.Action {
@@ -179,25 +272,9 @@ func (t *OnFeatureFlagChange) HandleWebhook(ctx core.WebhookRequestContext) (int
 		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
-	// Verify webhook signature
-	signingSecret := resolveSigningSecret(ctx)
-	if signingSecret == "" {
-		return http.StatusForbidden, fmt.Errorf("signing secret is required for webhook verification; the webhook may still be provisioning")
-	}
-
-	signature := ctx.Headers.Get("X-LD-Signature")
-	if signature == "" {
-		return http.StatusForbidden, fmt.Errorf("missing X-LD-Signature header")
-	}
-
-	if err := crypto.VerifySignature([]byte(signingSecret), ctx.Body, signature); err != nil {
-		return http.StatusForbidden, fmt.Errorf("invalid signature: %w", err)
-	}
-
-	// Parse the webhook payload
-	var payload map[string]any
-	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
-		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %w", err)
+	payload, status, err := verifyWebhookPayload(ctx, t.Name())
+	if err != nil {
+		return status, err
 	}
 
 	// LaunchDarkly webhook payloads have a "kind" field (e.g., "flag", "project", "environment")
@@ -213,81 +290,318 @@ func (t *OnFeatureFlagChange) HandleWebhook(ctx core.WebhookRequestContext) (int
 		return http.StatusOK, nil
 	}
 
-	// Extract action, environment key, and flag key from the accesses array.
-	// Resource format: proj/<projKey>:env/<envKey>:flag/<flagKey>
-	action := ""
-	envKey := ""
-	flagKey := ""
-	if accesses, ok := payload["accesses"].([]any); ok && len(accesses) > 0 {
-		if access, ok := accesses[0].(map[string]any); ok {
-			action, _ = access["action"].(string)
-			resource, _ := access["resource"].(string)
-			envKey, flagKey = parseResourceEnvAndFlag(resource)
+	matches := matchingFlagAccesses(ctx, config, payload)
+	if len(matches) == 0 {
+		return http.StatusOK, nil
+	}
+
+	events := []flagChangeEvent{}
+	if config.CombineAccesses {
+		events = append(events, combinedFlagChangeEvent(payload, config.ProjectKey, matches))
+	} else {
+		for _, match := range matches {
+			events = append(events, accessFlagChangeEvent(payload, config.ProjectKey, match))
 		}
 	}
 
-	// Filter by configured environments.
-	// Skip if: env key could not be extracted (no accesses), or env is "*" (project-scoped
-	// actions like createFlag use proj/<proj>:env/*:flag/<flag> and are not environment-specific).
-	if len(config.Environments) > 0 && envKey != "" && envKey != "*" && !slices.Contains(config.Environments, envKey) {
-		ctx.Logger.Infof("launchdarkly webhook: environment %q does not match configured environments, acknowledging without emitting", envKey)
-		return http.StatusOK, nil
+	for _, event := range events {
+		if config.Enrich {
+			enrichFlagPayload(ctx, event.payload, config.ProjectKey, event.access.flagKey, event.access.action)
+		}
+
+		payloadType := event.payloadType(kind)
+		payload, err := core.ApplyOutputMapping(event.payload, config.OutputMapping)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("error applying output mapping: %w", err)
+		}
+
+		if eventType := core.ApplyEventTypePrefix(payloadType, flagEventTypePrefix, config.EventTypePrefix); eventType != payloadType {
+			payload[core.CanonicalTypeField] = payloadType
+			payloadType = eventType
+		}
+
+		if err := ctx.Events.Emit(payloadType, payload); err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("error emitting event: %w", err)
+		}
+
+		ctx.Logger.Infof("launchdarkly webhook: emitted %s for workflow %s", payloadType, ctx.WorkflowID)
 	}
 
-	// Filter by configured flags.
-	// Skip if: flag key could not be extracted (no accesses).
-	if len(config.Flags) > 0 && flagKey != "" && !configuration.MatchesAnyPredicate(config.Flags, flagKey) {
-		ctx.Logger.Infof("launchdarkly webhook: flag %q does not match configured flags, acknowledging without emitting", flagKey)
-		return http.StatusOK, nil
+	return http.StatusOK, nil
+}
+
+// flagAccess is an entry of the accesses array of a webhook payload,
+// with the keys extracted from its resource.
+// Resource format: proj/<projKey>:env/<envKey>:flag/<flagKey>
+type flagAccess struct {
+	action  string
+	envKey  string
+	flagKey string
+}
+
+// flagChangeEvent is an event emitted for one or more accesses of a webhook payload.
+type flagChangeEvent struct {
+	payload map[string]any
+	access  flagAccess
+}
+
+// payloadType returns a specific event type from the kind and the action of the event.
+func (e flagChangeEvent) payloadType(kind string) string {
+	if e.access.action == "" {
+		return "launchdarkly." + kind
 	}
 
-	// Filter by configured actions (optional — empty means accept all)
-	if len(config.Actions) > 0 && !slices.Contains(config.Actions, action) {
-		ctx.Logger.Infof("launchdarkly webhook: action %q not in trigger config (configured: %v), acknowledging without emitting", action, config.Actions)
-		return http.StatusOK, nil
+	return "launchdarkly." + kind + "." + e.access.action
+}
+
+// matchingFlagAccesses returns the accesses of the payload that pass the environment, flag and action filters.
+// LaunchDarkly batches several actions in one webhook, so every access is matched on its own.
+// Payloads without accesses are matched as a single access without action and keys.
+func matchingFlagAccesses(ctx core.WebhookRequestContext, config OnFeatureFlagChangeConfiguration, payload map[string]any) []flagAccess {
+	accesses := []flagAccess{}
+	for _, access := range payloadAccesses(payload) {
+		envKey, flagKey := parseResourceEnvAndKey(access.resource, KindFlag)
+		accesses = append(accesses, flagAccess{action: access.action, envKey: envKey, flagKey: flagKey})
 	}
 
-	// Inject extracted keys into the payload so consumers can access them directly.
-	payload["projectKey"] = config.ProjectKey
-	if envKey != "" && envKey != "*" {
-		payload["environmentKey"] = envKey
+	if len(accesses) == 0 {
+		accesses = append(accesses, flagAccess{})
 	}
-	if flagKey != "" {
-		payload["flagKey"] = flagKey
+
+	matches := []flagAccess{}
+	for _, access := range accesses {
+		// Filter by configured environments.
+		// Skip if: env key could not be extracted (no accesses), or env is "*" (project-scoped
+		// actions like createFlag use proj/<proj>:env/*:flag/<flag> and are not environment-specific).
+		if len(config.Environments) > 0 && access.envKey != "" && access.envKey != "*" && !slices.Contains(config.Environments, access.envKey) {
+			ctx.Logger.Infof("launchdarkly webhook: environment %q does not match configured environments, skipping the access", access.envKey)
+			continue
+		}
+
+		// Filter by configured flags.
+		// Skip if: flag key could not be extracted (no accesses).
+		if len(config.Flags) > 0 && access.flagKey != "" && !configuration.MatchesAnyPredicate(config.Flags, access.flagKey) {
+			ctx.Logger.Infof("launchdarkly webhook: flag %q does not match configured flags, skipping the access", access.flagKey)
+			continue
+		}
+
+		// Filter by configured actions (optional — empty means accept all)
+		if len(config.Actions) > 0 && !slices.Contains(config.Actions, access.action) {
+			ctx.Logger.Infof("launchdarkly webhook: action %q not in trigger config (configured: %v), skipping the access", access.action, config.Actions)
+			continue
+		}
+
+		matches = append(matches, access)
 	}
 
-	// Determine a more specific payload type from the kind and action
-	payloadType := "launchdarkly." + kind
-	if action != "" {
-		payloadType = "launchdarkly." + kind + "." + action
+	return matches
+}
+
+// accessFlagChangeEvent returns the event of a single access,
+// with its keys and action injected so consumers can access them directly.
+func accessFlagChangeEvent(payload map[string]any, projectKey string, access flagAccess) flagChangeEvent {
+	event := maps.Clone(payload)
+	event["projectKey"] = projectKey
+	if access.envKey != "" && access.envKey != "*" {
+		event["environmentKey"] = access.envKey
+	}
+	if access.flagKey != "" {
+		event["flagKey"] = access.flagKey
+	}
+	if access.action != "" {
+		event["action"] = access.action
 	}
 
-	if err := ctx.Events.Emit(payloadType, payload); err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("error emitting event: %w", err)
+	return flagChangeEvent{payload: event, access: access}
+}
+
+// combinedFlagChangeEvent returns a single event for all the matched accesses.
+// Its type and keys are the ones of the first match, and the actions of all the matches
+// are listed under "actions".
+func combinedFlagChangeEvent(payload map[string]any, projectKey string, matches []flagAccess) flagChangeEvent {
+	event := accessFlagChangeEvent(payload, projectKey, matches[0])
+
+	actions := []string{}
+	for _, match := range matches {
+		if match.action != "" && !slices.Contains(actions, match.action) {
+			actions = append(actions, match.action)
+		}
 	}
 
-	ctx.Logger.Infof("launchdarkly webhook: emitted %s for workflow %s", payloadType, ctx.WorkflowID)
-	return http.StatusOK, nil
+	event.payload["actions"] = actions
+	return event
+}
+
+func (t *OnFeatureFlagChange) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (t *OnFeatureFlagChange) SupportsDryRun() bool {
+	return true
 }
 
 func (t *OnFeatureFlagChange) Cleanup(ctx core.TriggerContext) error {
 	return nil
 }
 
-// parseResourceEnvAndFlag extracts the environment and flag keys from a LaunchDarkly resource string.
-// Expected format: proj/<projKey>:env/<envKey>:flag/<flagKey>
-func parseResourceEnvAndFlag(resource string) (envKey, flagKey string) {
-	// Split on ":env/" to get the environment and flag parts
+// enrichFlagPayload adds the current state of the changed flag to the payload under "flag".
+// A failure to fetch the flag does not drop the event: it is emitted with the reason
+// in "enrichmentError" instead.
+func enrichFlagPayload(ctx core.WebhookRequestContext, payload map[string]any, projectKey, flagKey, action string) {
+	//
+	// A deleted flag cannot be fetched anymore,
+	// and events without accesses do not say which flag changed.
+	//
+	if flagKey == "" || action == ActionDeleteFlag {
+		return
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		payload["enrichmentError"] = fmt.Sprintf("failed to create LaunchDarkly client: %v", err)
+		return
+	}
+
+	flag, err := client.GetFeatureFlag(projectKey, flagKey)
+	if err != nil {
+		ctx.Logger.Warnf("launchdarkly webhook: failed to get flag %s for enrichment: %v", flagKey, err)
+		payload["enrichmentError"] = fmt.Sprintf("failed to get feature flag: %v", err)
+		return
+	}
+
+	payload["flag"] = flag
+}
+
+// webhookProvisioningWindow is how long after a trigger requests its webhook
+// a missing signing secret is expected while the webhook is being created.
+const webhookProvisioningWindow = 10 * time.Minute
+
+// WebhookNodeMetadata records when a trigger last requested its webhook.
+type WebhookNodeMetadata struct {
+	WebhookRequestedAt string `json:"webhookRequestedAt" mapstructure:"webhookRequestedAt"`
+}
+
+// requestWebhook requests the webhook of a trigger, and records when it was requested,
+// so events received before the webhook is provisioned can be told apart.
+func requestWebhook(ctx core.TriggerContext, webhookConfig WebhookConfiguration) error {
+	if err := ctx.Integration.RequestWebhook(webhookConfig); err != nil {
+		return err
+	}
+
+	if ctx.Metadata == nil {
+		return nil
+	}
+
+	return ctx.Metadata.Set(WebhookNodeMetadata{WebhookRequestedAt: time.Now().UTC().Format(time.RFC3339)})
+}
+
+// webhookProvisioning reports whether the webhook of the node was requested recently enough
+// to still be provisioning. Nodes without a request time were set up before it was recorded,
+// so their webhook is expected to be provisioned.
+func webhookProvisioning(metadata core.MetadataContext, now time.Time) bool {
+	if metadata == nil {
+		return false
+	}
+
+	nodeMetadata := WebhookNodeMetadata{}
+	if err := mapstructure.Decode(metadata.Get(), &nodeMetadata); err != nil {
+		return false
+	}
+
+	requestedAt, err := time.Parse(time.RFC3339, nodeMetadata.WebhookRequestedAt)
+	if err != nil {
+		return false
+	}
+
+	return now.Sub(requestedAt) < webhookProvisioningWindow
+}
+
+// verifyWebhookPayload verifies the signature of a webhook request and returns its payload.
+// The status is the one to respond with when the request is rejected.
+func verifyWebhookPayload(ctx core.WebhookRequestContext, trigger string) (map[string]any, int, error) {
+	signingSecret := resolveSigningSecret(ctx)
+	if signingSecret == "" {
+		//
+		// The webhook is created after the canvas is saved, so events can arrive
+		// before its secret is stored. Those are answered with a 503, which LaunchDarkly retries.
+		//
+		if webhookProvisioning(ctx.Metadata, time.Now()) {
+			return nil, http.StatusServiceUnavailable, fmt.Errorf("the webhook is still being provisioned, retry later")
+		}
+
+		return nil, http.StatusForbidden, fmt.Errorf("signing secret is required for webhook verification")
+	}
+
+	signature := ctx.Headers.Get("X-LD-Signature")
+	if signature == "" {
+		telemetry.RecordWebhookSignatureVerification(context.Background(), trigger, telemetry.SignatureVerificationMissing)
+		return nil, http.StatusForbidden, fmt.Errorf("missing X-LD-Signature header")
+	}
+
+	if err := crypto.VerifySignature([]byte(signingSecret), ctx.Body, signature); err != nil {
+		telemetry.RecordWebhookSignatureVerification(context.Background(), trigger, telemetry.SignatureVerificationMismatch)
+		return nil, http.StatusForbidden, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	telemetry.RecordWebhookSignatureVerification(context.Background(), trigger, telemetry.SignatureVerificationPass)
+
+	var payload map[string]any
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("error parsing request body: %w", err)
+	}
+
+	return payload, http.StatusOK, nil
+}
+
+// webhookAccess is an entry of the accesses array of a webhook payload.
+type webhookAccess struct {
+	action   string
+	resource string
+}
+
+// payloadAccesses returns the action and resource of every entry of the accesses array of a payload.
+func payloadAccesses(payload map[string]any) []webhookAccess {
+	entries, _ := payload["accesses"].([]any)
+	accesses := []webhookAccess{}
+	for _, entry := range entries {
+		access, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		action, _ := access["action"].(string)
+		resource, _ := access["resource"].(string)
+		accesses = append(accesses, webhookAccess{action: action, resource: resource})
+	}
+
+	return accesses
+}
+
+// firstAccess returns the action and resource of the first entry of the accesses array of a payload.
+func firstAccess(payload map[string]any) (action, resource string) {
+	accesses := payloadAccesses(payload)
+	if len(accesses) == 0 {
+		return "", ""
+	}
+
+	return accesses[0].action, accesses[0].resource
+}
+
+// parseResourceEnvAndKey extracts the environment key, and the key of the resource of the given kind,
+// from a LaunchDarkly resource string.
+// Expected formats: proj/<projKey>:env/<envKey>:flag/<flagKey> and proj/<projKey>:env/<envKey>:segment/<segmentKey>
+func parseResourceEnvAndKey(resource, kind string) (envKey, key string) {
+	// Split on ":env/" to get the environment and the resource parts
 	envParts := strings.SplitN(resource, ":env/", 2)
 	if len(envParts) != 2 {
 		return "", ""
 	}
-	// The remaining part is "<envKey>:flag/<flagKey>"
-	flagParts := strings.SplitN(envParts[1], ":flag/", 2)
-	if len(flagParts) != 2 {
+	// The remaining part is "<envKey>:<kind>/<key>"
+	keyParts := strings.SplitN(envParts[1], ":"+kind+"/", 2)
+	if len(keyParts) != 2 {
 		return envParts[1], ""
 	}
-	return flagParts[0], flagParts[1]
+	return keyParts[0], keyParts[1]
 }
 
 // resolveSigningSecret returns the webhook signing secret for verification.
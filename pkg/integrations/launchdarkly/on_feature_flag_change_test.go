@@ -4,14 +4,18 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
@@ -44,6 +48,40 @@ func Test__OnFeatureFlagChange__HandleWebhook(t *testing.T) {
 		assert.ErrorContains(t, err, "signing secret is required")
 	})
 
+	t.Run("missing signing secret shortly after the webhook was requested -> 503", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		require.NoError(t, metadata.Set(WebhookNodeMetadata{WebhookRequestedAt: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)}))
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       http.Header{},
+			Configuration: defaultConfig,
+			Metadata:      metadata,
+			Webhook:       &contexts.NodeWebhookContext{},
+			Events:        &contexts.EventContext{},
+			Logger:        testLogger,
+		})
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.ErrorContains(t, err, "still being provisioned")
+	})
+
+	t.Run("missing signing secret long after the webhook was requested -> 403", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		require.NoError(t, metadata.Set(WebhookNodeMetadata{WebhookRequestedAt: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)}))
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Headers:       http.Header{},
+			Configuration: defaultConfig,
+			Metadata:      metadata,
+			Webhook:       &contexts.NodeWebhookContext{},
+			Events:        &contexts.EventContext{},
+			Logger:        testLogger,
+		})
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "signing secret is required")
+	})
+
 	t.Run("missing X-LD-Signature header -> 403", func(t *testing.T) {
 		wc := &contexts.NodeWebhookContext{}
 		require.NoError(t, wc.SetSecret([]byte(validSecret)))
@@ -131,6 +169,66 @@ func Test__OnFeatureFlagChange__HandleWebhook(t *testing.T) {
 		assert.Equal(t, "My Feature", payload["name"])
 	})
 
+	t.Run("custom event type prefix -> prefix replaced and canonical type kept", func(t *testing.T) {
+		body := []byte(`{"kind":"flag","name":"My Feature","accesses":[{"action":"updateOn","resource":"proj/default:env/production:flag/my-flag"}]}`)
+		headers := http.Header{}
+		headers.Set("X-LD-Signature", hmacSignature(validSecret, body))
+
+		wc := &contexts.NodeWebhookContext{}
+		require.NoError(t, wc.SetSecret([]byte(validSecret)))
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       headers,
+			Configuration: map[string]any{"projectKey": "default", "eventTypePrefix": "payments.flag"},
+			Webhook:       wc,
+			Events:        eventContext,
+			Logger:        testLogger,
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "payments.flag.updateOn", eventContext.Payloads[0].Type)
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.updateOn", payload[core.CanonicalTypeField])
+	})
+
+	t.Run("output mapping -> fields renamed and the others dropped", func(t *testing.T) {
+		body := []byte(`{"kind":"flag","name":"My Feature","titleVerb":"turned on","member":{"email":"jane@example.com"},"accesses":[{"action":"updateOn","resource":"proj/default:env/production:flag/my-flag"}]}`)
+		headers := http.Header{}
+		headers.Set("X-LD-Signature", hmacSignature(validSecret, body))
+
+		wc := &contexts.NodeWebhookContext{}
+		require.NoError(t, wc.SetSecret([]byte(validSecret)))
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: headers,
+			Configuration: map[string]any{
+				"projectKey": "default",
+				"outputMapping": []map[string]any{
+					{"field": "flag", "expression": "$.flagKey"},
+					{"field": "environment", "expression": "$.environmentKey"},
+					{"field": "changedBy", "expression": "$.member?.email"},
+				},
+			},
+			Webhook: wc,
+			Events:  eventContext,
+			Logger:  testLogger,
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "launchdarkly.flag.updateOn", eventContext.Payloads[0].Type)
+		assert.Equal(t, map[string]any{
+			"flag":        "my-flag",
+			"environment": "production",
+			"changedBy":   "jane@example.com",
+		}, eventContext.Payloads[0].Data)
+	})
+
 	t.Run("flag event without accesses -> emit with kind-only type", func(t *testing.T) {
 		body := []byte(`{"kind":"flag","name":"Simple Flag"}`)
 		sig := hmacSignature(validSecret, body)
@@ -413,6 +511,155 @@ func Test__OnFeatureFlagChange__HandleWebhook(t *testing.T) {
 		assert.Equal(t, "launchdarkly.flag.deleteFlag", eventContext.Payloads[0].Type)
 	})
 
+	batchedWebhook := func(config map[string]any) *contexts.EventContext {
+		body := []byte(`{"kind":"flag","name":"My Feature","accesses":[` +
+			`{"action":"updateOn","resource":"proj/default:env/production:flag/my-flag"},` +
+			`{"action":"updateRules","resource":"proj/default:env/staging:flag/my-flag"}]}`)
+		headers := http.Header{}
+		headers.Set("X-LD-Signature", hmacSignature(validSecret, body))
+
+		wc := &contexts.NodeWebhookContext{}
+		require.NoError(t, wc.SetSecret([]byte(validSecret)))
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       headers,
+			Configuration: config,
+			Webhook:       wc,
+			Events:        eventContext,
+			Logger:        testLogger,
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		return eventContext
+	}
+
+	t.Run("two accesses -> one event per access", func(t *testing.T) {
+		eventContext := batchedWebhook(defaultConfig)
+
+		require.Equal(t, 2, eventContext.Count())
+		assert.Equal(t, "launchdarkly.flag.updateOn", eventContext.Payloads[0].Type)
+		first := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "updateOn", first["action"])
+		assert.Equal(t, "production", first["environmentKey"])
+		assert.Equal(t, "my-flag", first["flagKey"])
+
+		assert.Equal(t, "launchdarkly.flag.updateRules", eventContext.Payloads[1].Type)
+		second := eventContext.Payloads[1].Data.(map[string]any)
+		assert.Equal(t, "updateRules", second["action"])
+		assert.Equal(t, "staging", second["environmentKey"])
+	})
+
+	t.Run("two accesses, second matches the action filter -> emits the second", func(t *testing.T) {
+		eventContext := batchedWebhook(map[string]any{"projectKey": "default", "actions": []string{ActionUpdateRules}})
+
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "launchdarkly.flag.updateRules", eventContext.Payloads[0].Type)
+		assert.Equal(t, "staging", eventContext.Payloads[0].Data.(map[string]any)["environmentKey"])
+	})
+
+	t.Run("two accesses, environment filter applied per access -> emits the matching one", func(t *testing.T) {
+		eventContext := batchedWebhook(map[string]any{"projectKey": "default", "environments": []string{"production"}})
+
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "launchdarkly.flag.updateOn", eventContext.Payloads[0].Type)
+	})
+
+	t.Run("two accesses, combined -> single event with all the actions", func(t *testing.T) {
+		eventContext := batchedWebhook(map[string]any{"projectKey": "default", "combineAccesses": true})
+
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "launchdarkly.flag.updateOn", eventContext.Payloads[0].Type)
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, []string{ActionUpdateOn, ActionUpdateRules}, payload["actions"])
+		assert.Equal(t, "production", payload["environmentKey"])
+	})
+
+	t.Run("two accesses, none matching -> no emit", func(t *testing.T) {
+		eventContext := batchedWebhook(map[string]any{"projectKey": "default", "actions": []string{ActionDeleteFlag}})
+		assert.Equal(t, 0, eventContext.Count())
+	})
+
+	enrichedWebhook := func(body []byte, httpContext *contexts.HTTPContext) (*contexts.EventContext, int, error) {
+		headers := http.Header{}
+		headers.Set("X-LD-Signature", hmacSignature(validSecret, body))
+
+		wc := &contexts.NodeWebhookContext{}
+		require.NoError(t, wc.SetSecret([]byte(validSecret)))
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       headers,
+			Configuration: map[string]any{"projectKey": "default", "enrich": true},
+			Webhook:       wc,
+			Events:        eventContext,
+			Logger:        testLogger,
+			HTTP:          httpContext,
+			Integration:   &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+		})
+
+		return eventContext, code, err
+	}
+
+	t.Run("enrich -> current flag added to the payload", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"my-flag","variations":[{"value":true},{"value":false}]}`))},
+			},
+		}
+
+		body := []byte(`{"kind":"flag","accesses":[{"action":"updateRules","resource":"proj/default:env/production:flag/my-flag"}]}`)
+		eventContext, code, err := enrichedWebhook(body, httpContext)
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/my-flag", httpContext.Requests[0].URL.String())
+		require.Equal(t, 1, eventContext.Count())
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, map[string]any{
+			"key":        "my-flag",
+			"variations": []any{map[string]any{"value": true}, map[string]any{"value": false}},
+		}, payload["flag"])
+		assert.NotContains(t, payload, "enrichmentError")
+	})
+
+	t.Run("enrich deleted flag -> not fetched", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+
+		body := []byte(`{"kind":"flag","accesses":[{"action":"deleteFlag","resource":"proj/default:env/*:flag/my-flag"}]}`)
+		eventContext, code, err := enrichedWebhook(body, httpContext)
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		assert.Empty(t, httpContext.Requests)
+		require.Equal(t, 1, eventContext.Count())
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.NotContains(t, payload, "flag")
+		assert.NotContains(t, payload, "enrichmentError")
+	})
+
+	t.Run("enrich fails -> base payload emitted with the error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+			},
+		}
+
+		body := []byte(`{"kind":"flag","accesses":[{"action":"updateOn","resource":"proj/default:env/production:flag/my-flag"}]}`)
+		eventContext, code, err := enrichedWebhook(body, httpContext)
+
+		require.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "launchdarkly.flag.updateOn", eventContext.Payloads[0].Type)
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.NotContains(t, payload, "flag")
+		assert.Contains(t, payload["enrichmentError"], "failed to get feature flag")
+		assert.Equal(t, "my-flag", payload["flagKey"])
+	})
+
 	t.Run("missing kind in payload -> 400", func(t *testing.T) {
 		body := []byte(`{"name":"No Kind Field"}`)
 		sig := hmacSignature(validSecret, body)
@@ -448,6 +695,29 @@ func Test__OnFeatureFlagChange__Setup(t *testing.T) {
 		require.ErrorContains(t, err, "project key is required")
 	})
 
+	t.Run("invalid event type prefix -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Webhook:       &contexts.NodeWebhookContext{},
+			Configuration: OnFeatureFlagChangeConfiguration{ProjectKey: "default", EventTypePrefix: "payments..flag"},
+		})
+		require.ErrorContains(t, err, "invalid event type prefix")
+	})
+
+	t.Run("output mapping without field name -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Webhook:     &contexts.NodeWebhookContext{},
+			Configuration: OnFeatureFlagChangeConfiguration{
+				ProjectKey:    "default",
+				OutputMapping: []core.OutputMappingEntry{{Expression: "$.flagKey"}},
+			},
+		})
+		require.ErrorContains(t, err, "output mapping entry 1: field is required")
+	})
+
 	t.Run("project only requests webhook for all flags", func(t *testing.T) {
 		integrationCtx := &contexts.IntegrationContext{}
 		err := trigger.Setup(core.TriggerContext{
@@ -464,6 +734,51 @@ func Test__OnFeatureFlagChange__Setup(t *testing.T) {
 		assert.Equal(t, "default", req.ProjectKey)
 	})
 
+	t.Run("webhook requested -> request time recorded in the node metadata", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      metadata,
+			Webhook:       &contexts.NodeWebhookContext{},
+			Configuration: OnFeatureFlagChangeConfiguration{ProjectKey: "default"},
+		})
+
+		require.NoError(t, err)
+		nodeMetadata, ok := metadata.Get().(WebhookNodeMetadata)
+		require.True(t, ok)
+		requestedAt, err := time.Parse(time.RFC3339, nodeMetadata.WebhookRequestedAt)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), requestedAt, time.Minute)
+	})
+
+	t.Run("dry run -> plans the webhook without requesting it", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		integrationCtx := &contexts.IntegrationContext{}
+		plan := &core.SetupPlan{}
+
+		err := trigger.Setup(core.TriggerContext{
+			HTTP:          httpCtx,
+			Integration:   integrationCtx,
+			Metadata:      &contexts.MetadataContext{},
+			Webhook:       &contexts.NodeWebhookContext{},
+			Configuration: OnFeatureFlagChangeConfiguration{ProjectKey: "default"},
+			DryRun:        true,
+			Plan:          plan,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpCtx.Requests)
+		assert.Empty(t, integrationCtx.WebhookRequests)
+
+		require.Len(t, plan.Steps, 1)
+		assert.Equal(t, core.SetupPlanActionEnsure, plan.Steps[0].Action)
+		assert.Equal(t, "webhook", plan.Steps[0].Resource)
+		assert.Equal(t, "default", plan.Steps[0].Details["projectKey"])
+		assert.Equal(t, []WebhookStatement{
+			{Effect: "allow", Resources: []string{"proj/default:env/*:flag/*"}, Actions: []string{"*"}},
+		}, plan.Steps[0].Details["statements"])
+	})
+
 	t.Run("project with flags predicate requests webhook", func(t *testing.T) {
 		integrationCtx := &contexts.IntegrationContext{}
 		err := trigger.Setup(core.TriggerContext{
@@ -483,5 +798,104 @@ func Test__OnFeatureFlagChange__Setup(t *testing.T) {
 		req, ok := integrationCtx.WebhookRequests[0].(WebhookConfiguration)
 		require.True(t, ok)
 		assert.Equal(t, "default", req.ProjectKey)
+		assert.Equal(t, []string{"my-flag"}, req.Flags)
 	})
+
+	t.Run("environments and exact flags -> webhook scoped to them", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration: integrationCtx,
+			Metadata:    &contexts.MetadataContext{},
+			Webhook:     &contexts.NodeWebhookContext{},
+			Configuration: OnFeatureFlagChangeConfiguration{
+				ProjectKey:   "default",
+				Environments: []string{"production", "staging", "production"},
+				Flags: []configuration.Predicate{
+					{Type: configuration.PredicateTypeEquals, Value: "new-checkout"},
+					{Type: configuration.PredicateTypeEquals, Value: "dark-mode"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integrationCtx.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			ProjectKey:   "default",
+			Environments: []string{"production", "staging"},
+			Flags:        []string{"dark-mode", "new-checkout"},
+		}, integrationCtx.WebhookRequests[0])
+	})
+
+	t.Run("pattern flag filter -> webhook for all flags of the environments", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration: integrationCtx,
+			Metadata:    &contexts.MetadataContext{},
+			Webhook:     &contexts.NodeWebhookContext{},
+			Configuration: OnFeatureFlagChangeConfiguration{
+				ProjectKey:   "default",
+				Environments: []string{"production"},
+				Flags: []configuration.Predicate{
+					{Type: configuration.PredicateTypeEquals, Value: "new-checkout"},
+					{Type: configuration.PredicateTypeMatches, Value: "^release-.*"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		req := integrationCtx.WebhookRequests[0].(WebhookConfiguration)
+		assert.Equal(t, []string{"production"}, req.Environments)
+		assert.Empty(t, req.Flags)
+		assert.Equal(t, []WebhookStatement{
+			{Effect: "allow", Resources: []string{"proj/default:env/production:flag/*"}, Actions: []string{"*"}},
+		}, webhookStatements(req))
+	})
+}
+
+func Test__OnFeatureFlagChange__Documentation(t *testing.T) {
+	trigger := &OnFeatureFlagChange{}
+	doc := trigger.Documentation()
+
+	assert.NotContains(t, doc, "{{example")
+	assert.Contains(t, doc, "```json\n[\n  {\n    \"action\": \"updateOn\",\n    \"resource\": \"proj/default:env/test:flag/another-toggle-feature\"\n  }\n]\n```")
+}
+
+func Test__OnFeatureFlagChange__SignatureVerificationMetrics(t *testing.T) {
+	trigger := &OnFeatureFlagChange{}
+	secret := "test-signing-secret"
+	body := []byte(`{"kind":"project","name":"Some Project"}`)
+
+	count := func(outcome string) int64 {
+		return telemetry.WebhookSignatureVerificationCount(trigger.Name(), outcome)
+	}
+
+	handle := func(signature string) {
+		headers := http.Header{}
+		if signature != "" {
+			headers.Set("X-LD-Signature", signature)
+		}
+
+		wc := &contexts.NodeWebhookContext{}
+		require.NoError(t, wc.SetSecret([]byte(secret)))
+		_, _ = trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       headers,
+			Configuration: map[string]any{"projectKey": "default"},
+			Webhook:       wc,
+			Events:        &contexts.EventContext{},
+			Logger:        testLogger,
+		})
+	}
+
+	missing := count(telemetry.SignatureVerificationMissing)
+	mismatch := count(telemetry.SignatureVerificationMismatch)
+	pass := count(telemetry.SignatureVerificationPass)
+
+	handle("")
+	handle("invalidsignature")
+	handle(hmacSignature(secret, body))
+
+	assert.Equal(t, missing+1, count(telemetry.SignatureVerificationMissing))
+	assert.Equal(t, mismatch+1, count(telemetry.SignatureVerificationMismatch))
+	assert.Equal(t, pass+1, count(telemetry.SignatureVerificationPass))
 }
@@ -0,0 +1,238 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+// LaunchDarkly webhook "kind" value for segment events.
+const KindSegment = "segment"
+
+type OnSegmentChange struct{}
+
+type OnSegmentChangeConfiguration struct {
+	ProjectKey   string                    `json:"projectKey" mapstructure:"projectKey"`
+	Environments []string                  `json:"environments" mapstructure:"environments"`
+	Segments     []configuration.Predicate `json:"segments" mapstructure:"segments"`
+}
+
+func (t *OnSegmentChange) Name() string {
+	return "launchdarkly.onSegmentChange"
+}
+
+func (t *OnSegmentChange) Label() string {
+	return "On Segment Change"
+}
+
+func (t *OnSegmentChange) Description() string {
+	return "Listen to segment change events from LaunchDarkly"
+}
+
+func (t *OnSegmentChange) Documentation() string {
+	return utils.RenderDocumentation(`The On Segment Change trigger starts a workflow execution when LaunchDarkly sends webhooks for segments in a project.
+
+## Use Cases
+
+- **Targeting audits**: Track who is added to or removed from the segments flags target
+- **Notification workflows**: Notify the owners of the flags targeting a segment when it changes
+- **Integration workflows**: Sync segment changes with external systems
+
+## Configuration
+
+- **Project**: The LaunchDarkly project to monitor
+- **Environments**: Optionally filter by environment(s). Leave empty to receive events for all environments.
+- **Segments**: Optionally filter by specific segments or patterns. Leave empty to receive events for all segments.
+
+## Webhook Setup
+
+The webhook is automatically created in LaunchDarkly when you save the canvas. No manual setup is required. When environments, or segments that all use the Equals operator, are selected, the webhook only receives the changes of those environments and segments. Other segment filters are applied to the changes of all segments.
+
+SuperPlane uses the LaunchDarkly API (via your configured API access token) to create a signed webhook scoped to the segments of the selected project, and securely stores the auto-generated signing secret. When LaunchDarkly sends events, SuperPlane verifies the signature and filters to the configured environments and segments automatically.
+
+## Event Data
+
+Each event carries the LaunchDarkly audit log entry for the change, and is emitted as `+"`launchdarkly.segment.<action>`"+`. The `+"`accesses`"+` list holds the action and the affected resource, which is what the environment and segment filters are matched against:
+
+{{example:data.accesses}}`, t.ExampleData())
+}
+
+func (t *OnSegmentChange) Icon() string {
+	return "launchdarkly"
+}
+
+func (t *OnSegmentChange) Color() string {
+	return "gray"
+}
+
+func (t *OnSegmentChange) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project to monitor",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "environments",
+			Label:       "Environments",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Filter by environment. Leave empty to receive events for all environments.",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:  "environment",
+					Multi: true,
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "segments",
+			Label:       "Segments",
+			Type:        configuration.FieldTypeAnyPredicateList,
+			Required:    false,
+			Description: "Filter by segment key. Leave empty to receive events for all segments.",
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (t *OnSegmentChange) Setup(ctx core.TriggerContext) error {
+	config := OnSegmentChangeConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(config.ProjectKey) == "" {
+		return fmt.Errorf("project key is required")
+	}
+
+	webhookConfig := segmentWebhookConfiguration(config)
+	if ctx.DryRun {
+		ctx.Plan.Add(core.SetupPlanStep{
+			Action:      core.SetupPlanActionEnsure,
+			Resource:    "webhook",
+			Description: fmt.Sprintf("Signed LaunchDarkly webhook for the segment changes of project %s", config.ProjectKey),
+			Details: map[string]any{
+				"projectKey": config.ProjectKey,
+				"statements": webhookStatements(webhookConfig),
+			},
+		})
+
+		return nil
+	}
+
+	return requestWebhook(ctx, webhookConfig)
+}
+
+// segmentWebhookConfiguration scopes the webhook to the segments of the environments and segments
+// the trigger filters on, the same way flag webhooks are scoped, see webhookConfiguration.
+func segmentWebhookConfiguration(config OnSegmentChangeConfiguration) WebhookConfiguration {
+	return WebhookConfiguration{
+		ProjectKey:   config.ProjectKey,
+		Kind:         KindSegment,
+		Environments: normalizeWebhookKeys(config.Environments),
+		Segments:     exactPredicateKeys(config.Segments),
+	}
+}
+
+func (t *OnSegmentChange) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnSegmentChange) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, fmt.Errorf("action %s not supported", ctx.Name)
+}
+
+func (t *OnSegmentChange) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	ctx.Logger.Infof("launchdarkly webhook: received for workflow %s", ctx.WorkflowID)
+
+	config := OnSegmentChangeConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	payload, status, err := verifyWebhookPayload(ctx, t.Name())
+	if err != nil {
+		return status, err
+	}
+
+	kind, _ := payload["kind"].(string)
+	if kind == "" {
+		return http.StatusBadRequest, fmt.Errorf("missing kind in payload")
+	}
+
+	if kind != KindSegment {
+		ctx.Logger.Infof("launchdarkly webhook: event kind %q is not a segment event, acknowledging without emitting", kind)
+		return http.StatusOK, nil
+	}
+
+	// Resource format: proj/<projKey>:env/<envKey>:segment/<segmentKey>
+	action, resource := firstAccess(payload)
+	envKey, segmentKey := parseResourceEnvAndKey(resource, KindSegment)
+
+	if len(config.Environments) > 0 && envKey != "" && envKey != "*" && !slices.Contains(config.Environments, envKey) {
+		ctx.Logger.Infof("launchdarkly webhook: environment %q does not match configured environments, acknowledging without emitting", envKey)
+		return http.StatusOK, nil
+	}
+
+	if len(config.Segments) > 0 && segmentKey != "" && !configuration.MatchesAnyPredicate(config.Segments, segmentKey) {
+		ctx.Logger.Infof("launchdarkly webhook: segment %q does not match configured segments, acknowledging without emitting", segmentKey)
+		return http.StatusOK, nil
+	}
+
+	payload["projectKey"] = config.ProjectKey
+	if envKey != "" && envKey != "*" {
+		payload["environmentKey"] = envKey
+	}
+	if segmentKey != "" {
+		payload["segmentKey"] = segmentKey
+	}
+
+	payloadType := "launchdarkly." + KindSegment
+	if action != "" {
+		payloadType = payloadType + "." + action
+	}
+
+	if err := ctx.Events.Emit(payloadType, payload); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error emitting event: %w", err)
+	}
+
+	ctx.Logger.Infof("launchdarkly webhook: emitted %s for workflow %s", payloadType, ctx.WorkflowID)
+	return http.StatusOK, nil
+}
+
+func (t *OnSegmentChange) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (t *OnSegmentChange) SupportsDryRun() bool {
+	return true
+}
+
+func (t *OnSegmentChange) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
@@ -0,0 +1,164 @@
+package launchdarkly
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnSegmentChange__HandleWebhook(t *testing.T) {
+	trigger := &OnSegmentChange{}
+	validSecret := "test-signing-secret"
+
+	handle := func(config map[string]any, body []byte, signature string) (int, *contexts.EventContext, error) {
+		headers := http.Header{}
+		if signature != "" {
+			headers.Set("X-LD-Signature", signature)
+		}
+
+		wc := &contexts.NodeWebhookContext{}
+		require.NoError(t, wc.SetSecret([]byte(validSecret)))
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       headers,
+			Configuration: config,
+			Webhook:       wc,
+			Events:        eventContext,
+			Logger:        testLogger,
+		})
+
+		return code, eventContext, err
+	}
+
+	segmentBody := []byte(`{"kind":"segment","name":"Beta Testers","accesses":[{"action":"updateIncluded","resource":"proj/default:env/production:segment/beta-testers"}]}`)
+
+	t.Run("missing X-LD-Signature header -> 403", func(t *testing.T) {
+		code, _, err := handle(map[string]any{"projectKey": "default"}, segmentBody, "")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "missing X-LD-Signature header")
+	})
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		code, _, err := handle(map[string]any{"projectKey": "default"}, segmentBody, "invalidsignature")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("flag event -> no emit", func(t *testing.T) {
+		body := []byte(`{"kind":"flag","accesses":[{"action":"updateOn","resource":"proj/default:env/production:flag/my-flag"}]}`)
+		code, eventContext, err := handle(map[string]any{"projectKey": "default"}, body, hmacSignature(validSecret, body))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 0, eventContext.Count())
+	})
+
+	t.Run("no filters -> emits the segment event with the extracted keys", func(t *testing.T) {
+		code, eventContext, err := handle(map[string]any{"projectKey": "default"}, segmentBody, hmacSignature(validSecret, segmentBody))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "launchdarkly.segment.updateIncluded", eventContext.Payloads[0].Type)
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "default", payload["projectKey"])
+		assert.Equal(t, "production", payload["environmentKey"])
+		assert.Equal(t, "beta-testers", payload["segmentKey"])
+	})
+
+	t.Run("environment not configured -> no emit", func(t *testing.T) {
+		config := map[string]any{"projectKey": "default", "environments": []string{"staging"}}
+		code, eventContext, err := handle(config, segmentBody, hmacSignature(validSecret, segmentBody))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 0, eventContext.Count())
+	})
+
+	t.Run("segment matches a pattern -> emits", func(t *testing.T) {
+		config := map[string]any{
+			"projectKey": "default",
+			"segments":   []map[string]any{{"type": configuration.PredicateTypeMatches, "value": "beta-.*"}},
+		}
+
+		code, eventContext, err := handle(config, segmentBody, hmacSignature(validSecret, segmentBody))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, eventContext.Count())
+	})
+
+	t.Run("segment not configured -> no emit", func(t *testing.T) {
+		config := map[string]any{
+			"projectKey": "default",
+			"segments":   []map[string]any{{"type": configuration.PredicateTypeEquals, "value": "internal-users"}},
+		}
+
+		code, eventContext, err := handle(config, segmentBody, hmacSignature(validSecret, segmentBody))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 0, eventContext.Count())
+	})
+}
+
+func Test__OnSegmentChange__Setup(t *testing.T) {
+	trigger := &OnSegmentChange{}
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Webhook:       &contexts.NodeWebhookContext{},
+			Configuration: OnSegmentChangeConfiguration{},
+		})
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("environments and exact segments -> webhook scoped to them", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration: integrationCtx,
+			Metadata:    &contexts.MetadataContext{},
+			Webhook:     &contexts.NodeWebhookContext{},
+			Configuration: OnSegmentChangeConfiguration{
+				ProjectKey:   "default",
+				Environments: []string{"production"},
+				Segments:     []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "beta-testers"}},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integrationCtx.WebhookRequests, 1)
+		req := integrationCtx.WebhookRequests[0].(WebhookConfiguration)
+		assert.Equal(t, KindSegment, req.Kind)
+		assert.Equal(t, []WebhookStatement{
+			{Effect: "allow", Resources: []string{"proj/default:env/production:segment/beta-testers"}, Actions: []string{"*"}},
+		}, webhookStatements(req))
+	})
+
+	t.Run("dry run -> plans a webhook for all segments of the project", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{}
+		plan := &core.SetupPlan{}
+		err := trigger.Setup(core.TriggerContext{
+			Integration: integrationCtx,
+			Metadata:    &contexts.MetadataContext{},
+			Webhook:     &contexts.NodeWebhookContext{},
+			Configuration: OnSegmentChangeConfiguration{
+				ProjectKey: "default",
+				Segments:   []configuration.Predicate{{Type: configuration.PredicateTypeMatches, Value: "beta-.*"}},
+			},
+			DryRun: true,
+			Plan:   plan,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, integrationCtx.WebhookRequests)
+		require.Len(t, plan.Steps, 1)
+		assert.Equal(t, []WebhookStatement{
+			{Effect: "allow", Resources: []string{"proj/default:env/*:segment/*"}, Actions: []string{"*"}},
+		}, plan.Steps[0].Details["statements"])
+	})
+}
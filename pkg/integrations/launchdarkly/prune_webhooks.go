@@ -0,0 +1,170 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type PruneWebhooks struct{}
+
+type PruneWebhooksSpec struct {
+	DryRun bool `json:"dryRun" mapstructure:"dryRun"`
+}
+
+func (c *PruneWebhooks) Name() string {
+	return "launchdarkly.pruneWebhooks"
+}
+
+func (c *PruneWebhooks) Label() string {
+	return "Prune Webhooks"
+}
+
+func (c *PruneWebhooks) Description() string {
+	return "Delete the SuperPlane webhooks of the LaunchDarkly account that are no longer used"
+}
+
+func (c *PruneWebhooks) Documentation() string {
+	return `The Prune Webhooks component deletes the stale SuperPlane webhooks of the LaunchDarkly account, and reports what was deleted.
+
+## Use Cases
+
+- **Housekeeping**: Remove webhooks left behind when triggers were deleted while LaunchDarkly was unreachable
+- **Scheduled cleanup**: Run the component on a schedule to keep the LaunchDarkly webhook list tidy
+
+## Configuration
+
+- **Dry Run**: Report the stale webhooks without deleting them
+
+## How it works
+
+A webhook is stale when it is named "SuperPlane" and targets a webhook of this SuperPlane installation that no longer exists. Active webhooks, and webhooks targeting other installations or URLs, are never deleted. Webhooks already deleted in LaunchDarkly are reported as deleted.
+
+## Output
+
+Returns the deleted webhooks, the number of active and external webhooks that were kept, and whether it was a dry run.`
+}
+
+func (c *PruneWebhooks) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *PruneWebhooks) Color() string {
+	return "gray"
+}
+
+func (c *PruneWebhooks) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *PruneWebhooks) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "dryRun",
+			Label:       "Dry Run",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Report the stale webhooks without deleting them",
+		},
+	}
+}
+
+func (c *PruneWebhooks) Setup(ctx core.SetupContext) error {
+	spec := PruneWebhooksSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func (c *PruneWebhooks) Execute(ctx core.ExecutionContext) error {
+	spec := PruneWebhooksSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	webhooks, err := classifyWebhooks(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	deleted := []any{}
+	kept := map[string]int{WebhookStatusActive: 0, WebhookStatusExternal: 0}
+	for _, webhook := range webhooks {
+		if webhook.Status != WebhookStatusStale {
+			kept[webhook.Status]++
+			continue
+		}
+
+		if !spec.DryRun {
+			if err := deleteStaleWebhook(client, webhook.Webhook.ID); err != nil {
+				return err
+			}
+		}
+
+		deleted = append(deleted, webhookToMap(webhook))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.webhooks.pruned",
+		[]any{
+			map[string]any{
+				"dryRun":   spec.DryRun,
+				"count":    len(deleted),
+				"deleted":  deleted,
+				"active":   kept[WebhookStatusActive],
+				"external": kept[WebhookStatusExternal],
+			},
+		},
+	)
+}
+
+// deleteStaleWebhook deletes a webhook, treating webhooks already deleted in LaunchDarkly as deleted.
+func deleteStaleWebhook(client *Client, id string) error {
+	err := client.DeleteWebhook(id)
+	if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (c *PruneWebhooks) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *PruneWebhooks) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *PruneWebhooks) Actions() []core.Action {
+	return nil
+}
+
+func (c *PruneWebhooks) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *PruneWebhooks) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *PruneWebhooks) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
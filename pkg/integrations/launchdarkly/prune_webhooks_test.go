@@ -0,0 +1,109 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__PruneWebhooks__Execute(t *testing.T) {
+	component := &PruneWebhooks{}
+
+	t.Run("stale webhook -> deleted, active and external kept", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				listMembersResponse(webhooksResponse()),
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{},
+			HTTP:           httpContext,
+			Integration:    webhooksIntegration(),
+			Webhook:        &contexts.NodeWebhookContext{},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[1].Method)
+		assert.Equal(t, "/api/v2/webhooks/wh-stale", httpContext.Requests[1].URL.Path)
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["dryRun"])
+		assert.Equal(t, 1, data["count"])
+		assert.Equal(t, "wh-stale", data["deleted"].([]any)[0].(map[string]any)["id"])
+		assert.Equal(t, 1, data["active"])
+		assert.Equal(t, 1, data["external"])
+	})
+
+	t.Run("dry run -> stale webhook reported without deleting", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{listMembersResponse(webhooksResponse())},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"dryRun": true},
+			HTTP:           httpContext,
+			Integration:    webhooksIntegration(),
+			Webhook:        &contexts.NodeWebhookContext{},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["dryRun"])
+		assert.Equal(t, 1, data["count"])
+	})
+
+	t.Run("stale webhook already deleted in LaunchDarkly -> reported as deleted", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				listMembersResponse(webhooksResponse()),
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"code":"not_found"}`))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{},
+			HTTP:           httpContext,
+			Integration:    webhooksIntegration(),
+			Webhook:        &contexts.NodeWebhookContext{},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1, data["count"])
+	})
+
+	t.Run("delete fails -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				listMembersResponse(webhooksResponse()),
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{},
+			HTTP:           httpContext,
+			Integration:    webhooksIntegration(),
+			Webhook:        &contexts.NodeWebhookContext{},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to delete webhook wh-stale")
+	})
+}
@@ -0,0 +1,247 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type RemovePrerequisite struct{}
+
+type RemovePrerequisiteSpec struct {
+	ProjectKey          string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey             string `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey      string `json:"environmentKey" mapstructure:"environmentKey"`
+	PrerequisiteFlagKey string `json:"prerequisiteFlagKey" mapstructure:"prerequisiteFlagKey"`
+	Comment             string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *RemovePrerequisite) Name() string {
+	return "launchdarkly.removePrerequisite"
+}
+
+func (c *RemovePrerequisite) Label() string {
+	return "Remove Flag Prerequisite"
+}
+
+func (c *RemovePrerequisite) Description() string {
+	return "Remove a prerequisite from a LaunchDarkly feature flag in an environment"
+}
+
+func (c *RemovePrerequisite) Documentation() string {
+	return `The Remove Flag Prerequisite component removes a prerequisite from a feature flag in one environment.
+
+## Use Cases
+
+- **Decoupling features**: Let a flag be evaluated on its own once the feature it depended on is fully released
+- **Release cleanup**: Remove the release flag gate before archiving the release flag
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flags
+- **Feature Flag**: The key of the feature flag with the prerequisite
+- **Environment**: The environment where the prerequisite is removed
+- **Prerequisite Flag**: The key of the prerequisite flag to remove
+- **Comment**: Optional comment stored with the change
+
+The component fails if the flag has no such prerequisite in the environment.
+
+## Output
+
+Returns the project, flag, and environment keys and the flag's remaining prerequisites in the environment.`
+}
+
+func (c *RemovePrerequisite) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *RemovePrerequisite) Color() string {
+	return "gray"
+}
+
+func (c *RemovePrerequisite) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RemovePrerequisite) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag with the prerequisite",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment where the prerequisite is removed",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "prerequisiteFlagKey",
+			Label:       "Prerequisite Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The prerequisite flag to remove",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional comment stored with the change",
+		},
+	}
+}
+
+func (c *RemovePrerequisite) Setup(ctx core.SetupContext) error {
+	spec := RemovePrerequisiteSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validatePrerequisiteKeys(spec.ProjectKey, spec.FlagKey, spec.EnvironmentKey, spec.PrerequisiteFlagKey); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func (c *RemovePrerequisite) Execute(ctx core.ExecutionContext) error {
+	spec := RemovePrerequisiteSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validatePrerequisiteKeys(spec.ProjectKey, spec.FlagKey, spec.EnvironmentKey, spec.PrerequisiteFlagKey); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	if !hasPrerequisite(environmentPrerequisites(flag, spec.EnvironmentKey), spec.PrerequisiteFlagKey) {
+		return fmt.Errorf("flag %s has no prerequisite %s in environment %s", spec.FlagKey, spec.PrerequisiteFlagKey, spec.EnvironmentKey)
+	}
+
+	updated, err := client.PatchFeatureFlag(spec.ProjectKey, spec.FlagKey, SemanticPatchRequest{
+		EnvironmentKey: spec.EnvironmentKey,
+		Comment:        strings.TrimSpace(spec.Comment),
+		Instructions: []SemanticPatchInstruction{
+			{
+				"kind": "removePrerequisite",
+				"key":  spec.PrerequisiteFlagKey,
+			},
+		},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to remove prerequisite: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.prerequisiteRemoved",
+		[]any{
+			map[string]any{
+				"projectKey":     spec.ProjectKey,
+				"flagKey":        spec.FlagKey,
+				"environmentKey": spec.EnvironmentKey,
+				"prerequisites":  environmentPrerequisites(updated, spec.EnvironmentKey),
+			},
+		},
+	)
+}
+
+func hasPrerequisite(prerequisites []any, key string) bool {
+	for _, item := range prerequisites {
+		prerequisite, ok := item.(map[string]any)
+		if ok && prerequisite["key"] == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *RemovePrerequisite) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *RemovePrerequisite) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *RemovePrerequisite) Actions() []core.Action {
+	return nil
+}
+
+func (c *RemovePrerequisite) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *RemovePrerequisite) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RemovePrerequisite) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,100 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RemovePrerequisite__Execute(t *testing.T) {
+	component := &RemovePrerequisite{}
+	configuration := map[string]any{
+		"projectKey":          "default",
+		"flagKey":             "new-checkout",
+		"environmentKey":      "production",
+		"prerequisiteFlagKey": "checkout-v2-release",
+	}
+
+	t.Run("prerequisite is set -> sends removePrerequisite", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(strings.NewReader(`{
+						"key": "new-checkout",
+						"environments": {"production": {"prerequisites": [{"key": "checkout-v2-release", "variation": 0}]}}
+					}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key": "new-checkout", "environments": {"production": {"prerequisites": []}}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+
+		req := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", req.URL.String())
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		patch := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &patch))
+		assert.Equal(t, map[string]any{
+			"environmentKey": "production",
+			"instructions": []any{
+				map[string]any{
+					"kind": "removePrerequisite",
+					"key":  "checkout-v2-release",
+				},
+			},
+		}, patch)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.prerequisiteRemoved", payload["type"])
+		assert.Equal(t, []any{}, payload["data"].(map[string]any)["prerequisites"])
+	})
+
+	t.Run("prerequisite is not set -> error before patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key": "new-checkout", "environments": {"production": {"prerequisites": []}}}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "flag new-checkout has no prerequisite checkout-v2-release in environment production")
+		assert.Len(t, httpContext.Requests, 1)
+	})
+}
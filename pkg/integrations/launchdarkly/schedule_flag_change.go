@@ -0,0 +1,292 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ScheduledChangeTurnFlagOn  = "turnFlagOn"
+	ScheduledChangeTurnFlagOff = "turnFlagOff"
+)
+
+type ScheduleFlagChange struct{}
+
+type ScheduleFlagChangeSpec struct {
+	ProjectKey     string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey        string `json:"flagKey" mapstructure:"flagKey"`
+	EnvironmentKey string `json:"environmentKey" mapstructure:"environmentKey"`
+	Change         string `json:"change" mapstructure:"change"`
+	ExecutionDate  string `json:"executionDate" mapstructure:"executionDate"`
+	Comment        string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *ScheduleFlagChange) Name() string {
+	return "launchdarkly.scheduleFlagChange"
+}
+
+func (c *ScheduleFlagChange) Label() string {
+	return "Schedule Flag Change"
+}
+
+func (c *ScheduleFlagChange) Description() string {
+	return "Schedule a future change of a LaunchDarkly feature flag"
+}
+
+func (c *ScheduleFlagChange) Documentation() string {
+	return `The Schedule Flag Change component schedules a feature flag to be turned on or off at a future time in one environment.
+
+## Use Cases
+
+- **Timed launches**: Turn a flag on at the announced launch time
+- **Temporary rollouts**: Turn a flag off again after a campaign or maintenance window ends
+- **Release coordination**: Schedule flag changes as part of a release workflow
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to change
+- **Environment**: The environment where the change is applied
+- **Change**: Whether to turn the flag on or off
+- **Execution Date**: When the change runs, as an RFC 3339 timestamp (e.g. ` + "`2026-03-01T09:00:00Z`" + `). Supports expressions.
+- **Comment**: Optional comment stored with the scheduled change
+
+## Output
+
+Returns the scheduled change ID, together with the project, flag, and environment keys and the execution date.`
+}
+
+func (c *ScheduleFlagChange) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ScheduleFlagChange) Color() string {
+	return "gray"
+}
+
+func (c *ScheduleFlagChange) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ScheduleFlagChange) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to change",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment where the change is applied",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:     "change",
+			Label:    "Change",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  ScheduledChangeTurnFlagOn,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Turn flag on", Value: ScheduledChangeTurnFlagOn},
+						{Label: "Turn flag off", Value: ScheduledChangeTurnFlagOff},
+					},
+				},
+			},
+		},
+		{
+			Name:        "executionDate",
+			Label:       "Execution Date",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "e.g. 2026-03-01T09:00:00Z",
+			Description: "When the change runs, as an RFC 3339 timestamp",
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional comment stored with the scheduled change",
+		},
+	}
+}
+
+func (c *ScheduleFlagChange) Setup(ctx core.SetupContext) error {
+	spec := ScheduleFlagChangeSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateScheduleFlagChangeSpec(spec); err != nil {
+		return err
+	}
+
+	if err := checkWriteAccess(ctx.Integration); err != nil {
+		return err
+	}
+
+	//
+	// The execution date usually comes from an expression,
+	// so it can only be checked here when it is a static value.
+	//
+	if strings.Contains(spec.ExecutionDate, "{{") {
+		return nil
+	}
+
+	_, err := parseExecutionDate(spec.ExecutionDate)
+	return err
+}
+
+func validateScheduleFlagChangeSpec(spec ScheduleFlagChangeSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if strings.TrimSpace(spec.EnvironmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	if spec.Change != ScheduledChangeTurnFlagOn && spec.Change != ScheduledChangeTurnFlagOff {
+		return fmt.Errorf("invalid change %q", spec.Change)
+	}
+
+	if strings.TrimSpace(spec.ExecutionDate) == "" {
+		return errors.New("execution date is required")
+	}
+
+	return nil
+}
+
+func parseExecutionDate(value string) (time.Time, error) {
+	executionDate, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid execution date %q: must be an RFC 3339 timestamp", value)
+	}
+
+	return executionDate, nil
+}
+
+func (c *ScheduleFlagChange) Execute(ctx core.ExecutionContext) error {
+	spec := ScheduleFlagChangeSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateScheduleFlagChangeSpec(spec); err != nil {
+		return err
+	}
+
+	executionDate, err := parseExecutionDate(spec.ExecutionDate)
+	if err != nil {
+		return err
+	}
+
+	if !executionDate.After(time.Now()) {
+		return fmt.Errorf("execution date %s is not in the future", executionDate.Format(time.RFC3339))
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	scheduledChange, err := client.CreateScheduledChange(spec.ProjectKey, spec.FlagKey, spec.EnvironmentKey, CreateScheduledChangeRequest{
+		ExecutionDate: executionDate.UnixMilli(),
+		Comment:       strings.TrimSpace(spec.Comment),
+		Instructions:  []ScheduledChangeInstruction{{Kind: spec.Change}},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled change: %w", err)
+	}
+
+	result := map[string]any{
+		"id":             scheduledChange["_id"],
+		"projectKey":     spec.ProjectKey,
+		"flagKey":        spec.FlagKey,
+		"environmentKey": spec.EnvironmentKey,
+		"change":         spec.Change,
+		"executionDate":  executionDate.UTC().Format(time.RFC3339),
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.changeScheduled",
+		[]any{result},
+	)
+}
+
+func (c *ScheduleFlagChange) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ScheduleFlagChange) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ScheduleFlagChange) Actions() []core.Action {
+	return nil
+}
+
+func (c *ScheduleFlagChange) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ScheduleFlagChange) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ScheduleFlagChange) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
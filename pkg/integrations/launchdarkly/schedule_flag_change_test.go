@@ -0,0 +1,183 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ScheduleFlagChange__Setup(t *testing.T) {
+	component := &ScheduleFlagChange{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         ScheduledChangeTurnFlagOn,
+				"executionDate":  "2026-03-01T09:00:00Z",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("expression execution date is not parsed", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         ScheduledChangeTurnFlagOff,
+				"executionDate":  "{{ $.trigger.data.endsAt }}",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing environment key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":    "default",
+				"flagKey":       "new-checkout",
+				"change":        ScheduledChangeTurnFlagOn,
+				"executionDate": "2026-03-01T09:00:00Z",
+			},
+		})
+
+		require.ErrorContains(t, err, "environment key is required")
+	})
+
+	t.Run("invalid change returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         "archiveFlag",
+				"executionDate":  "2026-03-01T09:00:00Z",
+			},
+		})
+
+		require.ErrorContains(t, err, `invalid change "archiveFlag"`)
+	})
+
+	t.Run("invalid execution date returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         ScheduledChangeTurnFlagOn,
+				"executionDate":  "next monday",
+			},
+		})
+
+		require.ErrorContains(t, err, "invalid execution date")
+	})
+}
+
+func Test__ScheduleFlagChange__Execute(t *testing.T) {
+	component := &ScheduleFlagChange{}
+	executionDate := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	t.Run("success creates scheduled change and emits its ID", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(strings.NewReader(`{"_id":"sc-123","instructions":[{"kind":"turnFlagOn"}]}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         ScheduledChangeTurnFlagOn,
+				"executionDate":  executionDate.Format(time.RFC3339),
+				"comment":        "launch",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/projects/default/flags/new-checkout/environments/production/scheduled-changes", req.URL.String())
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		request := CreateScheduledChangeRequest{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, executionDate.UnixMilli(), request.ExecutionDate)
+		assert.Equal(t, "launch", request.Comment)
+		assert.Equal(t, []ScheduledChangeInstruction{{Kind: ScheduledChangeTurnFlagOn}}, request.Instructions)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.changeScheduled", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, "sc-123", data["id"])
+		assert.Equal(t, "production", data["environmentKey"])
+		assert.Equal(t, executionDate.Format(time.RFC3339), data["executionDate"])
+	})
+
+	t.Run("invalid execution date returns error before API call", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         ScheduledChangeTurnFlagOn,
+				"executionDate":  "2026-03-01 09:00",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "invalid execution date")
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("past execution date returns error before API call", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"environmentKey": "production",
+				"change":         ScheduledChangeTurnFlagOn,
+				"executionDate":  "2020-01-01T00:00:00Z",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "is not in the future")
+		assert.Empty(t, httpContext.Requests)
+	})
+}
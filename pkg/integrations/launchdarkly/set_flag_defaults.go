@@ -0,0 +1,275 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SetFlagDefaults struct{}
+
+type SetFlagDefaultsSpec struct {
+	ProjectKey   string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey      string `json:"flagKey" mapstructure:"flagKey"`
+	OnVariation  *int   `json:"onVariation,omitempty" mapstructure:"onVariation,omitempty"`
+	OffVariation *int   `json:"offVariation,omitempty" mapstructure:"offVariation,omitempty"`
+	Comment      string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *SetFlagDefaults) Name() string {
+	return "launchdarkly.setFlagDefaults"
+}
+
+func (c *SetFlagDefaults) Label() string {
+	return "Set Flag Defaults"
+}
+
+func (c *SetFlagDefaults) Description() string {
+	return "Set the default on and off variations of a LaunchDarkly feature flag"
+}
+
+func (c *SetFlagDefaults) Documentation() string {
+	return `The Set Flag Defaults component updates the default on and off variations of a feature flag. New environments use these defaults for the flag.
+
+## Use Cases
+
+- **Standardizing new flags**: Make sure every new flag serves the same variations by default
+- **Safe defaults**: Set the off variation to the value that disables a feature
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to update
+- **On Variation**: Index of the variation served by default when targeting is on (0 is the first variation)
+- **Off Variation**: Index of the variation served by default when targeting is off
+- **Comment**: Optional comment stored with the change
+
+At least one of the variations must be set. Indexes are validated against the flag's variations before the update.
+
+## Output
+
+Returns the project and flag keys and the flag's default variations after the update.`
+}
+
+func (c *SetFlagDefaults) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *SetFlagDefaults) Color() string {
+	return "gray"
+}
+
+func (c *SetFlagDefaults) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SetFlagDefaults) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to update",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "onVariation",
+			Label:       "On Variation",
+			Type:        configuration.FieldTypeNumber,
+			Togglable:   true,
+			Description: "Index of the default variation when targeting is on",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+				},
+			},
+		},
+		{
+			Name:        "offVariation",
+			Label:       "Off Variation",
+			Type:        configuration.FieldTypeNumber,
+			Togglable:   true,
+			Description: "Index of the default variation when targeting is off",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+				},
+			},
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional comment stored with the change",
+		},
+	}
+}
+
+func (c *SetFlagDefaults) Setup(ctx core.SetupContext) error {
+	spec := SetFlagDefaultsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateSetFlagDefaultsSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateSetFlagDefaultsSpec(spec SetFlagDefaultsSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if spec.OnVariation == nil && spec.OffVariation == nil {
+		return errors.New("on variation or off variation is required")
+	}
+
+	if spec.OnVariation != nil && *spec.OnVariation < 0 {
+		return errors.New("on variation must not be negative")
+	}
+
+	if spec.OffVariation != nil && *spec.OffVariation < 0 {
+		return errors.New("off variation must not be negative")
+	}
+
+	return nil
+}
+
+func (c *SetFlagDefaults) Execute(ctx core.ExecutionContext) error {
+	spec := SetFlagDefaultsSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateSetFlagDefaultsSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	//
+	// The semantic patch instruction takes variation values, not indexes,
+	// so the indexes are resolved against the flag's variations.
+	//
+	variations, _ := flag["variations"].([]any)
+	instruction := SemanticPatchInstruction{"kind": "updateDefaultVariation"}
+	if spec.OnVariation != nil {
+		value, err := variationValue(variations, *spec.OnVariation)
+		if err != nil {
+			return fmt.Errorf("invalid on variation: %w", err)
+		}
+
+		instruction["onVariationValue"] = value
+	}
+
+	if spec.OffVariation != nil {
+		value, err := variationValue(variations, *spec.OffVariation)
+		if err != nil {
+			return fmt.Errorf("invalid off variation: %w", err)
+		}
+
+		instruction["offVariationValue"] = value
+	}
+
+	updated, err := client.PatchFeatureFlag(spec.ProjectKey, spec.FlagKey, SemanticPatchRequest{
+		Comment:      strings.TrimSpace(spec.Comment),
+		Instructions: []SemanticPatchInstruction{instruction},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update flag defaults: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.defaultsUpdated",
+		[]any{
+			map[string]any{
+				"projectKey": spec.ProjectKey,
+				"flagKey":    spec.FlagKey,
+				"defaults":   updated["defaults"],
+			},
+		},
+	)
+}
+
+func variationValue(variations []any, index int) (any, error) {
+	if index < 0 || index >= len(variations) {
+		return nil, fmt.Errorf("index %d is out of range, the flag has %d variations", index, len(variations))
+	}
+
+	variation, ok := variations[index].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("variation %d has an unexpected format", index)
+	}
+
+	return variation["value"], nil
+}
+
+func (c *SetFlagDefaults) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *SetFlagDefaults) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *SetFlagDefaults) Actions() []core.Action {
+	return nil
+}
+
+func (c *SetFlagDefaults) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *SetFlagDefaults) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SetFlagDefaults) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
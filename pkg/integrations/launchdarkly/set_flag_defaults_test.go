@@ -0,0 +1,145 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const setFlagDefaultsFlagResponse = `{
+	"key": "new-checkout",
+	"variations": [
+		{"_id": "v1", "value": "control"},
+		{"_id": "v2", "value": "treatment"},
+		{"_id": "v3", "value": "off"}
+	],
+	"defaults": {"onVariation": 0, "offVariation": 0}
+}`
+
+func Test__SetFlagDefaults__Setup(t *testing.T) {
+	component := &SetFlagDefaults{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":   "default",
+				"flagKey":      "new-checkout",
+				"offVariation": 2,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("no variation set returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "on variation or off variation is required")
+	})
+
+	t.Run("negative variation returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "onVariation": -1},
+		})
+
+		require.ErrorContains(t, err, "on variation must not be negative")
+	})
+}
+
+func Test__SetFlagDefaults__Execute(t *testing.T) {
+	component := &SetFlagDefaults{}
+
+	t.Run("valid indexes -> sends semantic patch with variation values", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(setFlagDefaultsFlagResponse)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key": "new-checkout", "defaults": {"onVariation": 1, "offVariation": 2}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":   "default",
+				"flagKey":      "new-checkout",
+				"onVariation":  1,
+				"offVariation": 2,
+				"comment":      "standardize defaults",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+
+		req := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", req.URL.String())
+		assert.Equal(t, "application/json; domain-model=launchdarkly.semanticpatch", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		patch := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &patch))
+		assert.Equal(t, "standardize defaults", patch["comment"])
+		assert.Equal(t, []any{
+			map[string]any{
+				"kind":              "updateDefaultVariation",
+				"onVariationValue":  "treatment",
+				"offVariationValue": "off",
+			},
+		}, patch["instructions"])
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.defaultsUpdated", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, map[string]any{"onVariation": float64(1), "offVariation": float64(2)}, data["defaults"])
+	})
+
+	t.Run("out of range index -> error before patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(setFlagDefaultsFlagResponse)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":   "default",
+				"flagKey":      "new-checkout",
+				"offVariation": 3,
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "invalid off variation: index 3 is out of range, the flag has 3 variations")
+		assert.Len(t, httpContext.Requests, 1)
+	})
+}
@@ -0,0 +1,313 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	MaintainerTypeMember = "member"
+	MaintainerTypeTeam   = "team"
+)
+
+var AllMaintainerTypes = []configuration.FieldOption{
+	{Label: "Member", Value: MaintainerTypeMember},
+	{Label: "Team", Value: MaintainerTypeTeam},
+}
+
+type SetFlagMaintainer struct{}
+
+type SetFlagMaintainerSpec struct {
+	ProjectKey     string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey        string `json:"flagKey" mapstructure:"flagKey"`
+	MaintainerType string `json:"maintainerType" mapstructure:"maintainerType"`
+	MemberID       string `json:"memberId" mapstructure:"memberId"`
+	TeamKey        string `json:"teamKey" mapstructure:"teamKey"`
+	Comment        string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *SetFlagMaintainer) Name() string {
+	return "launchdarkly.setFlagMaintainer"
+}
+
+func (c *SetFlagMaintainer) Label() string {
+	return "Set Flag Maintainer"
+}
+
+func (c *SetFlagMaintainer) Description() string {
+	return "Reassign the maintainer of a LaunchDarkly feature flag"
+}
+
+func (c *SetFlagMaintainer) Documentation() string {
+	return `The Set Flag Maintainer component makes a member or a team the maintainer of a feature flag.
+
+## Use Cases
+
+- **Ownership cleanup**: Reassign the flags of people who left the team
+- **Handover**: Move the flags of a service to the team that now owns it
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to update
+- **Maintainer Type**: Whether the new maintainer is a member or a team
+- **Member**: The member who becomes the maintainer, when the type is member
+- **Team**: The team that becomes the maintainer, when the type is team
+- **Comment**: Optional comment stored with the change
+
+The member or team is looked up before the flag is updated, so an unknown maintainer fails without changing the flag.
+
+## Output
+
+Returns the project and flag keys and the new maintainer.`
+}
+
+func (c *SetFlagMaintainer) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *SetFlagMaintainer) Color() string {
+	return "gray"
+}
+
+func (c *SetFlagMaintainer) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SetFlagMaintainer) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to update",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:     "maintainerType",
+			Label:    "Maintainer Type",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  MaintainerTypeMember,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllMaintainerTypes,
+				},
+			},
+		},
+		{
+			Name:        "memberId",
+			Label:       "Member",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Description: "The member who becomes the maintainer",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "member",
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "maintainerType", Values: []string{MaintainerTypeMember}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "maintainerType", Values: []string{MaintainerTypeMember}},
+			},
+		},
+		{
+			Name:        "teamKey",
+			Label:       "Team",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Description: "The team that becomes the maintainer",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "team",
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "maintainerType", Values: []string{MaintainerTypeTeam}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "maintainerType", Values: []string{MaintainerTypeTeam}},
+			},
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional comment stored with the change",
+		},
+	}
+}
+
+func (c *SetFlagMaintainer) Setup(ctx core.SetupContext) error {
+	spec := SetFlagMaintainerSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateSetFlagMaintainerSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateSetFlagMaintainerSpec(spec SetFlagMaintainerSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if !slices.Contains([]string{MaintainerTypeMember, MaintainerTypeTeam}, spec.MaintainerType) {
+		return fmt.Errorf("invalid maintainer type %q", spec.MaintainerType)
+	}
+
+	if spec.MaintainerType == MaintainerTypeMember && strings.TrimSpace(spec.MemberID) == "" {
+		return errors.New("member is required")
+	}
+
+	if spec.MaintainerType == MaintainerTypeTeam && strings.TrimSpace(spec.TeamKey) == "" {
+		return errors.New("team is required")
+	}
+
+	return nil
+}
+
+func (c *SetFlagMaintainer) Execute(ctx core.ExecutionContext) error {
+	spec := SetFlagMaintainerSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateSetFlagMaintainerSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	instruction, maintainer, err := maintainerInstruction(client, spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PatchFeatureFlag(spec.ProjectKey, spec.FlagKey, SemanticPatchRequest{
+		Comment:      strings.TrimSpace(spec.Comment),
+		Instructions: []SemanticPatchInstruction{instruction},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update flag maintainer: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.maintainerUpdated",
+		[]any{
+			map[string]any{
+				"projectKey": spec.ProjectKey,
+				"flagKey":    spec.FlagKey,
+				"maintainer": maintainer,
+			},
+		},
+	)
+}
+
+// maintainerInstruction looks up the new maintainer, so that an unknown
+// member or team fails before the flag is changed, and returns the semantic
+// patch instruction that assigns it together with its output representation.
+func maintainerInstruction(client *Client, spec SetFlagMaintainerSpec) (SemanticPatchInstruction, map[string]any, error) {
+	if spec.MaintainerType == MaintainerTypeTeam {
+		teamKey := strings.TrimSpace(spec.TeamKey)
+		team, err := client.GetTeam(teamKey)
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return nil, nil, fmt.Errorf("team %s not found", teamKey)
+			}
+
+			return nil, nil, fmt.Errorf("failed to get team: %w", err)
+		}
+
+		instruction := SemanticPatchInstruction{"kind": "updateMaintainerTeam", "value": team.Key}
+		return instruction, map[string]any{"type": MaintainerTypeTeam, "key": team.Key, "name": team.Name}, nil
+	}
+
+	memberID := strings.TrimSpace(spec.MemberID)
+	member, err := client.GetMember(memberID)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil, fmt.Errorf("member %s not found", memberID)
+		}
+
+		return nil, nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	instruction := SemanticPatchInstruction{"kind": "updateMaintainerMember", "value": member.ID}
+	return instruction, map[string]any{
+		"type":  MaintainerTypeMember,
+		"id":    member.ID,
+		"email": member.Email,
+		"name":  memberName(*member),
+	}, nil
+}
+
+func (c *SetFlagMaintainer) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *SetFlagMaintainer) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *SetFlagMaintainer) Actions() []core.Action {
+	return nil
+}
+
+func (c *SetFlagMaintainer) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *SetFlagMaintainer) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SetFlagMaintainer) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
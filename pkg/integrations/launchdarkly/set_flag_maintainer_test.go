@@ -0,0 +1,201 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SetFlagMaintainer__Setup(t *testing.T) {
+	component := &SetFlagMaintainer{}
+
+	t.Run("member maintainer -> valid", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": MaintainerTypeMember,
+				"memberId":       "m1",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("member maintainer without member returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": MaintainerTypeMember,
+				"teamKey":        "payments",
+			},
+		})
+
+		require.ErrorContains(t, err, "member is required")
+	})
+
+	t.Run("team maintainer without team returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": MaintainerTypeTeam,
+			},
+		})
+
+		require.ErrorContains(t, err, "team is required")
+	})
+
+	t.Run("unknown maintainer type returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": "group",
+			},
+		})
+
+		require.ErrorContains(t, err, `invalid maintainer type "group"`)
+	})
+}
+
+func Test__SetFlagMaintainer__Execute(t *testing.T) {
+	component := &SetFlagMaintainer{}
+
+	patchInstructions := func(t *testing.T, req *http.Request) []any {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		patch := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &patch))
+		return patch["instructions"].([]any)
+	}
+
+	t.Run("member maintainer -> member is validated and assigned", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"_id":"m1","email":"ada@example.com","firstName":"Ada","lastName":"Lovelace"}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"new-checkout","_maintainer":{"_id":"m1"}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": MaintainerTypeMember,
+				"memberId":       "m1",
+				"comment":        "ownership cleanup",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/members/m1", httpContext.Requests[0].URL.String())
+
+		req := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", req.URL.String())
+		assert.Equal(t, "application/json; domain-model=launchdarkly.semanticpatch", req.Header.Get("Content-Type"))
+		assert.Equal(t, []any{
+			map[string]any{"kind": "updateMaintainerMember", "value": "m1"},
+		}, patchInstructions(t, req))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.maintainerUpdated", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Equal(t, map[string]any{
+			"type":  MaintainerTypeMember,
+			"id":    "m1",
+			"email": "ada@example.com",
+			"name":  "Ada Lovelace",
+		}, data["maintainer"])
+	})
+
+	t.Run("team maintainer -> team is validated and assigned", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"payments","name":"Payments"}`)),
+				},
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"new-checkout","_maintainerTeam":{"key":"payments"}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": MaintainerTypeTeam,
+				"teamKey":        "payments",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/teams/payments", httpContext.Requests[0].URL.String())
+		assert.Equal(t, []any{
+			map[string]any{"kind": "updateMaintainerTeam", "value": "payments"},
+		}, patchInstructions(t, httpContext.Requests[1]))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, map[string]any{"type": MaintainerTypeTeam, "key": "payments", "name": "Payments"}, data["maintainer"])
+	})
+
+	t.Run("unknown team -> error before patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"not_found"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"flagKey":        "new-checkout",
+				"maintainerType": MaintainerTypeTeam,
+				"teamKey":        "ghosts",
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "team ghosts not found")
+		require.Len(t, httpContext.Requests, 1)
+	})
+}
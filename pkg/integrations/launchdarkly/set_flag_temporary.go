@@ -0,0 +1,224 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SetFlagTemporary struct{}
+
+type SetFlagTemporarySpec struct {
+	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey    string `json:"flagKey" mapstructure:"flagKey"`
+	Temporary  bool   `json:"temporary" mapstructure:"temporary"`
+	Comment    string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *SetFlagTemporary) Name() string {
+	return "launchdarkly.setFlagTemporary"
+}
+
+func (c *SetFlagTemporary) Label() string {
+	return "Set Flag Temporary"
+}
+
+func (c *SetFlagTemporary) Description() string {
+	return "Mark a LaunchDarkly feature flag as temporary or permanent"
+}
+
+func (c *SetFlagTemporary) Documentation() string {
+	return `The Set Flag Temporary component marks a feature flag as temporary or permanent.
+
+## Use Cases
+
+- **Cleanup policies**: Mark flags as temporary once a rollout starts, so they are picked up by flag cleanup
+- **Long-lived flags**: Mark operational flags and kill switches as permanent, so they are never reported as stale
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to update
+- **Temporary**: Whether the flag is temporary; unset it to mark the flag as permanent
+- **Comment**: Optional comment stored with the change
+
+A flag that already has the requested status is not changed, and the output status is ` + "`skipped`" + `.
+
+## Output
+
+Returns the project and flag keys, whether the flag is now temporary, and whether it had to be changed.`
+}
+
+func (c *SetFlagTemporary) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *SetFlagTemporary) Color() string {
+	return "gray"
+}
+
+func (c *SetFlagTemporary) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SetFlagTemporary) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to update",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "temporary",
+			Label:       "Temporary",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Mark the flag as temporary, or as permanent when unset",
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional comment stored with the change",
+		},
+	}
+}
+
+func (c *SetFlagTemporary) Setup(ctx core.SetupContext) error {
+	spec := SetFlagTemporarySpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateSetFlagTemporarySpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateSetFlagTemporarySpec(spec SetFlagTemporarySpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	return nil
+}
+
+func (c *SetFlagTemporary) Execute(ctx core.ExecutionContext) error {
+	spec := SetFlagTemporarySpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateSetFlagTemporarySpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	previous, _ := flag["temporary"].(bool)
+	result := map[string]any{
+		"projectKey":           spec.ProjectKey,
+		"flagKey":              spec.FlagKey,
+		"temporary":            spec.Temporary,
+		"previousTemporary":    previous,
+		core.ResultStatusField: core.ResultStatusSuccess,
+	}
+
+	if previous == spec.Temporary {
+		result[core.ResultStatusField] = core.ResultStatusSkipped
+	} else {
+		kind := "markPermanent"
+		if spec.Temporary {
+			kind = "markTemporary"
+		}
+
+		updated, err := client.PatchFeatureFlag(spec.ProjectKey, spec.FlagKey, SemanticPatchRequest{
+			Comment:      strings.TrimSpace(spec.Comment),
+			Instructions: []SemanticPatchInstruction{{"kind": kind}},
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to update flag temporary status: %w", err)
+		}
+
+		if temporary, ok := updated["temporary"].(bool); ok {
+			result["temporary"] = temporary
+		}
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.temporaryUpdated",
+		[]any{result},
+	)
+}
+
+func (c *SetFlagTemporary) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *SetFlagTemporary) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *SetFlagTemporary) Actions() []core.Action {
+	return nil
+}
+
+func (c *SetFlagTemporary) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *SetFlagTemporary) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SetFlagTemporary) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,165 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SetFlagTemporary__Setup(t *testing.T) {
+	component := &SetFlagTemporary{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "temporary": true},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing flag key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("reader token -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+			Integration:   &contexts.IntegrationContext{Metadata: Metadata{TokenRole: TokenRoleReader}},
+		})
+
+		require.ErrorContains(t, err, "the API access token has the reader role")
+	})
+}
+
+func Test__SetFlagTemporary__Execute(t *testing.T) {
+	component := &SetFlagTemporary{}
+
+	execute := func(httpContext *contexts.HTTPContext, configuration map[string]any) (*contexts.ExecutionStateContext, error) {
+		config := map[string]any{"projectKey": "default", "flagKey": "new-checkout"}
+		for key, value := range configuration {
+			config[key] = value
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  config,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return execStateCtx, err
+	}
+
+	patchBody := func(t *testing.T, request *http.Request) map[string]any {
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+		return body
+	}
+
+	t.Run("permanent flag set to temporary -> markTemporary semantic patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","temporary":false}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","temporary":true}`))},
+			},
+		}
+
+		execStateCtx, err := execute(httpContext, map[string]any{"temporary": true, "comment": "rollout started"})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+
+		patch := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, patch.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", patch.URL.String())
+		assert.Equal(t, "application/json; domain-model=launchdarkly.semanticpatch", patch.Header.Get("Content-Type"))
+		assert.Equal(t, map[string]any{
+			"comment":      "rollout started",
+			"instructions": []any{map[string]any{"kind": "markTemporary"}},
+		}, patchBody(t, patch))
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.temporaryUpdated", payload["type"])
+		assert.Equal(t, map[string]any{
+			"projectKey":        "default",
+			"flagKey":           "new-checkout",
+			"temporary":         true,
+			"previousTemporary": false,
+			"status":            core.ResultStatusSuccess,
+		}, payload["data"])
+	})
+
+	t.Run("temporary flag set to permanent -> markPermanent semantic patch", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","temporary":true}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","temporary":false}`))},
+			},
+		}
+
+		execStateCtx, err := execute(httpContext, map[string]any{"temporary": false})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, map[string]any{
+			"instructions": []any{map[string]any{"kind": "markPermanent"}},
+		}, patchBody(t, httpContext.Requests[1]))
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["temporary"])
+		assert.Equal(t, true, data["previousTemporary"])
+		assert.Equal(t, core.ResultStatusSuccess, data["status"])
+	})
+
+	t.Run("flag already temporary -> skipped without patching", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","temporary":true}`))},
+			},
+		}
+
+		execStateCtx, err := execute(httpContext, map[string]any{"temporary": true})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["temporary"])
+		assert.Equal(t, core.ResultStatusSkipped, data["status"])
+	})
+
+	t.Run("patch rejected -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","temporary":false}`))},
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"code":"forbidden"}`))},
+			},
+		}
+
+		_, err := execute(httpContext, map[string]any{"temporary": true})
+		require.ErrorContains(t, err, "failed to update flag temporary status")
+	})
+}
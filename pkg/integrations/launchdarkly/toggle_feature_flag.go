@@ -0,0 +1,238 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type ToggleFeatureFlag struct{}
+
+type ToggleFeatureFlagSpec struct {
+	ProjectKey     string `json:"projectKey" mapstructure:"projectKey"`
+	EnvironmentKey string `json:"environmentKey" mapstructure:"environmentKey"`
+	FlagKey        string `json:"flagKey" mapstructure:"flagKey"`
+	On             bool   `json:"on" mapstructure:"on"`
+}
+
+func (c *ToggleFeatureFlag) Name() string {
+	return "launchdarkly.toggleFeatureFlag"
+}
+
+func (c *ToggleFeatureFlag) Label() string {
+	return "Toggle Feature Flag"
+}
+
+func (c *ToggleFeatureFlag) Description() string {
+	return "Turn a LaunchDarkly feature flag on or off in an environment"
+}
+
+func (c *ToggleFeatureFlag) Documentation() string {
+	return `The Toggle Feature Flag component turns targeting of a feature flag on or off in one environment.
+
+## Use Cases
+
+- **Releases**: Turn a flag on once a deployment is verified
+- **Kill switches**: Turn a flag off when an alert fires
+- **Maintenance windows**: Turn features off and back on around a maintenance
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Environment**: The environment where the flag is toggled
+- **Feature Flag**: The key of the feature flag to toggle
+- **On**: Whether targeting of the flag is turned on or off
+
+## Output
+
+Returns the project, environment and flag keys, whether the flag is on in the environment after the update, and the flag version.
+
+**Note**: The API access token needs a role with **Writer** permissions to update flags.`
+}
+
+func (c *ToggleFeatureFlag) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ToggleFeatureFlag) Color() string {
+	return "gray"
+}
+
+func (c *ToggleFeatureFlag) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ToggleFeatureFlag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment where the flag is toggled",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to toggle",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "on",
+			Label:       "On",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Turn targeting of the flag on, or off when unchecked",
+		},
+	}
+}
+
+func (c *ToggleFeatureFlag) Setup(ctx core.SetupContext) error {
+	spec := ToggleFeatureFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateToggleFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateToggleFeatureFlagSpec(spec ToggleFeatureFlagSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.EnvironmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	return nil
+}
+
+func (c *ToggleFeatureFlag) Execute(ctx core.ExecutionContext) error {
+	spec := ToggleFeatureFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateToggleFeatureFlagSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	updated, err := client.JSONPatchFeatureFlag(spec.ProjectKey, spec.FlagKey, []JSONPatchOperation{
+		{Op: "replace", Path: fmt.Sprintf("/environments/%s/on", spec.EnvironmentKey), Value: spec.On},
+	})
+
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("failed to toggle feature flag: the API access token is not allowed to update flags; use a token with a role that has Writer permissions: %w", err)
+		}
+
+		return fmt.Errorf("failed to toggle feature flag: %w", err)
+	}
+
+	//
+	// The state is read back from the updated flag, so the output
+	// reflects what LaunchDarkly applied.
+	//
+	on := spec.On
+	environments, _ := updated["environments"].(map[string]any)
+	if environment, ok := environments[spec.EnvironmentKey].(map[string]any); ok {
+		if value, ok := environment["on"].(bool); ok {
+			on = value
+		}
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.toggled",
+		[]any{
+			map[string]any{
+				"projectKey":     spec.ProjectKey,
+				"environmentKey": spec.EnvironmentKey,
+				"flagKey":        spec.FlagKey,
+				"on":             on,
+				"version":        updated["_version"],
+			},
+		},
+	)
+}
+
+func (c *ToggleFeatureFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ToggleFeatureFlag) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ToggleFeatureFlag) Actions() []core.Action {
+	return nil
+}
+
+func (c *ToggleFeatureFlag) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ToggleFeatureFlag) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ToggleFeatureFlag) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,201 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ToggleFeatureFlag__Setup(t *testing.T) {
+	component := &ToggleFeatureFlag{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"environmentKey": "production",
+				"flagKey":        "new-checkout",
+				"on":             false,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"environmentKey": "production", "flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing environment key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "environment key is required")
+	})
+
+	t.Run("missing flag key returns error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "environmentKey": "production"},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+}
+
+func Test__ToggleFeatureFlag__Execute(t *testing.T) {
+	component := &ToggleFeatureFlag{}
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{"apiKey": "test-api-key"},
+	}
+
+	t.Run("turn on -> patches the environment and emits the new state", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"new-checkout","_version":12,"environments":{"production":{"on":true}}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"environmentKey": "production",
+				"flagKey":        "new-checkout",
+				"on":             true,
+			},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", req.URL.String())
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		var operations []map[string]any
+		require.NoError(t, json.Unmarshal(body, &operations))
+		assert.Equal(t, []map[string]any{
+			{"op": "replace", "path": "/environments/production/on", "value": true},
+		}, operations)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.toggled", payload["type"])
+		assert.Equal(t, map[string]any{
+			"projectKey":     "default",
+			"environmentKey": "production",
+			"flagKey":        "new-checkout",
+			"on":             true,
+			"version":        float64(12),
+		}, payload["data"])
+	})
+
+	t.Run("turn off -> sends false and emits the state from the response", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"key":"new-checkout","_version":13,"environments":{"production":{"on":false}}}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"environmentKey": "production",
+				"flagKey":        "new-checkout",
+				"on":             false,
+			},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"op":"replace","path":"/environments/production/on","value":false}]`, string(body))
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["on"])
+	})
+
+	t.Run("token without Writer permission -> helpful error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"forbidden","message":"Access to the requested resource was denied"}`)),
+				},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"environmentKey": "production",
+				"flagKey":        "new-checkout",
+				"on":             true,
+			},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: execStateCtx,
+		})
+
+		require.ErrorContains(t, err, "Writer permissions")
+		assert.Empty(t, execStateCtx.Payloads)
+	})
+
+	t.Run("other API error -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"code":"not_found","message":"Unknown resource"}`)),
+				},
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":     "default",
+				"environmentKey": "production",
+				"flagKey":        "missing",
+			},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to toggle feature flag: request failed with 404")
+		assert.NotContains(t, err.Error(), "Writer")
+	})
+}
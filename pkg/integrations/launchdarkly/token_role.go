@@ -0,0 +1,87 @@
+package launchdarkly
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// Roles of the API access token recorded in the integration metadata.
+// Tokens with custom or inline roles are recorded as custom, and tokens
+// whose role could not be read as unknown.
+const (
+	TokenRoleReader   = "reader"
+	TokenRoleWriter   = "writer"
+	TokenRoleAdmin    = "admin"
+	TokenRoleOwner    = "owner"
+	TokenRoleNoAccess = "no_access"
+	TokenRoleCustom   = "custom"
+	TokenRoleUnknown  = "unknown"
+)
+
+// Metadata is the metadata of the integration, updated on every Sync.
+type Metadata struct {
+	TokenRole string `json:"tokenRole" mapstructure:"tokenRole"`
+	TokenName string `json:"tokenName,omitempty" mapstructure:"tokenName"`
+}
+
+// introspectToken reads the API access token of the client through the caller identity.
+func introspectToken(client *Client) (*AccessToken, error) {
+	identity, err := client.GetCallerIdentity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	if strings.TrimSpace(identity.TokenID) == "" {
+		return nil, fmt.Errorf("caller identity has no token")
+	}
+
+	token, err := client.GetToken(identity.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token %s: %w", identity.TokenID, err)
+	}
+
+	return token, nil
+}
+
+func tokenRole(token *AccessToken) string {
+	if len(token.CustomRoleIDs) > 0 || len(token.InlineRole) > 0 {
+		return TokenRoleCustom
+	}
+
+	role := strings.ToLower(strings.TrimSpace(token.Role))
+	if role == "" {
+		return TokenRoleUnknown
+	}
+
+	return role
+}
+
+// isReadOnlyTokenRole reports whether tokens with the role cannot change resources.
+// Custom and unknown roles may be allowed to, so they are not reported as read-only.
+func isReadOnlyTokenRole(role string) bool {
+	return slices.Contains([]string{TokenRoleReader, TokenRoleNoAccess}, role)
+}
+
+// checkWriteAccess fails the setup of components that change LaunchDarkly resources
+// when the last Sync found that the API access token cannot change them,
+// instead of letting every execution of the component fail.
+func checkWriteAccess(integration core.IntegrationContext) error {
+	if integration == nil {
+		return nil
+	}
+
+	metadata := Metadata{}
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return nil
+	}
+
+	if isReadOnlyTokenRole(metadata.TokenRole) {
+		return fmt.Errorf("the API access token has the %s role and cannot make changes in LaunchDarkly, use a token with the writer role", metadata.TokenRole)
+	}
+
+	return nil
+}
@@ -0,0 +1,461 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// What the default rule of the flag serves.
+const (
+	TargetingModeVariation = "variation"
+	TargetingModeRollout   = "rollout"
+)
+
+var AllTargetingModes = []configuration.FieldOption{
+	{Label: "Single variation", Value: TargetingModeVariation},
+	{Label: "Percentage rollout", Value: TargetingModeRollout},
+}
+
+// LaunchDarkly expresses rollout weights in thousandths of a percent.
+const (
+	rolloutWeightPerPercent = 1000
+	rolloutTotalWeight      = 100 * rolloutWeightPerPercent
+)
+
+type UpdateFlagTargeting struct{}
+
+type UpdateFlagTargetingSpec struct {
+	ProjectKey     string          `json:"projectKey" mapstructure:"projectKey"`
+	EnvironmentKey string          `json:"environmentKey" mapstructure:"environmentKey"`
+	FlagKey        string          `json:"flagKey" mapstructure:"flagKey"`
+	Mode           string          `json:"mode" mapstructure:"mode"`
+	Variation      *int            `json:"variation,omitempty" mapstructure:"variation,omitempty"`
+	Rollout        []RolloutWeight `json:"rollout" mapstructure:"rollout"`
+	BucketBy       string          `json:"bucketBy" mapstructure:"bucketBy"`
+}
+
+// RolloutWeight is the percentage of contexts a variation is served to.
+type RolloutWeight struct {
+	Variation  int     `json:"variation" mapstructure:"variation"`
+	Percentage float64 `json:"percentage" mapstructure:"percentage"`
+}
+
+func (c *UpdateFlagTargeting) Name() string {
+	return "launchdarkly.updateFlagTargeting"
+}
+
+func (c *UpdateFlagTargeting) Label() string {
+	return "Update Flag Targeting"
+}
+
+func (c *UpdateFlagTargeting) Description() string {
+	return "Set the variation or percentage rollout served by the default rule of a LaunchDarkly flag"
+}
+
+func (c *UpdateFlagTargeting) Documentation() string {
+	return `The Update Flag Targeting component changes what the default rule of a feature flag serves in one environment: a single variation, or a percentage rollout across variations.
+
+## Use Cases
+
+- **Progressive rollouts**: Bump the rollout of a flag from 10% to 50% once the first stage is healthy
+- **Rollbacks**: Serve the old variation to everyone when an alert fires
+- **Full releases**: Serve the new variation to everyone at the end of a rollout
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Environment**: The environment where the targeting is updated
+- **Feature Flag**: The key of the feature flag to update
+- **Serve**: A single variation, or a percentage rollout
+- **Variation**: Index of the variation served to everyone (0 is the first variation)
+- **Rollout**: The percentage of contexts each variation is served to. Percentages must add up to 100, with up to 3 decimals
+- **Bucket By**: Optional context attribute the rollout buckets contexts by. LaunchDarkly uses the context key when empty
+
+Variation indexes are validated against the flag's variations before the update. Individual targets and rules of the flag are left unchanged.
+
+## Output
+
+Returns the project, environment and flag keys, the variation or rollout served by the default rule after the update, and the flag version.`
+}
+
+func (c *UpdateFlagTargeting) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *UpdateFlagTargeting) Color() string {
+	return "gray"
+}
+
+func (c *UpdateFlagTargeting) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *UpdateFlagTargeting) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "environmentKey",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The environment where the targeting is updated",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "environment",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to update",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:     "mode",
+			Label:    "Serve",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  TargetingModeRollout,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllTargetingModes,
+				},
+			},
+		},
+		{
+			Name:        "variation",
+			Label:       "Variation",
+			Type:        configuration.FieldTypeNumber,
+			Description: "Index of the variation served to everyone",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 0; return &min }(),
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{TargetingModeVariation}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "mode", Values: []string{TargetingModeVariation}},
+			},
+		},
+		{
+			Name:        "rollout",
+			Label:       "Rollout",
+			Type:        configuration.FieldTypeList,
+			Description: "The percentage of contexts each variation is served to",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variation",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "variation",
+								Label:       "Variation",
+								Type:        configuration.FieldTypeNumber,
+								Required:    true,
+								Description: "Index of the variation",
+								TypeOptions: &configuration.TypeOptions{
+									Number: &configuration.NumberTypeOptions{
+										Min: func() *int { min := 0; return &min }(),
+									},
+								},
+							},
+							{
+								Name:        "percentage",
+								Label:       "Percentage",
+								Type:        configuration.FieldTypeNumber,
+								Required:    true,
+								Description: "Percentage of contexts served the variation",
+								TypeOptions: &configuration.TypeOptions{
+									Number: &configuration.NumberTypeOptions{
+										Min: func() *int { min := 0; return &min }(),
+										Max: func() *int { max := 100; return &max }(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{TargetingModeRollout}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "mode", Values: []string{TargetingModeRollout}},
+			},
+		},
+		{
+			Name:        "bucketBy",
+			Label:       "Bucket By",
+			Type:        configuration.FieldTypeString,
+			Description: "Context attribute the rollout buckets contexts by",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "mode", Values: []string{TargetingModeRollout}},
+			},
+		},
+	}
+}
+
+func (c *UpdateFlagTargeting) Setup(ctx core.SetupContext) error {
+	spec := UpdateFlagTargetingSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateUpdateFlagTargetingSpec(spec); err != nil {
+		return err
+	}
+
+	return checkWriteAccess(ctx.Integration)
+}
+
+func validateUpdateFlagTargetingSpec(spec UpdateFlagTargetingSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.EnvironmentKey) == "" {
+		return errors.New("environment key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	switch spec.Mode {
+	case TargetingModeVariation:
+		if spec.Variation == nil {
+			return errors.New("variation is required")
+		}
+
+		if *spec.Variation < 0 {
+			return errors.New("variation must not be negative")
+		}
+
+		return nil
+	case TargetingModeRollout:
+		_, err := rolloutWeights(spec.Rollout)
+		return err
+	default:
+		return fmt.Errorf("invalid mode %q", spec.Mode)
+	}
+}
+
+// rolloutWeights converts the rollout percentages into LaunchDarkly weights,
+// and checks that they add up to 100%.
+func rolloutWeights(rollout []RolloutWeight) ([]flagWeightedVariation, error) {
+	if len(rollout) == 0 {
+		return nil, errors.New("rollout must have at least one variation")
+	}
+
+	weights := make([]flagWeightedVariation, 0, len(rollout))
+	seen := map[int]bool{}
+	total := 0
+	for i, item := range rollout {
+		if item.Variation < 0 {
+			return nil, fmt.Errorf("rollout variation at index %d must not be negative", i)
+		}
+
+		if seen[item.Variation] {
+			return nil, fmt.Errorf("rollout variation %d is listed more than once", item.Variation)
+		}
+		seen[item.Variation] = true
+
+		if item.Percentage < 0 || item.Percentage > 100 {
+			return nil, fmt.Errorf("rollout percentage of variation %d must be between 0 and 100", item.Variation)
+		}
+
+		weight := math.Round(item.Percentage * rolloutWeightPerPercent)
+		weights = append(weights, flagWeightedVariation{Variation: item.Variation, Weight: int(weight)})
+		total += int(weight)
+	}
+
+	if total != rolloutTotalWeight {
+		return nil, fmt.Errorf("rollout percentages must add up to 100, got %s", formatRolloutPercentage(total))
+	}
+
+	return weights, nil
+}
+
+func formatRolloutPercentage(weight int) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", float64(weight)/rolloutWeightPerPercent), "0"), ".")
+}
+
+// fallthroughValue builds the default rule of the flag from the configuration.
+func fallthroughValue(spec UpdateFlagTargetingSpec) (map[string]any, error) {
+	if spec.Mode == TargetingModeVariation {
+		return map[string]any{"variation": *spec.Variation}, nil
+	}
+
+	weights, err := rolloutWeights(spec.Rollout)
+	if err != nil {
+		return nil, err
+	}
+
+	variations := make([]map[string]any, 0, len(weights))
+	for _, weight := range weights {
+		variations = append(variations, map[string]any{"variation": weight.Variation, "weight": weight.Weight})
+	}
+
+	rollout := map[string]any{"variations": variations}
+	if bucketBy := strings.TrimSpace(spec.BucketBy); bucketBy != "" {
+		rollout["bucketBy"] = bucketBy
+	}
+
+	return map[string]any{"rollout": rollout}, nil
+}
+
+// targetingVariations returns the variation indexes the configuration serves.
+func targetingVariations(spec UpdateFlagTargetingSpec) []int {
+	if spec.Mode == TargetingModeVariation {
+		return []int{*spec.Variation}
+	}
+
+	indexes := make([]int, 0, len(spec.Rollout))
+	for _, item := range spec.Rollout {
+		indexes = append(indexes, item.Variation)
+	}
+
+	return indexes
+}
+
+func (c *UpdateFlagTargeting) Execute(ctx core.ExecutionContext) error {
+	spec := UpdateFlagTargetingSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateUpdateFlagTargetingSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	variations, _ := flag["variations"].([]any)
+	for _, index := range targetingVariations(spec) {
+		if _, err := variationValue(variations, index); err != nil {
+			return fmt.Errorf("invalid variation: %w", err)
+		}
+	}
+
+	value, err := fallthroughValue(spec)
+	if err != nil {
+		return err
+	}
+
+	updated, err := client.JSONPatchFeatureFlag(spec.ProjectKey, spec.FlagKey, []JSONPatchOperation{
+		{Op: "replace", Path: fmt.Sprintf("/environments/%s/fallthrough", spec.EnvironmentKey), Value: value},
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update flag targeting: %w", err)
+	}
+
+	//
+	// The default rule is read back from the updated flag, so the output
+	// reflects what LaunchDarkly applied.
+	//
+	environment, err := flagEnvironment(updated, spec.EnvironmentKey)
+	if err != nil {
+		return err
+	}
+
+	result := map[string]any{
+		"projectKey":     spec.ProjectKey,
+		"environmentKey": spec.EnvironmentKey,
+		"flagKey":        spec.FlagKey,
+		"variation":      nil,
+		"rollout":        nil,
+		"version":        updated["_version"],
+	}
+
+	updatedVariations, _ := updated["variations"].([]any)
+	if environment.Fallthrough.Variation != nil {
+		result["variation"] = map[string]any{
+			"index": *environment.Fallthrough.Variation,
+			"value": ruleVariationValue(updatedVariations, *environment.Fallthrough.Variation),
+		}
+	}
+
+	if environment.Fallthrough.Rollout != nil {
+		result["rollout"] = normalizeRollout(environment.Fallthrough.Rollout, updatedVariations)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"launchdarkly.flag.targeting.updated",
+		[]any{result},
+	)
+}
+
+func (c *UpdateFlagTargeting) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *UpdateFlagTargeting) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *UpdateFlagTargeting) Actions() []core.Action {
+	return nil
+}
+
+func (c *UpdateFlagTargeting) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *UpdateFlagTargeting) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *UpdateFlagTargeting) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
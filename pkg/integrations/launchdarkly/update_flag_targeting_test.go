@@ -0,0 +1,240 @@
+package launchdarkly
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__UpdateFlagTargeting__Setup(t *testing.T) {
+	component := &UpdateFlagTargeting{}
+
+	rolloutConfiguration := func(rollout ...map[string]any) map[string]any {
+		return map[string]any{
+			"projectKey":     "default",
+			"environmentKey": "production",
+			"flagKey":        "new-checkout",
+			"mode":           TargetingModeRollout,
+			"rollout":        rollout,
+		}
+	}
+
+	t.Run("rollout adding up to 100 -> no error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: rolloutConfiguration(
+				map[string]any{"variation": 0, "percentage": 12.5},
+				map[string]any{"variation": 1, "percentage": 87.5},
+			),
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rollout not adding up to 100 -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: rolloutConfiguration(
+				map[string]any{"variation": 0, "percentage": 20},
+				map[string]any{"variation": 1, "percentage": 70.5},
+			),
+		})
+
+		require.ErrorContains(t, err, "rollout percentages must add up to 100, got 90.5")
+	})
+
+	t.Run("rollout with a repeated variation -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: rolloutConfiguration(
+				map[string]any{"variation": 0, "percentage": 50},
+				map[string]any{"variation": 0, "percentage": 50},
+			),
+		})
+
+		require.ErrorContains(t, err, "rollout variation 0 is listed more than once")
+	})
+
+	t.Run("empty rollout -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: rolloutConfiguration()})
+		require.ErrorContains(t, err, "rollout must have at least one variation")
+	})
+
+	t.Run("variation mode without variation -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "environmentKey": "production", "flagKey": "new-checkout", "mode": TargetingModeVariation},
+		})
+
+		require.ErrorContains(t, err, "variation is required")
+	})
+
+	t.Run("missing environment key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout", "mode": TargetingModeVariation, "variation": 0},
+		})
+
+		require.ErrorContains(t, err, "environment key is required")
+	})
+
+	t.Run("reader token -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "environmentKey": "production", "flagKey": "new-checkout", "mode": TargetingModeVariation, "variation": 0},
+			Integration:   &contexts.IntegrationContext{Metadata: Metadata{TokenRole: TokenRoleReader}},
+		})
+
+		require.ErrorContains(t, err, "the API access token has the reader role")
+	})
+}
+
+func Test__UpdateFlagTargeting__Execute(t *testing.T) {
+	component := &UpdateFlagTargeting{}
+	flagResponse := `{"key":"new-checkout","variations":[{"value":true},{"value":false}]}`
+
+	execute := func(configuration map[string]any, httpContext *contexts.HTTPContext) (*contexts.ExecutionStateContext, error) {
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		return execStateCtx, err
+	}
+
+	t.Run("percentage rollout -> fallthrough patched with weights", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{
+					"key": "new-checkout",
+					"_version": 12,
+					"variations": [{"value": true}, {"value": false}],
+					"environments": {"production": {"on": true, "fallthrough": {"rollout": {"bucketBy": "org", "variations": [{"variation": 0, "weight": 25000}, {"variation": 1, "weight": 75000}]}}}}
+				}`))},
+			},
+		}
+
+		execStateCtx, err := execute(map[string]any{
+			"projectKey":     "default",
+			"environmentKey": "production",
+			"flagKey":        "new-checkout",
+			"mode":           TargetingModeRollout,
+			"bucketBy":       "org",
+			"rollout": []map[string]any{
+				{"variation": 0, "percentage": 25},
+				{"variation": 1, "percentage": 75},
+			},
+		}, httpContext)
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		patch := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, patch.Method)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", patch.URL.String())
+
+		operations := []map[string]any{}
+		require.NoError(t, json.NewDecoder(patch.Body).Decode(&operations))
+		assert.Equal(t, []map[string]any{{
+			"op":   "replace",
+			"path": "/environments/production/fallthrough",
+			"value": map[string]any{
+				"rollout": map[string]any{
+					"bucketBy": "org",
+					"variations": []any{
+						map[string]any{"variation": float64(0), "weight": float64(25000)},
+						map[string]any{"variation": float64(1), "weight": float64(75000)},
+					},
+				},
+			},
+		}}, operations)
+
+		require.Len(t, execStateCtx.Payloads, 1)
+		payload := execStateCtx.Payloads[0].(map[string]any)
+		assert.Equal(t, "launchdarkly.flag.targeting.updated", payload["type"])
+		data := payload["data"].(map[string]any)
+		assert.Nil(t, data["variation"])
+		assert.Equal(t, float64(12), data["version"])
+		rollout := data["rollout"].(map[string]any)
+		assert.Equal(t, "org", rollout["bucketBy"])
+		assert.Equal(t, []map[string]any{
+			{"index": 0, "value": true, "weight": 25000, "percentage": float64(25)},
+			{"index": 1, "value": false, "weight": 75000, "percentage": float64(75)},
+		}, rollout["variations"])
+	})
+
+	t.Run("single variation -> fallthrough patched with the variation", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{
+					"key": "new-checkout",
+					"_version": 13,
+					"variations": [{"value": true}, {"value": false}],
+					"environments": {"production": {"on": true, "fallthrough": {"variation": 1}}}
+				}`))},
+			},
+		}
+
+		execStateCtx, err := execute(map[string]any{
+			"projectKey":     "default",
+			"environmentKey": "production",
+			"flagKey":        "new-checkout",
+			"mode":           TargetingModeVariation,
+			"variation":      1,
+		}, httpContext)
+
+		require.NoError(t, err)
+		operations := []map[string]any{}
+		require.NoError(t, json.NewDecoder(httpContext.Requests[1].Body).Decode(&operations))
+		assert.Equal(t, map[string]any{"variation": float64(1)}, operations[0]["value"])
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, map[string]any{"index": 1, "value": false}, data["variation"])
+		assert.Nil(t, data["rollout"])
+	})
+
+	t.Run("variation the flag does not have -> error without patching", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		_, err := execute(map[string]any{
+			"projectKey":     "default",
+			"environmentKey": "production",
+			"flagKey":        "new-checkout",
+			"mode":           TargetingModeVariation,
+			"variation":      2,
+		}, httpContext)
+
+		require.ErrorContains(t, err, "invalid variation: index 2 is out of range")
+		require.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("patch rejected -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+				{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"code":"invalid_request"}`))},
+			},
+		}
+
+		_, err := execute(map[string]any{
+			"projectKey":     "default",
+			"environmentKey": "production",
+			"flagKey":        "new-checkout",
+			"mode":           TargetingModeVariation,
+			"variation":      0,
+		}, httpContext)
+
+		require.ErrorContains(t, err, "failed to update flag targeting")
+	})
+}
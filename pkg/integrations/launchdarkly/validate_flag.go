@@ -0,0 +1,284 @@
+package launchdarkly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ValidateFlagPassedOutputChannel = "passed"
+	ValidateFlagFailedOutputChannel = "failed"
+)
+
+type ValidateFlag struct{}
+
+type ValidateFlagSpec struct {
+	ProjectKey         string `json:"projectKey" mapstructure:"projectKey"`
+	FlagKey            string `json:"flagKey" mapstructure:"flagKey"`
+	RequireTemporary   bool   `json:"requireTemporary" mapstructure:"requireTemporary"`
+	RequireMaintainer  bool   `json:"requireMaintainer" mapstructure:"requireMaintainer"`
+	RequireDescription bool   `json:"requireDescription" mapstructure:"requireDescription"`
+}
+
+// FlagPolicyViolation is a policy rule the flag does not comply with.
+type FlagPolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (c *ValidateFlag) Name() string {
+	return "launchdarkly.validateFlag"
+}
+
+func (c *ValidateFlag) Label() string {
+	return "Validate Flag"
+}
+
+func (c *ValidateFlag) Description() string {
+	return "Check a LaunchDarkly feature flag against policy rules"
+}
+
+func (c *ValidateFlag) Documentation() string {
+	return `The Validate Flag component checks a feature flag against a set of policy rules and routes the workflow on the result.
+
+## Use Cases
+
+- **Governance gates**: Block a release until its flags follow the team's flag policy
+- **Flag hygiene**: Flag missing descriptions or maintainers before they pile up
+- **Cleanup tracking**: Make sure release flags are marked as temporary so they get removed
+
+## Configuration
+
+- **Project Key**: The key of the LaunchDarkly project containing the flag
+- **Feature Flag**: The key of the feature flag to validate
+- **Must Be Temporary**: Require the flag to be marked as temporary
+- **Must Have Maintainer**: Require the flag to have a maintainer member or team
+- **Must Have Description**: Require the flag to have a non-empty description
+
+## Output Channels
+
+- **Passed**: The flag complies with every enabled rule
+- **Failed**: The flag violates at least one enabled rule
+
+## Output
+
+Returns the project and flag keys, whether the flag passed, the rules that were checked, and the violations, each with the rule it breaks and a message.`
+}
+
+func (c *ValidateFlag) Icon() string {
+	return "launchdarkly"
+}
+
+func (c *ValidateFlag) Color() string {
+	return "gray"
+}
+
+func (c *ValidateFlag) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{
+			Name:  ValidateFlagPassedOutputChannel,
+			Label: "Passed",
+		},
+		{
+			Name:  ValidateFlagFailedOutputChannel,
+			Label: "Failed",
+		},
+	}
+}
+
+func (c *ValidateFlag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "projectKey",
+			Label:       "Project",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The LaunchDarkly project",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "project",
+				},
+			},
+		},
+		{
+			Name:        "flagKey",
+			Label:       "Feature Flag",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "The feature flag to validate",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: "flag",
+					Parameters: []configuration.ParameterRef{
+						{
+							Name:      "projectKey",
+							ValueFrom: &configuration.ParameterValueFrom{Field: "projectKey"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "requireTemporary",
+			Label:       "Must Be Temporary",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Require the flag to be marked as temporary",
+		},
+		{
+			Name:        "requireMaintainer",
+			Label:       "Must Have Maintainer",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Require the flag to have a maintainer member or team",
+		},
+		{
+			Name:        "requireDescription",
+			Label:       "Must Have Description",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Require the flag to have a description",
+		},
+	}
+}
+
+func (c *ValidateFlag) Setup(ctx core.SetupContext) error {
+	spec := ValidateFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateValidateFlagSpec(spec)
+}
+
+func validateValidateFlagSpec(spec ValidateFlagSpec) error {
+	if strings.TrimSpace(spec.ProjectKey) == "" {
+		return errors.New("project key is required")
+	}
+
+	if strings.TrimSpace(spec.FlagKey) == "" {
+		return errors.New("flag key is required")
+	}
+
+	if !spec.RequireTemporary && !spec.RequireMaintainer && !spec.RequireDescription {
+		return errors.New("at least one policy rule must be enabled")
+	}
+
+	return nil
+}
+
+func (c *ValidateFlag) Execute(ctx core.ExecutionContext) error {
+	spec := ValidateFlagSpec{}
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateValidateFlagSpec(spec); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create LaunchDarkly client: %w", err)
+	}
+
+	flag, err := client.GetFeatureFlag(spec.ProjectKey, spec.FlagKey)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	rules, violations := checkFlagPolicy(flag, spec)
+
+	channel := ValidateFlagPassedOutputChannel
+	if len(violations) > 0 {
+		channel = ValidateFlagFailedOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(
+		channel,
+		"launchdarkly.flag.validation",
+		[]any{
+			map[string]any{
+				"projectKey": spec.ProjectKey,
+				"flagKey":    spec.FlagKey,
+				"passed":     len(violations) == 0,
+				"rules":      rules,
+				"violations": violations,
+			},
+		},
+	)
+}
+
+// checkFlagPolicy returns the enabled rules and the ones the flag violates.
+func checkFlagPolicy(flag map[string]any, spec ValidateFlagSpec) ([]string, []FlagPolicyViolation) {
+	rules := []string{}
+	violations := []FlagPolicyViolation{}
+
+	if spec.RequireTemporary {
+		rules = append(rules, "temporary")
+		if temporary, _ := flag["temporary"].(bool); !temporary {
+			violations = append(violations, FlagPolicyViolation{Rule: "temporary", Message: "flag is not marked as temporary"})
+		}
+	}
+
+	if spec.RequireMaintainer {
+		rules = append(rules, "maintainer")
+		if !flagHasMaintainer(flag) {
+			violations = append(violations, FlagPolicyViolation{Rule: "maintainer", Message: "flag has no maintainer"})
+		}
+	}
+
+	if spec.RequireDescription {
+		rules = append(rules, "description")
+		if description, _ := flag["description"].(string); strings.TrimSpace(description) == "" {
+			violations = append(violations, FlagPolicyViolation{Rule: "description", Message: "flag has no description"})
+		}
+	}
+
+	return rules, violations
+}
+
+// flagHasMaintainer reports whether a member or a team maintains the flag.
+func flagHasMaintainer(flag map[string]any) bool {
+	for _, field := range []string{"maintainerId", "maintainerTeamKey"} {
+		if value, _ := flag[field].(string); strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *ValidateFlag) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *ValidateFlag) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *ValidateFlag) Actions() []core.Action {
+	return nil
+}
+
+func (c *ValidateFlag) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *ValidateFlag) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ValidateFlag) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
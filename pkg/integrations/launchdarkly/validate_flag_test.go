@@ -0,0 +1,162 @@
+package launchdarkly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ValidateFlag__Setup(t *testing.T) {
+	component := &ValidateFlag{}
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"projectKey":        "default",
+				"flagKey":           "new-checkout",
+				"requireMaintainer": true,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing project key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"flagKey": "new-checkout", "requireMaintainer": true},
+		})
+
+		require.ErrorContains(t, err, "project key is required")
+	})
+
+	t.Run("missing flag key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "requireMaintainer": true},
+		})
+
+		require.ErrorContains(t, err, "flag key is required")
+	})
+
+	t.Run("no rules enabled -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"projectKey": "default", "flagKey": "new-checkout"},
+		})
+
+		require.ErrorContains(t, err, "at least one policy rule must be enabled")
+	})
+}
+
+func Test__ValidateFlag__Execute(t *testing.T) {
+	component := &ValidateFlag{}
+
+	allRules := map[string]any{
+		"projectKey":         "default",
+		"flagKey":            "new-checkout",
+		"requireTemporary":   true,
+		"requireMaintainer":  true,
+		"requireDescription": true,
+	}
+
+	execute := func(t *testing.T, flagResponse string) (*contexts.HTTPContext, *contexts.ExecutionStateContext) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(flagResponse))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  allRules,
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		return httpContext, execStateCtx
+	}
+
+	t.Run("compliant flag -> emits on passed channel", func(t *testing.T) {
+		httpContext, execStateCtx := execute(t, `{
+			"key": "new-checkout",
+			"description": "Rolls out the new checkout",
+			"temporary": true,
+			"maintainerId": "569f183514f4432160000007"
+		}`)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.launchdarkly.com/api/v2/flags/default/new-checkout", httpContext.Requests[0].URL.String())
+
+		assert.Equal(t, ValidateFlagPassedOutputChannel, execStateCtx.Channel)
+		assert.Equal(t, "launchdarkly.flag.validation", execStateCtx.Type)
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["passed"])
+		assert.Equal(t, []string{"temporary", "maintainer", "description"}, data["rules"])
+		assert.Empty(t, data["violations"])
+	})
+
+	t.Run("team maintainer -> maintainer rule passes", func(t *testing.T) {
+		_, execStateCtx := execute(t, `{
+			"key": "new-checkout",
+			"description": "Rolls out the new checkout",
+			"temporary": true,
+			"maintainerTeamKey": "payments"
+		}`)
+
+		assert.Equal(t, ValidateFlagPassedOutputChannel, execStateCtx.Channel)
+	})
+
+	t.Run("flag violating multiple rules -> emits violations on failed channel", func(t *testing.T) {
+		_, execStateCtx := execute(t, `{
+			"key": "new-checkout",
+			"description": "  ",
+			"temporary": false
+		}`)
+
+		assert.Equal(t, ValidateFlagFailedOutputChannel, execStateCtx.Channel)
+
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["passed"])
+		assert.Equal(t, []FlagPolicyViolation{
+			{Rule: "temporary", Message: "flag is not marked as temporary"},
+			{Rule: "maintainer", Message: "flag has no maintainer"},
+			{Rule: "description", Message: "flag has no description"},
+		}, data["violations"])
+	})
+
+	t.Run("disabled rule -> not checked", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"key":"new-checkout","description":"Permanent kill switch","temporary":false,"maintainerId":"abc"}`))},
+			},
+		}
+
+		execStateCtx := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"projectKey":         "default",
+				"flagKey":            "new-checkout",
+				"requireMaintainer":  true,
+				"requireDescription": true,
+			},
+			HTTP:           httpContext,
+			Integration:    &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			ExecutionState: execStateCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ValidateFlagPassedOutputChannel, execStateCtx.Channel)
+		data := execStateCtx.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []string{"maintainer", "description"}, data["rules"])
+	})
+}
@@ -3,14 +3,48 @@ package launchdarkly
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/core"
 )
 
+// webhookSetupRetryInterval is the minimum wait between attempts to provision a project webhook,
+// so failing attempts do not use up the rate limit of the LaunchDarkly API token.
+const webhookSetupRetryInterval = 30 * time.Second
+
 // WebhookConfiguration is the config stored with the webhook.
+// Kind is the kind of resource whose changes LaunchDarkly sends, flags when empty.
+// Environments, and Flags or Segments, narrow the changes LaunchDarkly sends;
+// when empty, the changes of all environments, flags or segments of the project are sent.
 type WebhookConfiguration struct {
-	ProjectKey string `json:"projectKey" mapstructure:"projectKey"`
+	ProjectKey   string   `json:"projectKey" mapstructure:"projectKey"`
+	Kind         string   `json:"kind,omitempty" mapstructure:"kind"`
+	Environments []string `json:"environments,omitempty" mapstructure:"environments"`
+	Flags        []string `json:"flags,omitempty" mapstructure:"flags"`
+	Segments     []string `json:"segments,omitempty" mapstructure:"segments"`
+}
+
+// webhookKind returns the kind of resource of the webhook.
+// Webhooks created before segments were supported have no kind, and send flag changes.
+func (c WebhookConfiguration) webhookKind() string {
+	if c.Kind == "" {
+		return KindFlag
+	}
+
+	return c.Kind
+}
+
+// webhookKeys returns the keys of the resources the webhook is scoped to.
+func (c WebhookConfiguration) webhookKeys() []string {
+	if c.webhookKind() == KindSegment {
+		return normalizeWebhookKeys(c.Segments)
+	}
+
+	return normalizeWebhookKeys(c.Flags)
 }
 
 // WebhookMetadata is stored after Setup. It holds the LaunchDarkly webhook ID
@@ -32,9 +66,17 @@ func (h *LaunchDarklyWebhookHandler) CompareConfig(a, b any) (bool, error) {
 		return false, err
 	}
 
-	return configA.ProjectKey == configB.ProjectKey, nil
+	//
+	// Webhooks are only shared by triggers with the same scope,
+	// so a trigger never misses the changes it filters on.
+	//
+	return configA.ProjectKey == configB.ProjectKey &&
+		configA.webhookKind() == configB.webhookKind() &&
+		slices.Equal(normalizeWebhookKeys(configA.Environments), normalizeWebhookKeys(configB.Environments)) &&
+		slices.Equal(configA.webhookKeys(), configB.webhookKeys()), nil
 }
 
+// Merge keeps the current configuration, since only webhooks with the same scope are shared.
 func (h *LaunchDarklyWebhookHandler) Merge(current, requested any) (any, bool, error) {
 	return current, false, nil
 }
@@ -52,19 +94,12 @@ func (h *LaunchDarklyWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any,
 		return nil, fmt.Errorf("failed to decode webhook configuration: %w", err)
 	}
 
-	resource := fmt.Sprintf("proj/%s:env/*:flag/*", config.ProjectKey)
 	webhook, err := client.CreateWebhook(CreateWebhookRequest{
-		URL:  ctx.Webhook.GetURL(),
-		Sign: true,
-		On:   true,
-		Name: "SuperPlane",
-		Statements: []WebhookStatement{
-			{
-				Effect:    "allow",
-				Resources: []string{resource},
-				Actions:   []string{"*"},
-			},
-		},
+		URL:        ctx.Webhook.GetURL(),
+		Sign:       true,
+		On:         true,
+		Name:       "SuperPlane",
+		Statements: webhookStatements(config),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create webhook in LaunchDarkly: %w", err)
@@ -77,6 +112,54 @@ func (h *LaunchDarklyWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any,
 	return WebhookMetadata{LDWebhookID: webhook.ID}, nil
 }
 
+// webhookStatements scopes the webhook to the changes of the configured environments and flags,
+// or segments, of the project, or of all of them when none are configured.
+func webhookStatements(config WebhookConfiguration) []WebhookStatement {
+	environments := normalizeWebhookKeys(config.Environments)
+	if len(environments) == 0 {
+		environments = []string{"*"}
+	}
+
+	keys := config.webhookKeys()
+	if len(keys) == 0 {
+		keys = []string{"*"}
+	}
+
+	resources := []string{}
+	for _, environment := range environments {
+		for _, key := range keys {
+			resources = append(resources, fmt.Sprintf("proj/%s:env/%s:%s/%s", config.ProjectKey, environment, config.webhookKind(), key))
+		}
+	}
+
+	return []WebhookStatement{
+		{
+			Effect:    "allow",
+			Resources: resources,
+			Actions:   []string{"*"},
+		},
+	}
+}
+
+// normalizeWebhookKeys returns the keys sorted and without duplicates, so scopes compare equal
+// regardless of the order they were configured in. A wildcard key selects all of them.
+func normalizeWebhookKeys(keys []string) []string {
+	normalized := []string{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "*" {
+			return []string{}
+		}
+
+		if key != "" && !slices.Contains(normalized, key) {
+			normalized = append(normalized, key)
+		}
+	}
+
+	sort.Strings(normalized)
+	return normalized
+}
+
 // Cleanup deletes the webhook from LaunchDarkly when the trigger is removed.
 func (h *LaunchDarklyWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
 	metadata := WebhookMetadata{}
@@ -33,6 +33,42 @@ func Test__LaunchDarklyWebhookHandler__CompareConfig(t *testing.T) {
 		require.NoError(t, err)
 		assert.False(t, equal)
 	})
+
+	t.Run("same environments and flags in another order -> true", func(t *testing.T) {
+		equal, err := handler.CompareConfig(
+			WebhookConfiguration{ProjectKey: "default", Environments: []string{"production", "staging"}, Flags: []string{"a", "b"}},
+			map[string]any{"projectKey": "default", "environments": []any{"staging", "production"}, "flags": []any{"b", "a"}},
+		)
+		require.NoError(t, err)
+		assert.True(t, equal)
+	})
+
+	t.Run("different environments -> false", func(t *testing.T) {
+		equal, err := handler.CompareConfig(
+			WebhookConfiguration{ProjectKey: "default", Environments: []string{"production"}},
+			WebhookConfiguration{ProjectKey: "default", Environments: []string{"staging"}},
+		)
+		require.NoError(t, err)
+		assert.False(t, equal)
+	})
+
+	t.Run("flag and segment webhooks of the same project -> false", func(t *testing.T) {
+		equal, err := handler.CompareConfig(
+			WebhookConfiguration{ProjectKey: "default"},
+			WebhookConfiguration{ProjectKey: "default", Kind: KindSegment},
+		)
+		require.NoError(t, err)
+		assert.False(t, equal)
+	})
+
+	t.Run("scoped and project-wide webhooks -> false", func(t *testing.T) {
+		equal, err := handler.CompareConfig(
+			map[string]any{"projectKey": "default"},
+			WebhookConfiguration{ProjectKey: "default", Flags: []string{"new-checkout"}},
+		)
+		require.NoError(t, err)
+		assert.False(t, equal)
+	})
 }
 
 func Test__LaunchDarklyWebhookHandler__Merge(t *testing.T) {
@@ -100,6 +136,64 @@ func Test__LaunchDarklyWebhookHandler__Setup(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "ld-webhook-abc123", metadata.LDWebhookID)
 	})
+
+	t.Run("environments and flags -> statement lists their resources", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(createWebhookResponse)),
+				},
+			},
+		}
+
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: &contexts.IntegrationContext{Configuration: map[string]any{"apiKey": "test-api-key"}},
+			Webhook: &contexts.WebhookContext{
+				URL: "https://example.com/api/v1/webhooks/w1",
+				Configuration: map[string]any{
+					"projectKey":   "default",
+					"environments": []any{"production", "staging"},
+					"flags":        []any{"new-checkout"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		bodyBytes, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		var body CreateWebhookRequest
+		require.NoError(t, json.Unmarshal(bodyBytes, &body))
+		require.Len(t, body.Statements, 1)
+		assert.Equal(t, []string{
+			"proj/default:env/production:flag/new-checkout",
+			"proj/default:env/staging:flag/new-checkout",
+		}, body.Statements[0].Resources)
+	})
+
+	t.Run("configured base URL -> webhook created through it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(createWebhookResponse))},
+			},
+		}
+
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP: httpContext,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"apiKey": "test-api-key", "baseURL": "https://app.launchdarkly.us"},
+			},
+			Webhook: &contexts.WebhookContext{
+				URL:           "https://example.com/api/v1/webhooks/w1",
+				Configuration: WebhookConfiguration{ProjectKey: "default"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.launchdarkly.us/api/v2/webhooks", httpContext.Requests[0].URL.String())
+	})
 }
 
 func Test__LaunchDarklyWebhookHandler__Cleanup(t *testing.T) {
@@ -136,6 +230,26 @@ func Test__LaunchDarklyWebhookHandler__Cleanup(t *testing.T) {
 		assert.Equal(t, "https://app.launchdarkly.com/api/v2/webhooks/ld-webhook-abc123", req.URL.String())
 	})
 
+	t.Run("configured base URL -> webhook deleted through it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))},
+			},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			HTTP: httpContext,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{"apiKey": "test-api-key", "baseURL": "https://app.launchdarkly.us"},
+			},
+			Webhook: &contexts.WebhookContext{Metadata: WebhookMetadata{LDWebhookID: "ld-webhook-abc123"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.launchdarkly.us/api/v2/webhooks/ld-webhook-abc123", httpContext.Requests[0].URL.String())
+	})
+
 	t.Run("no-op when LDWebhookID is empty", func(t *testing.T) {
 		httpContext := &contexts.HTTPContext{}
 
@@ -6,9 +6,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
 )
 
 type Client struct {
@@ -39,13 +44,37 @@ func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, err
 	}, nil
 }
 
+// Semaphore lists are paginated, and Link headers point to the next page.
+const (
+	defaultPageSize = 100
+	maxListPages    = 100
+)
+
 type ProjectResponse struct {
 	Metadata *ProjectMetadata `json:"metadata"`
+	Spec     *ProjectSpec     `json:"spec,omitempty"`
 }
 
 type ProjectMetadata struct {
 	ProjectName string `json:"name"`
 	ProjectID   string `json:"id"`
+	OwnerID     string `json:"owner_id,omitempty"`
+	OrgID       string `json:"org_id,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type ProjectSpec struct {
+	Visibility string             `json:"visibility,omitempty"`
+	Repository *ProjectRepository `json:"repository,omitempty"`
+}
+
+type ProjectRepository struct {
+	URL             string   `json:"url"`
+	Name            string   `json:"name"`
+	Owner           string   `json:"owner"`
+	RunOn           []string `json:"run_on,omitempty"`
+	PipelineFile    string   `json:"pipeline_file,omitempty"`
+	IntegrationType string   `json:"integration_type,omitempty"`
 }
 
 func (c *Client) GetProject(idOrName string) (*ProjectResponse, error) {
@@ -54,7 +83,7 @@ func (c *Client) GetProject(idOrName string) (*ProjectResponse, error) {
 		return c.getProjectByName(idOrName)
 	}
 
-	projects, err := c.listProjects()
+	projects, err := c.ListProjects()
 	if err != nil {
 		return nil, err
 	}
@@ -84,45 +113,89 @@ func (c *Client) getProjectByName(name string) (*ProjectResponse, error) {
 	return &project, nil
 }
 
-func (c *Client) listProjects() ([]ProjectResponse, error) {
-	URL := fmt.Sprintf("%s/api/v1alpha/projects", c.OrgURL)
-	responseBody, err := c.execRequest(http.MethodGet, URL, nil)
+// ListProjects returns all the projects of the organization, requesting pages until the last one.
+func (c *Client) ListProjects() ([]ProjectResponse, error) {
+	projects := []ProjectResponse{}
+	for page := 1; page <= maxListPages; page++ {
+		items, hasNext, err := c.ListProjectsPage(page)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, items...)
+		if !hasNext || len(items) == 0 {
+			break
+		}
+	}
+
+	return projects, nil
+}
+
+// ListProjectsPage returns a page of the projects of the organization,
+// and whether there are more pages after it.
+func (c *Client) ListProjectsPage(page int) ([]ProjectResponse, bool, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(defaultPageSize))
+
+	URL := fmt.Sprintf("%s/api/v1alpha/projects?%s", c.OrgURL, query.Encode())
+	responseBody, header, err := c.doRequest(http.MethodGet, URL, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	var projects []ProjectResponse
 	err = json.Unmarshal(responseBody, &projects)
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling response: %v", err)
+		return nil, false, fmt.Errorf("error unmarshaling response: %v", err)
 	}
 
-	return projects, nil
+	return projects, hasNextPage(header), nil
+}
+
+// hasNextPage reports whether the Link header of a list response points to a next page.
+func hasNextPage(header http.Header) bool {
+	for _, link := range header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			if strings.Contains(part, `rel="next"`) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 func (c *Client) execRequest(method, URL string, body io.Reader) ([]byte, error) {
+	responseBody, _, err := c.doRequest(method, URL, body)
+	return responseBody, err
+}
+
+func (c *Client) doRequest(method, URL string, body io.Reader) ([]byte, http.Header, error) {
 	req, err := http.NewRequest(method, URL, body)
 	if err != nil {
-		return nil, fmt.Errorf("error building request: %v", err)
+		return nil, nil, fmt.Errorf("error building request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Token "+c.APIToken)
 	res, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %v", err)
+		registry.TraceHTTP(c.http, req, 0, nil, err, c.APIToken)
+		return nil, nil, fmt.Errorf("error executing request: %v", err)
 	}
 
 	responseBody, err := io.ReadAll(res.Body)
+	registry.TraceHTTP(c.http, req, res.StatusCode, responseBody, err, c.APIToken)
 	if err != nil {
-		return nil, fmt.Errorf("error reading body: %v", err)
+		return nil, nil, fmt.Errorf("error reading body: %v", err)
 	}
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
-		return nil, fmt.Errorf("request got %d code: %s", res.StatusCode, string(responseBody))
+		return nil, nil, fmt.Errorf("request got %d code: %s", res.StatusCode, string(responseBody))
 	}
 
-	return responseBody, nil
+	return responseBody, res.Header, nil
 }
 
 type PipelineResponse struct {
@@ -166,19 +239,222 @@ func (c *Client) GetPipeline(id string) (*Pipeline, error) {
 	return pipelineResponse.Pipeline, nil
 }
 
-func (c *Client) ListPipelines(projectID string) ([]any, error) {
-	URL := fmt.Sprintf("%s/api/v1alpha/pipelines?project_id=%s", c.OrgURL, projectID)
-	response, err := c.execRequest(http.MethodGet, URL, nil)
+// How a workflow was started, in the triggered_by field of a workflow.
+const (
+	WorkflowTriggeredByHook      = "HOOK"
+	WorkflowTriggeredBySchedule  = "SCHEDULE"
+	WorkflowTriggeredByAPI       = "API"
+	WorkflowTriggeredByManualRun = "MANUAL_RUN"
+)
+
+// workflowTriggers are the triggered_by values in the order of the Semaphore enum,
+// for responses that have the number instead of the name.
+var workflowTriggers = []string{
+	WorkflowTriggeredByHook,
+	WorkflowTriggeredBySchedule,
+	WorkflowTriggeredByAPI,
+	WorkflowTriggeredByManualRun,
+}
+
+// WorkflowTrigger is the triggered_by field of a workflow, which Semaphore sends as a name or a number.
+type WorkflowTrigger string
+
+func (t *WorkflowTrigger) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		if number < 0 || number >= len(workflowTriggers) {
+			return fmt.Errorf("unknown workflow trigger %d", number)
+		}
+
+		*t = WorkflowTrigger(workflowTriggers[number])
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("invalid workflow trigger: %s", string(data))
+	}
+
+	*t = WorkflowTrigger(strings.ToUpper(name))
+	return nil
+}
+
+type WorkflowResponse struct {
+	Workflow *Workflow `json:"workflow"`
+}
+
+type Workflow struct {
+	WorkflowID        string          `json:"wf_id"`
+	InitialPipelineID string          `json:"initial_ppl_id"`
+	ProjectID         string          `json:"project_id"`
+	BranchName        string          `json:"branch_name"`
+	CommitSHA         string          `json:"commit_sha"`
+	TriggeredBy       WorkflowTrigger `json:"triggered_by"`
+}
+
+// IsScheduled tells if the workflow was started by a task.
+func (w *Workflow) IsScheduled() bool {
+	return w.TriggeredBy == WorkflowTriggeredBySchedule
+}
+
+func (c *Client) GetWorkflow(id string) (*Workflow, error) {
+	URL := fmt.Sprintf("%s/api/v1alpha/plumber-workflows/%s", c.OrgURL, id)
+	responseBody, err := c.execRequest(http.MethodGet, URL, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var pipelines []any
-	err = json.Unmarshal(response, &pipelines)
+	var workflowResponse WorkflowResponse
+	err = json.Unmarshal(responseBody, &workflowResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %v", err)
+	}
+
+	if workflowResponse.Workflow == nil {
+		return nil, fmt.Errorf("workflow %s not found in response", id)
+	}
+
+	return workflowResponse.Workflow, nil
+}
+
+// StopPipeline asks Semaphore to terminate a running pipeline.
+func (c *Client) StopPipeline(id string) error {
+	URL := fmt.Sprintf("%s/api/v1alpha/pipelines/%s", c.OrgURL, id)
+	body, err := json.Marshal(map[string]any{"terminate_request": true})
+	if err != nil {
+		return fmt.Errorf("error marshaling stop pipeline params: %v", err)
+	}
+
+	_, err = c.execRequest(http.MethodPatch, URL, bytes.NewReader(body))
+	return err
+}
+
+// How a pipeline is run again. A partial rebuild only reruns the failed blocks of the pipeline,
+// and a full rerun reschedules its whole workflow.
+const (
+	RerunModePartial = "partial"
+	RerunModeFull    = "full"
+)
+
+type RerunPipelineResponse struct {
+	PipelineID string `json:"ppl_id"`
+	WorkflowID string `json:"wf_id"`
+}
+
+// RerunPipeline runs a finished pipeline again. The request token makes retried requests
+// for the same rerun start a single pipeline.
+func (c *Client) RerunPipeline(pipeline *Pipeline, mode, requestToken string) (*RerunPipelineResponse, error) {
+	query := url.Values{}
+	query.Set("request_token", requestToken)
+
+	if mode == RerunModeFull {
+		URL := fmt.Sprintf("%s/api/v1alpha/workflows/%s/reschedule?%s", c.OrgURL, pipeline.WorkflowID, query.Encode())
+		responseBody, err := c.execRequest(http.MethodPost, URL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response RerunPipelineResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return nil, fmt.Errorf("error unmarshaling response: %v", err)
+		}
+
+		return &response, nil
+	}
+
+	URL := fmt.Sprintf("%s/api/v1alpha/pipelines/%s/partial_rebuild?%s", c.OrgURL, pipeline.PipelineID, query.Encode())
+	responseBody, err := c.execRequest(http.MethodPost, URL, nil)
 	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		PipelineID string `json:"pipeline_id"`
+	}
+
+	if err := json.Unmarshal(responseBody, &response); err != nil {
 		return nil, fmt.Errorf("error unmarshaling response: %v", err)
 	}
 
+	return &RerunPipelineResponse{PipelineID: response.PipelineID, WorkflowID: pipeline.WorkflowID}, nil
+}
+
+// ListPipelinesParams filters the pipelines of a project. Empty fields are not filtered on.
+type ListPipelinesParams struct {
+	BranchName    string
+	YAMLFilePath  string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	DoneAfter     time.Time
+	DoneBefore    time.Time
+
+	// Limit is the most pipelines returned, defaultPageSize when it is not set.
+	// Semaphore lists the most recent pipelines first.
+	Limit int
+}
+
+func (p ListPipelinesParams) query(projectID string, page, pageSize int) url.Values {
+	query := url.Values{}
+	query.Set("project_id", projectID)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+
+	if p.BranchName != "" {
+		query.Set("branch_name", p.BranchName)
+	}
+
+	if p.YAMLFilePath != "" {
+		query.Set("yml_file_path", p.YAMLFilePath)
+	}
+
+	for name, value := range map[string]time.Time{
+		"created_after":  p.CreatedAfter,
+		"created_before": p.CreatedBefore,
+		"done_after":     p.DoneAfter,
+		"done_before":    p.DoneBefore,
+	} {
+		if !value.IsZero() {
+			query.Set(name, strconv.FormatInt(value.Unix(), 10))
+		}
+	}
+
+	return query
+}
+
+// ListPipelines returns the most recent pipelines of a project,
+// requesting pages until the limit of the params or the last page is reached.
+func (c *Client) ListPipelines(projectID string, params ListPipelinesParams) ([]Pipeline, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	pageSize := min(limit, defaultPageSize)
+	pipelines := []Pipeline{}
+
+	for page := 1; page <= maxListPages && len(pipelines) < limit; page++ {
+		URL := fmt.Sprintf("%s/api/v1alpha/pipelines?%s", c.OrgURL, params.query(projectID, page, pageSize).Encode())
+		responseBody, header, err := c.doRequest(http.MethodGet, URL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []Pipeline
+		err = json.Unmarshal(responseBody, &items)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling response: %v", err)
+		}
+
+		pipelines = append(pipelines, items...)
+		if !hasNextPage(header) || len(items) == 0 {
+			break
+		}
+	}
+
+	if len(pipelines) > limit {
+		pipelines = pipelines[:limit]
+	}
+
 	return pipelines, nil
 }
 
@@ -313,6 +589,8 @@ func (c *Client) DeleteNotification(id string) error {
 	return nil
 }
 
+const WebhookSecretEnvVar = "WEBHOOK_SECRET"
+
 type Secret struct {
 	APIVersion string         `json:"apiVersion"`
 	Kind       string         `json:"kind"`
@@ -334,6 +612,17 @@ type SecretSpecDataEnvVar struct {
 	Value string `json:"value"`
 }
 
+// WebhookSecretValue returns the value stored for the webhook signing key, if any.
+func (s *Secret) WebhookSecretValue() string {
+	for _, envVar := range s.Data.EnvVars {
+		if envVar.Name == WebhookSecretEnvVar {
+			return envVar.Value
+		}
+	}
+
+	return ""
+}
+
 func (c *Client) GetSecret(id string) (*Secret, error) {
 	URL := fmt.Sprintf("%s/api/v1beta/secrets/%s", c.OrgURL, id)
 	responseBody, err := c.execRequest(http.MethodGet, URL, nil)
@@ -362,7 +651,15 @@ func (c *Client) DeleteSecret(name string) error {
 
 func (c *Client) CreateWebhookSecret(name, key string) (*Secret, error) {
 	URL := fmt.Sprintf("%s/api/v1beta/secrets", c.OrgURL)
+	return c.writeWebhookSecret(http.MethodPost, URL, name, key)
+}
 
+func (c *Client) UpdateWebhookSecret(name, key string) (*Secret, error) {
+	URL := fmt.Sprintf("%s/api/v1beta/secrets/%s", c.OrgURL, name)
+	return c.writeWebhookSecret(http.MethodPut, URL, name, key)
+}
+
+func (c *Client) writeWebhookSecret(method, URL, name, key string) (*Secret, error) {
 	secret := &Secret{
 		APIVersion: "v1beta",
 		Kind:       "Secret",
@@ -370,7 +667,7 @@ func (c *Client) CreateWebhookSecret(name, key string) (*Secret, error) {
 		Data: SecretSpecData{
 			EnvVars: []SecretSpecDataEnvVar{
 				{
-					Name:  "WEBHOOK_SECRET",
+					Name:  WebhookSecretEnvVar,
 					Value: string(key),
 				},
 			},
@@ -382,7 +679,7 @@ func (c *Client) CreateWebhookSecret(name, key string) (*Secret, error) {
 		return nil, fmt.Errorf("error marshaling secret: %v", err)
 	}
 
-	responseBody, err := c.execRequest(http.MethodPost, URL, bytes.NewReader(body))
+	responseBody, err := c.execRequest(method, URL, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,228 @@
+package semaphore
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Client__Trace(t *testing.T) {
+	t.Run("tracing HTTP context -> records the request without the API token", func(t *testing.T) {
+		httpCtx := &contexts.TracingHTTPContext{
+			HTTPContext: contexts.HTTPContext{
+				Responses: []*http.Response{
+					{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"metadata":{"name":"hello","id":"p1"},"spec":{"token":"project-token"}}`)),
+					},
+				},
+			},
+		}
+
+		client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"organizationUrl": "https://example.semaphoreci.com",
+				"apiToken":        "token-123",
+			},
+		})
+		require.NoError(t, err)
+
+		project, err := client.GetProject("hello")
+		require.NoError(t, err)
+		assert.Equal(t, "p1", project.Metadata.ProjectID)
+
+		require.Len(t, httpCtx.Traces, 1)
+		assert.Equal(t, core.HTTPTrace{
+			Method:       http.MethodGet,
+			URL:          "https://example.semaphoreci.com/api/v1alpha/projects/hello",
+			Status:       http.StatusOK,
+			ResponseBody: `{"metadata":{"name":"hello","id":"p1"},"spec":{"token":"[REDACTED]"}}`,
+		}, httpCtx.Traces[0])
+	})
+}
+
+func newTestClient(t *testing.T, httpCtx core.HTTPContext) *Client {
+	client, err := NewClient(httpCtx, &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"organizationUrl": "https://example.semaphoreci.com",
+			"apiToken":        "token-123",
+		},
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func pageResponse(body string, next bool) *http.Response {
+	header := http.Header{}
+	if next {
+		header.Set("Link", `<https://example.semaphoreci.com/api/v1alpha/projects?page=1>; rel="first", <https://example.semaphoreci.com/api/v1alpha/projects?page=2>; rel="next"`)
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func Test__Client__ListProjects(t *testing.T) {
+	t.Run("several pages -> requests pages until there is no next page", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pageResponse(`[{"metadata":{"name":"api","id":"p1"},"spec":{"repository":{"url":"git@github.com:acme/api.git","name":"api","owner":"acme"}}}]`, true),
+				pageResponse(`[{"metadata":{"name":"web","id":"p2"}}]`, false),
+			},
+		}
+
+		projects, err := newTestClient(t, httpCtx).ListProjects()
+		require.NoError(t, err)
+
+		require.Len(t, projects, 2)
+		assert.Equal(t, "p1", projects[0].Metadata.ProjectID)
+		assert.Equal(t, "acme", projects[0].Spec.Repository.Owner)
+		assert.Equal(t, "web", projects[1].Metadata.ProjectName)
+
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects?page=1&page_size=100", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects?page=2&page_size=100", httpCtx.Requests[1].URL.String())
+	})
+
+	t.Run("project ID -> found on a later page", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pageResponse(`[{"metadata":{"name":"api","id":"5a5b1b04-3a8e-4c7a-9e46-3a3f1b2f0c01"}}]`, true),
+				pageResponse(`[{"metadata":{"name":"web","id":"5a5b1b04-3a8e-4c7a-9e46-3a3f1b2f0c02"}}]`, false),
+			},
+		}
+
+		project, err := newTestClient(t, httpCtx).GetProject("5a5b1b04-3a8e-4c7a-9e46-3a3f1b2f0c02")
+		require.NoError(t, err)
+		assert.Equal(t, "web", project.Metadata.ProjectName)
+	})
+
+	t.Run("failing page -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pageResponse(`[{"metadata":{"name":"api","id":"p1"}}]`, true),
+				{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(`oops`))},
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).ListProjects()
+		require.ErrorContains(t, err, "request got 500 code")
+	})
+}
+
+func Test__Client__ListPipelines(t *testing.T) {
+	t.Run("filters -> sent as query parameters", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pageResponse(`[{"ppl_id":"ppl-1","wf_id":"wf-1","name":"Build","state":"done","result":"passed","branch_name":"main"}]`, false),
+			},
+		}
+
+		pipelines, err := newTestClient(t, httpCtx).ListPipelines("p1", ListPipelinesParams{
+			BranchName:   "main",
+			YAMLFilePath: ".semaphore/semaphore.yml",
+			CreatedAfter: time.Unix(1768824000, 0),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, pipelines, 1)
+		assert.Equal(t, Pipeline{
+			PipelineID:   "ppl-1",
+			WorkflowID:   "wf-1",
+			PipelineName: "Build",
+			State:        "done",
+			Result:       "passed",
+			BranchName:   "main",
+		}, pipelines[0])
+
+		require.Len(t, httpCtx.Requests, 1)
+		query := httpCtx.Requests[0].URL.Query()
+		assert.Equal(t, "p1", query.Get("project_id"))
+		assert.Equal(t, "main", query.Get("branch_name"))
+		assert.Equal(t, ".semaphore/semaphore.yml", query.Get("yml_file_path"))
+		assert.Equal(t, "1768824000", query.Get("created_after"))
+		assert.Equal(t, "1", query.Get("page"))
+		assert.Equal(t, "100", query.Get("page_size"))
+		assert.False(t, query.Has("done_before"))
+	})
+
+	t.Run("limit -> stops requesting pages once reached", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pageResponse(`[{"ppl_id":"ppl-1"},{"ppl_id":"ppl-2"}]`, true),
+				pageResponse(`[{"ppl_id":"ppl-3"},{"ppl_id":"ppl-4"}]`, true),
+			},
+		}
+
+		pipelines, err := newTestClient(t, httpCtx).ListPipelines("p1", ListPipelinesParams{Limit: 3})
+		require.NoError(t, err)
+
+		require.Len(t, pipelines, 3)
+		assert.Equal(t, "ppl-3", pipelines[2].PipelineID)
+		require.Len(t, httpCtx.Requests, 2)
+		assert.Equal(t, "3", httpCtx.Requests[0].URL.Query().Get("page_size"))
+		assert.Equal(t, "2", httpCtx.Requests[1].URL.Query().Get("page"))
+	})
+}
+
+func Test__Client__GetWorkflow(t *testing.T) {
+	response := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	t.Run("triggered_by name -> scheduled workflow", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				response(`{"workflow":{"wf_id":"wf-1","initial_ppl_id":"ppl-1","branch_name":"main","triggered_by":"SCHEDULE"}}`),
+			},
+		}
+
+		workflow, err := newTestClient(t, httpCtx).GetWorkflow("wf-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/plumber-workflows/wf-1", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, "ppl-1", workflow.InitialPipelineID)
+		assert.True(t, workflow.IsScheduled())
+	})
+
+	t.Run("triggered_by number -> mapped to its name", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				response(`{"workflow":{"wf_id":"wf-1","triggered_by":2}}`),
+			},
+		}
+
+		workflow, err := newTestClient(t, httpCtx).GetWorkflow("wf-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, WorkflowTrigger(WorkflowTriggeredByAPI), workflow.TriggeredBy)
+		assert.False(t, workflow.IsScheduled())
+	})
+
+	t.Run("unknown triggered_by number -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				response(`{"workflow":{"wf_id":"wf-1","triggered_by":9}}`),
+			},
+		}
+
+		_, err := newTestClient(t, httpCtx).GetWorkflow("wf-1")
+		require.ErrorContains(t, err, "unknown workflow trigger 9")
+	})
+
+	t.Run("no workflow in response -> error", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{response(`{}`)},
+		}
+
+		_, err := newTestClient(t, httpCtx).GetWorkflow("wf-1")
+		require.ErrorContains(t, err, "workflow wf-1 not found in response")
+	})
+}
@@ -13,18 +13,48 @@ var exampleOutputRunWorkflowBytes []byte
 //go:embed example_data_on_pipeline_done.json
 var exampleDataOnPipelineDoneBytes []byte
 
+//go:embed example_data_on_pipeline_started.json
+var exampleDataOnPipelineStartedBytes []byte
+
+//go:embed example_data_on_task_done.json
+var exampleDataOnTaskDoneBytes []byte
+
+//go:embed example_data_on_block_done.json
+var exampleDataOnBlockDoneBytes []byte
+
 //go:embed example_output_get_pipeline.json
 var exampleOutputGetPipelineBytes []byte
 
+//go:embed example_output_stop_pipeline.json
+var exampleOutputStopPipelineBytes []byte
+
+//go:embed example_output_rerun_pipeline.json
+var exampleOutputRerunPipelineBytes []byte
+
 var exampleOutputOnce sync.Once
 var exampleOutput map[string]any
 
 var exampleDataOnce sync.Once
 var exampleData map[string]any
 
+var exampleDataOnPipelineStartedOnce sync.Once
+var exampleDataOnPipelineStarted map[string]any
+
+var exampleDataOnTaskDoneOnce sync.Once
+var exampleDataOnTaskDone map[string]any
+
+var exampleDataOnBlockDoneOnce sync.Once
+var exampleDataOnBlockDone map[string]any
+
 var exampleOutputGetPipelineOnce sync.Once
 var exampleOutputGetPipeline map[string]any
 
+var exampleOutputStopPipelineOnce sync.Once
+var exampleOutputStopPipeline map[string]any
+
+var exampleOutputRerunPipelineOnce sync.Once
+var exampleOutputRerunPipeline map[string]any
+
 func (c *RunWorkflow) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputOnce, exampleOutputRunWorkflowBytes, &exampleOutput)
 }
@@ -33,6 +63,26 @@ func (t *OnPipelineDone) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnce, exampleDataOnPipelineDoneBytes, &exampleData)
 }
 
+func (t *OnPipelineStarted) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPipelineStartedOnce, exampleDataOnPipelineStartedBytes, &exampleDataOnPipelineStarted)
+}
+
+func (t *OnBlockDone) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnBlockDoneOnce, exampleDataOnBlockDoneBytes, &exampleDataOnBlockDone)
+}
+
+func (t *OnTaskDone) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnTaskDoneOnce, exampleDataOnTaskDoneBytes, &exampleDataOnTaskDone)
+}
+
 func (c *GetPipeline) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetPipelineOnce, exampleOutputGetPipelineBytes, &exampleOutputGetPipeline)
 }
+
+func (c *StopPipeline) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputStopPipelineOnce, exampleOutputStopPipelineBytes, &exampleOutputStopPipeline)
+}
+
+func (c *RerunPipeline) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRerunPipelineOnce, exampleOutputRerunPipelineBytes, &exampleOutputRerunPipeline)
+}
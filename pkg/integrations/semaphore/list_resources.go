@@ -12,7 +12,7 @@ func (s *Semaphore) ListResources(resourceType string, ctx core.ListResourcesCon
 		return nil, err
 	}
 
-	projects, err := client.listProjects()
+	projects, err := client.ListProjects()
 	if err != nil {
 		return nil, err
 	}
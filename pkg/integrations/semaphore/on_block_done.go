@@ -0,0 +1,258 @@
+package semaphore
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnBlockDone struct{}
+
+type OnBlockDoneConfiguration struct {
+	Project string                    `json:"project" mapstructure:"project"`
+	Refs    []configuration.Predicate `json:"refs" mapstructure:"refs"`
+	Blocks  []configuration.Predicate `json:"blocks" mapstructure:"blocks"`
+	Results []string                  `json:"results" mapstructure:"results"`
+}
+
+func (b *OnBlockDone) Name() string {
+	return "semaphore.onBlockDone"
+}
+
+func (b *OnBlockDone) Label() string {
+	return "On Block Done"
+}
+
+func (b *OnBlockDone) Description() string {
+	return "Listen to Semaphore block done events"
+}
+
+func (b *OnBlockDone) Documentation() string {
+	return `The On Block Done trigger starts a workflow execution when a block of a Semaphore pipeline completes.
+
+## Use Cases
+
+- **Granular orchestration**: React to a specific block (for example ` + "`Tests`" + ` or ` + "`Build`" + `) instead of the whole pipeline
+- **Failure triage**: Notify the owners of a block only when that block fails
+- **Partial promotions**: Continue a workflow as soon as the blocks it depends on have passed
+
+## Configuration
+
+- **Project**: Select the Semaphore project to monitor
+- **Refs**: Optional ref filters (for example ` + "`refs/heads/main`" + `)
+- **Blocks**: Optional block name filters (for example ` + "`Tests`" + `)
+- **Results**: Optional block result filters (for example ` + "`passed`" + `, ` + "`failed`" + `)
+
+## Event Data
+
+The trigger listens to the pipeline done events of the project, the same events as On Pipeline Done. Semaphore only sends webhooks when a pipeline is done, so block completion is derived from the blocks in that payload. One event is emitted for each block that matches the filters. Each event includes:
+- **block**: Block information including name, state, result, and jobs
+- **pipeline**: Pipeline information including ID, state, and result
+- **workflow**: Workflow information including ID
+- **project**: Project information
+- **revision**: Revision information including the ref and commit
+
+## Webhook Setup
+
+This trigger automatically sets up a Semaphore webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (b *OnBlockDone) Icon() string {
+	return "workflow"
+}
+
+func (b *OnBlockDone) Color() string {
+	return "gray"
+}
+
+func (b *OnBlockDone) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "project",
+			Label:    "Project",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "project",
+					UseNameAsValue: true,
+				},
+			},
+		},
+		{
+			Name:     "blocks",
+			Label:    "Blocks",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+		{
+			Name:     "results",
+			Label:    "Results",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: false,
+			Default:  []string{"passed"},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: AllPipelineDoneResults,
+				},
+			},
+		},
+		{
+			Name:     "refs",
+			Label:    "Refs",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			Default:  []map[string]any{{"type": configuration.PredicateTypeEquals, "value": "refs/heads/main"}},
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (b *OnBlockDone) Setup(ctx core.TriggerContext) error {
+	var metadata OnPipelineDoneMetadata
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	//
+	// If metadata is set, it means the trigger was already setup
+	//
+	if metadata.Project != nil {
+		return nil
+	}
+
+	config := OnBlockDoneConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	return setupProjectWebhook(ctx, config.Project)
+}
+
+func (b *OnBlockDone) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (b *OnBlockDone) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (b *OnBlockDone) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	config := OnBlockDoneConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if code, err := verifyWebhookSignature(ctx, b.Name()); err != nil {
+		return code, err
+	}
+
+	payload := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &payload)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if len(config.Refs) > 0 {
+		ref, ok := getNestedString(payload, "revision", "reference")
+		if !ok || strings.TrimSpace(ref) == "" {
+			return http.StatusBadRequest, fmt.Errorf("missing revision.reference")
+		}
+
+		if !configuration.MatchesAnyPredicate(config.Refs, ref) {
+			ctx.Logger.Infof("ref %s does not match the allowed predicates: %v", ref, config.Refs)
+			return http.StatusOK, nil
+		}
+	}
+
+	blocks, ok := payload["blocks"].([]any)
+	if !ok {
+		return http.StatusBadRequest, fmt.Errorf("missing blocks")
+	}
+
+	for _, item := range blocks {
+		block, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if !b.matchesBlock(ctx, config, block) {
+			continue
+		}
+
+		//
+		// Each event carries the pipeline context plus the single block that completed,
+		// so the other blocks are dropped from the emitted payload.
+		//
+		event := maps.Clone(payload)
+		delete(event, "blocks")
+		event["block"] = block
+
+		err = ctx.Events.Emit("semaphore.block.done", event)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("error emitting event: %v", err)
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+func (b *OnBlockDone) matchesBlock(ctx core.WebhookRequestContext, config OnBlockDoneConfiguration, block map[string]any) bool {
+	name, _ := block["name"].(string)
+
+	//
+	// Blocks that never ran (for example, because a dependency failed)
+	// are not reported as done.
+	//
+	state, _ := block["state"].(string)
+	if state != "done" {
+		ctx.Logger.Infof("block %s is not done (state: %s)", name, state)
+		return false
+	}
+
+	if len(config.Blocks) > 0 && !configuration.MatchesAnyPredicate(config.Blocks, name) {
+		ctx.Logger.Infof("block %s does not match the allowed predicates: %v", name, config.Blocks)
+		return false
+	}
+
+	if len(config.Results) > 0 {
+		result, _ := block["result"].(string)
+		if !matchesPipelineResult(config.Results, result) {
+			ctx.Logger.Infof("block %s result %s does not match the allowed results: %v", name, result, config.Results)
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *OnBlockDone) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (b *OnBlockDone) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
@@ -0,0 +1,192 @@
+package semaphore
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnBlockDone__HandleWebhook(t *testing.T) {
+	trigger := &OnBlockDone{}
+	logger := logrus.NewEntry(logrus.New())
+	secret := "test-secret"
+	body := []byte(`{
+		"revision": {"reference": "refs/heads/main"},
+		"pipeline": {"id": "ppl-1", "state": "done", "result": "failed"},
+		"blocks": [
+			{"name": "Build", "state": "done", "result": "passed"},
+			{"name": "Tests", "state": "done", "result": "failed"},
+			{"name": "Deploy", "state": "waiting", "result": ""}
+		]
+	}`)
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-Semaphore-Signature-256", "sha256=invalidsignature")
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: headers,
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  &contexts.EventContext{},
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("no filters -> one event per done block", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(secret, body),
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 2, eventContext.Count())
+		assert.Equal(t, "semaphore.block.done", eventContext.Payloads[0].Type)
+
+		event := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "Build", event["block"].(map[string]any)["name"])
+		assert.Equal(t, "ppl-1", event["pipeline"].(map[string]any)["id"])
+		assert.NotContains(t, event, "blocks")
+		assert.Equal(t, "Tests", eventContext.Payloads[1].Data.(map[string]any)["block"].(map[string]any)["name"])
+	})
+
+	t.Run("block name filter -> only matching block is emitted", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(secret, body),
+			Configuration: map[string]any{
+				"blocks": []configuration.Predicate{
+					{Type: configuration.PredicateTypeEquals, Value: "Tests"},
+				},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "Tests", eventContext.Payloads[0].Data.(map[string]any)["block"].(map[string]any)["name"])
+	})
+
+	t.Run("block name and result mismatch -> event is ignored", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(secret, body),
+			Configuration: map[string]any{
+				"blocks": []configuration.Predicate{
+					{Type: configuration.PredicateTypeEquals, Value: "Tests"},
+				},
+				"results": []string{"passed"},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, eventContext.Count())
+	})
+
+	t.Run("result filter -> only blocks with matching result are emitted", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(secret, body),
+			Configuration: map[string]any{
+				"results": []string{"failed"},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "Tests", eventContext.Payloads[0].Data.(map[string]any)["block"].(map[string]any)["name"])
+	})
+
+	t.Run("ref filter mismatch -> event is ignored", func(t *testing.T) {
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(secret, body),
+			Configuration: map[string]any{
+				"refs": []configuration.Predicate{
+					{Type: configuration.PredicateTypeEquals, Value: "refs/heads/release"},
+				},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, eventContext.Count())
+	})
+
+	t.Run("missing blocks -> 400", func(t *testing.T) {
+		body := []byte(`{"pipeline":{"state":"done","result":"passed"}}`)
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(secret, body),
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  &contexts.EventContext{},
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.ErrorContains(t, err, "missing blocks")
+	})
+}
+
+func Test__OnBlockDone__Setup(t *testing.T) {
+	trigger := OnBlockDone{}
+
+	t.Run("project is required", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: OnBlockDoneConfiguration{Project: ""},
+		})
+
+		require.ErrorContains(t, err, "project is required")
+	})
+
+	t.Run("metadata already set -> returns early", func(t *testing.T) {
+		testProject := &Project{ID: "proj-123", Name: "test-project", URL: "https://example.semaphoreci.com/projects/proj-123"}
+		metadataCtx := &contexts.MetadataContext{
+			Metadata: OnPipelineDoneMetadata{Project: testProject},
+		}
+
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      metadataCtx,
+			Configuration: OnBlockDoneConfiguration{Project: "test-project"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, testProject, metadataCtx.Get().(OnPipelineDoneMetadata).Project)
+	})
+}
@@ -1,16 +1,19 @@
 package semaphore
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 )
 
 type OnPipelineDone struct{}
@@ -31,8 +34,16 @@ type OnPipelineDoneConfiguration struct {
 	Refs      []configuration.Predicate `json:"refs" mapstructure:"refs"`
 	Results   []string                  `json:"results" mapstructure:"results"`
 	Pipelines []configuration.Predicate `json:"pipelines" mapstructure:"pipelines"`
+
+	EventTypePrefix string                    `json:"eventTypePrefix" mapstructure:"eventTypePrefix"`
+	OutputMapping   []core.OutputMappingEntry `json:"outputMapping" mapstructure:"outputMapping"`
 }
 
+const (
+	pipelineDoneEventType       = "semaphore.pipeline.done"
+	pipelineDoneEventTypePrefix = "semaphore.pipeline"
+)
+
 func (p *OnPipelineDone) Name() string {
 	return "semaphore.onPipelineDone"
 }
@@ -61,6 +72,8 @@ func (p *OnPipelineDone) Documentation() string {
 - **Refs**: Optional ref filters (for example ` + "`refs/heads/main`" + `)
 - **Results**: Optional pipeline result filters (for example ` + "`passed`" + `, ` + "`failed`" + `)
 - **Pipelines**: Optional pipeline file filters (for example ` + "`.semaphore/semaphore.yml`" + `, ` + "`.semaphore/production/deploy.yml`" + `)
+- **Event Type Prefix**: Optionally replace ` + "`semaphore.pipeline`" + ` in the event type, for example with ` + "`payments.pipeline`" + ` to namespace events by team. The original type is kept in the ` + "`canonicalType`" + ` field of the payload.
+- **Output Mapping**: Optionally emit only the listed fields, each set to an expression on the pipeline done payload, available as ` + "`$`" + `. For example, ` + "`result`" + ` set to ` + "`$.pipeline.result`" + ` and ` + "`branch`" + ` set to ` + "`$.revision.branch?.name`" + ` emits just those two fields.
 
 ## Event Data
 
@@ -134,12 +147,28 @@ func (p *OnPipelineDone) Configuration() []configuration.Field {
 				},
 			},
 		},
+		core.EventTypePrefixConfiguration(pipelineDoneEventTypePrefix),
+		core.OutputMappingConfiguration(),
 	}
 }
 
 func (p *OnPipelineDone) Setup(ctx core.TriggerContext) error {
+	config := OnPipelineDoneConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := core.ValidateEventTypePrefix(config.EventTypePrefix); err != nil {
+		return err
+	}
+
+	if err := core.ValidateOutputMapping(config.OutputMapping); err != nil {
+		return err
+	}
+
 	var metadata OnPipelineDoneMetadata
-	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	err = mapstructure.Decode(ctx.Metadata.Get(), &metadata)
 	if err != nil {
 		return fmt.Errorf("failed to parse metadata: %w", err)
 	}
@@ -151,31 +180,88 @@ func (p *OnPipelineDone) Setup(ctx core.TriggerContext) error {
 		return nil
 	}
 
+	if config.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	return setupProjectWebhook(ctx, config.Project)
+}
+
+func (p *OnPipelineDone) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (p *OnPipelineDone) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPipelineDone) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
 	config := OnPipelineDoneConfiguration{}
-	err = mapstructure.Decode(ctx.Configuration, &config)
+	err := mapstructure.Decode(ctx.Configuration, &config)
 	if err != nil {
-		return fmt.Errorf("failed to decode configuration: %w", err)
+		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
-	if config.Project == "" {
-		return fmt.Errorf("project is required")
+	if code, err := verifyWebhookSignature(ctx, p.Name()); err != nil {
+		return code, err
 	}
 
-	//
-	// If this is the same project, nothing to do.
-	//
-	if metadata.Project != nil && (config.Project == metadata.Project.ID || config.Project == metadata.Project.Name) {
-		return nil
+	payload := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &payload)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if matches, err := matchesRefFilter(ctx, config.Refs, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	if matches, err := matchesResultFilter(ctx, config.Results, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	if matches, err := matchesPipelineFileFilter(ctx, config.Pipelines, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	payload, err = core.ApplyOutputMapping(payload, config.OutputMapping)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error applying output mapping: %v", err)
+	}
+
+	eventType := core.ApplyEventTypePrefix(pipelineDoneEventType, pipelineDoneEventTypePrefix, config.EventTypePrefix)
+	if eventType != pipelineDoneEventType {
+		payload[core.CanonicalTypeField] = pipelineDoneEventType
 	}
 
+	err = ctx.Events.Emit(eventType, payload)
+
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+func (p *OnPipelineDone) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (p *OnPipelineDone) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+// setupProjectWebhook stores the project in the trigger metadata
+// and requests the project webhook used by the Semaphore triggers.
+func setupProjectWebhook(ctx core.TriggerContext, projectName string) error {
 	client, err := NewClient(ctx.HTTP, ctx.Integration)
 	if err != nil {
 		return err
 	}
 
-	project, err := client.GetProject(config.Project)
+	project, err := client.GetProject(projectName)
 	if err != nil {
-		return fmt.Errorf("error finding project %s: %v", config.Project, err)
+		return fmt.Errorf("error finding project %s: %v", projectName, err)
 	}
 
 	err = ctx.Metadata.Set(OnPipelineDoneMetadata{
@@ -195,28 +281,13 @@ func (p *OnPipelineDone) Setup(ctx core.TriggerContext) error {
 	})
 }
 
-func (p *OnPipelineDone) Actions() []core.Action {
-	return []core.Action{}
-}
-
-func (p *OnPipelineDone) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
-	return nil, nil
-}
-
-func (p *OnPipelineDone) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
-	config := OnPipelineDoneConfiguration{}
-	err := mapstructure.Decode(ctx.Configuration, &config)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
-	}
-
+// verifyWebhookSignature checks the X-Semaphore-Signature-256 header against the webhook secret,
+// and records the outcome for the given trigger or component.
+func verifyWebhookSignature(ctx core.WebhookRequestContext, name string) (int, error) {
 	signature := ctx.Headers.Get("X-Semaphore-Signature-256")
-	if signature == "" {
-		return http.StatusForbidden, fmt.Errorf("invalid signature")
-	}
-
 	signature = strings.TrimPrefix(signature, "sha256=")
 	if signature == "" {
+		telemetry.RecordWebhookSignatureVerification(context.Background(), name, telemetry.SignatureVerificationMissing)
 		return http.StatusForbidden, fmt.Errorf("invalid signature")
 	}
 
@@ -226,68 +297,97 @@ func (p *OnPipelineDone) HandleWebhook(ctx core.WebhookRequestContext) (int, err
 	}
 
 	if err := crypto.VerifySignature(secret, ctx.Body, signature); err != nil {
+		telemetry.RecordWebhookSignatureVerification(context.Background(), name, telemetry.SignatureVerificationMismatch)
 		return http.StatusForbidden, fmt.Errorf("invalid signature")
 	}
 
-	payload := map[string]any{}
-	err = json.Unmarshal(ctx.Body, &payload)
-	if err != nil {
-		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	telemetry.RecordWebhookSignatureVerification(context.Background(), name, telemetry.SignatureVerificationPass)
+	return http.StatusOK, nil
+}
+
+// matchesRefFilter checks the revision reference of a pipeline payload against the ref filters.
+// It returns an error when there are filters and the payload has no reference.
+func matchesRefFilter(ctx core.WebhookRequestContext, refs []configuration.Predicate, payload map[string]any) (bool, error) {
+	if len(refs) == 0 {
+		return true, nil
 	}
 
-	if len(config.Refs) > 0 {
-		ref, ok := getNestedString(payload, "revision", "reference")
-		if !ok || strings.TrimSpace(ref) == "" {
-			return http.StatusBadRequest, fmt.Errorf("missing revision.reference")
-		}
+	ref, ok := getNestedString(payload, "revision", "reference")
+	if !ok || strings.TrimSpace(ref) == "" {
+		return false, fmt.Errorf("missing revision.reference")
+	}
 
-		if !configuration.MatchesAnyPredicate(config.Refs, ref) {
-			ctx.Logger.Infof("ref %s does not match the allowed predicates: %v", ref, config.Refs)
-			return http.StatusOK, nil
-		}
+	if !configuration.MatchesAnyPredicate(refs, ref) {
+		ctx.Logger.Infof("ref %s does not match the allowed predicates: %v", ref, refs)
+		return false, nil
 	}
 
-	if len(config.Results) > 0 {
-		result, ok := getNestedString(payload, "pipeline", "result")
-		if !ok || strings.TrimSpace(result) == "" {
-			return http.StatusBadRequest, fmt.Errorf("missing pipeline.result")
-		}
+	return true, nil
+}
 
-		if !matchesPipelineResult(config.Results, result) {
-			ctx.Logger.Infof("result %s does not match the allowed predicates: %v", result, config.Results)
-			return http.StatusOK, nil
-		}
+// matchesResultFilter checks the result of a pipeline payload against the allowed results.
+func matchesResultFilter(ctx core.WebhookRequestContext, results []string, payload map[string]any) (bool, error) {
+	if len(results) == 0 {
+		return true, nil
 	}
 
-	if len(config.Pipelines) > 0 {
-		workingDirectory, ok := getNestedString(payload, "pipeline", "working_directory")
-		if !ok || strings.TrimSpace(workingDirectory) == "" {
-			return http.StatusBadRequest, fmt.Errorf("missing pipeline.working_directory")
-		}
+	result, ok := getNestedString(payload, "pipeline", "result")
+	if !ok || strings.TrimSpace(result) == "" {
+		return false, fmt.Errorf("missing pipeline.result")
+	}
 
-		pipelineFile, ok := getNestedString(payload, "pipeline", "yaml_file_name")
-		if !ok || strings.TrimSpace(pipelineFile) == "" {
-			return http.StatusBadRequest, fmt.Errorf("missing pipeline.yaml_file_name")
-		}
+	if !matchesPipelineResult(results, result) {
+		ctx.Logger.Infof("result %s does not match the allowed predicates: %v", result, results)
+		return false, nil
+	}
 
-		pipelinePath := fmt.Sprintf("%s/%s", workingDirectory, pipelineFile)
-		if !configuration.MatchesAnyPredicate(config.Pipelines, pipelinePath) {
-			ctx.Logger.Infof("pipeline file %s does not match the allowed predicates: %v", pipelinePath, config.Pipelines)
-			return http.StatusOK, nil
-		}
+	return true, nil
+}
+
+// matchesPipelineFileFilter checks the pipeline file of a pipeline payload, such as
+// .semaphore/semaphore.yml, against the pipeline filters.
+func matchesPipelineFileFilter(ctx core.WebhookRequestContext, pipelines []configuration.Predicate, payload map[string]any) (bool, error) {
+	if len(pipelines) == 0 {
+		return true, nil
+	}
+
+	workingDirectory, ok := getNestedString(payload, "pipeline", "working_directory")
+	if !ok || strings.TrimSpace(workingDirectory) == "" {
+		return false, fmt.Errorf("missing pipeline.working_directory")
+	}
+
+	pipelineFile, ok := getNestedString(payload, "pipeline", "yaml_file_name")
+	if !ok || strings.TrimSpace(pipelineFile) == "" {
+		return false, fmt.Errorf("missing pipeline.yaml_file_name")
+	}
+
+	pipelinePath := fmt.Sprintf("%s/%s", workingDirectory, pipelineFile)
+	if !configuration.MatchesAnyPredicate(pipelines, pipelinePath) {
+		ctx.Logger.Infof("pipeline file %s does not match the allowed predicates: %v", pipelinePath, pipelines)
+		return false, nil
 	}
 
-	err = ctx.Events.Emit("semaphore.pipeline.done", payload)
+	return true, nil
+}
 
+// webhookFilterStatus is the response status of a webhook that did not pass the filters:
+// payloads that are filtered out are acknowledged, and payloads missing a filtered field are rejected.
+func webhookFilterStatus(err error) int {
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("error emitting event: %v", err)
+		return http.StatusBadRequest
 	}
 
-	return http.StatusOK, nil
+	return http.StatusOK
 }
 
-func (p *OnPipelineDone) Cleanup(ctx core.TriggerContext) error {
-	return nil
+// appendRecent appends an ID to a list kept in metadata, dropping the oldest IDs over the limit.
+func appendRecent(ids []string, id string, limit int) []string {
+	ids = append(ids, id)
+	if len(ids) > limit {
+		ids = ids[len(ids)-limit:]
+	}
+
+	return ids
 }
 
 func getNestedString(payload map[string]any, keys ...string) (string, bool) {
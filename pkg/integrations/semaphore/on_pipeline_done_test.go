@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/telemetry"
 	contexts "github.com/superplanehq/superplane/test/support/contexts"
 )
 
@@ -82,6 +83,88 @@ func Test__OnPipelineDone__HandleWebhook(t *testing.T) {
 		assert.Equal(t, "semaphore.pipeline.done", eventContext.Payloads[0].Type)
 	})
 
+	t.Run("custom event type prefix -> prefix replaced and canonical type kept", func(t *testing.T) {
+		secret := "test-secret"
+		body := []byte(`{"revision":{"reference":"refs/heads/main"},"pipeline":{"state":"done","result":"passed","yaml_file_name":"semaphore.yml"}}`)
+		headers := buildSemaphoreHeaders(secret, body)
+
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       headers,
+			Configuration: map[string]any{"eventTypePrefix": "payments.pipeline"},
+			Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+			Events:        eventContext,
+			Logger:        logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "payments.pipeline.done", eventContext.Payloads[0].Type)
+		payload := eventContext.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "semaphore.pipeline.done", payload[core.CanonicalTypeField])
+	})
+
+	t.Run("output mapping -> fields renamed and the others dropped", func(t *testing.T) {
+		secret := "test-secret"
+		body := []byte(`{"revision":{"reference":"refs/heads/main"},"pipeline":{"id":"ppl-1","state":"done","result":"passed","yaml_file_name":"semaphore.yml"},"workflow":{"id":"wf-1"}}`)
+		headers := buildSemaphoreHeaders(secret, body)
+
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: headers,
+			Configuration: map[string]any{
+				"outputMapping": []map[string]any{
+					{"field": "result", "expression": "$.pipeline.result"},
+					{"field": "ref", "expression": "$.revision.reference"},
+					{"field": "passed", "expression": `$.pipeline.result == "passed"`},
+				},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, "semaphore.pipeline.done", eventContext.Payloads[0].Type)
+		assert.Equal(t, map[string]any{
+			"result": "passed",
+			"ref":    "refs/heads/main",
+			"passed": true,
+		}, eventContext.Payloads[0].Data)
+	})
+
+	t.Run("output mapping with custom event type prefix -> canonical type kept", func(t *testing.T) {
+		secret := "test-secret"
+		body := []byte(`{"pipeline":{"state":"done","result":"passed"}}`)
+		headers := buildSemaphoreHeaders(secret, body)
+
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: headers,
+			Configuration: map[string]any{
+				"eventTypePrefix": "payments.pipeline",
+				"outputMapping":   []map[string]any{{"field": "result", "expression": "$.pipeline.result"}},
+			},
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  eventContext,
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		require.Equal(t, 1, eventContext.Count())
+		assert.Equal(t, map[string]any{
+			"result":                "passed",
+			core.CanonicalTypeField: "semaphore.pipeline.done",
+		}, eventContext.Payloads[0].Data)
+	})
+
 	t.Run("invalid JSON body -> 400", func(t *testing.T) {
 		body := []byte(`invalid json`)
 
@@ -252,6 +335,45 @@ func Test__OnPipelineDone__Setup(t *testing.T) {
 		assert.Equal(t, testProject, metadata.Project)
 	})
 
+	t.Run("invalid event type prefix -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: OnPipelineDoneConfiguration{Project: "test-project", EventTypePrefix: "payments pipeline"},
+		})
+
+		require.ErrorContains(t, err, "invalid event type prefix")
+	})
+
+	t.Run("invalid output mapping -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: OnPipelineDoneConfiguration{
+				Project:       "test-project",
+				OutputMapping: []core.OutputMappingEntry{{Field: "result", Expression: "$.pipeline.result =="}},
+			},
+		})
+
+		require.ErrorContains(t, err, "output mapping field result: invalid expression")
+	})
+
+	t.Run("duplicate output mapping field -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration: &contexts.IntegrationContext{},
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: OnPipelineDoneConfiguration{
+				Project: "test-project",
+				OutputMapping: []core.OutputMappingEntry{
+					{Field: "result", Expression: "$.pipeline.result"},
+					{Field: "result", Expression: "$.pipeline.state"},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "output mapping field result is set more than once")
+	})
+
 	t.Run("invalid configuration -> decode error", func(t *testing.T) {
 		integrationCtx := &contexts.IntegrationContext{}
 		err := trigger.Setup(core.TriggerContext{
@@ -274,3 +396,44 @@ func buildSemaphoreHeaders(secret string, body []byte) http.Header {
 
 	return headers
 }
+
+func Test__OnPipelineDone__SignatureVerificationMetrics(t *testing.T) {
+	trigger := &OnPipelineDone{}
+	logger := logrus.NewEntry(logrus.New())
+	secret := "test-secret"
+	body := []byte(`{"revision":{"reference":"refs/heads/main"},"pipeline":{"state":"done","result":"passed"}}`)
+
+	count := func(outcome string) int64 {
+		return telemetry.WebhookSignatureVerificationCount(trigger.Name(), outcome)
+	}
+
+	missing := count(telemetry.SignatureVerificationMissing)
+	mismatch := count(telemetry.SignatureVerificationMismatch)
+	pass := count(telemetry.SignatureVerificationPass)
+
+	_, _ = trigger.HandleWebhook(core.WebhookRequestContext{Headers: http.Header{}, Logger: logger})
+
+	wrongHeaders := http.Header{}
+	wrongHeaders.Set("X-Semaphore-Signature-256", "sha256=invalidsignature")
+	_, _ = trigger.HandleWebhook(core.WebhookRequestContext{
+		Body:    body,
+		Headers: wrongHeaders,
+		Webhook: &contexts.NodeWebhookContext{Secret: secret},
+		Events:  &contexts.EventContext{},
+		Logger:  logger,
+	})
+
+	code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+		Body:    body,
+		Headers: buildSemaphoreHeaders(secret, body),
+		Webhook: &contexts.NodeWebhookContext{Secret: secret},
+		Events:  &contexts.EventContext{},
+		Logger:  logger,
+	})
+
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, err)
+	assert.Equal(t, missing+1, count(telemetry.SignatureVerificationMissing))
+	assert.Equal(t, mismatch+1, count(telemetry.SignatureVerificationMismatch))
+	assert.Equal(t, pass+1, count(telemetry.SignatureVerificationPass))
+}
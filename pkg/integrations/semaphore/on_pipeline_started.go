@@ -0,0 +1,235 @@
+package semaphore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	pipelineStartedEventType = "semaphore.pipeline.started"
+	pipelineRunningState     = "running"
+
+	// maxStartedPipelines is how many pipeline IDs are kept to avoid emitting a pipeline twice.
+	maxStartedPipelines = 100
+)
+
+type OnPipelineStarted struct{}
+
+type OnPipelineStartedMetadata struct {
+	Project *Project `json:"project"`
+
+	//
+	// StartedPipelines are the IDs of the last pipelines a started event was emitted for,
+	// so a pipeline is emitted once even if Semaphore sends several running events for it.
+	//
+	StartedPipelines []string `json:"startedPipelines,omitempty"`
+}
+
+type OnPipelineStartedConfiguration struct {
+	Project   string                    `json:"project" mapstructure:"project"`
+	Refs      []configuration.Predicate `json:"refs" mapstructure:"refs"`
+	Pipelines []configuration.Predicate `json:"pipelines" mapstructure:"pipelines"`
+}
+
+func (p *OnPipelineStarted) Name() string {
+	return "semaphore.onPipelineStarted"
+}
+
+func (p *OnPipelineStarted) Label() string {
+	return "On Pipeline Started"
+}
+
+func (p *OnPipelineStarted) Description() string {
+	return "Listen to Semaphore pipeline started events"
+}
+
+func (p *OnPipelineStarted) Documentation() string {
+	return `The On Pipeline Started trigger starts a workflow execution when a Semaphore pipeline starts running.
+
+## Use Cases
+
+- **Monitoring setup**: Start watching dashboards or error rates as soon as a deployment pipeline starts
+- **Status reporting**: Report that a pipeline is in progress to chat or to a status page
+- **Orchestration**: Prepare resources that a running pipeline depends on
+
+## Configuration
+
+- **Project**: Select the Semaphore project to monitor
+- **Refs**: Optional ref filters (for example ` + "`refs/heads/main`" + `)
+- **Pipelines**: Optional pipeline file filters (for example ` + "`.semaphore/semaphore.yml`" + `, ` + "`.semaphore/production/deploy.yml`" + `)
+
+## Event Data
+
+The trigger listens to the same webhook as On Pipeline Done, and only emits the events of pipelines in the ` + "`running`" + ` state. Each pipeline is emitted once, even if Semaphore reports it as running more than once. Each event includes:
+- **pipeline**: Pipeline information including ID, state, and when it started running
+- **workflow**: Workflow information including ID
+- **project**: Project information
+- **revision**: Revision information including the ref and commit
+
+## Webhook Setup
+
+This trigger automatically sets up a Semaphore webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (p *OnPipelineStarted) Icon() string {
+	return "workflow"
+}
+
+func (p *OnPipelineStarted) Color() string {
+	return "gray"
+}
+
+func (p *OnPipelineStarted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "project",
+			Label:    "Project",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "project",
+					UseNameAsValue: true,
+				},
+			},
+		},
+		{
+			Name:     "refs",
+			Label:    "Refs",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			Default:  []map[string]any{{"type": configuration.PredicateTypeEquals, "value": "refs/heads/main"}},
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+		{
+			Name:     "pipelines",
+			Label:    "Pipelines",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			Default:  []map[string]any{{"type": configuration.PredicateTypeEquals, "value": ".semaphore/semaphore.yml"}},
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPipelineStarted) Setup(ctx core.TriggerContext) error {
+	var metadata OnPipelineStartedMetadata
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	//
+	// If metadata is set, it means the trigger was already setup
+	//
+	if metadata.Project != nil {
+		return nil
+	}
+
+	config := OnPipelineStartedConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	return setupProjectWebhook(ctx, config.Project)
+}
+
+func (p *OnPipelineStarted) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (p *OnPipelineStarted) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPipelineStarted) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	config := OnPipelineStartedConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if code, err := verifyWebhookSignature(ctx, p.Name()); err != nil {
+		return code, err
+	}
+
+	payload := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &payload)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	state, _ := getNestedString(payload, "pipeline", "state")
+	if state != pipelineRunningState {
+		ctx.Logger.Infof("pipeline is not running (state: %s)", state)
+		return http.StatusOK, nil
+	}
+
+	pipelineID, ok := getNestedString(payload, "pipeline", "id")
+	if !ok || strings.TrimSpace(pipelineID) == "" {
+		return http.StatusBadRequest, fmt.Errorf("missing pipeline.id")
+	}
+
+	if matches, err := matchesRefFilter(ctx, config.Refs, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	if matches, err := matchesPipelineFileFilter(ctx, config.Pipelines, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	var metadata OnPipelineStartedMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	if slices.Contains(metadata.StartedPipelines, pipelineID) {
+		ctx.Logger.Infof("pipeline %s was already emitted as started", pipelineID)
+		return http.StatusOK, nil
+	}
+
+	err = ctx.Events.Emit(pipelineStartedEventType, payload)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	//
+	// The pipeline is only recorded once its event is emitted,
+	// so a webhook that failed to emit is emitted when Semaphore redelivers it.
+	//
+	metadata.StartedPipelines = appendRecent(metadata.StartedPipelines, pipelineID, maxStartedPipelines)
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+func (p *OnPipelineStarted) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (p *OnPipelineStarted) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
@@ -0,0 +1,223 @@
+package semaphore
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type failingEventContext struct{}
+
+func (e *failingEventContext) Emit(payloadType string, payload any) error {
+	return errors.New("oops")
+}
+
+func pipelineStartedBody(pipelineID, state string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"revision": {"reference": "refs/heads/main"},
+		"pipeline": {"id": %q, "state": %q, "working_directory": ".semaphore", "yaml_file_name": "deploy.yml"}
+	}`, pipelineID, state))
+}
+
+func Test__OnPipelineStarted__HandleWebhook(t *testing.T) {
+	trigger := &OnPipelineStarted{}
+	logger := logrus.NewEntry(logrus.New())
+	secret := "test-secret"
+
+	handle := func(body []byte, config OnPipelineStartedConfiguration, metadata *contexts.MetadataContext, events *contexts.EventContext) (int, error) {
+		return trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       buildSemaphoreHeaders(secret, body),
+			Configuration: config,
+			Metadata:      metadata,
+			Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+			Events:        events,
+			Logger:        logger,
+		})
+	}
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-Semaphore-Signature-256", "sha256=invalidsignature")
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    pipelineStartedBody("ppl-1", "running"),
+			Headers: headers,
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  &contexts.EventContext{},
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("running pipeline -> started event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		metadata := &contexts.MetadataContext{}
+		code, err := handle(pipelineStartedBody("ppl-1", "running"), OnPipelineStartedConfiguration{}, metadata, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "semaphore.pipeline.started", events.Payloads[0].Type)
+		assert.Equal(t, []string{"ppl-1"}, metadata.Get().(OnPipelineStartedMetadata).StartedPipelines)
+	})
+
+	t.Run("done pipeline -> event is ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, err := handle(pipelineStartedBody("ppl-1", "done"), OnPipelineStartedConfiguration{}, &contexts.MetadataContext{}, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("same pipeline running twice -> emitted once", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		metadata := &contexts.MetadataContext{}
+
+		for range 2 {
+			code, err := handle(pipelineStartedBody("ppl-1", "running"), OnPipelineStartedConfiguration{}, metadata, events)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, code)
+		}
+
+		_, err := handle(pipelineStartedBody("ppl-2", "running"), OnPipelineStartedConfiguration{}, metadata, events)
+		require.NoError(t, err)
+		assert.Equal(t, 2, events.Count())
+	})
+
+	t.Run("project metadata -> kept when the pipeline is recorded", func(t *testing.T) {
+		project := &Project{ID: "proj-123", Name: "test-project"}
+		metadata := &contexts.MetadataContext{Metadata: OnPipelineDoneMetadata{Project: project}}
+		_, err := handle(pipelineStartedBody("ppl-1", "running"), OnPipelineStartedConfiguration{}, metadata, &contexts.EventContext{})
+
+		require.NoError(t, err)
+		assert.Equal(t, project, metadata.Get().(OnPipelineStartedMetadata).Project)
+	})
+
+	t.Run("started pipelines over the limit -> oldest are dropped", func(t *testing.T) {
+		started := make([]string, 0, maxStartedPipelines)
+		for i := range maxStartedPipelines {
+			started = append(started, fmt.Sprintf("old-%d", i))
+		}
+
+		metadata := &contexts.MetadataContext{Metadata: OnPipelineStartedMetadata{StartedPipelines: started}}
+		_, err := handle(pipelineStartedBody("ppl-1", "running"), OnPipelineStartedConfiguration{}, metadata, &contexts.EventContext{})
+
+		require.NoError(t, err)
+		recorded := metadata.Get().(OnPipelineStartedMetadata).StartedPipelines
+		assert.Len(t, recorded, maxStartedPipelines)
+		assert.Equal(t, "old-1", recorded[0])
+		assert.Equal(t, "ppl-1", recorded[len(recorded)-1])
+	})
+
+	t.Run("emit fails -> pipeline is not recorded and is emitted on redelivery", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		body := pipelineStartedBody("ppl-1", "running")
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       buildSemaphoreHeaders(secret, body),
+			Configuration: OnPipelineStartedConfiguration{},
+			Metadata:      metadata,
+			Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+			Events:        &failingEventContext{},
+			Logger:        logger,
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, code)
+		assert.ErrorContains(t, err, "error emitting event")
+		assert.Nil(t, metadata.Get())
+
+		events := &contexts.EventContext{}
+		_, err = handle(body, OnPipelineStartedConfiguration{}, metadata, events)
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("missing pipeline id -> 400", func(t *testing.T) {
+		code, err := handle([]byte(`{"pipeline":{"state":"running"}}`), OnPipelineStartedConfiguration{}, &contexts.MetadataContext{}, &contexts.EventContext{})
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.ErrorContains(t, err, "missing pipeline.id")
+	})
+
+	t.Run("ref filter mismatch -> event is ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		config := OnPipelineStartedConfiguration{
+			Refs: []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/heads/release"}},
+		}
+
+		code, err := handle(pipelineStartedBody("ppl-1", "running"), config, &contexts.MetadataContext{}, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("pipeline filter match -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		config := OnPipelineStartedConfiguration{
+			Pipelines: []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: ".semaphore/deploy.yml"}},
+		}
+
+		code, err := handle(pipelineStartedBody("ppl-1", "running"), config, &contexts.MetadataContext{}, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("pipeline filter mismatch -> event is ignored and pipeline is not recorded", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		metadata := &contexts.MetadataContext{}
+		config := OnPipelineStartedConfiguration{
+			Pipelines: []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: ".semaphore/semaphore.yml"}},
+		}
+
+		code, err := handle(pipelineStartedBody("ppl-1", "running"), config, metadata, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+		assert.Nil(t, metadata.Get())
+	})
+}
+
+func Test__OnPipelineStarted__Setup(t *testing.T) {
+	trigger := OnPipelineStarted{}
+
+	t.Run("project is required", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: OnPipelineStartedConfiguration{Project: ""},
+		})
+
+		require.ErrorContains(t, err, "project is required")
+	})
+
+	t.Run("metadata already set -> returns early", func(t *testing.T) {
+		project := &Project{ID: "proj-123", Name: "test-project"}
+		metadataCtx := &contexts.MetadataContext{Metadata: OnPipelineDoneMetadata{Project: project}}
+
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      metadataCtx,
+			Configuration: OnPipelineStartedConfiguration{Project: "test-project"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, project, metadataCtx.Get().(OnPipelineDoneMetadata).Project)
+	})
+}
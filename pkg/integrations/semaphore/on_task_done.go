@@ -0,0 +1,275 @@
+package semaphore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	taskDoneEventType = "semaphore.task.done"
+
+	// maxCachedWorkflows is how many workflows are kept in metadata, for each kind.
+	maxCachedWorkflows = 100
+)
+
+type OnTaskDone struct{}
+
+type OnTaskDoneMetadata struct {
+	Project *Project `json:"project"`
+
+	//
+	// The webhook payload does not tell if a workflow was started by a task,
+	// so the workflow is looked up once and kept here. The pipelines of a workflow,
+	// such as its promotions, are then checked without calling the API again.
+	//
+	TaskWorkflows  []string `json:"taskWorkflows,omitempty"`
+	OtherWorkflows []string `json:"otherWorkflows,omitempty"`
+}
+
+type OnTaskDoneConfiguration struct {
+	Project   string                    `json:"project" mapstructure:"project"`
+	Refs      []configuration.Predicate `json:"refs" mapstructure:"refs"`
+	Results   []string                  `json:"results" mapstructure:"results"`
+	Pipelines []configuration.Predicate `json:"pipelines" mapstructure:"pipelines"`
+}
+
+func (t *OnTaskDone) Name() string {
+	return "semaphore.onTaskDone"
+}
+
+func (t *OnTaskDone) Label() string {
+	return "On Task Done"
+}
+
+func (t *OnTaskDone) Description() string {
+	return "Listen to Semaphore pipelines of scheduled tasks completing"
+}
+
+func (t *OnTaskDone) Documentation() string {
+	return `The On Task Done trigger starts a workflow execution when a pipeline started by a Semaphore task completes.
+
+## Use Cases
+
+- **Nightly jobs**: React to the result of nightly builds, dependency updates, or data jobs run by a scheduled task
+- **Scheduled deployments**: Continue a release when a deployment task has passed
+- **Failure alerts**: Notify the owners of a task when its run fails
+
+## Configuration
+
+- **Project**: Select the Semaphore project to monitor
+- **Refs**: Optional ref filters (for example ` + "`refs/heads/main`" + `)
+- **Results**: Optional pipeline result filters (for example ` + "`passed`" + `, ` + "`failed`" + `)
+- **Pipelines**: Optional pipeline file filters, to pick a task by the pipeline file it runs (for example ` + "`.semaphore/nightly.yml`" + `)
+
+## Event Data
+
+The trigger listens to the pipeline done events of the project, the same events as On Pipeline Done, and only emits the ones whose workflow was started by a task. The workflow is looked up with the Semaphore API the first time one of its pipelines is done. Each event includes:
+- **pipeline**: Pipeline information including ID, state, and result
+- **workflow**: Workflow information including ID
+- **project**: Project information
+- **revision**: Revision information including the ref and commit
+
+## Webhook Setup
+
+This trigger automatically sets up a Semaphore webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (t *OnTaskDone) Icon() string {
+	return "workflow"
+}
+
+func (t *OnTaskDone) Color() string {
+	return "gray"
+}
+
+func (t *OnTaskDone) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "project",
+			Label:    "Project",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           "project",
+					UseNameAsValue: true,
+				},
+			},
+		},
+		{
+			Name:     "results",
+			Label:    "Results",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: AllPipelineDoneResults,
+				},
+			},
+		},
+		{
+			Name:     "refs",
+			Label:    "Refs",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+		{
+			Name:     "pipelines",
+			Label:    "Pipelines",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (t *OnTaskDone) Setup(ctx core.TriggerContext) error {
+	var metadata OnTaskDoneMetadata
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	//
+	// If metadata is set, it means the trigger was already setup
+	//
+	if metadata.Project != nil {
+		return nil
+	}
+
+	config := OnTaskDoneConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	return setupProjectWebhook(ctx, config.Project)
+}
+
+func (t *OnTaskDone) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (t *OnTaskDone) HandleAction(ctx core.TriggerActionContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnTaskDone) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	config := OnTaskDoneConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if code, err := verifyWebhookSignature(ctx, t.Name()); err != nil {
+		return code, err
+	}
+
+	payload := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &payload)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if matches, err := matchesRefFilter(ctx, config.Refs, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	if matches, err := matchesResultFilter(ctx, config.Results, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	if matches, err := matchesPipelineFileFilter(ctx, config.Pipelines, payload); err != nil || !matches {
+		return webhookFilterStatus(err), err
+	}
+
+	workflowID, ok := getNestedString(payload, "workflow", "id")
+	if !ok || strings.TrimSpace(workflowID) == "" {
+		return http.StatusBadRequest, fmt.Errorf("missing workflow.id")
+	}
+
+	scheduled, err := t.isTaskWorkflow(ctx, workflowID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if !scheduled {
+		ctx.Logger.Infof("workflow %s was not started by a task", workflowID)
+		return http.StatusOK, nil
+	}
+
+	err = ctx.Events.Emit(taskDoneEventType, payload)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// isTaskWorkflow tells if a workflow was started by a task,
+// from the workflows in metadata or by looking the workflow up.
+func (t *OnTaskDone) isTaskWorkflow(ctx core.WebhookRequestContext, workflowID string) (bool, error) {
+	var metadata OnTaskDoneMetadata
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return false, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	if slices.Contains(metadata.TaskWorkflows, workflowID) {
+		return true, nil
+	}
+
+	if slices.Contains(metadata.OtherWorkflows, workflowID) {
+		return false, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return false, err
+	}
+
+	workflow, err := client.GetWorkflow(workflowID)
+	if err != nil {
+		return false, fmt.Errorf("error finding workflow %s: %v", workflowID, err)
+	}
+
+	if workflow.IsScheduled() {
+		metadata.TaskWorkflows = appendRecent(metadata.TaskWorkflows, workflowID, maxCachedWorkflows)
+	} else {
+		metadata.OtherWorkflows = appendRecent(metadata.OtherWorkflows, workflowID, maxCachedWorkflows)
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return false, fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	return workflow.IsScheduled(), nil
+}
+
+func (t *OnTaskDone) SetupRetryInterval() time.Duration {
+	return webhookSetupRetryInterval
+}
+
+func (t *OnTaskDone) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
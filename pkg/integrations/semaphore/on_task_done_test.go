@@ -0,0 +1,206 @@
+package semaphore
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func taskDoneBody(workflowID string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"revision": {"reference": "refs/heads/main"},
+		"workflow": {"id": %q},
+		"pipeline": {"id": "ppl-1", "state": "done", "result": "passed", "working_directory": ".semaphore", "yaml_file_name": "nightly.yml"}
+	}`, workflowID))
+}
+
+func workflowResponse(workflowID, triggeredBy string) *http.Response {
+	body := fmt.Sprintf(`{"workflow":{"wf_id":%q,"triggered_by":%q}}`, workflowID, triggeredBy)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func Test__OnTaskDone__HandleWebhook(t *testing.T) {
+	trigger := &OnTaskDone{}
+	logger := logrus.NewEntry(logrus.New())
+	secret := "test-secret"
+	integration := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"organizationUrl": "https://example.semaphoreci.com",
+			"apiToken":        "token-123",
+		},
+	}
+
+	handle := func(body []byte, config OnTaskDoneConfiguration, metadata *contexts.MetadataContext, httpCtx *contexts.HTTPContext, events *contexts.EventContext) (int, error) {
+		return trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          body,
+			Headers:       buildSemaphoreHeaders(secret, body),
+			Configuration: config,
+			Metadata:      metadata,
+			HTTP:          httpCtx,
+			Integration:   integration,
+			Webhook:       &contexts.NodeWebhookContext{Secret: secret},
+			Events:        events,
+			Logger:        logger,
+		})
+	}
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-Semaphore-Signature-256", "sha256=invalidsignature")
+
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    taskDoneBody("wf-1"),
+			Headers: headers,
+			Webhook: &contexts.NodeWebhookContext{Secret: secret},
+			Events:  &contexts.EventContext{},
+			Logger:  logger,
+		})
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("workflow started by a task -> event is emitted and workflow is cached", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{workflowResponse("wf-1", "SCHEDULE")}}
+		metadata := &contexts.MetadataContext{}
+		events := &contexts.EventContext{}
+
+		code, err := handle(taskDoneBody("wf-1"), OnTaskDoneConfiguration{}, metadata, httpCtx, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "semaphore.task.done", events.Payloads[0].Type)
+		require.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/plumber-workflows/wf-1", httpCtx.Requests[0].URL.String())
+		assert.Equal(t, []string{"wf-1"}, metadata.Get().(OnTaskDoneMetadata).TaskWorkflows)
+	})
+
+	t.Run("cached task workflow -> event is emitted without looking it up", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		metadata := &contexts.MetadataContext{Metadata: OnTaskDoneMetadata{TaskWorkflows: []string{"wf-1"}}}
+		events := &contexts.EventContext{}
+
+		code, err := handle(taskDoneBody("wf-1"), OnTaskDoneConfiguration{}, metadata, httpCtx, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, events.Count())
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("workflow started by a push -> event is ignored and workflow is cached", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{workflowResponse("wf-2", "HOOK")}}
+		metadata := &contexts.MetadataContext{}
+		events := &contexts.EventContext{}
+
+		code, err := handle(taskDoneBody("wf-2"), OnTaskDoneConfiguration{}, metadata, httpCtx, events)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+
+		code, err = handle(taskDoneBody("wf-2"), OnTaskDoneConfiguration{}, metadata, httpCtx, events)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+
+		assert.Zero(t, events.Count())
+		assert.Len(t, httpCtx.Requests, 1)
+		assert.Equal(t, []string{"wf-2"}, metadata.Get().(OnTaskDoneMetadata).OtherWorkflows)
+	})
+
+	t.Run("workflow lookup fails -> 500", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"message":"Not Found"}`))},
+			},
+		}
+
+		code, err := handle(taskDoneBody("wf-1"), OnTaskDoneConfiguration{}, &contexts.MetadataContext{}, httpCtx, &contexts.EventContext{})
+
+		assert.Equal(t, http.StatusInternalServerError, code)
+		assert.ErrorContains(t, err, "error finding workflow wf-1")
+	})
+
+	t.Run("missing workflow id -> 400", func(t *testing.T) {
+		body := []byte(`{"pipeline":{"state":"done","result":"passed"}}`)
+		code, err := handle(body, OnTaskDoneConfiguration{}, &contexts.MetadataContext{}, &contexts.HTTPContext{}, &contexts.EventContext{})
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.ErrorContains(t, err, "missing workflow.id")
+	})
+
+	t.Run("results filter mismatch -> ignored without looking the workflow up", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{}
+		events := &contexts.EventContext{}
+
+		code, err := handle(taskDoneBody("wf-1"), OnTaskDoneConfiguration{Results: []string{"failed"}}, &contexts.MetadataContext{}, httpCtx, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+		assert.Empty(t, httpCtx.Requests)
+	})
+
+	t.Run("pipeline filter match -> event is emitted", func(t *testing.T) {
+		httpCtx := &contexts.HTTPContext{Responses: []*http.Response{workflowResponse("wf-1", "SCHEDULE")}}
+		events := &contexts.EventContext{}
+		config := OnTaskDoneConfiguration{
+			Pipelines: []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: ".semaphore/nightly.yml"}},
+		}
+
+		code, err := handle(taskDoneBody("wf-1"), config, &contexts.MetadataContext{}, httpCtx, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("ref filter mismatch -> event is ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		config := OnTaskDoneConfiguration{
+			Refs: []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/heads/release"}},
+		}
+
+		code, err := handle(taskDoneBody("wf-1"), config, &contexts.MetadataContext{}, &contexts.HTTPContext{}, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+}
+
+func Test__OnTaskDone__Setup(t *testing.T) {
+	trigger := OnTaskDone{}
+
+	t.Run("project is required", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: OnTaskDoneConfiguration{Project: ""},
+		})
+
+		require.ErrorContains(t, err, "project is required")
+	})
+
+	t.Run("metadata already set -> returns early", func(t *testing.T) {
+		project := &Project{ID: "proj-123", Name: "test-project"}
+		metadataCtx := &contexts.MetadataContext{Metadata: OnPipelineDoneMetadata{Project: project}}
+
+		err := trigger.Setup(core.TriggerContext{
+			Integration:   &contexts.IntegrationContext{},
+			Metadata:      metadataCtx,
+			Configuration: OnTaskDoneConfiguration{Project: "test-project"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, project, metadataCtx.Get().(OnPipelineDoneMetadata).Project)
+	})
+}
@@ -0,0 +1,203 @@
+package semaphore
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+var AllRerunModes = []configuration.FieldOption{
+	{Label: "Failed blocks only", Value: RerunModePartial},
+	{Label: "Whole workflow", Value: RerunModeFull},
+}
+
+type RerunPipeline struct{}
+
+type RerunPipelineSpec struct {
+	PipelineID string `json:"pipelineId" mapstructure:"pipelineId"`
+	Mode       string `json:"mode" mapstructure:"mode"`
+}
+
+func (c *RerunPipeline) Name() string {
+	return "semaphore.rerunPipeline"
+}
+
+func (c *RerunPipeline) Label() string {
+	return "Rerun Pipeline"
+}
+
+func (c *RerunPipeline) Description() string {
+	return "Run a finished Semaphore pipeline again"
+}
+
+func (c *RerunPipeline) Documentation() string {
+	return `The Rerun Pipeline component runs a finished Semaphore pipeline again.
+
+## Use Cases
+
+- **Flaky tests**: Rerun the failed blocks of a pipeline that failed because of a flaky test
+- **Redeploys**: Run the whole workflow of a deploy again after an incident is resolved
+
+## Configuration
+
+- **Pipeline ID**: The Semaphore pipeline ID (supports expressions, e.g. ` + "`{{ event.pipeline.id }}`" + `)
+- **Mode**: Rerun only the failed blocks of the pipeline, or reschedule its whole workflow
+
+## Output
+
+Returns the new pipeline and the ID of the pipeline it reruns.
+Only a done pipeline can be run again, so a running pipeline fails the component.
+A passed pipeline has no failed blocks to rerun, so the failed blocks mode does not change it and the output status is ` + "`skipped`" + `.`
+}
+
+func (c *RerunPipeline) Icon() string {
+	return "workflow"
+}
+
+func (c *RerunPipeline) Color() string {
+	return "gray"
+}
+
+func (c *RerunPipeline) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RerunPipeline) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "pipelineId",
+			Label:       "Pipeline ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The Semaphore pipeline ID",
+			Placeholder: "e.g. {{ event.pipeline.id }}",
+		},
+		{
+			Name:     "mode",
+			Label:    "Mode",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  RerunModePartial,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: AllRerunModes,
+				},
+			},
+		},
+	}
+}
+
+func (c *RerunPipeline) Setup(ctx core.SetupContext) error {
+	var spec RerunPipelineSpec
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validateRerunPipelineSpec(spec)
+}
+
+func validateRerunPipelineSpec(spec RerunPipelineSpec) error {
+	if err := validatePipelineID(spec.PipelineID); err != nil {
+		return err
+	}
+
+	switch spec.Mode {
+	case "", RerunModePartial, RerunModeFull:
+		return nil
+	default:
+		return fmt.Errorf("invalid mode %q, must be %s or %s", spec.Mode, RerunModePartial, RerunModeFull)
+	}
+}
+
+func (c *RerunPipeline) Execute(ctx core.ExecutionContext) error {
+	var spec RerunPipelineSpec
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validateRerunPipelineSpec(spec); err != nil {
+		return err
+	}
+
+	mode := spec.Mode
+	if mode == "" {
+		mode = RerunModePartial
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	pipelineID := strings.TrimSpace(spec.PipelineID)
+	pipeline, err := client.GetPipeline(pipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	if pipeline.State != PipelineStateDone {
+		return fmt.Errorf("pipeline %s is %s, only done pipelines can be rerun", pipelineID, pipeline.State)
+	}
+
+	output := map[string]any{
+		"previousPipelineId":   pipelineID,
+		"mode":                 mode,
+		core.ResultStatusField: core.ResultStatusSuccess,
+	}
+
+	if mode == RerunModePartial && pipeline.Result == PipelineResultPassed {
+		output["pipeline"] = pipeline
+		output[core.ResultStatusField] = core.ResultStatusSkipped
+	} else {
+		//
+		// The execution ID is the request token,
+		// so retrying the execution does not start the pipeline twice.
+		//
+		response, err := client.RerunPipeline(pipeline, mode, ctx.ID.String())
+		if err != nil {
+			return fmt.Errorf("failed to rerun pipeline: %w", err)
+		}
+
+		rerun, err := client.GetPipeline(response.PipelineID)
+		if err != nil {
+			return fmt.Errorf("failed to get pipeline: %w", err)
+		}
+
+		output["pipeline"] = rerun
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"semaphore.pipeline.rerun",
+		[]any{output},
+	)
+}
+
+func (c *RerunPipeline) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *RerunPipeline) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *RerunPipeline) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *RerunPipeline) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *RerunPipeline) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RerunPipeline) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,131 @@
+package semaphore
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RerunPipeline__Setup(t *testing.T) {
+	component := &RerunPipeline{}
+
+	t.Run("pipeline ID that is not a UUID -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": "deploy"}})
+		require.ErrorContains(t, err, "invalid pipeline ID deploy")
+	})
+
+	t.Run("invalid mode -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": testPipelineID, "mode": "everything"}})
+		require.ErrorContains(t, err, `invalid mode "everything"`)
+	})
+
+	t.Run("valid configuration -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": testPipelineID, "mode": RerunModeFull}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__RerunPipeline__Execute(t *testing.T) {
+	component := &RerunPipeline{}
+	executionID := uuid.New()
+
+	execute := func(httpContext *contexts.HTTPContext, mode string) (*contexts.ExecutionStateContext, error) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			ID:             executionID,
+			Configuration:  map[string]any{"pipelineId": testPipelineID, "mode": mode},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{
+					"organizationUrl": "https://example.semaphoreci.com",
+					"apiToken":        "token-123",
+				},
+			},
+		})
+
+		return execState, err
+	}
+
+	rerunResponse := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	t.Run("failed pipeline -> partial rebuild of the pipeline", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pipelineResponse("done", "failed"),
+				rerunResponse(`{"pipeline_id": "33333333-3333-3333-3333-333333333333"}`),
+				rerunResponse(`{"pipeline": {"ppl_id": "33333333-3333-3333-3333-333333333333", "state": "running"}}`),
+			},
+		}
+
+		execState, err := execute(httpContext, "")
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 3)
+		rebuild := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPost, rebuild.Method)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/pipelines/"+testPipelineID+"/partial_rebuild?request_token="+executionID.String(), rebuild.URL.String())
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/pipelines/33333333-3333-3333-3333-333333333333", httpContext.Requests[2].URL.String())
+
+		assert.Equal(t, "semaphore.pipeline.rerun", execState.Type)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, testPipelineID, data["previousPipelineId"])
+		assert.Equal(t, RerunModePartial, data["mode"])
+		assert.Equal(t, core.ResultStatusSuccess, data["status"])
+		assert.Equal(t, "running", data["pipeline"].(*Pipeline).State)
+	})
+
+	t.Run("full mode -> workflow is rescheduled", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pipelineResponse("done", "passed"),
+				rerunResponse(`{"wf_id": "44444444-4444-4444-4444-444444444444", "ppl_id": "33333333-3333-3333-3333-333333333333"}`),
+				rerunResponse(`{"pipeline": {"ppl_id": "33333333-3333-3333-3333-333333333333", "state": "running"}}`),
+			},
+		}
+
+		execState, err := execute(httpContext, RerunModeFull)
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 3)
+		reschedule := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPost, reschedule.Method)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/workflows/11111111-1111-1111-1111-111111111111/reschedule?request_token="+executionID.String(), reschedule.URL.String())
+
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "33333333-3333-3333-3333-333333333333", data["pipeline"].(*Pipeline).PipelineID)
+	})
+
+	t.Run("passed pipeline in partial mode -> skipped without rerunning", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{pipelineResponse("done", "passed")},
+		}
+
+		execState, err := execute(httpContext, RerunModePartial)
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, core.ResultStatusSkipped, data["status"])
+		assert.Equal(t, testPipelineID, data["pipeline"].(*Pipeline).PipelineID)
+	})
+
+	t.Run("running pipeline -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{pipelineResponse("running", "")},
+		}
+
+		_, err := execute(httpContext, RerunModePartial)
+		require.ErrorContains(t, err, "is running, only done pipelines can be rerun")
+		require.Len(t, httpContext.Requests, 1)
+	})
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
-	"github.com/superplanehq/superplane/pkg/crypto"
 )
 
 const PayloadType = "semaphore.workflow.finished"
@@ -56,6 +55,10 @@ type RunWorkflowSpec struct {
 	PipelineFile string      `json:"pipelineFile"`
 	CommitSha    string      `json:"commitSha"`
 	Parameters   []Parameter `json:"parameters"`
+
+	// ParameterValues are more parameters given as an object, usually from an expression.
+	// Parameters with the same name in the list take precedence.
+	ParameterValues map[string]any `json:"parameterValues" mapstructure:"parameterValues"`
 }
 
 type Parameter struct {
@@ -100,6 +103,7 @@ func (r *RunWorkflow) Documentation() string {
 - **Ref**: Git reference to run the workflow on (branch, tag, or commit SHA)
 - **Commit SHA**: Optional specific commit SHA to run (if not provided, uses latest from ref)
 - **Parameters**: Optional workflow parameters as key-value pairs (supports expressions)
+- **Parameters Object**: Optional workflow parameters as a JSON object, for example to pass the parameters of an upstream workflow with an expression. Values that are not strings are sent as JSON, and the parameters of the list take precedence
 
 ## Output Channels
 
@@ -110,6 +114,7 @@ func (r *RunWorkflow) Documentation() string {
 
 - The component automatically sets up webhook monitoring for pipeline completion
 - Falls back to polling if webhook doesn't arrive
+- The pipeline file must be a YAML file, and each parameter can only be set once in the list
 - Can be cancelled, which will stop the running Semaphore workflow`
 }
 
@@ -193,6 +198,14 @@ func (r *RunWorkflow) Configuration() []configuration.Field {
 				},
 			},
 		},
+		{
+			Name:        "parameterValues",
+			Label:       "Parameters Object",
+			Type:        configuration.FieldTypeObject,
+			Required:    false,
+			Togglable:   true,
+			Description: "Workflow parameters as a JSON object, merged with the parameters above",
+		},
 	}
 }
 
@@ -207,8 +220,8 @@ func (r *RunWorkflow) Setup(ctx core.SetupContext) error {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
 
-	if config.Project == "" {
-		return fmt.Errorf("project is required")
+	if err := validateRunWorkflowSpec(config); err != nil {
+		return err
 	}
 
 	metadata := RunWorkflowNodeMetadata{}
@@ -253,6 +266,44 @@ func (r *RunWorkflow) Setup(ctx core.SetupContext) error {
 	return nil
 }
 
+func validateRunWorkflowSpec(spec RunWorkflowSpec) error {
+	if strings.TrimSpace(spec.Project) == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	if strings.TrimSpace(spec.Ref) == "" {
+		return fmt.Errorf("pipeline file location is required")
+	}
+
+	pipelineFile := strings.TrimSpace(spec.PipelineFile)
+	if pipelineFile == "" {
+		return fmt.Errorf("pipeline file is required")
+	}
+
+	//
+	// Pipeline files built from expressions are only known at execution time.
+	//
+	if !strings.Contains(pipelineFile, "{{") && !strings.HasSuffix(pipelineFile, ".yml") && !strings.HasSuffix(pipelineFile, ".yaml") {
+		return fmt.Errorf("pipeline file %s must be a YAML file", pipelineFile)
+	}
+
+	names := map[string]bool{}
+	for i, param := range spec.Parameters {
+		name := strings.TrimSpace(param.Name)
+		if name == "" {
+			return fmt.Errorf("parameter %d must have a name", i+1)
+		}
+
+		if names[name] {
+			return fmt.Errorf("parameter %s is set more than once", name)
+		}
+
+		names[name] = true
+	}
+
+	return nil
+}
+
 func (r *RunWorkflow) Execute(ctx core.ExecutionContext) error {
 	spec := RunWorkflowSpec{}
 	err := mapstructure.Decode(ctx.Configuration, &spec)
@@ -260,6 +311,10 @@ func (r *RunWorkflow) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	if err := validateRunWorkflowSpec(spec); err != nil {
+		return err
+	}
+
 	metadata := RunWorkflowNodeMetadata{}
 	err = mapstructure.Decode(ctx.NodeMetadata.Get(), &metadata)
 	if err != nil {
@@ -275,7 +330,7 @@ func (r *RunWorkflow) Execute(ctx core.ExecutionContext) error {
 		"project_id":    metadata.Project.ID,
 		"reference":     spec.Ref,
 		"pipeline_file": spec.PipelineFile,
-		"parameters":    r.buildParameters(ctx, spec.Parameters),
+		"parameters":    r.buildParameters(ctx, spec),
 	}
 
 	if spec.CommitSha != "" {
@@ -316,31 +371,41 @@ func (r *RunWorkflow) Execute(ctx core.ExecutionContext) error {
 }
 
 func (r *RunWorkflow) Cancel(ctx core.ExecutionContext) error {
-	return nil
-}
+	metadata := RunWorkflowExecutionMetadata{}
+	err := mapstructure.Decode(ctx.Metadata.Get(), &metadata)
+	if err != nil {
+		return fmt.Errorf("error decoding metadata: %v", err)
+	}
 
-func (r *RunWorkflow) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
-	signature := ctx.Headers.Get("X-Semaphore-Signature-256")
-	if signature == "" {
-		return http.StatusForbidden, fmt.Errorf("invalid signature")
+	//
+	// Nothing to stop if the workflow was never started
+	// or if the pipeline already finished.
+	//
+	if metadata.Pipeline == nil || metadata.Pipeline.ID == "" || metadata.Pipeline.State == PipelineStateDone {
+		return nil
 	}
 
-	signature = strings.TrimPrefix(signature, "sha256=")
-	if signature == "" {
-		return http.StatusForbidden, fmt.Errorf("invalid signature")
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
 	}
 
-	secret, err := ctx.Webhook.GetSecret()
+	err = client.StopPipeline(metadata.Pipeline.ID)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("error authenticating request")
+		return fmt.Errorf("error stopping pipeline %s: %v", metadata.Pipeline.ID, err)
 	}
 
-	if err := crypto.VerifySignature(secret, ctx.Body, signature); err != nil {
-		return http.StatusForbidden, fmt.Errorf("invalid signature")
+	ctx.Logger.Infof("Stopped pipeline %s", metadata.Pipeline.ID)
+	return nil
+}
+
+func (r *RunWorkflow) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	if code, err := verifyWebhookSignature(ctx, r.Name()); err != nil {
+		return code, err
 	}
 
 	var payload map[string]any
-	err = json.Unmarshal(ctx.Body, &payload)
+	err := json.Unmarshal(ctx.Body, &payload)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
 	}
@@ -536,9 +601,13 @@ func (r *RunWorkflow) finish(ctx core.ActionContext) error {
 	return nil
 }
 
-func (r *RunWorkflow) buildParameters(ctx core.ExecutionContext, params []Parameter) map[string]any {
+func (r *RunWorkflow) buildParameters(ctx core.ExecutionContext, spec RunWorkflowSpec) map[string]any {
 	parameters := make(map[string]any)
-	for _, param := range params {
+	for name, value := range spec.ParameterValues {
+		parameters[name] = parameterValue(value)
+	}
+
+	for _, param := range spec.Parameters {
 		parameters[param.Name] = param.Value
 	}
 
@@ -548,6 +617,24 @@ func (r *RunWorkflow) buildParameters(ctx core.ExecutionContext, params []Parame
 	return parameters
 }
 
+// parameterValue returns the value of a parameter of the object as a string,
+// since Semaphore parameters are environment variables.
+func parameterValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+
+		return string(encoded)
+	}
+}
+
 func (r *RunWorkflow) Cleanup(ctx core.SetupContext) error {
 	return nil
 }
@@ -0,0 +1,224 @@
+package semaphore
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunWorkflow__Setup(t *testing.T) {
+	component := &RunWorkflow{}
+
+	setup := func(configuration map[string]any) (*contexts.HTTPContext, *contexts.IntegrationContext, error) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"metadata":{"name":"api","id":"p1"}}`))},
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"organizationUrl": "https://example.semaphoreci.com",
+				"apiToken":        "token-123",
+			},
+		}
+
+		config := map[string]any{
+			"project":      "api",
+			"ref":          "refs/heads/main",
+			"pipelineFile": ".semaphore/deploy.yml",
+		}
+		for key, value := range configuration {
+			config[key] = value
+		}
+
+		err := component.Setup(core.SetupContext{
+			Configuration: config,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		return httpContext, integrationCtx, err
+	}
+
+	t.Run("valid configuration -> project is resolved and webhook requested", func(t *testing.T) {
+		httpContext, integrationCtx, err := setup(map[string]any{
+			"parameters": []map[string]any{{"name": "ENVIRONMENT", "value": "production"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects/api", httpContext.Requests[0].URL.String())
+		assert.Equal(t, []any{WebhookConfiguration{Project: "api"}}, integrationCtx.WebhookRequests)
+	})
+
+	t.Run("missing project -> error", func(t *testing.T) {
+		_, _, err := setup(map[string]any{"project": ""})
+		require.ErrorContains(t, err, "project is required")
+	})
+
+	t.Run("missing reference -> error", func(t *testing.T) {
+		_, _, err := setup(map[string]any{"ref": ""})
+		require.ErrorContains(t, err, "pipeline file location is required")
+	})
+
+	t.Run("missing pipeline file -> error", func(t *testing.T) {
+		_, _, err := setup(map[string]any{"pipelineFile": " "})
+		require.ErrorContains(t, err, "pipeline file is required")
+	})
+
+	t.Run("pipeline file that is not YAML -> error", func(t *testing.T) {
+		_, _, err := setup(map[string]any{"pipelineFile": ".semaphore/deploy.json"})
+		require.ErrorContains(t, err, "pipeline file .semaphore/deploy.json must be a YAML file")
+	})
+
+	t.Run("pipeline file from an expression -> validated at execution", func(t *testing.T) {
+		_, _, err := setup(map[string]any{"pipelineFile": `{{ $["Trigger"].data.pipelineFile }}`})
+		require.NoError(t, err)
+	})
+
+	t.Run("parameter set twice -> error", func(t *testing.T) {
+		_, _, err := setup(map[string]any{
+			"parameters": []map[string]any{
+				{"name": "ENVIRONMENT", "value": "production"},
+				{"name": "ENVIRONMENT", "value": "staging"},
+			},
+		})
+
+		require.ErrorContains(t, err, "parameter ENVIRONMENT is set more than once")
+	})
+}
+
+func Test__RunWorkflow__BuildParameters(t *testing.T) {
+	component := &RunWorkflow{}
+	ctx := core.ExecutionContext{WorkflowID: "canvas-1"}
+
+	t.Run("parameters object -> merged with the list, which takes precedence", func(t *testing.T) {
+		parameters := component.buildParameters(ctx, RunWorkflowSpec{
+			Parameters: []Parameter{{Name: "ENVIRONMENT", Value: "production"}},
+			ParameterValues: map[string]any{
+				"ENVIRONMENT": "staging",
+				"VERSION":     "1.4.0",
+				"REPLICAS":    float64(3),
+				"DRY_RUN":     false,
+				"REGIONS":     []any{"us-east-1", "eu-west-1"},
+				"EMPTY":       nil,
+			},
+		})
+
+		assert.Equal(t, "production", parameters["ENVIRONMENT"])
+		assert.Equal(t, "1.4.0", parameters["VERSION"])
+		assert.Equal(t, "3", parameters["REPLICAS"])
+		assert.Equal(t, "false", parameters["DRY_RUN"])
+		assert.Equal(t, `["us-east-1","eu-west-1"]`, parameters["REGIONS"])
+		assert.Equal(t, "", parameters["EMPTY"])
+		assert.Equal(t, "canvas-1", parameters["SUPERPLANE_CANVAS_ID"])
+	})
+}
+
+func Test__RunWorkflow__Cancel(t *testing.T) {
+	component := &RunWorkflow{}
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"organizationUrl": "https://example.semaphoreci.com",
+			"apiToken":        "token-123",
+		},
+	}
+
+	t.Run("running pipeline -> stops pipeline", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				},
+			},
+		}
+
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunWorkflowExecutionMetadata{
+					Pipeline: &PipelineMetadata{ID: "ppl-123", State: "running"},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		req := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/pipelines/ppl-123", req.URL.String())
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		params := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &params))
+		assert.Equal(t, true, params["terminate_request"])
+	})
+
+	t.Run("pipeline already done -> no request", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunWorkflowExecutionMetadata{
+					Pipeline: &PipelineMetadata{ID: "ppl-123", State: PipelineStateDone},
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("workflow not started -> no request", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			Metadata:    &contexts.MetadataContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("stop request fails -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(`{"message":"not found"}`)),
+				},
+			},
+		}
+
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Logger:      logrus.NewEntry(logrus.New()),
+			Metadata: &contexts.MetadataContext{
+				Metadata: RunWorkflowExecutionMetadata{
+					Pipeline: &PipelineMetadata{ID: "ppl-123", State: "running"},
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "error stopping pipeline ppl-123")
+	})
+}
@@ -91,7 +91,7 @@ func (s *Semaphore) Sync(ctx core.SyncContext) error {
 	// Semaphore doesn't have a whoami endpoint, so
 	// we list projects just to verify that the connection is working.
 	//
-	_, err = client.listProjects()
+	_, _, err = client.ListProjectsPage(1)
 	if err != nil {
 		return fmt.Errorf("error listing projects: %v", err)
 	}
@@ -116,11 +116,16 @@ func (s *Semaphore) Components() []core.Component {
 	return []core.Component{
 		&RunWorkflow{},
 		&GetPipeline{},
+		&StopPipeline{},
+		&RerunPipeline{},
 	}
 }
 
 func (s *Semaphore) Triggers() []core.Trigger {
 	return []core.Trigger{
 		&OnPipelineDone{},
+		&OnPipelineStarted{},
+		&OnBlockDone{},
+		&OnTaskDone{},
 	}
 }
@@ -42,7 +42,7 @@ func Test__Semaphore__Sync(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "ready", integrationCtx.State)
 		require.Len(t, httpContext.Requests, 1)
-		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects?page=1&page_size=100", httpContext.Requests[0].URL.String())
 	})
 
 	t.Run("failure listing projects -> error", func(t *testing.T) {
@@ -72,6 +72,6 @@ func Test__Semaphore__Sync(t *testing.T) {
 		require.Error(t, err)
 		assert.NotEqual(t, "ready", integrationCtx.State)
 		require.Len(t, httpContext.Requests, 1)
-		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/projects?page=1&page_size=100", httpContext.Requests[0].URL.String())
 	})
 }
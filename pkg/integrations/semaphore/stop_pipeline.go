@@ -0,0 +1,175 @@
+package semaphore
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type StopPipeline struct{}
+
+type StopPipelineSpec struct {
+	PipelineID string `json:"pipelineId" mapstructure:"pipelineId"`
+}
+
+func (c *StopPipeline) Name() string {
+	return "semaphore.stopPipeline"
+}
+
+func (c *StopPipeline) Label() string {
+	return "Stop Pipeline"
+}
+
+func (c *StopPipeline) Description() string {
+	return "Stop a running Semaphore pipeline"
+}
+
+func (c *StopPipeline) Documentation() string {
+	return `The Stop Pipeline component stops a running Semaphore pipeline.
+
+## Use Cases
+
+- **Incident response**: Abort a deploy pipeline that is rolling out a bad change
+- **Superseded runs**: Stop a pipeline once a newer one was started for the same branch
+
+## Configuration
+
+- **Pipeline ID**: The Semaphore pipeline ID (supports expressions, e.g. ` + "`{{ event.pipeline.id }}`" + `)
+
+## Output
+
+Returns the pipeline after the stop was requested, and whether it was stopped.
+A pipeline that is already done is not changed, and the output status is ` + "`skipped`" + `.
+Semaphore stops the running jobs in the background, so the pipeline is usually still stopping when the component finishes.`
+}
+
+func (c *StopPipeline) Icon() string {
+	return "workflow"
+}
+
+func (c *StopPipeline) Color() string {
+	return "gray"
+}
+
+func (c *StopPipeline) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *StopPipeline) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "pipelineId",
+			Label:       "Pipeline ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The Semaphore pipeline ID",
+			Placeholder: "e.g. {{ event.pipeline.id }}",
+		},
+	}
+}
+
+func (c *StopPipeline) Setup(ctx core.SetupContext) error {
+	var spec StopPipelineSpec
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return validatePipelineID(spec.PipelineID)
+}
+
+// validatePipelineID checks that a pipeline ID is a UUID.
+// IDs built from expressions are only known at execution time.
+func validatePipelineID(id string) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return fmt.Errorf("pipeline ID is required")
+	}
+
+	if strings.Contains(id, "{{") {
+		return nil
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("invalid pipeline ID %s", id)
+	}
+
+	return nil
+}
+
+func (c *StopPipeline) Execute(ctx core.ExecutionContext) error {
+	var spec StopPipelineSpec
+	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := validatePipelineID(spec.PipelineID); err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	pipelineID := strings.TrimSpace(spec.PipelineID)
+	pipeline, err := client.GetPipeline(pipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	output := map[string]any{
+		"pipeline":             pipeline,
+		"stopped":              false,
+		core.ResultStatusField: core.ResultStatusSkipped,
+	}
+
+	if pipeline.State != PipelineStateDone {
+		if err := client.StopPipeline(pipelineID); err != nil {
+			return fmt.Errorf("failed to stop pipeline: %w", err)
+		}
+
+		stopped, err := client.GetPipeline(pipelineID)
+		if err != nil {
+			return fmt.Errorf("failed to get pipeline: %w", err)
+		}
+
+		output["pipeline"] = stopped
+		output["stopped"] = true
+		output[core.ResultStatusField] = core.ResultStatusSuccess
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"semaphore.pipeline.stopped",
+		[]any{output},
+	)
+}
+
+func (c *StopPipeline) ProcessQueueItem(ctx core.ProcessQueueContext) (*uuid.UUID, error) {
+	return ctx.DefaultProcessing()
+}
+
+func (c *StopPipeline) HandleWebhook(ctx core.WebhookRequestContext) (int, error) {
+	return http.StatusOK, nil
+}
+
+func (c *StopPipeline) Actions() []core.Action {
+	return []core.Action{}
+}
+
+func (c *StopPipeline) HandleAction(ctx core.ActionContext) error {
+	return nil
+}
+
+func (c *StopPipeline) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *StopPipeline) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
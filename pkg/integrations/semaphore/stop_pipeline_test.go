@@ -0,0 +1,123 @@
+package semaphore
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testPipelineID = "00000000-0000-0000-0000-000000000000"
+
+func pipelineResponse(state, result string) *http.Response {
+	body := `{"pipeline": {"ppl_id": "` + testPipelineID + `", "wf_id": "11111111-1111-1111-1111-111111111111", "state": "` + state + `", "result": "` + result + `"}}`
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func Test__StopPipeline__Setup(t *testing.T) {
+	component := &StopPipeline{}
+
+	t.Run("missing pipeline ID -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": ""}})
+		require.ErrorContains(t, err, "pipeline ID is required")
+	})
+
+	t.Run("pipeline ID that is not a UUID -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": "deploy"}})
+		require.ErrorContains(t, err, "invalid pipeline ID deploy")
+	})
+
+	t.Run("pipeline ID from an expression -> validated at execution", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": "{{ event.pipeline.id }}"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("valid configuration -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"pipelineId": testPipelineID}})
+		require.NoError(t, err)
+	})
+}
+
+func Test__StopPipeline__Execute(t *testing.T) {
+	component := &StopPipeline{}
+
+	execute := func(httpContext *contexts.HTTPContext) (*contexts.ExecutionStateContext, error) {
+		execState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			ID:             uuid.New(),
+			Configuration:  map[string]any{"pipelineId": testPipelineID},
+			HTTP:           httpContext,
+			ExecutionState: execState,
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{
+					"organizationUrl": "https://example.semaphoreci.com",
+					"apiToken":        "token-123",
+				},
+			},
+		})
+
+		return execState, err
+	}
+
+	t.Run("running pipeline -> stopped and emitted with its new state", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pipelineResponse("running", ""),
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+				pipelineResponse("stopping", ""),
+			},
+		}
+
+		execState, err := execute(httpContext)
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 3)
+		stop := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPatch, stop.Method)
+		assert.Equal(t, "https://example.semaphoreci.com/api/v1alpha/pipelines/"+testPipelineID, stop.URL.String())
+		body := map[string]any{}
+		require.NoError(t, json.NewDecoder(stop.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"terminate_request": true}, body)
+
+		assert.Equal(t, "semaphore.pipeline.stopped", execState.Type)
+		require.Len(t, execState.Payloads, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, data["stopped"])
+		assert.Equal(t, core.ResultStatusSuccess, data["status"])
+		assert.Equal(t, "stopping", data["pipeline"].(*Pipeline).State)
+	})
+
+	t.Run("done pipeline -> skipped without stopping", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{pipelineResponse("done", "passed")},
+		}
+
+		execState, err := execute(httpContext)
+		require.NoError(t, err)
+
+		require.Len(t, httpContext.Requests, 1)
+		data := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, data["stopped"])
+		assert.Equal(t, core.ResultStatusSkipped, data["status"])
+		assert.Equal(t, "done", data["pipeline"].(*Pipeline).State)
+	})
+
+	t.Run("stop request fails -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				pipelineResponse("running", ""),
+				{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"message":"forbidden"}`))},
+			},
+		}
+
+		_, err := execute(httpContext)
+		require.ErrorContains(t, err, "failed to stop pipeline")
+	})
+}
@@ -3,6 +3,7 @@ package semaphore
 import (
 	"crypto/sha256"
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/core"
@@ -27,6 +28,10 @@ type WebhookConfiguration struct {
 	Project string `json:"project"`
 }
 
+// webhookSetupRetryInterval is the minimum wait between attempts to provision a project webhook.
+// Each attempt creates a secret and a notification with the Semaphore API.
+const webhookSetupRetryInterval = 30 * time.Second
+
 type SemaphoreWebhookHandler struct{}
 
 func (h *SemaphoreWebhookHandler) CompareConfig(a, b any) (bool, error) {
@@ -68,11 +73,19 @@ func (h *SemaphoreWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, er
 	suffix := fmt.Sprintf("%x", hash.Sum(nil))
 	name := fmt.Sprintf("superplane-webhook-%x", suffix[:16])
 
+	//
+	// The same webhook secret is used by HandleWebhook to verify the
+	// X-Semaphore-Signature-256 header, so Semaphore must sign with exactly this value.
+	//
 	webhookSecret, err := ctx.Webhook.GetSecret()
 	if err != nil {
 		return nil, fmt.Errorf("error getting webhook secret: %v", err)
 	}
 
+	if len(webhookSecret) == 0 {
+		return nil, fmt.Errorf("webhook secret is empty")
+	}
+
 	//
 	// Create Semaphore secret to store the event source key.
 	//
@@ -118,9 +131,20 @@ func (h *SemaphoreWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error
 func upsertSecret(client *Client, name string, key []byte) (*Secret, error) {
 	//
 	// Check if secret already exists.
+	// If it holds a different key, update it,
+	// otherwise signatures would never match the webhook secret.
 	//
 	secret, err := client.GetSecret(name)
 	if err == nil {
+		if secret.WebhookSecretValue() == string(key) {
+			return secret, nil
+		}
+
+		secret, err = client.UpdateWebhookSecret(name, string(key))
+		if err != nil {
+			return nil, fmt.Errorf("error updating secret: %v", err)
+		}
+
 		return secret, nil
 	}
 
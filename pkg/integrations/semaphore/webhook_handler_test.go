@@ -1,10 +1,17 @@
 package semaphore
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
 func Test__SemaphoreWebhookHandler__CompareConfig(t *testing.T) {
@@ -84,3 +91,156 @@ func Test__SemaphoreWebhookHandler__CompareConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test__SemaphoreWebhookHandler__Setup(t *testing.T) {
+	handler := &SemaphoreWebhookHandler{}
+	trigger := &OnPipelineDone{}
+
+	integrationCtx := &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"organizationUrl": "https://example.semaphoreci.com",
+			"apiToken":        "token-123",
+		},
+	}
+
+	notificationResponse := `{"metadata":{"id":"notification-123","name":"superplane-webhook"}}`
+	body := []byte(`{"revision":{"reference":"refs/heads/main"},"pipeline":{"state":"done","result":"passed"}}`)
+
+	t.Run("new secret -> HandleWebhook verifies against the secret set during Setup", func(t *testing.T) {
+		webhookSecret := "webhook-secret-123"
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"message":"not found"}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"metadata":{"id":"secret-123","name":"superplane-webhook"}}`))},
+				{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"message":"not found"}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(notificationResponse))},
+			},
+		}
+
+		webhookCtx := &contexts.WebhookContext{
+			ID:            "webhook-123",
+			URL:           "https://superplane.example.com/webhooks/webhook-123",
+			Secret:        []byte(webhookSecret),
+			Configuration: WebhookConfiguration{Project: "my-project"},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Webhook:     webhookCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret-123", metadata.(WebhookMetadata).Secret.ID)
+		require.Len(t, httpContext.Requests, 4)
+
+		createSecretRequest := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPost, createSecretRequest.Method)
+		stored := requestSecretValue(t, createSecretRequest)
+		assert.Equal(t, webhookSecret, stored)
+
+		eventContext := &contexts.EventContext{}
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders(stored, body),
+			Webhook: &contexts.NodeWebhookContext{Secret: string(webhookCtx.Secret)},
+			Events:  eventContext,
+			Logger:  logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, eventContext.Count())
+	})
+
+	t.Run("existing secret with stale value -> secret is updated", func(t *testing.T) {
+		webhookSecret := "new-webhook-secret"
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"metadata":{"id":"secret-123","name":"superplane-webhook"},"data":{"env_vars":[{"name":"WEBHOOK_SECRET","value":"old-webhook-secret"}]}}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"metadata":{"id":"secret-123","name":"superplane-webhook"}}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(notificationResponse))},
+			},
+		}
+
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Webhook: &contexts.WebhookContext{
+				ID:            "webhook-123",
+				URL:           "https://superplane.example.com/webhooks/webhook-123",
+				Secret:        []byte(webhookSecret),
+				Configuration: WebhookConfiguration{Project: "my-project"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 3)
+
+		updateSecretRequest := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPut, updateSecretRequest.Method)
+		assert.Equal(t, webhookSecret, requestSecretValue(t, updateSecretRequest))
+
+		//
+		// A signature computed with the old Semaphore secret must not be accepted.
+		//
+		code, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:    body,
+			Headers: buildSemaphoreHeaders("old-webhook-secret", body),
+			Webhook: &contexts.NodeWebhookContext{Secret: webhookSecret},
+			Events:  &contexts.EventContext{},
+			Logger:  logrus.NewEntry(logrus.New()),
+		})
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("existing secret with matching value -> secret is reused", func(t *testing.T) {
+		webhookSecret := "webhook-secret-123"
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"metadata":{"id":"secret-123","name":"superplane-webhook"},"data":{"env_vars":[{"name":"WEBHOOK_SECRET","value":"webhook-secret-123"}]}}`))},
+				{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(notificationResponse))},
+			},
+		}
+
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: integrationCtx,
+			Webhook: &contexts.WebhookContext{
+				ID:            "webhook-123",
+				URL:           "https://superplane.example.com/webhooks/webhook-123",
+				Secret:        []byte(webhookSecret),
+				Configuration: WebhookConfiguration{Project: "my-project"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[0].Method)
+		assert.Equal(t, http.MethodGet, httpContext.Requests[1].Method)
+	})
+
+	t.Run("empty webhook secret -> error", func(t *testing.T) {
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: integrationCtx,
+			Webhook: &contexts.WebhookContext{
+				ID:            "webhook-123",
+				Configuration: WebhookConfiguration{Project: "my-project"},
+			},
+		})
+
+		require.ErrorContains(t, err, "webhook secret is empty")
+	})
+}
+
+func requestSecretValue(t *testing.T, request *http.Request) string {
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+
+	secret := Secret{}
+	require.NoError(t, json.Unmarshal(body, &secret))
+	return secret.WebhookSecretValue()
+}
@@ -12,9 +12,10 @@ import (
 )
 
 const (
-	IntegrationStatePending = "pending"
-	IntegrationStateReady   = "ready"
-	IntegrationStateError   = "error"
+	IntegrationStatePending  = "pending"
+	IntegrationStateReady    = "ready"
+	IntegrationStateError    = "error"
+	IntegrationStateDegraded = "degraded"
 )
 
 type Integration struct {
@@ -36,6 +37,22 @@ func (a *Integration) TableName() string {
 	return "app_installations"
 }
 
+// Usable reports whether components can run against the integration.
+// Degraded integrations failed to set up part of their resources, but still work for the rest.
+func (a *Integration) Usable() bool {
+	return a.State == IntegrationStateReady || a.State == IntegrationStateDegraded
+}
+
+// NotReadyMessage describes why components cannot run against the integration.
+func (a *Integration) NotReadyMessage() string {
+	message := fmt.Sprintf("integration %s is not ready (state: %s)", a.InstallationName, a.State)
+	if a.StateDescription != "" {
+		message += ": " + a.StateDescription
+	}
+
+	return message
+}
+
 type IntegrationSecret struct {
 	ID             uuid.UUID `gorm:"primary_key;default:uuid_generate_v4()"`
 	OrganizationID uuid.UUID
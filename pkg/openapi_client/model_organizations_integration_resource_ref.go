@@ -20,9 +20,10 @@ var _ MappedNullable = &OrganizationsIntegrationResourceRef{}
 
 // OrganizationsIntegrationResourceRef struct for OrganizationsIntegrationResourceRef
 type OrganizationsIntegrationResourceRef struct {
-	Type *string `json:"type,omitempty"`
-	Name *string `json:"name,omitempty"`
-	Id   *string `json:"id,omitempty"`
+	Type     *string                               `json:"type,omitempty"`
+	Name     *string                               `json:"name,omitempty"`
+	Id       *string                               `json:"id,omitempty"`
+	Children []OrganizationsIntegrationResourceRef `json:"children,omitempty"`
 }
 
 // NewOrganizationsIntegrationResourceRef instantiates a new OrganizationsIntegrationResourceRef object
@@ -138,6 +139,38 @@ func (o *OrganizationsIntegrationResourceRef) SetId(v string) {
 	o.Id = &v
 }
 
+// GetChildren returns the Children field value if set, zero value otherwise.
+func (o *OrganizationsIntegrationResourceRef) GetChildren() []OrganizationsIntegrationResourceRef {
+	if o == nil || IsNil(o.Children) {
+		var ret []OrganizationsIntegrationResourceRef
+		return ret
+	}
+	return o.Children
+}
+
+// GetChildrenOk returns a tuple with the Children field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *OrganizationsIntegrationResourceRef) GetChildrenOk() ([]OrganizationsIntegrationResourceRef, bool) {
+	if o == nil || IsNil(o.Children) {
+		return nil, false
+	}
+	return o.Children, true
+}
+
+// HasChildren returns a boolean if a field has been set.
+func (o *OrganizationsIntegrationResourceRef) HasChildren() bool {
+	if o != nil && !IsNil(o.Children) {
+		return true
+	}
+
+	return false
+}
+
+// SetChildren gets a reference to the given []OrganizationsIntegrationResourceRef and assigns it to the Children field.
+func (o *OrganizationsIntegrationResourceRef) SetChildren(v []OrganizationsIntegrationResourceRef) {
+	o.Children = v
+}
+
 func (o OrganizationsIntegrationResourceRef) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -157,6 +190,9 @@ func (o OrganizationsIntegrationResourceRef) ToMap() (map[string]interface{}, er
 	if !IsNil(o.Id) {
 		toSerialize["id"] = o.Id
 	}
+	if !IsNil(o.Children) {
+		toSerialize["children"] = o.Children
+	}
 	return toSerialize, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/core"
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/grpc"
+	"github.com/superplanehq/superplane/pkg/grpc/actions/canvases"
 	"github.com/superplanehq/superplane/pkg/jwt"
 	"github.com/superplanehq/superplane/pkg/logging"
 	"github.com/superplanehq/superplane/pkg/registry"
@@ -52,7 +53,9 @@ import (
 	"github.com/superplanehq/superplane/pkg/web"
 	"github.com/superplanehq/superplane/pkg/web/assets"
 	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -251,6 +254,14 @@ func (s *Server) RegisterGRPCGateway(grpcServerAddr string) error {
 	accountAuthMiddleware := middleware.AccountAuthMiddleware(s.jwt)
 	protectedAccountGRPCHandler := accountAuthMiddleware(s.grpcGatewayAccountHandler(grpcGatewayMux))
 
+	// The catalog is served from the registry, so it is registered before the gateway routes for integrations.
+	s.Router.Handle("/api/v1/integrations/catalog", orgAuthMiddleware(http.HandlerFunc(s.listIntegrationsCatalog))).
+		Methods("GET")
+
+	// Setup previews run the triggers in dry-run mode, so they are registered before the gateway routes for canvases.
+	s.Router.Handle("/api/v1/canvases/triggers/setup-plan", orgAuthMiddleware(http.HandlerFunc(s.previewTriggerSetup))).
+		Methods("POST")
+
 	s.Router.PathPrefix("/api/v1/users").Handler(protectedGRPCHandler)
 	s.Router.PathPrefix("/api/v1/groups").Handler(protectedGRPCHandler)
 	s.Router.PathPrefix("/api/v1/roles").Handler(protectedGRPCHandler)
@@ -480,6 +491,62 @@ func respondJSON(w http.ResponseWriter, payload any) {
 	}
 }
 
+// listIntegrationsCatalog returns the integrations of the registry,
+// with their triggers, components and configuration schemas.
+func (s *Server) listIntegrationsCatalog(w http.ResponseWriter, _ *http.Request) {
+	respondJSON(w, s.registry.Catalog())
+}
+
+type TriggerSetupPreviewRequest struct {
+	Trigger       string         `json:"trigger"`
+	IntegrationID string         `json:"integrationId"`
+	Configuration map[string]any `json:"configuration"`
+}
+
+// previewTriggerSetup returns what a trigger Setup would provision for a configuration,
+// so canvas changes can be previewed before they are saved.
+func (s *Server) previewTriggerSetup(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(user.ID.String(), user.OrganizationID.String(), "canvases", "update")
+	if err != nil || !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	var req TriggerSetupPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Trigger == "" {
+		http.Error(w, "Trigger is required", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := canvases.PreviewTriggerSetup(s.registry, user.OrganizationID.String(), req.Trigger, req.IntegrationID, req.Configuration)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.NotFound:
+			http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+		default:
+			log.Errorf("Error previewing setup of trigger %s: %v", req.Trigger, err)
+			http.Error(w, "Failed to preview trigger setup", http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	respondJSON(w, plan)
+}
+
 func (s *Server) HandleIntegrationRequest(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 
@@ -509,7 +576,7 @@ func (s *Server) HandleIntegrationRequest(w http.ResponseWriter, r *http.Request
 		BaseURL:         s.BaseURL,
 		WebhooksBaseURL: s.WebhooksBaseURL,
 		OrganizationID:  integrationInstance.OrganizationID.String(),
-		HTTP:            s.registry.HTTPContext(),
+		HTTP:            s.registry.HTTPContextForIntegration(integrationInstance.Configuration.Data()),
 		Integration: contexts.NewIntegrationContext(
 			database.Conn(),
 			nil,
@@ -797,6 +864,7 @@ func (s *Server) executeTriggerNode(ctx context.Context, body []byte, headers ht
 	logger := logging.ForNode(node)
 	tx := database.Conn()
 	var integrationCtx core.IntegrationContext
+	var httpCtx core.HTTPContext = s.registry.HTTPContext()
 	if node.AppInstallationID != nil {
 		integration, integrationErr := models.FindUnscopedIntegrationInTransaction(tx, *node.AppInstallationID)
 		if integrationErr != nil {
@@ -805,6 +873,7 @@ func (s *Server) executeTriggerNode(ctx context.Context, body []byte, headers ht
 
 		logger = logging.WithIntegration(logger, *integration)
 		integrationCtx = contexts.NewIntegrationContext(tx, &node, integration, s.encryptor, s.registry)
+		httpCtx = s.registry.HTTPContextForIntegration(integration.Configuration.Data())
 	}
 
 	return trigger.HandleWebhook(core.WebhookRequestContext{
@@ -815,7 +884,7 @@ func (s *Server) executeTriggerNode(ctx context.Context, body []byte, headers ht
 		Configuration: node.Configuration.Data(),
 		Metadata:      contexts.NewNodeMetadataContext(tx, &node),
 		Logger:        logger,
-		HTTP:          s.registry.HTTPContext(),
+		HTTP:          httpCtx,
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, s.encryptor, &node, s.BaseURL+s.BasePath),
 		Events:        contexts.NewEventContext(tx, &node),
 		Integration:   integrationCtx,
@@ -832,6 +901,7 @@ func (s *Server) executeComponentNode(ctx context.Context, body []byte, headers
 	logger := logging.ForNode(node)
 	tx := database.Conn()
 	var integrationCtx core.IntegrationContext
+	var httpCtx core.HTTPContext = s.registry.HTTPContext()
 	if node.AppInstallationID != nil {
 		integration, integrationErr := models.FindUnscopedIntegrationInTransaction(tx, *node.AppInstallationID)
 		if integrationErr != nil {
@@ -840,6 +910,7 @@ func (s *Server) executeComponentNode(ctx context.Context, body []byte, headers
 
 		logger = logging.WithIntegration(logger, *integration)
 		integrationCtx = contexts.NewIntegrationContext(tx, &node, integration, s.encryptor, s.registry)
+		httpCtx = s.registry.HTTPContextForIntegration(integration.Configuration.Data())
 	}
 
 	return component.HandleWebhook(core.WebhookRequestContext{
@@ -850,7 +921,7 @@ func (s *Server) executeComponentNode(ctx context.Context, body []byte, headers
 		Configuration: node.Configuration.Data(),
 		Metadata:      contexts.NewNodeMetadataContext(tx, &node),
 		Logger:        logger,
-		HTTP:          s.registry.HTTPContext(),
+		HTTP:          httpCtx,
 		Webhook:       contexts.NewNodeWebhookContext(ctx, tx, s.encryptor, &node, s.BaseURL+s.BasePath),
 		Events:        contexts.NewEventContext(tx, &node),
 		Integration:   integrationCtx,
@@ -866,7 +937,7 @@ func (s *Server) executeComponentNode(ctx context.Context, body []byte, headers
 				NodeID:         execution.NodeID,
 				BaseURL:        s.BaseURL,
 				Configuration:  execution.Configuration.Data(),
-				HTTP:           s.registry.HTTPContext(),
+				HTTP:           httpCtx,
 				Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
 				NodeMetadata:   contexts.NewNodeMetadataContext(tx, &node),
 				ExecutionState: contexts.NewExecutionStateContext(tx, execution),
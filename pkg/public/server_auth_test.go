@@ -1,17 +1,22 @@
 package public
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/markbates/goth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	_ "github.com/superplanehq/superplane/pkg/integrations/launchdarkly"
 	"github.com/superplanehq/superplane/pkg/jwt"
 	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/test/support"
 )
 
@@ -186,3 +191,80 @@ func TestServer_AuthIntegration(t *testing.T) {
 		assert.Equal(t, gothUser.Name, resultAccount.Name)
 	})
 }
+
+func Test__IntegrationsCatalog(t *testing.T) {
+	r := support.Setup(t)
+	server, _, token := setupTestServer(r, t)
+	require.NoError(t, server.RegisterGRPCGateway("localhost:50051"))
+
+	t.Run("no authenticated user -> unauthorized", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/integrations/catalog", nil)
+		response := httptest.NewRecorder()
+		server.Router.ServeHTTP(response, req)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
+	t.Run("authenticated user -> catalog of the registry", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/integrations/catalog", nil)
+		req.AddCookie(&http.Cookie{Name: "account_token", Value: token})
+		req.Header.Set("x-organization-id", r.Organization.ID.String())
+		response := httptest.NewRecorder()
+		server.Router.ServeHTTP(response, req)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		catalog := []registry.CatalogEntry{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &catalog))
+		assert.Equal(t, len(r.Registry.Catalog()), len(catalog))
+	})
+}
+
+func Test__PreviewTriggerSetup(t *testing.T) {
+	r := support.Setup(t)
+	server, _, token := setupTestServer(r, t)
+	require.NoError(t, server.RegisterGRPCGateway("localhost:50051"))
+
+	preview := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/canvases/triggers/setup-plan", strings.NewReader(body))
+		req.AddCookie(&http.Cookie{Name: "account_token", Value: token})
+		req.Header.Set("x-organization-id", r.Organization.ID.String())
+		response := httptest.NewRecorder()
+		server.Router.ServeHTTP(response, req)
+		return response
+	}
+
+	t.Run("no authenticated user -> unauthorized", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/canvases/triggers/setup-plan", strings.NewReader(`{}`))
+		response := httptest.NewRecorder()
+		server.Router.ServeHTTP(response, req)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
+	t.Run("trigger with dry-run support -> setup plan", func(t *testing.T) {
+		response := preview(`{"trigger": "launchdarkly.onFeatureFlagChange", "configuration": {"projectKey": "default"}}`)
+		require.Equal(t, http.StatusOK, response.Code)
+
+		plan := core.SetupPlan{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &plan))
+		require.Len(t, plan.Steps, 1)
+		assert.Equal(t, core.SetupPlanActionEnsure, plan.Steps[0].Action)
+		assert.Equal(t, "webhook", plan.Steps[0].Resource)
+		assert.Equal(t, "default", plan.Steps[0].Details["projectKey"])
+	})
+
+	t.Run("invalid configuration -> bad request", func(t *testing.T) {
+		response := preview(`{"trigger": "launchdarkly.onFeatureFlagChange", "configuration": {}}`)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "project key is required")
+	})
+
+	t.Run("trigger without dry-run support -> bad request", func(t *testing.T) {
+		response := preview(`{"trigger": "schedule", "configuration": {}}`)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "does not support previewing its setup")
+	})
+
+	t.Run("unknown trigger -> not found", func(t *testing.T) {
+		response := preview(`{"trigger": "does-not-exist", "configuration": {}}`)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
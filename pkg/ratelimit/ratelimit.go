@@ -0,0 +1,107 @@
+// Package ratelimit holds an in-memory token bucket limiter, keyed by an arbitrary string.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// idleTimeout is how long a bucket is kept after its last call.
+// Keys come and go with the triggers using them, so idle buckets are evicted
+// instead of being kept for the lifetime of the process.
+const idleTimeout = time.Hour
+
+// Limiter keeps one token bucket per key.
+// Buckets live in memory, so limits apply per process and reset on restart.
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	capacity float64
+	rate     float64
+	last     time.Time
+	dropped  int64
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket for key and reports whether one was available.
+// The bucket holds up to max tokens and is refilled at max tokens per interval,
+// so bursts of up to max events are allowed, followed by a steady max per interval.
+// Calls that are not allowed are counted, and the count is returned by Dropped.
+func (l *Limiter) Allow(key string, max int, interval time.Duration) bool {
+	if max <= 0 || interval <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.evictIdle(now)
+
+	capacity := float64(max)
+	rate := capacity / interval.Seconds()
+
+	b, ok := l.buckets[key]
+	if !ok || b.capacity != capacity || b.rate != rate {
+		dropped := int64(0)
+		if ok {
+			dropped = b.dropped
+		}
+
+		b = &bucket{tokens: capacity, capacity: capacity, rate: rate, last: now, dropped: dropped}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		b.dropped++
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// evictIdle removes the buckets that were not used for idleTimeout, at most once per idleTimeout.
+// Buckets refill in their interval, so an evicted bucket is full unless its interval
+// is longer than idleTimeout; those are kept until they are full.
+// The drops of evicted buckets are forgotten.
+func (l *Limiter) evictIdle(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		idle := now.Sub(b.last)
+		if idle >= idleTimeout && idle.Seconds()*b.rate >= b.capacity {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Dropped returns how many calls to Allow were rejected for key.
+func (l *Limiter) Dropped(key string) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		return b.dropped
+	}
+
+	return 0
+}
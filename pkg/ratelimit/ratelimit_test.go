@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__Limiter__Allow(t *testing.T) {
+	now := time.Date(2026, 1, 19, 12, 0, 0, 0, time.UTC)
+	newLimiter := func() *Limiter {
+		l := NewLimiter()
+		l.now = func() time.Time { return now }
+		return l
+	}
+
+	t.Run("burst above max -> only max calls allowed", func(t *testing.T) {
+		l := newLimiter()
+
+		allowed := 0
+		for range 10 {
+			if l.Allow("trigger-1", 3, time.Minute) {
+				allowed++
+			}
+		}
+
+		assert.Equal(t, 3, allowed)
+		assert.Equal(t, int64(7), l.Dropped("trigger-1"))
+	})
+
+	t.Run("tokens are refilled over time", func(t *testing.T) {
+		l := newLimiter()
+		for range 3 {
+			assert.True(t, l.Allow("trigger-1", 3, time.Minute))
+		}
+		assert.False(t, l.Allow("trigger-1", 3, time.Minute))
+
+		now = now.Add(20 * time.Second)
+		assert.True(t, l.Allow("trigger-1", 3, time.Minute))
+		assert.False(t, l.Allow("trigger-1", 3, time.Minute))
+	})
+
+	t.Run("keys have separate buckets", func(t *testing.T) {
+		l := newLimiter()
+		assert.True(t, l.Allow("trigger-1", 1, time.Minute))
+		assert.False(t, l.Allow("trigger-1", 1, time.Minute))
+		assert.True(t, l.Allow("trigger-2", 1, time.Minute))
+		assert.Equal(t, int64(0), l.Dropped("trigger-2"))
+	})
+
+	t.Run("no limit configured -> always allowed", func(t *testing.T) {
+		l := newLimiter()
+		for range 100 {
+			assert.True(t, l.Allow("trigger-1", 0, time.Minute))
+		}
+		assert.Equal(t, int64(0), l.Dropped("trigger-1"))
+	})
+
+	t.Run("changed limit -> bucket is reset but drops are kept", func(t *testing.T) {
+		l := newLimiter()
+		assert.True(t, l.Allow("trigger-1", 1, time.Minute))
+		assert.False(t, l.Allow("trigger-1", 1, time.Minute))
+
+		assert.True(t, l.Allow("trigger-1", 2, time.Minute))
+		assert.Equal(t, int64(1), l.Dropped("trigger-1"))
+	})
+
+	t.Run("bucket idle for the idle timeout -> evicted on the next call", func(t *testing.T) {
+		l := newLimiter()
+		assert.True(t, l.Allow("trigger-1", 1, time.Minute))
+		assert.False(t, l.Allow("trigger-1", 1, time.Minute))
+
+		now = now.Add(30 * time.Minute)
+		assert.True(t, l.Allow("trigger-2", 1, time.Minute))
+		assert.Len(t, l.buckets, 2)
+
+		now = now.Add(idleTimeout)
+		assert.True(t, l.Allow("trigger-2", 1, time.Minute))
+		assert.Len(t, l.buckets, 1)
+		assert.Equal(t, int64(0), l.Dropped("trigger-1"))
+	})
+
+	t.Run("idle bucket not refilled yet -> kept", func(t *testing.T) {
+		l := newLimiter()
+		assert.True(t, l.Allow("trigger-1", 1, 2*idleTimeout))
+		assert.False(t, l.Allow("trigger-1", 1, 2*idleTimeout))
+
+		now = now.Add(idleTimeout)
+		assert.True(t, l.Allow("trigger-2", 1, time.Minute))
+		assert.False(t, l.Allow("trigger-1", 1, 2*idleTimeout))
+		assert.Equal(t, int64(2), l.Dropped("trigger-1"))
+	})
+}
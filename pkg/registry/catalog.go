@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"sort"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * CatalogEntry describes a registered integration, with the triggers
+ * and components it provides and their configuration schemas.
+ */
+type CatalogEntry struct {
+	Name          string                `json:"name"`
+	Label         string                `json:"label"`
+	Icon          string                `json:"icon"`
+	Description   string                `json:"description"`
+	Configuration []configuration.Field `json:"configuration"`
+	Components    []CatalogItem         `json:"components"`
+	Triggers      []CatalogItem         `json:"triggers"`
+}
+
+/*
+ * CatalogItem describes a trigger or a component of an integration.
+ */
+type CatalogItem struct {
+	Name          string                `json:"name"`
+	Label         string                `json:"label"`
+	Description   string                `json:"description"`
+	Configuration []configuration.Field `json:"configuration"`
+}
+
+/*
+ * Catalog lists the registered integrations, sorted by name,
+ * so catalogs can be rendered without hardcoding what is available.
+ */
+func (r *Registry) Catalog() []CatalogEntry {
+	integrations := r.ListIntegrations()
+	catalog := make([]CatalogEntry, 0, len(integrations))
+	for _, integration := range integrations {
+		catalog = append(catalog, CatalogEntry{
+			Name:          integration.Name(),
+			Label:         integration.Label(),
+			Icon:          integration.Icon(),
+			Description:   integration.Description(),
+			Configuration: integration.Configuration(),
+			Components:    catalogComponents(integration.Components()),
+			Triggers:      catalogTriggers(integration.Triggers()),
+		})
+	}
+
+	return catalog
+}
+
+func catalogComponents(components []core.Component) []CatalogItem {
+	items := make([]CatalogItem, 0, len(components))
+	for _, component := range components {
+		items = append(items, CatalogItem{
+			Name:          component.Name(),
+			Label:         component.Label(),
+			Description:   component.Description(),
+			Configuration: component.Configuration(),
+		})
+	}
+
+	return sortCatalogItems(items)
+}
+
+func catalogTriggers(triggers []core.Trigger) []CatalogItem {
+	items := make([]CatalogItem, 0, len(triggers))
+	for _, trigger := range triggers {
+		items = append(items, CatalogItem{
+			Name:          trigger.Name(),
+			Label:         trigger.Label(),
+			Description:   trigger.Description(),
+			Configuration: trigger.Configuration(),
+		})
+	}
+
+	return sortCatalogItems(items)
+}
+
+func sortCatalogItems(items []CatalogItem) []CatalogItem {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	return items
+}
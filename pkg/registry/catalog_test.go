@@ -0,0 +1,82 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/registry"
+
+	_ "github.com/superplanehq/superplane/pkg/server"
+)
+
+func Test__Registry__Catalog(t *testing.T) {
+	reg, err := registry.NewRegistry(&crypto.NoOpEncryptor{}, registry.HTTPOptions{})
+	require.NoError(t, err)
+
+	catalog := reg.Catalog()
+	entries := map[string]registry.CatalogEntry{}
+	for i, entry := range catalog {
+		entries[entry.Name] = entry
+		if i > 0 {
+			assert.Less(t, catalog[i-1].Name, entry.Name)
+		}
+	}
+
+	itemNames := func(items []registry.CatalogItem) []string {
+		names := []string{}
+		for _, item := range items {
+			names = append(names, item.Name)
+		}
+
+		return names
+	}
+
+	t.Run("launchdarkly -> components, triggers and configuration", func(t *testing.T) {
+		entry, ok := entries["launchdarkly"]
+		require.True(t, ok)
+		assert.Equal(t, "LaunchDarkly", entry.Label)
+		assert.NotEmpty(t, entry.Configuration)
+
+		components := itemNames(entry.Components)
+		assert.Contains(t, components, "launchdarkly.getFeatureFlag")
+		assert.Contains(t, components, "launchdarkly.deleteFeatureFlag")
+		assert.Contains(t, components, "launchdarkly.followFlag")
+		assert.IsNonDecreasing(t, components)
+		assert.Contains(t, itemNames(entry.Triggers), "launchdarkly.onFeatureFlagChange")
+	})
+
+	t.Run("honeycomb -> components and triggers", func(t *testing.T) {
+		entry, ok := entries["honeycomb"]
+		require.True(t, ok)
+
+		assert.Contains(t, itemNames(entry.Components), "honeycomb.queryDataset")
+		assert.Contains(t, itemNames(entry.Components), "honeycomb.createMarker")
+		assert.Contains(t, itemNames(entry.Triggers), "honeycomb.onAlertFired")
+	})
+
+	t.Run("semaphore -> components and triggers", func(t *testing.T) {
+		entry, ok := entries["semaphore"]
+		require.True(t, ok)
+
+		assert.Contains(t, itemNames(entry.Components), "semaphore.runWorkflow")
+		assert.Contains(t, itemNames(entry.Triggers), "semaphore.onPipelineDone")
+	})
+
+	t.Run("components carry their configuration schema", func(t *testing.T) {
+		for _, item := range entries["launchdarkly"].Components {
+			if item.Name != "launchdarkly.getFeatureFlag" {
+				continue
+			}
+
+			fields := []string{}
+			for _, field := range item.Configuration {
+				fields = append(fields, field.Name)
+			}
+
+			assert.Contains(t, fields, "projectKey")
+			assert.Contains(t, fields, "flagKey")
+		}
+	})
+}
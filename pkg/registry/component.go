@@ -15,10 +15,29 @@ import (
  */
 type PanicableComponent struct {
 	underlying core.Component
+
+	//
+	// errorReporter describes the errors emitted when the node continues on error.
+	// It is nil for components that cannot continue on error.
+	//
+	errorReporter core.ErrorReporter
 }
 
 func NewPanicableComponent(c core.Component) core.Component {
-	return &PanicableComponent{underlying: c}
+	return newPanicableComponent(c, nil)
+}
+
+/*
+ * newPanicableComponent wraps a component of an integration,
+ * which continues on error with the integration's reporter when it has no reporter of its own.
+ */
+func newPanicableComponent(c core.Component, integrationReporter core.ErrorReporter) core.Component {
+	reporter, ok := c.(core.ErrorReporter)
+	if !ok {
+		reporter = integrationReporter
+	}
+
+	return &PanicableComponent{underlying: c, errorReporter: reporter}
 }
 
 /*
@@ -54,7 +73,11 @@ func (s *PanicableComponent) ExampleOutput() map[string]any {
 }
 
 func (s *PanicableComponent) Configuration() []configuration.Field {
-	return s.underlying.Configuration()
+	if s.errorReporter == nil {
+		return s.underlying.Configuration()
+	}
+
+	return withContinueOnErrorField(s.underlying.Configuration())
 }
 
 func (s *PanicableComponent) Actions() []core.Action {
@@ -62,7 +85,11 @@ func (s *PanicableComponent) Actions() []core.Action {
 }
 
 func (s *PanicableComponent) OutputChannels(config any) []core.OutputChannel {
-	return s.underlying.OutputChannels(config)
+	if s.errorReporter == nil || !ContinuesOnError(config) {
+		return s.underlying.OutputChannels(config)
+	}
+
+	return withErrorOutputChannel(s.underlying.OutputChannels(config))
 }
 
 /*
@@ -79,10 +106,30 @@ func (s *PanicableComponent) Setup(ctx core.SetupContext) (err error) {
 				s.underlying.Name(), r)
 		}
 	}()
+
+	ctx.HTTP = withHTTPTrace(ctx.HTTP, ctx.Logger)
 	return s.underlying.Setup(ctx)
 }
 
-func (s *PanicableComponent) Execute(ctx core.ExecutionContext) (err error) {
+/*
+ * Execute runs the component. When its node continues on error,
+ * errors are emitted on the error channel instead of failing the node,
+ * unless the component already finished the execution itself.
+ */
+func (s *PanicableComponent) Execute(ctx core.ExecutionContext) error {
+	err := s.execute(ctx)
+	if err == nil || s.errorReporter == nil || !ContinuesOnError(ctx.Configuration) {
+		return err
+	}
+
+	if ctx.ExecutionState == nil || ctx.ExecutionState.IsFinished() {
+		return err
+	}
+
+	return emitError(ctx, s.errorReporter, err)
+}
+
+func (s *PanicableComponent) execute(ctx core.ExecutionContext) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			ctx.Logger.Errorf("Component %s panicked in Execute(): %v\nStack: %s",
@@ -91,6 +138,17 @@ func (s *PanicableComponent) Execute(ctx core.ExecutionContext) (err error) {
 				s.underlying.Name(), r)
 		}
 	}()
+
+	//
+	// Retries go through the tracer, so every attempt is traced,
+	// and not only the one whose response the component sees.
+	//
+	ctx.HTTP = withHTTPTrace(ctx.HTTP, ctx.Logger)
+
+	if provider, ok := s.underlying.(core.RetryPolicyProvider); ok && ctx.HTTP != nil {
+		ctx.HTTP = NewRetryingHTTPContext(ctx.HTTP, provider.RetryPolicy())
+	}
+
 	return s.underlying.Execute(ctx)
 }
 
@@ -1,6 +1,10 @@
 package registry
 
 import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -10,6 +14,7 @@ import (
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
 // panickingComponent is a component that panics in all panicable methods
@@ -67,6 +72,193 @@ func TestPanicableComponent_Execute_CatchesPanic(t *testing.T) {
 	assert.Contains(t, err.Error(), "execute panic")
 }
 
+// retryingComponent records the HTTP context it is executed with
+type retryingComponent struct {
+	panickingComponent
+	http core.HTTPContext
+}
+
+func (r *retryingComponent) Execute(ctx core.ExecutionContext) error {
+	r.http = ctx.HTTP
+	return nil
+}
+
+func (r *retryingComponent) RetryPolicy() core.RetryPolicy {
+	return core.RetryPolicy{MaxAttempts: 2, RetryableStatuses: []int{429}}
+}
+
+func TestPanicableComponent_Execute_AppliesRetryPolicy(t *testing.T) {
+	comp := &retryingComponent{panickingComponent: panickingComponent{name: "retrying-comp"}}
+	panicable := NewPanicableComponent(comp)
+	httpCtx := &contexts.HTTPContext{}
+
+	err := panicable.Execute(core.ExecutionContext{
+		Logger: log.NewEntry(log.StandardLogger()),
+		HTTP:   httpCtx,
+	})
+
+	require.NoError(t, err)
+	retrying, ok := comp.http.(*RetryingHTTPContext)
+	require.True(t, ok)
+	assert.Same(t, httpCtx, retrying.underlying)
+	assert.Equal(t, comp.RetryPolicy(), retrying.policy)
+}
+
+func TestPanicableComponent_Execute_TracesRequestsWhenEnabled(t *testing.T) {
+	httpTraceEnabled = func() bool { return true }
+	t.Cleanup(func() { httpTraceEnabled = func() bool { return false } })
+
+	comp := &retryingComponent{panickingComponent: panickingComponent{name: "retrying-comp"}}
+	panicable := NewPanicableComponent(comp)
+	httpCtx := &contexts.HTTPContext{}
+
+	err := panicable.Execute(core.ExecutionContext{
+		Logger: log.NewEntry(log.StandardLogger()),
+		HTTP:   httpCtx,
+	})
+
+	require.NoError(t, err)
+	retrying, ok := comp.http.(*RetryingHTTPContext)
+	require.True(t, ok)
+	tracing, ok := retrying.underlying.(*TracingHTTPContext)
+	require.True(t, ok)
+	assert.Same(t, httpCtx, tracing.underlying)
+}
+
+// tracedRequestComponent sends a request and traces its response, like integration clients do
+type tracedRequestComponent struct {
+	retryingComponent
+}
+
+func (r *tracedRequestComponent) Execute(ctx core.ExecutionContext) error {
+	request, err := http.NewRequest(http.MethodGet, "https://api.example.com/items", nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := ctx.HTTP.Do(request)
+	if err != nil {
+		TraceHTTP(ctx.HTTP, request, 0, nil, err)
+		return err
+	}
+
+	defer response.Body.Close()
+	TraceHTTP(ctx.HTTP, request, response.StatusCode, nil, nil)
+	return nil
+}
+
+func TestPanicableComponent_Execute_TracesEveryRetriedAttempt(t *testing.T) {
+	httpTraceEnabled = func() bool { return true }
+	t.Cleanup(func() { httpTraceEnabled = func() bool { return false } })
+
+	output := &bytes.Buffer{}
+	logger := log.New()
+	logger.SetOutput(output)
+	logger.SetLevel(log.DebugLevel)
+
+	comp := &tracedRequestComponent{retryingComponent{panickingComponent: panickingComponent{name: "traced-comp"}}}
+	httpCtx := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			retryResponse(http.StatusTooManyRequests, ""),
+			retryResponse(http.StatusOK, ""),
+		},
+	}
+
+	err := NewPanicableComponent(comp).Execute(core.ExecutionContext{
+		Logger: log.NewEntry(logger),
+		HTTP:   httpCtx,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, httpCtx.Requests, 2)
+	assert.Equal(t, 2, strings.Count(output.String(), "Integration request GET https://api.example.com/items"))
+	assert.Contains(t, output.String(), "-> 429")
+	assert.Contains(t, output.String(), "-> 200")
+}
+
+type failingComponent struct {
+	panickingComponent
+	err error
+}
+
+func (f *failingComponent) Execute(ctx core.ExecutionContext) error {
+	return f.err
+}
+
+func (f *failingComponent) DescribeError(err error) core.ErrorPayload {
+	return core.HTTPErrorPayload(http.StatusTooManyRequests, err.Error())
+}
+
+func TestPanicableComponent_ContinueOnError(t *testing.T) {
+	comp := &failingComponent{panickingComponent: panickingComponent{name: "failing-comp"}, err: errors.New("rate limited")}
+	panicable := NewPanicableComponent(comp)
+
+	t.Run("continueOnError field is added to the configuration", func(t *testing.T) {
+		fields := panicable.Configuration()
+		require.Len(t, fields, 1)
+		assert.Equal(t, core.ContinueOnErrorField, fields[0].Name)
+		assert.Equal(t, configuration.FieldTypeBool, fields[0].Type)
+	})
+
+	t.Run("error channel is only added when continueOnError is set", func(t *testing.T) {
+		assert.Nil(t, panicable.OutputChannels(map[string]any{}))
+		assert.Equal(t,
+			[]core.OutputChannel{core.DefaultOutputChannel, core.ErrorOutputChannel},
+			panicable.OutputChannels(map[string]any{core.ContinueOnErrorField: true}),
+		)
+	})
+
+	t.Run("continueOnError not set -> error is returned", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := panicable.Execute(core.ExecutionContext{
+			Logger:         log.NewEntry(log.StandardLogger()),
+			Configuration:  map[string]any{},
+			ExecutionState: state,
+		})
+
+		require.ErrorContains(t, err, "rate limited")
+		assert.False(t, state.Finished)
+	})
+
+	t.Run("continueOnError set -> error payload is emitted on the error channel", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := panicable.Execute(core.ExecutionContext{
+			Logger:         log.NewEntry(log.StandardLogger()),
+			Configuration:  map[string]any{core.ContinueOnErrorField: true},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.Passed)
+		assert.Equal(t, core.ErrorOutputChannel.Name, state.Channel)
+		assert.Equal(t, core.ErrorPayloadType, state.Type)
+		require.Len(t, state.Payloads, 1)
+		assert.Equal(t, map[string]any{
+			"code":      core.ErrorCodeRateLimited,
+			"message":   "rate limited",
+			"retryable": true,
+		}, state.Payloads[0].(map[string]any)["data"])
+	})
+
+	t.Run("continueOnError set and execution already finished -> error is returned", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{Finished: true}
+		err := panicable.Execute(core.ExecutionContext{
+			Logger:         log.NewEntry(log.StandardLogger()),
+			Configuration:  map[string]any{core.ContinueOnErrorField: true},
+			ExecutionState: state,
+		})
+
+		require.ErrorContains(t, err, "rate limited")
+		assert.Empty(t, state.Payloads)
+	})
+
+	t.Run("component without reporter -> continueOnError is ignored", func(t *testing.T) {
+		panicable := NewPanicableComponent(&retryingComponent{panickingComponent: panickingComponent{name: "retrying-comp"}})
+		assert.Empty(t, panicable.Configuration())
+		assert.Nil(t, panicable.OutputChannels(map[string]any{core.ContinueOnErrorField: true}))
+	})
+}
+
 func TestPanicableComponent_ProcessQueueItem_CatchesPanic(t *testing.T) {
 	comp := &panickingComponent{name: "panicking-comp"}
 	panicable := NewPanicableComponent(comp)
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+/*
+ * ContinueOnErrorConfigField is added to the configuration of components
+ * that can continue on error, see core.ErrorReporter.
+ */
+func ContinueOnErrorConfigField() configuration.Field {
+	return configuration.Field{
+		Name:        core.ContinueOnErrorField,
+		Label:       "Continue On Error",
+		Type:        configuration.FieldTypeBool,
+		Required:    false,
+		Default:     false,
+		Description: "Emit the error on the error channel instead of failing the node",
+	}
+}
+
+// ContinuesOnError reports whether Continue On Error is set in the node configuration.
+func ContinuesOnError(config any) bool {
+	values, ok := config.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	switch value := values[core.ContinueOnErrorField].(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(strings.TrimSpace(value), "true")
+	default:
+		return false
+	}
+}
+
+func withContinueOnErrorField(fields []configuration.Field) []configuration.Field {
+	if slices.ContainsFunc(fields, func(field configuration.Field) bool { return field.Name == core.ContinueOnErrorField }) {
+		return fields
+	}
+
+	return append(slices.Clone(fields), ContinueOnErrorConfigField())
+}
+
+func withErrorOutputChannel(channels []core.OutputChannel) []core.OutputChannel {
+	if len(channels) == 0 {
+		channels = []core.OutputChannel{core.DefaultOutputChannel}
+	}
+
+	if slices.ContainsFunc(channels, func(channel core.OutputChannel) bool { return channel.Name == core.ErrorOutputChannel.Name }) {
+		return channels
+	}
+
+	return append(slices.Clone(channels), core.ErrorOutputChannel)
+}
+
+/*
+ * emitError passes the execution, emitting the standardized payload of the error
+ * on the error channel. Codes and messages left empty by the reporter are filled in,
+ * so the payload always has the same shape.
+ */
+func emitError(ctx core.ExecutionContext, reporter core.ErrorReporter, err error) error {
+	payload := reporter.DescribeError(err)
+	if payload.Code == "" {
+		payload.Code = core.ErrorCodeFailed
+	}
+
+	if payload.Message == "" {
+		payload.Message = err.Error()
+	}
+
+	if ctx.Logger != nil {
+		ctx.Logger.Warnf("Execution failed, emitting error on the %s channel: %v", core.ErrorOutputChannel.Name, err)
+	}
+
+	return ctx.ExecutionState.Emit(core.ErrorOutputChannel.Name, core.ErrorPayloadType, []any{
+		map[string]any{
+			"code":      payload.Code,
+			"message":   payload.Message,
+			"retryable": payload.Retryable,
+		},
+	})
+}
@@ -96,6 +96,83 @@ func NewHTTPContext(options HTTPOptions) (*HTTPContext, error) {
 	return httpCtx, nil
 }
 
+/*
+ * WithProxy returns a copy of the HTTP context that sends requests through the proxy.
+ * The dialer only connects to the proxy, so the private IP checks are applied to the proxy connection,
+ * and request hosts are resolved and checked before they are handed to the proxy.
+ * The proxy resolves them again, so this does not cover DNS rebinding between the two lookups.
+ */
+func (c *HTTPContext) WithProxy(options *ProxyOptions) (*HTTPContext, error) {
+	transport, err := c.cloneTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	transport.Proxy = func(request *http.Request) (*url.URL, error) {
+		proxyURL, err := options.Proxy(request)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+
+		if err := c.validateProxiedHost(request.Context(), request.URL.Hostname()); err != nil {
+			return nil, fmt.Errorf("proxied request blocked: %w", err)
+		}
+
+		return proxyURL, nil
+	}
+
+	client := *c.client
+	client.Transport = transport
+
+	return &HTTPContext{
+		client:           &client,
+		dialer:           c.dialer,
+		blockedHosts:     c.blockedHosts,
+		privateIPRanges:  c.privateIPRanges,
+		maxResponseBytes: c.maxResponseBytes,
+	}, nil
+}
+
+/*
+ * cloneTransport copies the transport of the HTTP context,
+ * so derived contexts can change its settings without affecting the shared one.
+ */
+func (c *HTTPContext) cloneTransport() (*http.Transport, error) {
+	transport, ok := c.client.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unsupported HTTP transport %T", c.client.Transport)
+	}
+
+	return transport.Clone(), nil
+}
+
+/*
+ * validateProxiedHost resolves a host the proxy will connect to,
+ * and checks its addresses against the private IP ranges.
+ */
+func (c *HTTPContext) validateProxiedHost(ctx context.Context, host string) error {
+	if len(c.privateIPRanges) == 0 {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return c.validateIP(ip)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	for _, addr := range addrs {
+		if err := c.validateIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *HTTPContext) Do(request *http.Request) (*http.Response, error) {
 	if len(c.privateIPRanges) == 0 && len(c.blockedHosts) == 0 {
 		return c.do(request)
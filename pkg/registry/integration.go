@@ -3,6 +3,7 @@ package registry
 import (
 	"fmt"
 	"runtime/debug"
+	"slices"
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
@@ -45,7 +46,14 @@ func (s *PanicableIntegration) Instructions() string {
 }
 
 func (s *PanicableIntegration) Configuration() []configuration.Field {
-	return s.underlying.Configuration()
+	fields := slices.Clone(s.underlying.Configuration())
+	for _, field := range append(ProxyConfigurationFields(), TransportConfigurationFields()...) {
+		if !slices.ContainsFunc(fields, func(f configuration.Field) bool { return f.Name == field.Name }) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
 }
 
 func (s *PanicableIntegration) Actions() []core.Action {
@@ -54,9 +62,10 @@ func (s *PanicableIntegration) Actions() []core.Action {
 
 func (s *PanicableIntegration) Components() []core.Component {
 	components := s.underlying.Components()
+	reporter, _ := s.underlying.(core.ErrorReporter)
 	safe := make([]core.Component, len(components))
 	for i, c := range components {
-		safe[i] = NewPanicableComponent(c)
+		safe[i] = newPanicableComponent(c, reporter)
 	}
 	return safe
 }
@@ -82,6 +91,17 @@ func (s *PanicableIntegration) Sync(ctx core.SyncContext) (err error) {
 				s.underlying.Name(), r)
 		}
 	}()
+
+	//
+	// Integrations only sync configurations that match their fields,
+	// so they do not need to check them on their own.
+	//
+	config, _ := ctx.Configuration.(map[string]any)
+	if err := configuration.ValidateFields(s.underlying.Configuration(), config); err != nil {
+		return err
+	}
+
+	ctx.HTTP = withHTTPTrace(ctx.HTTP, ctx.Logger)
 	return s.underlying.Sync(ctx)
 }
 
@@ -118,6 +138,23 @@ func (s *PanicableIntegration) ListResources(resourceType string, ctx core.ListR
 	return s.underlying.ListResources(resourceType, ctx)
 }
 
+func (s *PanicableIntegration) ListResourcesWithChildren(resourceType, childType string, ctx core.ListResourcesContext) (resources []core.IntegrationResourceWithChildren, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			resources = nil
+			err = fmt.Errorf("integration %s panicked in ListResourcesWithChildren(): %v",
+				s.underlying.Name(), r)
+		}
+	}()
+
+	lister, ok := s.underlying.(core.BatchResourceLister)
+	if !ok {
+		return nil, fmt.Errorf("integration %s does not support listing resources with children", s.underlying.Name())
+	}
+
+	return lister.ListResourcesWithChildren(resourceType, childType, ctx)
+}
+
 func (s *PanicableIntegration) HandleRequest(ctx core.HTTPRequestContext) {
 	defer func() {
 		if r := recover(); r != nil {
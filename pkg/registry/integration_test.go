@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"errors"
 	"net/http/httptest"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
 )
 
 // panickingIntegration is an integration that panics in all panicable methods
@@ -52,6 +54,28 @@ func TestPanicableIntegration_Sync_CatchesPanic(t *testing.T) {
 	assert.Contains(t, err.Error(), "sync panic")
 }
 
+// requiredFieldIntegration panics like panickingIntegration, but has a required field,
+// so Sync is only reached with a valid configuration.
+type requiredFieldIntegration struct {
+	panickingIntegration
+}
+
+func (p *requiredFieldIntegration) Configuration() []configuration.Field {
+	return []configuration.Field{{Name: "apiKey", Type: configuration.FieldTypeString, Required: true}}
+}
+
+func TestPanicableIntegration_Sync_ValidatesConfiguration(t *testing.T) {
+	panicable := NewPanicableIntegration(&requiredFieldIntegration{})
+
+	err := panicable.Sync(core.SyncContext{Configuration: map[string]any{"apiKey": " "}})
+	require.Error(t, err)
+	assert.Equal(t, "field 'apiKey' is required", err.Error())
+
+	err = panicable.Sync(core.SyncContext{Configuration: map[string]any{"apiKey": "secret"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sync panic")
+}
+
 func TestPanicableIntegration_HandleRequest_CatchesPanic(t *testing.T) {
 	integration := &panickingIntegration{}
 	panicable := NewPanicableIntegration(integration)
@@ -65,3 +89,84 @@ func TestPanicableIntegration_HandleRequest_CatchesPanic(t *testing.T) {
 
 	assert.Equal(t, 500, recorder.Code)
 }
+
+// panickingBatchIntegration also panics when listing resources with children
+type panickingBatchIntegration struct {
+	panickingIntegration
+}
+
+func (p *panickingBatchIntegration) ListResourcesWithChildren(resourceType, childType string, ctx core.ListResourcesContext) ([]core.IntegrationResourceWithChildren, error) {
+	panic("list resources with children panic")
+}
+
+func TestPanicableIntegration_ListResourcesWithChildren(t *testing.T) {
+	t.Run("panic is caught", func(t *testing.T) {
+		panicable := NewPanicableIntegration(&panickingBatchIntegration{})
+		lister, ok := panicable.(core.BatchResourceLister)
+		require.True(t, ok)
+
+		resources, err := lister.ListResourcesWithChildren("project", "environment", core.ListResourcesContext{})
+
+		require.Error(t, err)
+		assert.Nil(t, resources)
+		assert.Contains(t, err.Error(), "panicked in ListResourcesWithChildren()")
+	})
+
+	t.Run("unsupported integration -> error", func(t *testing.T) {
+		panicable := NewPanicableIntegration(&panickingIntegration{})
+		lister, ok := panicable.(core.BatchResourceLister)
+		require.True(t, ok)
+
+		_, err := lister.ListResourcesWithChildren("project", "environment", core.ListResourcesContext{})
+		require.ErrorContains(t, err, "integration panicking-integration does not support listing resources with children")
+	})
+}
+
+type reportingIntegration struct {
+	panickingIntegration
+	components []core.Component
+}
+
+func (r *reportingIntegration) Components() []core.Component {
+	return r.components
+}
+
+func (r *reportingIntegration) DescribeError(err error) core.ErrorPayload {
+	return core.ErrorPayload{Code: "integration_error"}
+}
+
+func TestPanicableIntegration_Components_ContinueOnError(t *testing.T) {
+	comp := &failingComponent{panickingComponent: panickingComponent{name: "failing-comp"}, err: errors.New("boom")}
+	plain := &panickingComponent{name: "plain-comp"}
+	panicable := NewPanicableIntegration(&reportingIntegration{components: []core.Component{comp, plain}})
+
+	components := panicable.Components()
+	require.Len(t, components, 2)
+
+	t.Run("component reporter takes precedence", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := components[0].Execute(core.ExecutionContext{
+			Configuration:  map[string]any{core.ContinueOnErrorField: true},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, core.ErrorCodeRateLimited, state.Payloads[0].(map[string]any)["data"].(map[string]any)["code"])
+	})
+
+	t.Run("integration reporter is used for the other components", func(t *testing.T) {
+		state := &contexts.ExecutionStateContext{}
+		err := components[1].Execute(core.ExecutionContext{
+			Logger:         log.NewEntry(log.StandardLogger()),
+			Configuration:  map[string]any{core.ContinueOnErrorField: "true"},
+			ExecutionState: state,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"code":      "integration_error",
+			"message":   "component plain-comp panicked in Execute(): execute panic",
+			"retryable": false,
+		}, state.Payloads[0].(map[string]any)["data"])
+	})
+}
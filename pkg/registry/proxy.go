@@ -0,0 +1,165 @@
+package registry
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	ProxyURLConfigField = "proxyUrl"
+	NoProxyConfigField  = "noProxy"
+)
+
+/*
+ * ProxyConfigurationFields are added to the configuration of every integration,
+ * so outbound requests made for an integration can go through an HTTP proxy.
+ */
+func ProxyConfigurationFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        ProxyURLConfigField,
+			Label:       "Proxy URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "e.g. http://proxy.example.com:3128",
+			Description: "HTTP proxy used for requests made by this integration",
+		},
+		{
+			Name:        NoProxyConfigField,
+			Label:       "No Proxy",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Placeholder: "e.g. internal.example.com,.corp.example.com,10.0.0.0/8",
+			Description: "Comma-separated hosts, domains, or CIDR ranges that bypass the proxy",
+		},
+	}
+}
+
+type ProxyOptions struct {
+	URL     *url.URL
+	NoProxy []string
+}
+
+/*
+ * ParseProxyOptions reads the proxy settings from an integration configuration.
+ * It returns nil options when no proxy is configured.
+ */
+func ParseProxyOptions(config map[string]any) (*ProxyOptions, error) {
+	rawURL, _ := config[ProxyURLConfigField].(string)
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	scheme := strings.ToLower(proxyURL.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return nil, fmt.Errorf("invalid proxy URL: only http and https schemes are allowed")
+	}
+
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL: URL must have a host")
+	}
+
+	options := &ProxyOptions{URL: proxyURL, NoProxy: []string{}}
+	rawNoProxy, _ := config[NoProxyConfigField].(string)
+	for _, entry := range strings.Split(rawNoProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry != "" {
+			options.NoProxy = append(options.NoProxy, entry)
+		}
+	}
+
+	return options, nil
+}
+
+/*
+ * Proxy returns the proxy URL to use for a request,
+ * or nil if the request host matches one of the NoProxy entries.
+ */
+func (o *ProxyOptions) Proxy(request *http.Request) (*url.URL, error) {
+	if o.bypasses(request.URL) {
+		return nil, nil
+	}
+
+	return o.URL, nil
+}
+
+/*
+ * NoProxy entries follow the NO_PROXY conventions:
+ * "*" matches every host, "example.com" and ".example.com" match the domain and its subdomains,
+ * "host:port" only matches that port, and CIDR ranges match IP addresses.
+ */
+func (o *ProxyOptions) bypasses(target *url.URL) bool {
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = defaultPort(target.Scheme)
+	}
+
+	ip := net.ParseIP(host)
+	for _, entry := range o.NoProxy {
+		if entry == "*" {
+			return true
+		}
+
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && ipNet.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+		if err != nil {
+			entryHost, entryPort = entry, ""
+		}
+
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+
+		entryHost = strings.TrimPrefix(entryHost, ".")
+		if host == entryHost || strings.HasSuffix(host, "."+entryHost) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func defaultPort(scheme string) string {
+	if strings.ToLower(scheme) == "https" {
+		return "443"
+	}
+
+	return "80"
+}
+
+func (o *ProxyOptions) cacheKey() string {
+	return o.URL.String() + "|" + strings.Join(o.NoProxy, ",")
+}
+
+/*
+ * failingHTTPContext is used when an integration has an invalid proxy configuration.
+ * Requests fail instead of silently bypassing the proxy.
+ */
+type failingHTTPContext struct {
+	err error
+}
+
+func (c *failingHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	return nil, c.err
+}
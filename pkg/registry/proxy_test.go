@@ -0,0 +1,267 @@
+package registry
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/crypto"
+)
+
+func Test__ParseProxyOptions(t *testing.T) {
+	t.Run("no proxy configured -> nil options", func(t *testing.T) {
+		options, err := ParseProxyOptions(map[string]any{"apiKey": "test"})
+		require.NoError(t, err)
+		assert.Nil(t, options)
+
+		options, err = ParseProxyOptions(map[string]any{ProxyURLConfigField: "  "})
+		require.NoError(t, err)
+		assert.Nil(t, options)
+	})
+
+	t.Run("valid proxy URL and no proxy list", func(t *testing.T) {
+		options, err := ParseProxyOptions(map[string]any{
+			ProxyURLConfigField: "http://proxy.example.com:3128",
+			NoProxyConfigField:  " internal.example.com, .corp.example.com,,10.0.0.0/8 ",
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, options)
+		assert.Equal(t, "proxy.example.com:3128", options.URL.Host)
+		assert.Equal(t, []string{"internal.example.com", ".corp.example.com", "10.0.0.0/8"}, options.NoProxy)
+	})
+
+	t.Run("unsupported scheme -> error", func(t *testing.T) {
+		_, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: "socks5://proxy.example.com:1080"})
+		require.ErrorContains(t, err, "only http and https schemes are allowed")
+	})
+
+	t.Run("missing host -> error", func(t *testing.T) {
+		_, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: "http://"})
+		require.ErrorContains(t, err, "URL must have a host")
+	})
+
+	t.Run("unparseable URL -> error", func(t *testing.T) {
+		_, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: "http://proxy example.com"})
+		require.ErrorContains(t, err, "invalid proxy URL")
+	})
+}
+
+func Test__ProxyOptions__Proxy(t *testing.T) {
+	options, err := ParseProxyOptions(map[string]any{
+		ProxyURLConfigField: "http://proxy.example.com:3128",
+		NoProxyConfigField:  "internal.example.com,.corp.example.com,api.example.com:8443,10.0.0.0/8",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		url     string
+		proxied bool
+	}{
+		{url: "https://api.github.com/repos", proxied: true},
+		{url: "https://internal.example.com/api", proxied: false},
+		{url: "https://svc.internal.example.com/api", proxied: false},
+		{url: "https://corp.example.com", proxied: false},
+		{url: "https://build.corp.example.com", proxied: false},
+		{url: "https://notcorp.example.com", proxied: true},
+		{url: "https://api.example.com:8443/v1", proxied: false},
+		{url: "https://api.example.com/v1", proxied: true},
+		{url: "http://10.1.2.3/health", proxied: false},
+		{url: "http://11.1.2.3/health", proxied: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			proxyURL, err := options.Proxy(request)
+			require.NoError(t, err)
+			if tt.proxied {
+				assert.Equal(t, options.URL, proxyURL)
+			} else {
+				assert.Nil(t, proxyURL)
+			}
+		})
+	}
+
+	t.Run("wildcard bypasses every host", func(t *testing.T) {
+		options := &ProxyOptions{URL: options.URL, NoProxy: []string{"*"}}
+		request, err := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+		require.NoError(t, err)
+
+		proxyURL, err := options.Proxy(request)
+		require.NoError(t, err)
+		assert.Nil(t, proxyURL)
+	})
+}
+
+func Test__HTTPContext__WithProxy(t *testing.T) {
+	//
+	// The stub proxy receives absolute-form requests for the target host,
+	// and answers them without forwarding.
+	//
+	var proxiedURLs []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURLs = append(proxiedURLs, r.URL.String())
+		_, _ = w.Write([]byte("from proxy"))
+	}))
+	defer proxy.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("from target"))
+	}))
+	defer target.Close()
+
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+
+	base, err := NewHTTPContext(HTTPOptions{})
+	require.NoError(t, err)
+
+	t.Run("request is routed through the proxy", func(t *testing.T) {
+		proxiedURLs = nil
+		options, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: proxy.URL})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodGet, "http://api.example.com/v1/items", nil)
+		require.NoError(t, err)
+
+		proxied, err := base.WithProxy(options)
+		require.NoError(t, err)
+
+		response, err := proxied.Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "from proxy", string(body))
+		assert.Equal(t, []string{"http://api.example.com/v1/items"}, proxiedURLs)
+	})
+
+	t.Run("no proxy host is requested directly", func(t *testing.T) {
+		proxiedURLs = nil
+		options, err := ParseProxyOptions(map[string]any{
+			ProxyURLConfigField: proxy.URL,
+			NoProxyConfigField:  targetURL.Hostname(),
+		})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodGet, target.URL, nil)
+		require.NoError(t, err)
+
+		proxied, err := base.WithProxy(options)
+		require.NoError(t, err)
+
+		response, err := proxied.Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "from target", string(body))
+		assert.Empty(t, proxiedURLs)
+	})
+
+	t.Run("proxy connection is subject to private IP checks", func(t *testing.T) {
+		restricted, err := NewHTTPContext(defaultHTTPOptions())
+		require.NoError(t, err)
+
+		options, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: proxy.URL})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodGet, "http://203.0.113.10/v1/items", nil)
+		require.NoError(t, err)
+
+		proxied, err := restricted.WithProxy(options)
+		require.NoError(t, err)
+
+		_, err = proxied.Do(request)
+		require.ErrorContains(t, err, "connection blocked")
+	})
+
+	t.Run("proxied host is subject to private IP checks", func(t *testing.T) {
+		restricted, err := NewHTTPContext(HTTPOptions{PrivateIPRanges: []string{"127.0.0.0/8"}})
+		require.NoError(t, err)
+
+		options, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: proxy.URL})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodGet, "http://localhost/v1/items", nil)
+		require.NoError(t, err)
+
+		proxied, err := restricted.WithProxy(options)
+		require.NoError(t, err)
+
+		proxiedURLs = nil
+		_, err = proxied.Do(request)
+		require.ErrorContains(t, err, "proxied request blocked")
+		assert.Empty(t, proxiedURLs)
+	})
+
+	t.Run("wrapped transport -> error", func(t *testing.T) {
+		wrapped := &HTTPContext{client: &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}}
+
+		options, err := ParseProxyOptions(map[string]any{ProxyURLConfigField: proxy.URL})
+		require.NoError(t, err)
+
+		_, err = wrapped.WithProxy(options)
+		require.ErrorContains(t, err, "unsupported HTTP transport")
+	})
+}
+
+func Test__Registry__HTTPContextForIntegration(t *testing.T) {
+	reg, err := NewRegistry(&crypto.NoOpEncryptor{}, HTTPOptions{})
+	require.NoError(t, err)
+
+	t.Run("no proxy -> shared HTTP context", func(t *testing.T) {
+		assert.Same(t, reg.HTTPContext(), reg.HTTPContextForIntegration(map[string]any{}))
+	})
+
+	t.Run("same proxy configuration -> cached HTTP context", func(t *testing.T) {
+		config := map[string]any{ProxyURLConfigField: "http://proxy.example.com:3128"}
+		first := reg.HTTPContextForIntegration(config)
+		second := reg.HTTPContextForIntegration(config)
+
+		assert.NotSame(t, reg.HTTPContext(), first)
+		assert.Same(t, first, second)
+	})
+
+	t.Run("invalid proxy configuration -> requests fail", func(t *testing.T) {
+		httpCtx := reg.HTTPContextForIntegration(map[string]any{ProxyURLConfigField: "ftp://proxy.example.com"})
+
+		request, err := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+		require.NoError(t, err)
+
+		_, err = httpCtx.Do(request)
+		require.ErrorContains(t, err, "invalid proxy URL")
+	})
+}
+
+func Test__PanicableIntegration__ProxyConfiguration(t *testing.T) {
+	integration := NewPanicableIntegration(&panickingIntegration{})
+
+	names := []string{}
+	for _, field := range integration.Configuration() {
+		names = append(names, field.Name)
+	}
+
+	assert.Equal(t, []string{
+		ProxyURLConfigField,
+		NoProxyConfigField,
+		MaxIdleConnsConfigField,
+		IdleConnTimeoutConfigField,
+		KeepAliveConfigField,
+	}, names)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -16,6 +16,7 @@ var (
 	registeredIntegrations    = make(map[string]core.Integration)
 	registeredWebhookHandlers = make(map[string]core.WebhookHandler)
 	registeredWidgets         = make(map[string]core.Widget)
+	registeredShutdownHooks   = make(map[string]func())
 	mu                        sync.RWMutex
 )
 
@@ -50,6 +51,14 @@ func RegisterWidget(name string, w core.Widget) {
 	registeredWidgets[name] = w
 }
 
+// RegisterShutdownHook registers a function that is called when the process shuts down,
+// for integrations that keep work in memory, like events that are not sent yet.
+func RegisterShutdownHook(name string, hook func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	registeredShutdownHooks[name] = hook
+}
+
 type IntegrationRegistration struct {
 	Name           string
 	Integration    core.Integration
@@ -57,13 +66,14 @@ type IntegrationRegistration struct {
 }
 
 type Registry struct {
-	httpCtx         *HTTPContext
-	Encryptor       crypto.Encryptor
-	Integrations    map[string]core.Integration
-	WebhookHandlers map[string]core.WebhookHandler
-	Components      map[string]core.Component
-	Triggers        map[string]core.Trigger
-	Widgets         map[string]core.Widget
+	httpCtx             *HTTPContext
+	integrationHTTPCtxs sync.Map
+	Encryptor           crypto.Encryptor
+	Integrations        map[string]core.Integration
+	WebhookHandlers     map[string]core.WebhookHandler
+	Components          map[string]core.Component
+	Triggers            map[string]core.Trigger
+	Widgets             map[string]core.Widget
 }
 
 func NewRegistry(encryptor crypto.Encryptor, httpOptions HTTPOptions) (*Registry, error) {
@@ -119,10 +129,114 @@ func (r *Registry) Init() {
 	}
 }
 
+// Shutdown calls the registered shutdown hooks and waits for all of them to return.
+func (r *Registry) Shutdown() {
+	mu.RLock()
+	hooks := make([]func(), 0, len(registeredShutdownHooks))
+	for _, hook := range registeredShutdownHooks {
+		hooks = append(hooks, hook)
+	}
+	mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, hook := range hooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hook()
+		}()
+	}
+
+	wg.Wait()
+}
+
 func (r *Registry) HTTPContext() *HTTPContext {
 	return r.httpCtx
 }
 
+/*
+ * HTTPContextForIntegration returns the HTTP context to use for requests made for an integration.
+ * If the integration configures a proxy, requests go through it,
+ * and if it configures connection pooling settings, they are applied to its connections.
+ * Contexts are cached per configuration, so connections are reused across requests.
+ */
+func (r *Registry) HTTPContextForIntegration(config map[string]any) core.HTTPContext {
+	proxy, err := ParseProxyOptions(config)
+	if err != nil {
+		return &failingHTTPContext{err: err}
+	}
+
+	transport, err := ParseTransportOptions(config)
+	if err != nil {
+		return &failingHTTPContext{err: err}
+	}
+
+	if proxy == nil && transport == nil {
+		return r.httpCtx
+	}
+
+	key := integrationHTTPContextKey(proxy, transport)
+	if httpCtx, ok := r.integrationHTTPCtxs.Load(key); ok {
+		return httpCtx.(core.HTTPContext)
+	}
+
+	httpCtx, _ := r.integrationHTTPCtxs.LoadOrStore(key, r.newIntegrationHTTPContext(proxy, transport))
+	return httpCtx.(core.HTTPContext)
+}
+
+/*
+ * newIntegrationHTTPContext builds the HTTP context of an integration from the shared one.
+ * Either of the options may be nil. If the HTTP context cannot be built, its requests fail.
+ */
+func (r *Registry) newIntegrationHTTPContext(proxy *ProxyOptions, transport *TransportOptions) core.HTTPContext {
+	httpCtx := r.httpCtx
+	if proxy != nil {
+		proxied, err := httpCtx.WithProxy(proxy)
+		if err != nil {
+			return &failingHTTPContext{err: err}
+		}
+
+		httpCtx = proxied
+	}
+
+	if transport != nil {
+		pooled, err := httpCtx.WithTransport(transport)
+		if err != nil {
+			return &failingHTTPContext{err: err}
+		}
+
+		httpCtx = pooled
+	}
+
+	return httpCtx
+}
+
+func integrationHTTPContextKey(proxy *ProxyOptions, transport *TransportOptions) string {
+	key := "proxy:"
+	if proxy != nil {
+		key += proxy.cacheKey()
+	}
+
+	key += "#transport:"
+	if transport != nil {
+		key += transport.cacheKey()
+	}
+
+	return key
+}
+
+/*
+ * ValidateHTTPConfiguration checks the proxy and connection pooling settings of an integration.
+ */
+func (r *Registry) ValidateHTTPConfiguration(config map[string]any) error {
+	if _, err := ParseProxyOptions(config); err != nil {
+		return err
+	}
+
+	_, err := ParseTransportOptions(config)
+	return err
+}
+
 func (r *Registry) ListTriggers() []core.Trigger {
 	triggers := make([]core.Trigger, 0, len(r.Triggers))
 	for _, trigger := range r.Triggers {
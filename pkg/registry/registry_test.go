@@ -0,0 +1,24 @@
+package registry
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Shutdown_CallsShutdownHooks(t *testing.T) {
+	var calls atomic.Int32
+	RegisterShutdownHook("test.first", func() { calls.Add(1) })
+	RegisterShutdownHook("test.second", func() { calls.Add(1) })
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(registeredShutdownHooks, "test.first")
+		delete(registeredShutdownHooks, "test.second")
+	})
+
+	(&Registry{}).Shutdown()
+
+	assert.Equal(t, int32(2), calls.Load())
+}
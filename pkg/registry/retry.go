@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// retrySleep is replaced in tests, so retries do not wait.
+var retrySleep = waitForRetry
+
+// waitForRetry waits before the next attempt, unless the request is canceled first.
+func waitForRetry(request *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-request.Context().Done():
+		return request.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+/*
+ * RetryingHTTPContext retries requests according to a component's retry policy.
+ * Responses with a retryable status are sent again, with the same body,
+ * until the policy runs out of attempts. The last response is returned as is,
+ * so components still see, and report, the final status.
+ *
+ * Clients only trace the last response, so the attempts that are retried
+ * are traced here, and traces are passed on to the underlying HTTP context.
+ */
+type RetryingHTTPContext struct {
+	underlying core.HTTPContext
+	policy     core.RetryPolicy
+}
+
+func NewRetryingHTTPContext(underlying core.HTTPContext, policy core.RetryPolicy) *RetryingHTTPContext {
+	return &RetryingHTTPContext{underlying: underlying, policy: policy}
+}
+
+func (c *RetryingHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := c.underlying.Do(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt >= c.policy.MaxAttempts || !c.policy.IsRetryableStatus(response.StatusCode) {
+			return response, nil
+		}
+
+		//
+		// Requests whose body cannot be read again are not retried.
+		//
+		if request.Body != nil && request.GetBody == nil {
+			return response, nil
+		}
+
+		wait := c.wait(response, attempt)
+		responseBody, _ := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		TraceHTTP(c.underlying, request, response.StatusCode, responseBody, nil)
+
+		if request.Body != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			request.Body = body
+		}
+
+		if err := retrySleep(request, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *RetryingHTTPContext) TraceHTTP(trace core.HTTPTrace) {
+	if tracer, ok := c.underlying.(core.HTTPTracer); ok {
+		tracer.TraceHTTP(trace)
+	}
+}
+
+func (c *RetryingHTTPContext) wait(response *http.Response, attempt int) time.Duration {
+	backoff := c.policy.Backoff(attempt)
+
+	seconds, err := strconv.Atoi(strings.TrimSpace(response.Header.Get("Retry-After")))
+	if err != nil || seconds < 0 {
+		return backoff
+	}
+
+	wait := time.Duration(seconds) * time.Second
+	if c.policy.MaxBackoff > 0 && wait > c.policy.MaxBackoff {
+		return c.policy.MaxBackoff
+	}
+
+	return wait
+}
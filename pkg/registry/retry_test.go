@@ -0,0 +1,167 @@
+package registry
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func retryResponse(status int, retryAfter string) *http.Response {
+	response := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+	}
+
+	if retryAfter != "" {
+		response.Header.Set("Retry-After", retryAfter)
+	}
+
+	return response
+}
+
+func Test__RetryingHTTPContext(t *testing.T) {
+	var waits []time.Duration
+	retrySleep = func(request *http.Request, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { retrySleep = waitForRetry })
+
+	policy := core.RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    time.Second,
+		MaxBackoff:        5 * time.Second,
+		RetryableStatuses: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		request, err := http.NewRequest(http.MethodPost, "https://api.example.com/events", strings.NewReader(`{"message":"deploy"}`))
+		require.NoError(t, err)
+		return request
+	}
+
+	t.Run("retryable status then success -> retried with the same body", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				retryResponse(http.StatusTooManyRequests, ""),
+				retryResponse(http.StatusOK, ""),
+			},
+		}
+
+		response, err := NewRetryingHTTPContext(httpCtx, policy).Do(newRequest(t))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		require.Len(t, httpCtx.Requests, 2)
+		body, err := io.ReadAll(httpCtx.Requests[1].Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"message":"deploy"}`, string(body))
+		assert.Equal(t, []time.Duration{time.Second}, waits)
+	})
+
+	t.Run("always retryable -> last response after max attempts with exponential backoff", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				retryResponse(http.StatusServiceUnavailable, ""),
+				retryResponse(http.StatusServiceUnavailable, ""),
+				retryResponse(http.StatusServiceUnavailable, ""),
+				retryResponse(http.StatusOK, ""),
+			},
+		}
+
+		response, err := NewRetryingHTTPContext(httpCtx, policy).Do(newRequest(t))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Len(t, httpCtx.Requests, 3)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+	})
+
+	t.Run("status not in the policy -> not retried", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				retryResponse(http.StatusBadRequest, ""),
+				retryResponse(http.StatusOK, ""),
+			},
+		}
+
+		response, err := NewRetryingHTTPContext(httpCtx, policy).Do(newRequest(t))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Len(t, httpCtx.Requests, 1)
+		assert.Empty(t, waits)
+	})
+
+	t.Run("Retry-After -> honored up to the max backoff", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				retryResponse(http.StatusTooManyRequests, "3"),
+				retryResponse(http.StatusTooManyRequests, "60"),
+				retryResponse(http.StatusOK, ""),
+			},
+		}
+
+		_, err := NewRetryingHTTPContext(httpCtx, policy).Do(newRequest(t))
+
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{3 * time.Second, 5 * time.Second}, waits)
+	})
+
+	t.Run("body that cannot be read again -> not retried", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				retryResponse(http.StatusTooManyRequests, ""),
+				retryResponse(http.StatusOK, ""),
+			},
+		}
+
+		request := newRequest(t)
+		request.GetBody = nil
+
+		response, err := NewRetryingHTTPContext(httpCtx, policy).Do(request)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Len(t, httpCtx.Requests, 1)
+	})
+
+	t.Run("retried attempts -> traced to the underlying HTTP context", func(t *testing.T) {
+		waits = nil
+		httpCtx := &contexts.TracingHTTPContext{
+			HTTPContext: contexts.HTTPContext{
+				Responses: []*http.Response{
+					retryResponse(http.StatusServiceUnavailable, ""),
+					retryResponse(http.StatusTooManyRequests, ""),
+					retryResponse(http.StatusOK, ""),
+				},
+			},
+		}
+
+		retrying := NewRetryingHTTPContext(httpCtx, policy)
+		request := newRequest(t)
+		response, err := retrying.Do(request)
+		require.NoError(t, err)
+		TraceHTTP(retrying, request, response.StatusCode, nil, nil)
+
+		require.Len(t, httpCtx.Traces, 3)
+		assert.Equal(t, http.StatusServiceUnavailable, httpCtx.Traces[0].Status)
+		assert.Equal(t, `{"message":"deploy"}`, httpCtx.Traces[0].RequestBody)
+		assert.Equal(t, `{}`, httpCtx.Traces[0].ResponseBody)
+		assert.Equal(t, http.StatusTooManyRequests, httpCtx.Traces[1].Status)
+		assert.Equal(t, http.StatusOK, httpCtx.Traces[2].Status)
+	})
+}
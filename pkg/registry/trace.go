@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// maxTraceBodyBytes is how much of each body is kept in a trace.
+const maxTraceBodyBytes = 2048
+
+const redacted = "[REDACTED]"
+
+// httpTraceEnabled is replaced in tests, so tracing does not depend on the environment.
+var httpTraceEnabled = func() bool {
+	return os.Getenv("INTEGRATION_HTTP_TRACE") == "yes"
+}
+
+// sensitiveNames matches the query parameters and JSON fields whose values are never traced.
+var sensitiveNames = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[_-]?key|apikey|access[_-]?key|private[_-]?key|authorization|signature|credential)`)
+
+// sensitiveJSONFields matches string fields of JSON bodies, to redact the sensitive ones.
+var sensitiveJSONFields = regexp.MustCompile(`"([^"\\]+)"(\s*:\s*)"((?:[^"\\]|\\.)*)"`)
+
+/*
+ * TracingHTTPContext records the requests integration clients report with TraceHTTP
+ * in the debug log of the setup or execution it is given to.
+ * Requests are sent by the underlying HTTP context as they are.
+ */
+type TracingHTTPContext struct {
+	underlying core.HTTPContext
+	logger     *log.Entry
+}
+
+func NewTracingHTTPContext(underlying core.HTTPContext, logger *log.Entry) *TracingHTTPContext {
+	return &TracingHTTPContext{underlying: underlying, logger: logger}
+}
+
+// withHTTPTrace wraps the HTTP context with tracing, when tracing of integration requests is enabled.
+func withHTTPTrace(httpCtx core.HTTPContext, logger *log.Entry) core.HTTPContext {
+	if httpCtx == nil || logger == nil || !httpTraceEnabled() {
+		return httpCtx
+	}
+
+	return NewTracingHTTPContext(httpCtx, logger)
+}
+
+func (c *TracingHTTPContext) Do(request *http.Request) (*http.Response, error) {
+	return c.underlying.Do(request)
+}
+
+func (c *TracingHTTPContext) TraceHTTP(trace core.HTTPTrace) {
+	fields := log.Fields{
+		"method": trace.Method,
+		"url":    trace.URL,
+		"status": trace.Status,
+	}
+
+	if trace.RequestBody != "" {
+		fields["request_body"] = trace.RequestBody
+	}
+
+	if trace.ResponseBody != "" {
+		fields["response_body"] = trace.ResponseBody
+	}
+
+	if trace.Error != "" {
+		fields["error"] = trace.Error
+	}
+
+	c.logger.WithFields(fields).Debugf("Integration request %s %s -> %d", trace.Method, trace.URL, trace.Status)
+}
+
+/*
+ * TraceHTTP reports a request made by an integration client to the HTTP context,
+ * when it records requests. The URL and bodies are sanitized first: the given secrets,
+ * sensitive query parameters and JSON fields, and credentials in the URL are redacted,
+ * and bodies are truncated. Headers are never traced, since they carry the credentials.
+ *
+ * The request body is read again with GetBody, so it is only traced for requests
+ * built from a buffer or string, which is what the clients do.
+ */
+func TraceHTTP(httpCtx core.HTTPContext, request *http.Request, status int, responseBody []byte, err error, secrets ...string) {
+	tracer, ok := httpCtx.(core.HTTPTracer)
+	if !ok || request == nil {
+		return
+	}
+
+	trace := core.HTTPTrace{
+		Method:       request.Method,
+		URL:          sanitizeURL(request.URL, secrets),
+		Status:       status,
+		RequestBody:  sanitizeBody(requestBody(request), secrets),
+		ResponseBody: sanitizeBody(responseBody, secrets),
+	}
+
+	if err != nil {
+		trace.Error = redactSecrets(err.Error(), secrets)
+	}
+
+	tracer.TraceHTTP(trace)
+}
+
+func requestBody(request *http.Request) []byte {
+	if request.GetBody == nil {
+		return nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil
+	}
+
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+func sanitizeURL(u *url.URL, secrets []string) string {
+	if u == nil {
+		return ""
+	}
+
+	sanitized := *u
+	if sanitized.User != nil {
+		sanitized.User = url.User(redacted)
+	}
+
+	query := sanitized.Query()
+	for name := range query {
+		if sensitiveNames.MatchString(name) {
+			query[name] = []string{redacted}
+		}
+	}
+
+	sanitized.RawQuery = query.Encode()
+	return redactSecrets(sanitized.String(), secrets)
+}
+
+func sanitizeBody(body []byte, secrets []string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	text := sensitiveJSONFields.ReplaceAllStringFunc(string(body), func(field string) string {
+		parts := sensitiveJSONFields.FindStringSubmatch(field)
+		if !sensitiveNames.MatchString(parts[1]) {
+			return field
+		}
+
+		return fmt.Sprintf(`"%s"%s"%s"`, parts[1], parts[2], redacted)
+	})
+
+	text = redactSecrets(text, secrets)
+	if len(text) > maxTraceBodyBytes {
+		return text[:maxTraceBodyBytes] + fmt.Sprintf("... (%d more bytes)", len(text)-maxTraceBodyBytes)
+	}
+
+	return text
+}
+
+func redactSecrets(text string, secrets []string) string {
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		if secret == "" {
+			continue
+		}
+
+		text = strings.ReplaceAll(text, secret, redacted)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			text = strings.ReplaceAll(text, escaped, redacted)
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	MaxIdleConnsConfigField    = "httpMaxIdleConns"
+	IdleConnTimeoutConfigField = "httpIdleConnTimeout"
+	KeepAliveConfigField       = "httpKeepAlive"
+)
+
+const (
+	maxIdleConnsLimit       = 1000
+	maxIdleConnTimeoutLimit = 3600
+	maxKeepAliveLimit       = 3600
+)
+
+/*
+ * TransportConfigurationFields are added to the configuration of every integration,
+ * so integrations that send many requests can keep more connections open for longer.
+ * Integrations that do not configure them use the shared HTTP context.
+ */
+func TransportConfigurationFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        MaxIdleConnsConfigField,
+			Label:       "Max Idle Connections",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Togglable:   true,
+			Description: "Idle connections kept open to each host for requests made by this integration",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxIdleConnsLimit; return &max }(),
+				},
+			},
+		},
+		{
+			Name:        IdleConnTimeoutConfigField,
+			Label:       "Idle Connection Timeout (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Togglable:   true,
+			Description: "How long an idle connection is kept open before it is closed",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxIdleConnTimeoutLimit; return &max }(),
+				},
+			},
+		},
+		{
+			Name:        KeepAliveConfigField,
+			Label:       "Keep-Alive Interval (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Togglable:   true,
+			Description: "Interval between TCP keep-alive probes on open connections",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: func() *int { min := 1; return &min }(),
+					Max: func() *int { max := maxKeepAliveLimit; return &max }(),
+				},
+			},
+		},
+	}
+}
+
+type TransportOptions struct {
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	KeepAlive       time.Duration
+}
+
+/*
+ * ParseTransportOptions reads the connection pooling settings from an integration configuration.
+ * It returns nil options when none of them is configured.
+ */
+func ParseTransportOptions(config map[string]any) (*TransportOptions, error) {
+	maxIdleConns, err := transportSetting(config, MaxIdleConnsConfigField, maxIdleConnsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	idleConnTimeout, err := transportSetting(config, IdleConnTimeoutConfigField, maxIdleConnTimeoutLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	keepAlive, err := transportSetting(config, KeepAliveConfigField, maxKeepAliveLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxIdleConns == 0 && idleConnTimeout == 0 && keepAlive == 0 {
+		return nil, nil
+	}
+
+	return &TransportOptions{
+		MaxIdleConns:    maxIdleConns,
+		IdleConnTimeout: time.Duration(idleConnTimeout) * time.Second,
+		KeepAlive:       time.Duration(keepAlive) * time.Second,
+	}, nil
+}
+
+/*
+ * transportSetting returns the whole number in a transport field, or 0 when the field is not set.
+ */
+func transportSetting(config map[string]any, field string, limit int) (int, error) {
+	var value float64
+	switch v := config[field].(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		value = v
+	case int:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, nil
+		}
+
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: must be a number", field)
+		}
+		value = parsed
+	default:
+		return 0, fmt.Errorf("invalid %s: must be a number", field)
+	}
+
+	if value != float64(int(value)) || value < 1 || value > float64(limit) {
+		return 0, fmt.Errorf("invalid %s: must be a whole number between 1 and %d", field, limit)
+	}
+
+	return int(value), nil
+}
+
+/*
+ * WithTransport returns a copy of the HTTP context with the connection pooling settings applied.
+ * Settings that are not configured keep the values of the shared HTTP context.
+ */
+func (c *HTTPContext) WithTransport(options *TransportOptions) (*HTTPContext, error) {
+	transport, err := c.cloneTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := c.dialer
+
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = max(transport.MaxIdleConns, options.MaxIdleConns)
+		transport.MaxIdleConnsPerHost = options.MaxIdleConns
+	}
+
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+
+	//
+	// The keep-alive interval is a setting of the dialer, so the copy gets its own dialer.
+	// It keeps the Control function of the shared one, so the private IP checks still apply.
+	//
+	if options.KeepAlive > 0 {
+		keepAliveDialer := *c.dialer
+		keepAliveDialer.KeepAlive = options.KeepAlive
+		dialer = &keepAliveDialer
+		transport.DialContext = dialer.DialContext
+	}
+
+	client := *c.client
+	client.Transport = transport
+
+	return &HTTPContext{
+		client:           &client,
+		dialer:           dialer,
+		blockedHosts:     c.blockedHosts,
+		privateIPRanges:  c.privateIPRanges,
+		maxResponseBytes: c.maxResponseBytes,
+	}, nil
+}
+
+func (o *TransportOptions) cacheKey() string {
+	return fmt.Sprintf("%d|%s|%s", o.MaxIdleConns, o.IdleConnTimeout, o.KeepAlive)
+}
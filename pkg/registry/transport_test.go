@@ -0,0 +1,149 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/crypto"
+)
+
+func Test__ParseTransportOptions(t *testing.T) {
+	t.Run("no settings configured -> nil options", func(t *testing.T) {
+		options, err := ParseTransportOptions(map[string]any{"apiKey": "test"})
+		require.NoError(t, err)
+		assert.Nil(t, options)
+
+		options, err = ParseTransportOptions(map[string]any{MaxIdleConnsConfigField: " "})
+		require.NoError(t, err)
+		assert.Nil(t, options)
+	})
+
+	t.Run("all settings configured", func(t *testing.T) {
+		options, err := ParseTransportOptions(map[string]any{
+			MaxIdleConnsConfigField:    float64(50),
+			IdleConnTimeoutConfigField: 120,
+			KeepAliveConfigField:       "15",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &TransportOptions{
+			MaxIdleConns:    50,
+			IdleConnTimeout: 2 * time.Minute,
+			KeepAlive:       15 * time.Second,
+		}, options)
+	})
+
+	t.Run("value out of range -> error", func(t *testing.T) {
+		_, err := ParseTransportOptions(map[string]any{MaxIdleConnsConfigField: float64(5000)})
+		require.ErrorContains(t, err, "invalid httpMaxIdleConns: must be a whole number between 1 and 1000")
+	})
+
+	t.Run("fractional value -> error", func(t *testing.T) {
+		_, err := ParseTransportOptions(map[string]any{KeepAliveConfigField: 1.5})
+		require.ErrorContains(t, err, "invalid httpKeepAlive: must be a whole number between 1 and 3600")
+	})
+
+	t.Run("not a number -> error", func(t *testing.T) {
+		_, err := ParseTransportOptions(map[string]any{IdleConnTimeoutConfigField: "soon"})
+		require.ErrorContains(t, err, "invalid httpIdleConnTimeout: must be a number")
+	})
+}
+
+func Test__HTTPContext__WithTransport(t *testing.T) {
+	base, err := NewHTTPContext(defaultHTTPOptions())
+	require.NoError(t, err)
+
+	t.Run("settings are applied to the transport and dialer", func(t *testing.T) {
+		httpCtx, err := base.WithTransport(&TransportOptions{
+			MaxIdleConns:    200,
+			IdleConnTimeout: 5 * time.Minute,
+			KeepAlive:       10 * time.Second,
+		})
+		require.NoError(t, err)
+
+		transport := httpCtx.client.Transport.(*http.Transport)
+		assert.Equal(t, 200, transport.MaxIdleConns)
+		assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 5*time.Minute, transport.IdleConnTimeout)
+		assert.Equal(t, 10*time.Second, httpCtx.dialer.KeepAlive)
+		assert.NotNil(t, httpCtx.dialer.Control)
+
+		sharedTransport := base.client.Transport.(*http.Transport)
+		assert.Equal(t, 100, sharedTransport.MaxIdleConns)
+		assert.Equal(t, 90*time.Second, sharedTransport.IdleConnTimeout)
+		assert.Equal(t, 30*time.Second, base.dialer.KeepAlive)
+	})
+
+	t.Run("unset settings -> keep the shared values", func(t *testing.T) {
+		httpCtx, err := base.WithTransport(&TransportOptions{MaxIdleConns: 10})
+		require.NoError(t, err)
+
+		transport := httpCtx.client.Transport.(*http.Transport)
+		assert.Equal(t, 100, transport.MaxIdleConns)
+		assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+		assert.Same(t, base.dialer, httpCtx.dialer)
+	})
+
+	t.Run("connections are subject to private IP checks", func(t *testing.T) {
+		httpCtx, err := base.WithTransport(&TransportOptions{KeepAlive: 10 * time.Second})
+		require.NoError(t, err)
+
+		transport := httpCtx.client.Transport.(*http.Transport)
+		_, err = transport.DialContext(context.Background(), "tcp", "127.0.0.1:1")
+		require.ErrorContains(t, err, "connection blocked")
+	})
+
+	t.Run("wrapped transport -> error", func(t *testing.T) {
+		wrapped := &HTTPContext{client: &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}}
+
+		_, err := wrapped.WithTransport(&TransportOptions{MaxIdleConns: 10})
+		require.ErrorContains(t, err, "unsupported HTTP transport")
+	})
+}
+
+func Test__Registry__HTTPContextForIntegration__Transport(t *testing.T) {
+	reg, err := NewRegistry(&crypto.NoOpEncryptor{}, HTTPOptions{})
+	require.NoError(t, err)
+
+	t.Run("transport settings -> cached HTTP context with the settings applied", func(t *testing.T) {
+		config := map[string]any{MaxIdleConnsConfigField: float64(64), KeepAliveConfigField: float64(20)}
+		first := reg.HTTPContextForIntegration(config)
+		second := reg.HTTPContextForIntegration(config)
+
+		assert.NotSame(t, reg.HTTPContext(), first)
+		assert.Same(t, first, second)
+
+		httpCtx := first.(*HTTPContext)
+		assert.Equal(t, 64, httpCtx.client.Transport.(*http.Transport).MaxIdleConnsPerHost)
+		assert.Equal(t, 20*time.Second, httpCtx.dialer.KeepAlive)
+	})
+
+	t.Run("proxy and transport settings -> both applied", func(t *testing.T) {
+		httpCtx := reg.HTTPContextForIntegration(map[string]any{
+			ProxyURLConfigField:        "http://proxy.example.com:3128",
+			IdleConnTimeoutConfigField: float64(30),
+		}).(*HTTPContext)
+
+		transport := httpCtx.client.Transport.(*http.Transport)
+		assert.NotNil(t, transport.Proxy)
+		assert.Equal(t, 30*time.Second, transport.IdleConnTimeout)
+
+		proxyOnly := reg.HTTPContextForIntegration(map[string]any{ProxyURLConfigField: "http://proxy.example.com:3128"})
+		assert.NotSame(t, httpCtx, proxyOnly)
+	})
+
+	t.Run("invalid transport configuration -> requests fail", func(t *testing.T) {
+		httpCtx := reg.HTTPContextForIntegration(map[string]any{MaxIdleConnsConfigField: float64(0)})
+
+		request, err := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+		require.NoError(t, err)
+
+		_, err = httpCtx.Do(request)
+		require.ErrorContains(t, err, "invalid httpMaxIdleConns")
+	})
+}
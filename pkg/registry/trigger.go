@@ -74,6 +74,8 @@ func (s *PanicableTrigger) Setup(ctx core.TriggerContext) (err error) {
 				s.underlying.Name(), r)
 		}
 	}()
+
+	ctx.HTTP = withHTTPTrace(ctx.HTTP, ctx.Logger)
 	return s.underlying.Setup(ctx)
 }
 
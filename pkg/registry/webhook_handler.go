@@ -52,3 +52,19 @@ func (h *PanicableWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) (err e
 	}()
 	return h.underlying.Cleanup(ctx)
 }
+
+func (h *PanicableWebhookHandler) Release(current, nodeMetadata any) (released any, changed bool, err error) {
+	releaser, ok := h.underlying.(core.WebhookReferenceReleaser)
+	if !ok {
+		return current, false, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			released = current
+			changed = false
+			err = fmt.Errorf("webhook handler panicked in Release(): %v", r)
+		}
+	}()
+	return releaser.Release(current, nodeMetadata)
+}
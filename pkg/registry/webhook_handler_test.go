@@ -28,6 +28,29 @@ func (p *panickingWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error
 	panic("cleanup panic")
 }
 
+func (p *panickingWebhookHandler) Release(current, nodeMetadata any) (any, bool, error) {
+	panic("release panic")
+}
+
+// basicWebhookHandler is a webhook handler that does not release node references
+type basicWebhookHandler struct{}
+
+func (h *basicWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	return true, nil
+}
+
+func (h *basicWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
+
+func (h *basicWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	return nil, nil
+}
+
+func (h *basicWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	return nil
+}
+
 func Test_PanicableWebhookHandler_Setup_CatchesPanic(t *testing.T) {
 	handler := &panickingWebhookHandler{}
 	panicable := NewPanicableWebhookHandler(handler)
@@ -62,3 +85,23 @@ func Test_PanicableWebhookHandler_Merge_CatchesPanic(t *testing.T) {
 	assert.Equal(t, map[string]any{"a": "b"}, merged)
 	assert.Contains(t, err.Error(), "merge panic")
 }
+
+func Test_PanicableWebhookHandler_Release_CatchesPanic(t *testing.T) {
+	handler := &panickingWebhookHandler{}
+	panicable := NewPanicableWebhookHandler(handler)
+
+	released, changed, err := panicable.Release(map[string]any{"a": "b"}, nil)
+	require.Error(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, map[string]any{"a": "b"}, released)
+	assert.Contains(t, err.Error(), "release panic")
+}
+
+func Test_PanicableWebhookHandler_Release_WithoutReleaser(t *testing.T) {
+	panicable := NewPanicableWebhookHandler(&basicWebhookHandler{})
+
+	released, changed, err := panicable.Release(map[string]any{"a": "b"}, nil)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, map[string]any{"a": "b"}, released)
+}
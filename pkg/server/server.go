@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -394,7 +396,12 @@ func Start() {
 
 	log.Println("SuperPlane is UP.")
 
-	select {}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-signals
+
+	log.Infof("Received %s, shutting down", sig)
+	registry.Shutdown()
 }
 
 // getWebhookBaseURL returns the webhook base URL, using the same pattern as SyncContext.
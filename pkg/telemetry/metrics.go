@@ -168,6 +168,10 @@ func InitMetrics(ctx context.Context) error {
 		return err
 	}
 
+	if err := initWebhookSignatureMetrics(); err != nil {
+		return err
+	}
+
 	StartPeriodicMetricsReporter()
 
 	metricsReady.Store(true)
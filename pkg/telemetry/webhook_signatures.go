@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Outcomes of a webhook signature verification.
+const (
+	SignatureVerificationPass     = "pass"
+	SignatureVerificationMissing  = "missing"
+	SignatureVerificationMismatch = "mismatch"
+)
+
+var (
+	webhookSignatureCounter metric.Int64Counter
+
+	//
+	// Counts are also kept in memory, so they can be inspected
+	// even when the metrics exporter is not configured.
+	//
+	webhookSignatureCounts sync.Map
+)
+
+type webhookSignatureKey struct {
+	trigger string
+	outcome string
+}
+
+func initWebhookSignatureMetrics() error {
+	var err error
+	webhookSignatureCounter, err = meter.Int64Counter(
+		"webhooks.signature_verifications",
+		metric.WithDescription("Number of webhook signature verifications, by trigger and outcome"),
+		metric.WithUnit("1"),
+	)
+
+	return err
+}
+
+// RecordWebhookSignatureVerification counts the outcome of verifying a webhook signature for a trigger.
+// A trigger that only records mismatches usually has a misconfigured secret.
+func RecordWebhookSignatureVerification(ctx context.Context, trigger, outcome string) {
+	counter, _ := webhookSignatureCounts.LoadOrStore(webhookSignatureKey{trigger: trigger, outcome: outcome}, &atomic.Int64{})
+	counter.(*atomic.Int64).Add(1)
+
+	if !metricsReady.Load() {
+		return
+	}
+
+	webhookSignatureCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("trigger", trigger),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// WebhookSignatureVerificationCount returns how many verifications with the outcome were recorded for a trigger.
+func WebhookSignatureVerificationCount(trigger, outcome string) int64 {
+	counter, ok := webhookSignatureCounts.Load(webhookSignatureKey{trigger: trigger, outcome: outcome})
+	if !ok {
+		return 0
+	}
+
+	return counter.(*atomic.Int64).Load()
+}
@@ -0,0 +1,23 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordWebhookSignatureVerification_CountsPerTriggerAndOutcome(t *testing.T) {
+	ctx := context.Background()
+
+	RecordWebhookSignatureVerification(ctx, "test.triggerA", SignatureVerificationPass)
+	RecordWebhookSignatureVerification(ctx, "test.triggerA", SignatureVerificationPass)
+	RecordWebhookSignatureVerification(ctx, "test.triggerA", SignatureVerificationMismatch)
+	RecordWebhookSignatureVerification(ctx, "test.triggerB", SignatureVerificationMissing)
+
+	assert.Equal(t, int64(2), WebhookSignatureVerificationCount("test.triggerA", SignatureVerificationPass))
+	assert.Equal(t, int64(1), WebhookSignatureVerificationCount("test.triggerA", SignatureVerificationMismatch))
+	assert.Equal(t, int64(0), WebhookSignatureVerificationCount("test.triggerA", SignatureVerificationMissing))
+	assert.Equal(t, int64(1), WebhookSignatureVerificationCount("test.triggerB", SignatureVerificationMissing))
+	assert.Equal(t, int64(0), WebhookSignatureVerificationCount("test.triggerB", SignatureVerificationPass))
+}
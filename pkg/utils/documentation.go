@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var examplePlaceholderRegex = regexp.MustCompile(`\{\{example(?::([A-Za-z0-9_.]+))?\}\}`)
+
+// RenderDocumentation replaces example placeholders in documentation
+// with a JSON code block, so docs can show realistic payloads inline.
+// {{example}} embeds the whole example, and {{example:data.field}}
+// embeds the value at that path. Placeholders that cannot be resolved are left as they are.
+func RenderDocumentation(doc string, example map[string]any) string {
+	return examplePlaceholderRegex.ReplaceAllStringFunc(doc, func(placeholder string) string {
+		match := examplePlaceholderRegex.FindStringSubmatch(placeholder)
+
+		var value any = example
+		if match[1] != "" {
+			var ok bool
+			value, ok = lookupExamplePath(example, strings.Split(match[1], "."))
+			if !ok {
+				return placeholder
+			}
+		}
+
+		raw, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return placeholder
+		}
+
+		return "```json\n" + string(raw) + "\n```"
+	})
+}
+
+func lookupExamplePath(example map[string]any, path []string) (any, bool) {
+	var value any = example
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = object[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
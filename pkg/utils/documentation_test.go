@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDocumentation(t *testing.T) {
+	example := map[string]any{
+		"type": "test.event",
+		"data": map[string]any{
+			"accesses": []any{
+				map[string]any{"action": "updateOn"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		doc      string
+		expected string
+	}{
+		{
+			name:     "whole example",
+			doc:      "Payload:\n\n{{example}}",
+			expected: "Payload:\n\n```json\n{\n  \"data\": {\n    \"accesses\": [\n      {\n        \"action\": \"updateOn\"\n      }\n    ]\n  },\n  \"type\": \"test.event\"\n}\n```",
+		},
+		{
+			name:     "nested path",
+			doc:      "Accesses:\n\n{{example:data.accesses}}\n\nMore text.",
+			expected: "Accesses:\n\n```json\n[\n  {\n    \"action\": \"updateOn\"\n  }\n]\n```\n\nMore text.",
+		},
+		{
+			name:     "unknown path is left as is",
+			doc:      "{{example:data.missing}}",
+			expected: "{{example:data.missing}}",
+		},
+		{
+			name:     "expressions are not touched",
+			doc:      "Use {{ $.data.kind }} in expressions.",
+			expected: "Use {{ $.data.kind }} in expressions.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, RenderDocumentation(tc.doc, example))
+		})
+	}
+}
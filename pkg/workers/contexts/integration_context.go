@@ -40,6 +40,20 @@ func (c *IntegrationContext) ID() uuid.UUID {
 	return c.integration.ID
 }
 
+// WebhookExists reports whether the webhook exists and was not deleted.
+func (c *IntegrationContext) WebhookExists(id uuid.UUID) (bool, error) {
+	_, err := models.FindWebhookInTransaction(c.tx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	return true, nil
+}
+
 func (c *IntegrationContext) RequestWebhook(configuration any) error {
 	handler, err := c.registry.GetWebhookHandler(c.integration.AppName)
 	if err != nil {
@@ -101,12 +115,60 @@ func (c *IntegrationContext) replaceMismatchedWebhook(configuration any, handler
 	}
 
 	if len(nodes) > 1 {
-		return nil
+		return c.releaseWebhookReferences(handler, webhook)
 	}
 
 	return c.tx.Delete(webhook).Error
 }
 
+// ReleaseWebhook drops the references of the node from its webhook, before the node
+// is deleted while other nodes still use the webhook. A webhook used only by the node
+// is deleted together with the node instead.
+func (c *IntegrationContext) ReleaseWebhook() error {
+	if c.node == nil || c.node.WebhookID == nil {
+		return nil
+	}
+
+	handler, err := c.registry.GetWebhookHandler(c.integration.AppName)
+	if err != nil {
+		return err
+	}
+
+	webhook, err := models.FindWebhookInTransaction(c.tx, *c.node.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := models.FindWebhookNodesInTransaction(c.tx, webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) <= 1 {
+		return nil
+	}
+
+	return c.releaseWebhookReferences(handler, webhook)
+}
+
+func (c *IntegrationContext) releaseWebhookReferences(handler core.WebhookHandler, webhook *models.Webhook) error {
+	releaser, ok := handler.(core.WebhookReferenceReleaser)
+	if !ok {
+		return nil
+	}
+
+	releasedConfiguration, changed, err := releaser.Release(webhook.Configuration.Data(), c.node.Metadata.Data())
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return c.updateWebhookConfiguration(webhook, releasedConfiguration)
+}
+
 func (c *IntegrationContext) createWebhook(configuration any) error {
 	webhookID := uuid.New()
 	_, encryptedKey, err := crypto.NewRandomKey(context.Background(), c.encryptor, webhookID.String())
@@ -147,7 +209,13 @@ func (c *IntegrationContext) mergeWebhookConfiguration(
 		return nil
 	}
 
-	webhook.Configuration = datatypes.NewJSONType(mergedConfiguration)
+	return c.updateWebhookConfiguration(webhook, mergedConfiguration)
+}
+
+// updateWebhookConfiguration stores the new configuration of the webhook,
+// and marks the webhook as pending, so it is set up again with it.
+func (c *IntegrationContext) updateWebhookConfiguration(webhook *models.Webhook, configuration any) error {
+	webhook.Configuration = datatypes.NewJSONType(configuration)
 	webhook.State = models.WebhookStatePending
 	webhook.RetryCount = 0
 
@@ -298,6 +366,11 @@ func (c *IntegrationContext) Error(message string) {
 	c.integration.StateDescription = message
 }
 
+func (c *IntegrationContext) Degraded(message string) {
+	c.integration.State = models.IntegrationStateDegraded
+	c.integration.StateDescription = message
+}
+
 func (c *IntegrationContext) SetSecret(name string, value []byte) error {
 	now := time.Now()
 
@@ -253,3 +253,103 @@ func Test__IntegrationContext_RequestWebhook_MergesExistingWebhookConfig(t *test
 	require.NoError(t, marshalErr)
 	assert.JSONEq(t, `{"eventTypes":["build_ended","deploy_ended"]}`, string(configurationJSON))
 }
+
+func Test__IntegrationContext_ReleaseWebhook(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	r.Registry.Integrations["dummy"] = support.NewDummyIntegration(support.DummyIntegrationOptions{})
+	r.Registry.WebhookHandlers["dummy"] = support.NewDummyWebhookHandler(support.DummyWebhookHandlerOptions{
+		ReleaseFunc: func(current, nodeMetadata any) (any, bool, error) {
+			references := map[string]any{}
+			for key, value := range current.(map[string]any)["references"].(map[string]any) {
+				references[key] = value
+			}
+
+			reference, _ := nodeMetadata.(map[string]any)["reference"].(string)
+			if _, ok := references[reference]; !ok {
+				return current, false, nil
+			}
+
+			delete(references, reference)
+			return map[string]any{"references": references}, true, nil
+		},
+	})
+
+	integration, err := models.CreateIntegration(
+		uuid.New(),
+		r.Organization.ID,
+		"dummy",
+		support.RandomName("installation"),
+		map[string]any{},
+	)
+	require.NoError(t, err)
+
+	webhookID := uuid.New()
+	_, encryptedKey, err := crypto.NewRandomKey(context.Background(), r.Encryptor, webhookID.String())
+	require.NoError(t, err)
+
+	now := time.Now()
+	webhook := models.Webhook{
+		ID:                webhookID,
+		State:             models.WebhookStateReady,
+		Secret:            encryptedKey,
+		Configuration:     datatypes.NewJSONType[any](map[string]any{"references": map[string]any{"a": "t1", "b": "t1"}}),
+		Metadata:          datatypes.NewJSONType[any](map[string]any{}),
+		AppInstallationID: &integration.ID,
+		CreatedAt:         &now,
+	}
+	require.NoError(t, database.Conn().Create(&webhook).Error)
+
+	inputNodes := []models.CanvasNode{}
+	for _, reference := range []string{"a", "b"} {
+		inputNodes = append(inputNodes, models.CanvasNode{
+			NodeID:        "node-" + reference,
+			Name:          "Node " + reference,
+			Type:          models.NodeTypeTrigger,
+			Ref:           datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+			Configuration: datatypes.NewJSONType(map[string]any{}),
+			Metadata:      datatypes.NewJSONType(map[string]any{"reference": reference}),
+			Position:      datatypes.NewJSONType(models.Position{}),
+		})
+	}
+
+	canvas, nodes := support.CreateCanvas(t, r.Organization.ID, r.User, inputNodes, nil)
+	require.NotNil(t, canvas)
+	require.Len(t, nodes, 2)
+
+	for i := range nodes {
+		nodes[i].AppInstallationID = &integration.ID
+		nodes[i].WebhookID = &webhookID
+		require.NoError(t, database.Conn().Save(&nodes[i]).Error)
+	}
+
+	t.Run("other nodes use the webhook -> references of the node are released", func(t *testing.T) {
+		ctx := NewIntegrationContext(database.Conn(), &nodes[0], integration, r.Encryptor, r.Registry)
+		require.NoError(t, ctx.ReleaseWebhook())
+
+		updatedWebhook, err := models.FindWebhookInTransaction(database.Conn(), webhookID)
+		require.NoError(t, err)
+		assert.Equal(t, models.WebhookStatePending, updatedWebhook.State)
+
+		configurationJSON, marshalErr := json.Marshal(updatedWebhook.Configuration.Data())
+		require.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"references":{"b":"t1"}}`, string(configurationJSON))
+	})
+
+	t.Run("only node using the webhook -> webhook is left for deletion with the node", func(t *testing.T) {
+		require.NoError(t, models.DeleteCanvasNode(database.Conn(), nodes[0]))
+		require.NoError(t, database.Conn().Model(&webhook).Update("state", models.WebhookStateReady).Error)
+
+		ctx := NewIntegrationContext(database.Conn(), &nodes[1], integration, r.Encryptor, r.Registry)
+		require.NoError(t, ctx.ReleaseWebhook())
+
+		updatedWebhook, err := models.FindWebhookInTransaction(database.Conn(), webhookID)
+		require.NoError(t, err)
+		assert.Equal(t, models.WebhookStateReady, updatedWebhook.State)
+
+		configurationJSON, marshalErr := json.Marshal(updatedWebhook.Configuration.Data())
+		require.NoError(t, marshalErr)
+		assert.JSONEq(t, `{"references":{"b":"t1"}}`, string(configurationJSON))
+	})
+}
@@ -108,7 +108,7 @@ func (w *IntegrationRequestWorker) syncIntegration(tx *gorm.DB, request *models.
 	integrationCtx := contexts.NewIntegrationContext(tx, nil, instance, w.encryptor, w.registry)
 	syncErr := integration.Sync(core.SyncContext{
 		Logger:          logging.ForIntegration(*instance),
-		HTTP:            w.registry.HTTPContext(),
+		HTTP:            w.registry.HTTPContextForIntegration(instance.Configuration.Data()),
 		Integration:     integrationCtx,
 		Configuration:   instance.Configuration.Data(),
 		BaseURL:         w.baseURL,
@@ -120,7 +120,7 @@ func (w *IntegrationRequestWorker) syncIntegration(tx *gorm.DB, request *models.
 	if syncErr != nil {
 		instance.State = models.IntegrationStateError
 		instance.StateDescription = fmt.Sprintf("Sync failed: %v", syncErr)
-	} else {
+	} else if instance.State != models.IntegrationStateDegraded {
 		instance.StateDescription = ""
 	}
 
@@ -152,7 +152,7 @@ func (w *IntegrationRequestWorker) invokeIntegrationAction(tx *gorm.DB, request
 		Configuration:   integration.Configuration.Data(),
 		Logger:          logger,
 		Integration:     integrationCtx,
-		HTTP:            w.registry.HTTPContext(),
+		HTTP:            w.registry.HTTPContextForIntegration(integration.Configuration.Data()),
 	}
 
 	err = integrationImpl.HandleAction(actionCtx)
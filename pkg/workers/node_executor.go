@@ -75,7 +75,7 @@ func (w *NodeExecutor) Start(ctx context.Context) {
 				go func(execution models.CanvasNodeExecution) {
 					defer w.semaphore.Release(1)
 
-					err := w.LockAndProcessNodeExecution(execution.ID)
+					err := w.LockAndProcessNodeExecution(ctx, execution.ID)
 					if err == nil {
 						messages.NewCanvasExecutionMessage(execution.WorkflowID.String(), execution.ID.String(), execution.NodeID).Publish()
 						return
@@ -94,7 +94,7 @@ func (w *NodeExecutor) Start(ctx context.Context) {
 	}
 }
 
-func (w *NodeExecutor) LockAndProcessNodeExecution(id uuid.UUID) error {
+func (w *NodeExecutor) LockAndProcessNodeExecution(ctx context.Context, id uuid.UUID) error {
 	return database.Conn().Transaction(func(tx *gorm.DB) error {
 		var execution models.CanvasNodeExecution
 
@@ -129,11 +129,11 @@ func (w *NodeExecutor) LockAndProcessNodeExecution(id uuid.UUID) error {
 			return ErrRecordLocked
 		}
 
-		return w.processNodeExecution(tx, &execution)
+		return w.processNodeExecution(ctx, tx, &execution)
 	})
 }
 
-func (w *NodeExecutor) processNodeExecution(tx *gorm.DB, execution *models.CanvasNodeExecution) error {
+func (w *NodeExecutor) processNodeExecution(ctx context.Context, tx *gorm.DB, execution *models.CanvasNodeExecution) error {
 	node, err := models.FindCanvasNode(tx, execution.WorkflowID, execution.NodeID)
 	if err != nil {
 		return err
@@ -143,7 +143,7 @@ func (w *NodeExecutor) processNodeExecution(tx *gorm.DB, execution *models.Canva
 		return w.executeBlueprintNode(tx, execution, node)
 	}
 
-	return w.executeComponentNode(tx, execution, node)
+	return w.executeComponentNode(ctx, tx, execution, node)
 }
 
 func (w *NodeExecutor) executeBlueprintNode(tx *gorm.DB, execution *models.CanvasNodeExecution, node *models.CanvasNode) error {
@@ -241,7 +241,7 @@ func (w *NodeExecutor) configurationFieldsForBlueprintNode(tx *gorm.DB, node mod
 	}
 }
 
-func (w *NodeExecutor) executeComponentNode(tx *gorm.DB, execution *models.CanvasNodeExecution, node *models.CanvasNode) error {
+func (w *NodeExecutor) executeComponentNode(workerCtx context.Context, tx *gorm.DB, execution *models.CanvasNodeExecution, node *models.CanvasNode) error {
 	logger := logging.WithExecution(
 		logging.WithNode(w.logger, *node),
 		execution,
@@ -276,6 +276,7 @@ func (w *NodeExecutor) executeComponentNode(tx *gorm.DB, execution *models.Canva
 	}
 
 	ctx := core.ExecutionContext{
+		Context:        workerCtx,
 		ID:             execution.ID,
 		WorkflowID:     execution.WorkflowID.String(),
 		OrganizationID: workflow.OrganizationID.String(),
@@ -319,7 +320,18 @@ func (w *NodeExecutor) executeComponentNode(tx *gorm.DB, execution *models.Canva
 		}
 
 		logger = logging.WithIntegration(logger, *instance)
+
+		//
+		// Clients can be created for integrations that are not set up yet,
+		// so this fails with the state of the integration instead of an API error.
+		//
+		if !instance.Usable() {
+			logger.Infof("integration is not ready: %s", instance.State)
+			return execution.FailInTransaction(tx, models.CanvasNodeExecutionResultReasonError, instance.NotReadyMessage())
+		}
+
 		ctx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry)
+		ctx.HTTP = w.registry.HTTPContextForIntegration(instance.Configuration.Data())
 	}
 
 	ctx.Logger = logger
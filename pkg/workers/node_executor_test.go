@@ -1,11 +1,14 @@
 package workers
 
 import (
+	"context"
 	"log"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/test/support"
 	"gorm.io/datatypes"
@@ -57,12 +60,12 @@ func Test__NodeExecutor_PreventsConcurrentProcessing(t *testing.T) {
 	//
 	go func() {
 		executor1 := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
-		results <- executor1.LockAndProcessNodeExecution(execution.ID)
+		results <- executor1.LockAndProcessNodeExecution(context.Background(), execution.ID)
 	}()
 
 	go func() {
 		executor2 := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
-		results <- executor2.LockAndProcessNodeExecution(execution.ID)
+		results <- executor2.LockAndProcessNodeExecution(context.Background(), execution.ID)
 	}()
 
 	// Collect results - one should succeed (return nil) and one should get ErrRecordLocked
@@ -147,7 +150,7 @@ func Test__NodeExecutor_BlueprintNodeExecution(t *testing.T) {
 	// and moves the parent execution to started state.
 	//
 	executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
-	err := executor.LockAndProcessNodeExecution(execution.ID)
+	err := executor.LockAndProcessNodeExecution(context.Background(), execution.ID)
 	require.NoError(t, err)
 
 	// Verify parent execution moved to started state
@@ -225,7 +228,7 @@ func Test__NodeExecutor_ComponentNodeWithoutStateChange(t *testing.T) {
 	// The approval component doesn't call Pass() in Execute(), so it should remain in started state.
 	//
 	executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
-	err = executor.LockAndProcessNodeExecution(execution.ID)
+	err = executor.LockAndProcessNodeExecution(context.Background(), execution.ID)
 	require.NoError(t, err)
 
 	// Verify execution moved to started state but not finished,
@@ -292,7 +295,7 @@ func Test__NodeExecutor_ComponentNodeWithStateChange(t *testing.T) {
 	// The noop component calls Pass() in Execute(), which should finish the execution.
 	//
 	executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
-	err := executor.LockAndProcessNodeExecution(execution.ID)
+	err := executor.LockAndProcessNodeExecution(context.Background(), execution.ID)
 	require.NoError(t, err)
 
 	// Verify execution moved to finished state with passed result
@@ -373,7 +376,7 @@ func Test__NodeExecutor_BlueprintNodeExecutionFailsWhenConfigurationCannotBeBuil
 	// since this isn't a runtime error, but a configuration error.
 	//
 	executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
-	err := executor.LockAndProcessNodeExecution(execution.ID)
+	err := executor.LockAndProcessNodeExecution(context.Background(), execution.ID)
 	require.NoError(t, err)
 
 	//
@@ -386,6 +389,88 @@ func Test__NodeExecutor_BlueprintNodeExecutionFailsWhenConfigurationCannotBeBuil
 	assert.Contains(t, failedExecution.ResultMessage, "error building configuration for execution of node")
 }
 
+func Test__NodeExecutor_ComponentNodeIntegrationState(t *testing.T) {
+	r := support.Setup(t)
+	r.Registry.Integrations["dummy"] = support.NewDummyIntegration(support.DummyIntegrationOptions{})
+
+	processWithIntegration := func(t *testing.T, state, stateDescription string) *models.CanvasNodeExecution {
+		integration, err := models.CreateIntegration(uuid.New(), r.Organization.ID, "dummy", support.RandomName("integration"), nil)
+		require.NoError(t, err)
+		require.NoError(t, database.Conn().Model(integration).Updates(map[string]any{
+			"state":             state,
+			"state_description": stateDescription,
+		}).Error)
+
+		triggerNode := "trigger-1"
+		noopNode := "noop-1"
+		canvas, _ := support.CreateCanvas(
+			t,
+			r.Organization.ID,
+			r.User,
+			[]models.CanvasNode{
+				{
+					NodeID: triggerNode,
+					Type:   models.NodeTypeTrigger,
+					Ref:    datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "start"}}),
+				},
+				{
+					NodeID: noopNode,
+					Type:   models.NodeTypeComponent,
+					Ref:    datatypes.NewJSONType(models.NodeRef{Component: &models.ComponentRef{Name: "noop"}}),
+				},
+			},
+			[]models.Edge{
+				{SourceID: triggerNode, TargetID: noopNode, Channel: "default"},
+			},
+		)
+
+		require.NoError(t, database.Conn().
+			Model(&models.CanvasNode{}).
+			Where("workflow_id = ? AND node_id = ?", canvas.ID, noopNode).
+			Update("app_installation_id", integration.ID).
+			Error)
+
+		rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, triggerNode, "default", nil)
+		execution := support.CreateCanvasNodeExecution(t, canvas.ID, noopNode, rootEvent.ID, rootEvent.ID, nil)
+
+		executor := NewNodeExecutor(r.Encryptor, r.Registry, "http://localhost", "http://localhost")
+		require.NoError(t, executor.LockAndProcessNodeExecution(context.Background(), execution.ID))
+
+		updatedExecution, err := models.FindNodeExecution(canvas.ID, execution.ID)
+		require.NoError(t, err)
+		return updatedExecution
+	}
+
+	t.Run("pending integration -> execution fails with integration not ready", func(t *testing.T) {
+		execution := processWithIntegration(t, models.IntegrationStatePending, "")
+
+		assert.Equal(t, models.CanvasNodeExecutionStateFinished, execution.State)
+		assert.Equal(t, models.CanvasNodeExecutionResultFailed, execution.Result)
+		assert.Equal(t, models.CanvasNodeExecutionResultReasonError, execution.ResultReason)
+		assert.Contains(t, execution.ResultMessage, "is not ready (state: pending)")
+	})
+
+	t.Run("integration in error -> execution fails with the state description", func(t *testing.T) {
+		execution := processWithIntegration(t, models.IntegrationStateError, "invalid API token")
+
+		assert.Equal(t, models.CanvasNodeExecutionResultFailed, execution.Result)
+		assert.Contains(t, execution.ResultMessage, "is not ready (state: error): invalid API token")
+	})
+
+	t.Run("ready integration -> component is executed", func(t *testing.T) {
+		execution := processWithIntegration(t, models.IntegrationStateReady, "")
+
+		assert.Equal(t, models.CanvasNodeExecutionStateFinished, execution.State)
+		assert.Equal(t, models.CanvasNodeExecutionResultPassed, execution.Result)
+	})
+
+	t.Run("degraded integration -> component is executed", func(t *testing.T) {
+		execution := processWithIntegration(t, models.IntegrationStateDegraded, "webhook could not be created")
+
+		assert.Equal(t, models.CanvasNodeExecutionResultPassed, execution.Result)
+	})
+}
+
 func countConcurrentExecutionResults(t *testing.T, results []error) (successCount int, lockedCount int) {
 	for i, result := range results {
 		switch result {
@@ -176,6 +176,7 @@ func (w *NodeRequestWorker) invokeTriggerAction(tx *gorm.DB, request *models.Can
 		}
 
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry)
+		actionCtx.HTTP = w.registry.HTTPContextForIntegration(instance.Configuration.Data())
 	}
 
 	_, err = trigger.HandleAction(actionCtx)
@@ -237,6 +238,7 @@ func (w *NodeRequestWorker) invokeNodeComponentAction(tx *gorm.DB, request *mode
 
 		logger = logging.WithIntegration(logger, *instance)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry)
+		actionCtx.HTTP = w.registry.HTTPContextForIntegration(instance.Configuration.Data())
 		actionCtx.Logger = logger
 	}
 
@@ -315,6 +317,7 @@ func (w *NodeRequestWorker) invokeParentNodeComponentAction(tx *gorm.DB, request
 
 		logger = logging.WithIntegration(logger, *instance)
 		actionCtx.Integration = contexts.NewIntegrationContext(tx, node, instance, w.encryptor, w.registry)
+		actionCtx.HTTP = w.registry.HTTPContextForIntegration(instance.Configuration.Data())
 	}
 
 	actionCtx.Logger = logger
@@ -22,6 +22,9 @@ type WebhookProvisioner struct {
 	registry  *registry.Registry
 	encryptor crypto.Encryptor
 	baseURL   string
+
+	// now is replaced in tests, to check the retry intervals declared by triggers.
+	now func() time.Time
 }
 
 func NewWebhookProvisioner(baseURL string, encryptor crypto.Encryptor, registry *registry.Registry) *WebhookProvisioner {
@@ -30,6 +33,7 @@ func NewWebhookProvisioner(baseURL string, encryptor crypto.Encryptor, registry
 		baseURL:   baseURL,
 		encryptor: encryptor,
 		semaphore: semaphore.NewWeighted(25),
+		now:       time.Now,
 	}
 }
 
@@ -86,6 +90,10 @@ func (w *WebhookProvisioner) processWebhook(tx *gorm.DB, webhook *models.Webhook
 }
 
 func (w *WebhookProvisioner) processIntegrationWebhook(tx *gorm.DB, webhook *models.Webhook) error {
+	if !retryDue(webhook, w.setupRetryInterval(tx, webhook), w.now()) {
+		return nil
+	}
+
 	instance, err := models.FindUnscopedIntegrationInTransaction(tx, *webhook.AppInstallationID)
 	if err != nil {
 		return w.handleWebhookError(tx, webhook, err)
@@ -97,7 +105,7 @@ func (w *WebhookProvisioner) processIntegrationWebhook(tx *gorm.DB, webhook *mod
 	}
 
 	metadata, err := handler.Setup(core.WebhookHandlerContext{
-		HTTP:        w.registry.HTTPContext(),
+		HTTP:        w.registry.HTTPContextForIntegration(instance.Configuration.Data()),
 		Integration: contexts.NewIntegrationContext(tx, nil, instance, w.encryptor, w.registry),
 		Webhook:     contexts.NewWebhookContext(tx, webhook, w.encryptor, w.baseURL),
 		Logger:      logging.ForIntegration(*instance),
@@ -110,6 +118,49 @@ func (w *WebhookProvisioner) processIntegrationWebhook(tx *gorm.DB, webhook *mod
 	return webhook.ReadyWithMetadata(tx, metadata)
 }
 
+// setupRetryInterval returns the longest retry interval declared by the triggers using the webhook.
+func (w *WebhookProvisioner) setupRetryInterval(tx *gorm.DB, webhook *models.Webhook) time.Duration {
+	if webhook.RetryCount == 0 {
+		return 0
+	}
+
+	nodes, err := models.FindWebhookNodesInTransaction(tx, webhook.ID)
+	if err != nil {
+		w.log("Error finding nodes for webhook %s: %v", webhook.ID, err)
+		return 0
+	}
+
+	var interval time.Duration
+	for _, node := range nodes {
+		ref := node.Ref.Data()
+		if node.Type != models.NodeTypeTrigger || ref.Trigger == nil {
+			continue
+		}
+
+		trigger, err := w.registry.GetTrigger(ref.Trigger.Name)
+		if err != nil {
+			continue
+		}
+
+		if provider, ok := trigger.(core.SetupRetryIntervalProvider); ok {
+			interval = max(interval, provider.SetupRetryInterval())
+		}
+	}
+
+	return interval
+}
+
+// retryDue tells if a webhook whose provisioning failed can be retried at the given time.
+// The last failed attempt is the last update of the webhook.
+func retryDue(webhook *models.Webhook, interval time.Duration, now time.Time) bool {
+	if webhook.RetryCount == 0 || webhook.UpdatedAt == nil {
+		return true
+	}
+
+	backoff := core.SetupRetryBackoff(interval, webhook.RetryCount)
+	return !now.Before(webhook.UpdatedAt.Add(backoff))
+}
+
 func (w *WebhookProvisioner) handleWebhookError(tx *gorm.DB, webhook *models.Webhook, originalErr error) error {
 	if webhook.HasExceededRetries() {
 		w.log("Webhook %s has exceeded max retries (%d), marking as failed", webhook.ID, webhook.MaxRetries)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/test/support"
+	"gorm.io/datatypes"
 )
 
 type BadEncryptor struct{}
@@ -134,6 +136,153 @@ func Test__WebhookProvisioner_MaxRetriesExceeded(t *testing.T) {
 	assert.Equal(t, 3, updatedWebhook.RetryCount)
 }
 
+type retryIntervalTrigger struct {
+	core.Trigger
+	interval time.Duration
+}
+
+func (t *retryIntervalTrigger) SetupRetryInterval() time.Duration {
+	return t.interval
+}
+
+func Test__WebhookProvisioner_SetupRetryInterval(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	r.Registry.Triggers["slow"] = &retryIntervalTrigger{Trigger: r.Registry.Triggers["start"], interval: time.Minute}
+	r.Registry.Integrations["dummy"] = support.NewDummyIntegration(support.DummyIntegrationOptions{})
+
+	setupCalls := 0
+	r.Registry.WebhookHandlers["dummy"] = support.NewDummyWebhookHandler(support.DummyWebhookHandlerOptions{
+		SetupFunc: func(ctx core.WebhookHandlerContext) (any, error) {
+			setupCalls++
+			return nil, errors.New("oops")
+		},
+	})
+
+	integration, err := models.CreateIntegration(uuid.New(), r.Organization.ID, "dummy", support.RandomName("integration"), nil)
+	require.NoError(t, err)
+
+	webhook := models.Webhook{
+		ID:                uuid.New(),
+		State:             models.WebhookStatePending,
+		Secret:            []byte("encrypted-secret"),
+		AppInstallationID: &integration.ID,
+		MaxRetries:        5,
+	}
+	require.NoError(t, database.Conn().Create(&webhook).Error)
+
+	support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{
+		{
+			NodeID:    "trigger-1",
+			Type:      models.NodeTypeTrigger,
+			Ref:       datatypes.NewJSONType(models.NodeRef{Trigger: &models.TriggerRef{Name: "slow"}}),
+			WebhookID: &webhook.ID,
+		},
+	}, []models.Edge{})
+
+	now := time.Now()
+	provisioner := NewWebhookProvisioner("https://example.com", r.Encryptor, r.Registry)
+	provisioner.now = func() time.Time { return now }
+
+	process := func() *models.Webhook {
+		current, err := models.FindWebhook(webhook.ID)
+		require.NoError(t, err)
+		require.NoError(t, provisioner.LockAndProcessWebhook(*current))
+
+		updated, err := models.FindWebhook(webhook.ID)
+		require.NoError(t, err)
+		return updated
+	}
+
+	//
+	// The first attempt is not delayed, and the next ones wait
+	// for the interval of the trigger, doubled on every failed attempt.
+	//
+	updated := process()
+	assert.Equal(t, 1, setupCalls)
+	assert.Equal(t, 1, updated.RetryCount)
+
+	now = updated.UpdatedAt.Add(59 * time.Second)
+	process()
+	assert.Equal(t, 1, setupCalls)
+
+	now = updated.UpdatedAt.Add(time.Minute)
+	updated = process()
+	assert.Equal(t, 2, setupCalls)
+	assert.Equal(t, 2, updated.RetryCount)
+
+	now = updated.UpdatedAt.Add(119 * time.Second)
+	process()
+	assert.Equal(t, 2, setupCalls)
+
+	now = updated.UpdatedAt.Add(2 * time.Minute)
+	process()
+	assert.Equal(t, 3, setupCalls)
+}
+
+func Test__WebhookProvisioner_RetryDue(t *testing.T) {
+	failedAt := time.Date(2026, 1, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		retryCount int
+		interval   time.Duration
+		now        time.Time
+		expected   bool
+	}{
+		{
+			name:       "first attempt",
+			retryCount: 0,
+			interval:   time.Minute,
+			now:        failedAt,
+			expected:   true,
+		},
+		{
+			name:       "no declared interval",
+			retryCount: 2,
+			interval:   0,
+			now:        failedAt,
+			expected:   true,
+		},
+		{
+			name:       "within the interval",
+			retryCount: 1,
+			interval:   time.Minute,
+			now:        failedAt.Add(30 * time.Second),
+			expected:   false,
+		},
+		{
+			name:       "interval elapsed",
+			retryCount: 1,
+			interval:   time.Minute,
+			now:        failedAt.Add(time.Minute),
+			expected:   true,
+		},
+		{
+			name:       "interval doubled after the second failure",
+			retryCount: 2,
+			interval:   time.Minute,
+			now:        failedAt.Add(90 * time.Second),
+			expected:   false,
+		},
+		{
+			name:       "backoff capped",
+			retryCount: 10,
+			interval:   time.Minute,
+			now:        failedAt.Add(core.MaxSetupRetryBackoff),
+			expected:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &models.Webhook{RetryCount: tt.retryCount, UpdatedAt: &failedAt}
+			assert.Equal(t, tt.expected, retryDue(webhook, tt.interval, tt.now))
+		})
+	}
+}
+
 func Test__WebhookProvisioner_ConcurrentProcessing(t *testing.T) {
 	r := support.Setup(t)
 	defer r.Close()
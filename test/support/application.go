@@ -101,6 +101,7 @@ type DummyWebhookHandlerOptions struct {
 	CleanupFunc       func(ctx core.WebhookHandlerContext) error
 	CompareConfigFunc func(a, b any) (bool, error)
 	MergeFunc         func(current, requested any) (any, bool, error)
+	ReleaseFunc       func(current, nodeMetadata any) (any, bool, error)
 }
 
 type DummyWebhookHandler struct {
@@ -108,6 +109,7 @@ type DummyWebhookHandler struct {
 	cleanupFunc       func(ctx core.WebhookHandlerContext) error
 	compareConfigFunc func(a, b any) (bool, error)
 	mergeFunc         func(current, requested any) (any, bool, error)
+	releaseFunc       func(current, nodeMetadata any) (any, bool, error)
 }
 
 func NewDummyWebhookHandler(options DummyWebhookHandlerOptions) *DummyWebhookHandler {
@@ -116,6 +118,7 @@ func NewDummyWebhookHandler(options DummyWebhookHandlerOptions) *DummyWebhookHan
 		cleanupFunc:       options.CleanupFunc,
 		compareConfigFunc: options.CompareConfigFunc,
 		mergeFunc:         options.MergeFunc,
+		releaseFunc:       options.ReleaseFunc,
 	}
 }
 
@@ -146,3 +149,10 @@ func (t *DummyWebhookHandler) Merge(current, requested any) (any, bool, error) {
 	}
 	return t.mergeFunc(current, requested)
 }
+
+func (t *DummyWebhookHandler) Release(current, nodeMetadata any) (any, bool, error) {
+	if t.releaseFunc == nil {
+		return current, false, nil
+	}
+	return t.releaseFunc(current, nodeMetadata)
+}
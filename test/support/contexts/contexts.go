@@ -3,6 +3,7 @@ package contexts
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -93,6 +94,7 @@ type IntegrationContext struct {
 	BrowserAction    *core.BrowserAction
 	Secrets          map[string]core.IntegrationSecret
 	WebhookRequests  []any
+	Webhooks         []uuid.UUID
 	ResyncRequests   []time.Duration
 	ActionRequests   []ActionRequest
 	Subscriptions    []Subscription
@@ -157,6 +159,11 @@ func (c *IntegrationContext) Error(message string) {
 	c.StateDescription = message
 }
 
+func (c *IntegrationContext) Degraded(message string) {
+	c.State = "degraded"
+	c.StateDescription = message
+}
+
 func (c *IntegrationContext) NewBrowserAction(action core.BrowserAction) {
 	c.BrowserAction = &action
 }
@@ -178,6 +185,10 @@ func (c *IntegrationContext) GetSecrets() ([]core.IntegrationSecret, error) {
 	return secrets, nil
 }
 
+func (c *IntegrationContext) WebhookExists(id uuid.UUID) (bool, error) {
+	return slices.Contains(c.Webhooks, id), nil
+}
+
 func (c *IntegrationContext) RequestWebhook(configuration any) error {
 	c.WebhookRequests = append(c.WebhookRequests, configuration)
 	return nil
@@ -355,6 +366,16 @@ func (c *HTTPContext) Do(request *http.Request) (*http.Response, error) {
 	return response, nil
 }
 
+// TracingHTTPContext is an HTTPContext that records the traces integration clients report.
+type TracingHTTPContext struct {
+	HTTPContext
+	Traces []core.HTTPTrace
+}
+
+func (c *TracingHTTPContext) TraceHTTP(trace core.HTTPTrace) {
+	c.Traces = append(c.Traces, trace)
+}
+
 type SecretsContext struct {
 	Values map[string][]byte
 }
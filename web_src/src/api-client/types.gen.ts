@@ -765,6 +765,7 @@ export type OrganizationsIntegrationResourceRef = {
   type?: string;
   name?: string;
   id?: string;
+  children?: Array<OrganizationsIntegrationResourceRef>;
 };
 
 export type OrganizationsIntegrationSpec = {
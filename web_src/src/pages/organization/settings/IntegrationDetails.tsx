@@ -1,4 +1,4 @@
-import { ArrowLeft, CircleX, ExternalLink, Loader2, Plug, Trash2 } from "lucide-react";
+import { ArrowLeft, CircleX, ExternalLink, Loader2, Plug, Trash2, TriangleAlert } from "lucide-react";
 import { useNavigate, useParams } from "react-router-dom";
 import { useState, useEffect, useMemo } from "react";
 import {
@@ -261,7 +261,9 @@ export function IntegrationDetails({ organizationId }: IntegrationDetailsProps)
                 ? "text-green-500"
                 : integration.status?.state === "error"
                   ? "text-red-600"
-                  : "text-amber-600"
+                  : integration.status?.state === "degraded"
+                    ? "text-yellow-600"
+                    : "text-amber-600"
             }`}
           />
           <span
@@ -270,7 +272,9 @@ export function IntegrationDetails({ organizationId }: IntegrationDetailsProps)
                 ? "text-green-500"
                 : integration.status?.state === "error"
                   ? "text-red-600"
-                  : "text-amber-600"
+                  : integration.status?.state === "degraded"
+                    ? "text-yellow-600"
+                    : "text-amber-600"
             }`}
           >
             {(integration.status?.state || "unknown").charAt(0).toUpperCase() +
@@ -287,6 +291,13 @@ export function IntegrationDetails({ organizationId }: IntegrationDetailsProps)
           </Alert>
         )}
 
+        {integration.status?.state === "degraded" && integration.status?.stateDescription && (
+          <Alert className="[&>svg+div]:translate-y-0 [&>svg]:top-[14px]">
+            <TriangleAlert className="h-4 w-4" />
+            <AlertDescription>{integration.status.stateDescription}</AlertDescription>
+          </Alert>
+        )}
+
         {integration?.status?.browserAction && (
           <IntegrationInstructions
             description={integration.status.browserAction.description}
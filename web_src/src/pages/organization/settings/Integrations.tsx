@@ -300,7 +300,9 @@ export function Integrations({ organizationId }: IntegrationsProps) {
                                 ? "text-green-500"
                                 : integration.status?.state === "error"
                                   ? "text-red-500"
-                                  : "text-amber-600"
+                                  : integration.status?.state === "degraded"
+                                    ? "text-yellow-600"
+                                    : "text-amber-600"
                             }`}
                           />
                           <span
@@ -309,8 +311,15 @@ export function Integrations({ organizationId }: IntegrationsProps) {
                                 ? "bg-white text-green-500"
                                 : integration.status?.state === "error"
                                   ? "bg-white text-red-500"
-                                  : "bg-white text-amber-600"
+                                  : integration.status?.state === "degraded"
+                                    ? "bg-white text-yellow-600"
+                                    : "bg-white text-amber-600"
                             }`}
+                            title={
+                              integration.status?.state === "degraded"
+                                ? integration.status.stateDescription || undefined
+                                : undefined
+                            }
                           >
                             {statusLabel}
                           </span>
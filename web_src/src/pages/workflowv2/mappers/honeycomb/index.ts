@@ -3,6 +3,7 @@ import { buildActionStateRegistry } from "../utils";
 
 import { createEventMapper } from "./create_event";
 import { onAlertFiredTriggerRenderer } from "./on_alert_fired";
+import { onSLOBurnAlertTriggerRenderer } from "./on_slo_burn_alert";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   createEvent: createEventMapper,
@@ -10,6 +11,7 @@ export const componentMappers: Record<string, ComponentBaseMapper> = {
 
 export const triggerRenderers: Record<string, TriggerRenderer> = {
   onAlertFired: onAlertFiredTriggerRenderer,
+  onSLOBurnAlert: onSLOBurnAlertTriggerRenderer,
 };
 
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
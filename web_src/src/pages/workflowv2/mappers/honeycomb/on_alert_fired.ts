@@ -6,7 +6,9 @@ import { formatTimeAgo } from "@/utils/date";
 
 interface OnAlertFiredConfiguration {
   datasetSlug?: string;
+  selectBy?: string;
   trigger?: string;
+  triggerId?: string;
 }
 
 interface OnAlertFiredEventData {
@@ -57,10 +59,11 @@ export const onAlertFiredTriggerRenderer: TriggerRenderer = {
       });
     }
 
-    if (configuration?.trigger) {
+    const triggerLabel = configuration?.selectBy === "id" ? configuration.triggerId : configuration?.trigger;
+    if (triggerLabel) {
       metadataItems.push({
         icon: "bell",
-        label: configuration.trigger,
+        label: triggerLabel,
       });
     }
 
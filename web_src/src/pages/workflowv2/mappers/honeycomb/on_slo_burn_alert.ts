@@ -0,0 +1,103 @@
+import { getColorClass, getBackgroundColorClass } from "@/utils/colors";
+import { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import honeycombIcon from "@/assets/icons/integrations/honeycomb.svg";
+import { TriggerProps } from "@/ui/trigger";
+import { formatTimeAgo } from "@/utils/date";
+
+interface OnSLOBurnAlertConfiguration {
+  datasetSlug?: string;
+  slo?: string;
+}
+
+interface OnSLOBurnAlertMetadata {
+  sloName?: string;
+}
+
+interface OnSLOBurnAlertEventData {
+  sloId?: string;
+  sloName?: string;
+  status?: string;
+  alertType?: string;
+  budgetRemaining?: number;
+  exhaustionTime?: string;
+  summary?: string;
+}
+
+export const onSLOBurnAlertTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string } => {
+    const eventData = context.event?.data as OnSLOBurnAlertEventData;
+
+    return {
+      title: buildEventTitle(eventData),
+      subtitle: context.event?.createdAt ? formatTimeAgo(new Date(context.event.createdAt)) : "",
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as OnSLOBurnAlertEventData;
+
+    return {
+      SLO: eventData?.sloName ?? "-",
+      "Alert Type": eventData?.alertType ?? "-",
+      Status: eventData?.status ?? "-",
+      "Budget Remaining": eventData?.budgetRemaining?.toString() ?? "-",
+      "Exhaustion Time": eventData?.exhaustionTime ?? "-",
+      Summary: eventData?.summary ?? "-",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext): TriggerProps => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnSLOBurnAlertConfiguration;
+    const metadata = node.metadata as unknown as OnSLOBurnAlertMetadata;
+    const metadataItems = [];
+
+    if (configuration?.datasetSlug) {
+      metadataItems.push({
+        icon: "database",
+        label: configuration.datasetSlug,
+      });
+    }
+
+    const slo = metadata?.sloName || configuration?.slo;
+    if (slo) {
+      metadataItems.push({
+        icon: "gauge",
+        label: slo,
+      });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: honeycombIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as OnSLOBurnAlertEventData;
+
+      props.lastEventData = {
+        title: buildEventTitle(eventData),
+        subtitle: lastEvent.createdAt ? formatTimeAgo(new Date(lastEvent.createdAt)) : "",
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
+
+function buildEventTitle(eventData?: OnSLOBurnAlertEventData): string {
+  const name = eventData?.sloName?.trim() || "SLO Burn Alert";
+  const alertType = eventData?.alertType?.trim();
+
+  if (!alertType) {
+    return name;
+  }
+
+  return `${name} · ${alertType}`;
+}
@@ -18,12 +18,19 @@ import { formatTimeAgo } from "@/utils/date";
 interface DeleteFeatureFlagConfiguration {
   projectKey?: string;
   flagKey?: string;
+  projectKeys?: string[];
+}
+
+interface DeleteFeatureFlagProjectResult {
+  projectKey?: string;
+  deleted?: boolean;
 }
 
 interface DeleteFeatureFlagOutput {
   projectKey?: string;
   flagKey?: string;
   deleted?: boolean;
+  projects?: DeleteFeatureFlagProjectResult[];
 }
 
 function getEventSections(nodes: NodeInfo[], execution: ExecutionInfo, componentName: string): EventSection[] {
@@ -48,7 +55,9 @@ function deleteFeatureFlagMetadata(node: NodeInfo): MetadataItem[] {
   const configuration = node.configuration as DeleteFeatureFlagConfiguration | undefined;
 
   if (configuration?.projectKey) {
-    metadata.push({ icon: "folder", label: configuration.projectKey });
+    const additional = configuration.projectKeys?.filter((key) => key && key !== configuration.projectKey).length || 0;
+    const label = additional > 0 ? `${configuration.projectKey} +${additional}` : configuration.projectKey;
+    metadata.push({ icon: "folder", label });
   }
 
   if (configuration?.flagKey) {
@@ -95,6 +104,10 @@ export const deleteFeatureFlagMapper: ComponentBaseMapper = {
     if (result.projectKey) details["Project"] = result.projectKey;
     if (result.flagKey) details["Flag"] = result.flagKey;
     if (result.deleted !== undefined) details["Deleted"] = result.deleted ? "Yes" : "No";
+    if (result.projects && result.projects.length > 1) {
+      const deletedFrom = result.projects.filter((project) => project.deleted).map((project) => project.projectKey);
+      details["Deleted From"] = deletedFrom.length > 0 ? deletedFrom.join(", ") : "None";
+    }
 
     return details;
   },
@@ -1086,7 +1086,7 @@ function CategorySection({
   const appLogo = appLogoMap[integrationName];
   const categoryIconSrc = typeof appLogo === "string" ? appLogo : integrationName === "aws" ? awsIcon : undefined;
 
-  // Mirror org/integrations colors: ready=green, pending=amber, error=red, default=gray.
+  // Mirror org/integrations colors: ready=green, degraded=yellow, pending=amber, error=red, default=gray.
   const normalizedIntegrationName = normalizeIntegrationName(firstBlock?.integrationName);
   const matchingIntegrationStates = normalizedIntegrationName
     ? integrations
@@ -1101,20 +1101,24 @@ function CategorySection({
       ? "ready"
       : matchingIntegrationStates.includes("ready")
         ? "ready"
-        : matchingIntegrationStates.includes("error")
-          ? "error"
-          : matchingIntegrationStates.includes("pending")
-            ? "pending"
-            : undefined;
+        : matchingIntegrationStates.includes("degraded")
+          ? "degraded"
+          : matchingIntegrationStates.includes("error")
+            ? "error"
+            : matchingIntegrationStates.includes("pending")
+              ? "pending"
+              : undefined;
 
   const integrationStatusColorClass =
     integrationState === "ready"
       ? "text-green-500"
-      : integrationState === "error"
-        ? "text-red-500"
-        : integrationState === "pending"
-          ? "text-amber-600"
-          : "text-gray-500";
+      : integrationState === "degraded"
+        ? "text-yellow-600"
+        : integrationState === "error"
+          ? "text-red-500"
+          : integrationState === "pending"
+            ? "text-amber-600"
+            : "text-gray-500";
 
   // Determine icon for special categories (Core, Bundles, SMTP use Lucide SVG; others use img when categoryIconSrc)
   let CategoryIcon: React.ComponentType<{ size?: number; className?: string }> | null = null;
@@ -247,8 +247,12 @@ export function SettingsTab({
     });
   }, [integrationsOfType, selectedIntegration]);
 
+  // Degraded integrations still work for the capabilities that synced, so their nodes can be configured.
   const isIntegrationReady =
-    !integrationName || !allowIntegrations || selectedIntegrationFull?.status?.state === "ready";
+    !integrationName ||
+    !allowIntegrations ||
+    selectedIntegrationFull?.status?.state === "ready" ||
+    selectedIntegrationFull?.status?.state === "degraded";
   const shouldShowConfiguration = (!integrationName || !!selectedIntegration?.id) && isIntegrationReady;
 
   const handleSave = () => {
@@ -371,8 +375,9 @@ export function SettingsTab({
                   <>
                     <p className="py-2 text-xs text-gray-500">Connection</p>
                     {(() => {
-                      const hasIntegrationError =
-                        selectedIntegrationFull.status?.state === "error" &&
+                      const hasIntegrationStateDescription =
+                        (selectedIntegrationFull.status?.state === "error" ||
+                          selectedIntegrationFull.status?.state === "degraded") &&
                         !!selectedIntegrationFull.status?.stateDescription;
 
                       const integrationStatusCard = (
@@ -382,7 +387,9 @@ export function SettingsTab({
                               ? "bg-green-100 dark:bg-green-950/30"
                               : selectedIntegrationFull.status?.state === "error"
                                 ? "bg-red-100 dark:bg-red-950/30"
-                                : "bg-orange-100 dark:bg-orange-950/30"
+                                : selectedIntegrationFull.status?.state === "degraded"
+                                  ? "bg-yellow-100 dark:bg-yellow-950/30"
+                                  : "bg-orange-100 dark:bg-orange-950/30"
                           }`}
                         >
                           <div className="flex items-center gap-2 min-w-0">
@@ -407,7 +414,9 @@ export function SettingsTab({
                                   ? "border border-green-950/15 bg-green-100 text-green-800 dark:border-green-950/15 dark:bg-green-900/30 dark:text-green-400"
                                   : selectedIntegrationFull.status?.state === "error"
                                     ? "border border-red-950/15 bg-red-100 text-red-800 dark:border-red-950/15 dark:bg-red-900/30 dark:text-red-400"
-                                    : "border border-orange-950/15 bg-orange-100 text-yellow-800 dark:border-orange-950/15 dark:bg-orange-950/30 dark:text-yellow-400"
+                                    : selectedIntegrationFull.status?.state === "degraded"
+                                      ? "border border-yellow-950/15 bg-yellow-100 text-yellow-800 dark:border-yellow-950/15 dark:bg-yellow-900/30 dark:text-yellow-400"
+                                      : "border border-orange-950/15 bg-orange-100 text-yellow-800 dark:border-orange-950/15 dark:bg-orange-950/30 dark:text-yellow-400"
                               }`}
                             >
                               {selectedIntegrationFull.status?.state
@@ -430,7 +439,7 @@ export function SettingsTab({
                         </div>
                       );
 
-                      if (hasIntegrationError) {
+                      if (hasIntegrationStateDescription) {
                         return (
                           <SimpleTooltip content={selectedIntegrationFull.status?.stateDescription || ""}>
                             {integrationStatusCard}